@@ -15,15 +15,17 @@ TRUSTED_CERTS=${CERTS_DIR}/trustedca
 ROOT_CA_DIR=${TRUSTED_CERTS}/root
 ENDORSEMENTS_CA_DIR=${CERTS_DIR}/endorsement
 PRIVACY_CA_DIR=${TRUSTED_CERTS}/privacy-ca
+PREVIOUS_FLAVOR_SIGNING_DIR=${TRUSTED_CERTS}/flavor-signing-previous
 TRUSTED_KEYS_DIR=${CONFIG_PATH}/trusted-keys
 CERTDIR_TRUSTEDJWTCERTS=${CERTS_DIR}/trustedjwt
+CERTDIR_TRUSTEDFLAVORSIGNING=${CERTS_DIR}/trustedflavorsigning
 TEMPLATES_PATH=$CONFIG_PATH/templates
 CREDENTIAL_PATH=$CONFIG_PATH/credentials
 SCHEMA_PATH=$CONFIG_PATH/schema
 
 
 if [ ! -f $CONFIG_PATH/.setup_done ]; then
-  for directory in $LOG_PATH $CONFIG_PATH $CERTS_DIR $TRUSTED_CERTS $ROOT_CA_DIR $ENDORSEMENTS_CA_DIR $PRIVACY_CA_DIR $TRUSTED_KEYS_DIR $CERTDIR_TRUSTEDJWTCERTS $TEMPLATES_PATH $CREDENTIAL_PATH $SCHEMA_PATH ; do
+  for directory in $LOG_PATH $CONFIG_PATH $CERTS_DIR $TRUSTED_CERTS $ROOT_CA_DIR $ENDORSEMENTS_CA_DIR $PRIVACY_CA_DIR $PREVIOUS_FLAVOR_SIGNING_DIR $TRUSTED_KEYS_DIR $CERTDIR_TRUSTEDJWTCERTS $CERTDIR_TRUSTEDFLAVORSIGNING $TEMPLATES_PATH $CREDENTIAL_PATH $SCHEMA_PATH ; do
     mkdir -p $directory
     if [ $? -ne 0 ]; then
       echo "Cannot create directory: $directory"
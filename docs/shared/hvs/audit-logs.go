@@ -0,0 +1,211 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+
+// AuditLogEntry response payload
+// swagger:parameters AuditLogEntry
+type AuditLogEntry struct {
+	// in:body
+	Body hvs.AuditLogEntry
+}
+
+// AuditLogEntryCollection response payload
+// swagger:parameters AuditLogEntryCollection
+type AuditLogEntryCollection struct {
+	// in:body
+	Body hvs.AuditLogEntryCollection
+}
+
+// ---
+//
+// swagger:operation GET /audit-logs AuditLogs Search-AuditLogs
+// ---
+//
+// description: |
+//   Searches the audit log for before/after snapshots of reports and host status records. Entries are returned
+//   oldest first from all the rotated audit log partitions that are still retained in the database.
+//   When a full page of entries is returned, next_offset holds the offset of the next page.
+//   The renewals of the tag certificates that expire soon are recorded with the tag_certificate entity type, the
+//   renew action records the issuance of the renewed tag certificate and the deploy action its deployment to the host.
+//
+// x-permissions: audit_logs:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: entityId
+//   description: ID of the audited entity, i.e. the report, host status or tag certificate ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: entityType
+//   description: Type of the audited entity.
+//   in: query
+//   type: string
+//   enum:
+//     - report
+//     - host_status
+//     - tag_certificate
+//   required: false
+// - name: action
+//   description: Action performed on the entity.
+//   in: query
+//   type: string
+//   enum:
+//     - create
+//     - update
+//     - delete
+//     - renew
+//     - deploy
+//   required: false
+// - name: fromDate
+//   description: |
+//     Filters entries created at or after this date.
+//      date                                   Ex: fromDate=2006-01-02
+//      date+time                              Ex: fromDate=2006-01-02 15:04:05
+//      date+time(with milli seconds)          Ex: fromDate=2006-01-02T15:04:05.000Z
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: toDate
+//   description: Filters entries created before this date. Same formats as fromDate.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: limit
+//   description: Maximum number of entries in the page.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   default: 10000
+//   required: false
+// - name: offset
+//   description: Number of matching entries to skip.
+//   in: query
+//   type: integer
+//   minimum: 0
+//   default: 0
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the audit log.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AuditLogEntryCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/audit-logs?entityType=report&action=create&limit=1
+// x-sample-call-output: |
+//      {
+//          "audit_logs": [
+//          {
+//              "id": "c8b2ddfb-3e1d-4b2f-a3a2-5e1eb2a8a6f3",
+//              "entity_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//              "entity_type": "report",
+//              "created": "2020-06-21T07:18:00.57085Z",
+//              "action": "create",
+//              "columns": [
+//                  {
+//                      "name": "id",
+//                      "value": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                      "is_updated": false
+//                  },
+//                  {
+//                      "name": "host_id",
+//                      "value": "47a3b602-f321-4e03-b3b2-8f3ca3cde128",
+//                      "is_updated": false
+//                  }
+//              ]
+//          } ],
+//          "next_offset": 1
+//      }
+
+// ---
+
+// swagger:operation GET /audit-logs/export AuditLogs Export-AuditLogs
+// ---
+//
+// description: |
+//   Exports all the audit log entries matching the filter criteria as newline delimited JSON, one entry per line.
+//   The response is streamed so that the complete history can be pulled in a single request.
+//   The filter parameters are the same as the audit log search API, paging parameters are not accepted.
+//
+// x-permissions: audit_logs:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/x-ndjson
+// parameters:
+// - name: entityId
+//   description: ID of the audited entity
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: entityType
+//   description: Type of the audited entity.
+//   in: query
+//   type: string
+//   required: false
+// - name: action
+//   description: Action performed on the entity.
+//   in: query
+//   type: string
+//   required: false
+// - name: fromDate
+//   description: Filters entries created at or after this date.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: toDate
+//   description: Filters entries created before this date.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/x-ndjson
+// responses:
+//   '200':
+//     description: Successfully exported the audit log.
+//     content:
+//       application/x-ndjson
+//   '400':
+//     description: Invalid filter criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/audit-logs/export?entityId=ee37c360-7eae-4250-a677-6ee12adce8e2
+// x-sample-call-output: |
+//    {"id":"c8b2ddfb-3e1d-4b2f-a3a2-5e1eb2a8a6f3","entity_id":"ee37c360-7eae-4250-a677-6ee12adce8e2","entity_type":"report","created":"2020-06-21T07:18:00.57085Z","action":"create","columns":[...]}
+//    {"id":"1fd8c7d5-7c51-4e2a-a6ad-52c8e8c1c3c2","entity_id":"ee37c360-7eae-4250-a677-6ee12adce8e2","entity_type":"report","created":"2020-06-21T08:18:00.12043Z","action":"delete","columns":[...]}
@@ -0,0 +1,68 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+
+// DataEncryptionKeyRotation response payload
+// swagger:parameters DataEncryptionKeyRotation
+type DataEncryptionKeyRotation struct {
+	// in:body
+	Body hvs.DataEncryptionKeyRotation
+}
+
+// ---
+
+// swagger:operation POST /data-encryption-keys/rotate DataEncryptionKeys RotateDataEncryptionKey
+// ---
+//
+// description: |
+//   Rotates the data encryption key the host credentials and the ESXi cluster connection strings are encrypted with.
+//
+//   A new data encryption key is generated and saved to the configuration file along with the key it replaces, then
+//   all the credentials are re-encrypted with the new key in a single database transaction. Each credential is stored
+//   with the id of the key it is encrypted with, so that the credentials can still be decrypted if the rotation fails.
+//   The replaced key is removed from the configuration file once no credential is encrypted with it. A failed rotation
+//   can be retried.
+//
+//   The response contains the id of the new key and the number of credentials re-encrypted.
+//
+// x-permissions: data_encryption_keys:rotate
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully rotated the data encryption key.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/DataEncryptionKeyRotation"
+//   '400':
+//     description: Invalid values for request params
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/data-encryption-keys/rotate
+// x-sample-call-output: |
+//    {
+//        "key_id": "3f9a1c07d2e84b65",
+//        "host_credentials": 12,
+//        "esxi_clusters": 1
+//    }
+
+// ---
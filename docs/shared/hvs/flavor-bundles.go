@@ -0,0 +1,184 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+
+// SignedFlavorBundle request/response payload
+// swagger:parameters SignedFlavorBundle
+type SignedFlavorBundle struct {
+	// in:body
+	Body hvs.SignedFlavorBundle
+}
+
+// FlavorBundleImportResult response payload
+// swagger:parameters FlavorBundleImportResult
+type FlavorBundleImportResult struct {
+	// in:body
+	Body hvs.FlavorBundleImportResult
+}
+
+// ---
+
+// swagger:operation GET /flavor-bundles FlavorBundles ExportFlavorBundle
+// ---
+//
+// description: |
+//   Exports flavorgroups as a flavor bundle to be imported by another HVS instance.
+//
+//   The bundle holds the name and the flavor match policies of each flavorgroup, its signed flavors and the flavor templates the flavors were created from.
+//   The content of the bundle is the base64 encoded JSON of the bundle and the signature is its RSASSA-PKCS1-v1_5 SHA384 signature by the flavor signing key of the HVS.
+//   The host_unique flavorgroup cannot be exported.
+//
+// x-permissions: flavor_bundles:export
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: flavorgroupId
+//   description: ID of a flavorgroup to export. Can be repeated.
+//   in: query
+//   type: string
+//   format: uuid
+// - name: flavorgroupName
+//   description: Name of a flavorgroup to export. Can be repeated.
+//   in: query
+//   type: string
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully exported the flavor bundle.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/SignedFlavorBundle"
+//   '400':
+//     description: No flavorgroup, an unknown flavorgroup or the host_unique flavorgroup was selected
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavor-bundles?flavorgroupName=automatic
+// x-sample-call-output: |
+//    {
+//        "content": "eyJjcmVhdGVkIjoiMjAyMS0wNi0wMlQxMjoxMDo0NS4xMTJaIiwiZmxhdm9yZ3JvdXBzIjpbeyJuYW1lIjoiYXV0b21hdGljIiwic2lnbmVkX2ZsYXZvcnMiOltdfV19",
+//        "signature": "TZty4EZSn3HRfeOv+7nZUY6+jxKQTRWIDP6aseSTsZ/+wD0pSbP5jYH3TNnbzG8v6eOw45U/qKZklMgQkFX7h6nS10dT0yRjCXgT+eUCNCsIrOSwjL8VW0i3Tlcc..."
+//    }
+
+// ---
+
+// swagger:operation POST /flavor-bundles FlavorBundles ImportFlavorBundle
+// ---
+//
+// description: |
+//   Imports a flavor bundle exported by another HVS instance.
+//
+//   The signature of the bundle and of each of its flavors must be verified by the flavor signing certificate of the HVS or by one of the trusted flavor signing certificates
+//   in /etc/hvs/certs/trustedflavorsigning, otherwise nothing is imported. The imported flavors are signed with the flavor signing key of the HVS.
+//
+//   The flavor templates are created with their IDs unless they exist. The flavorgroups are created with their flavor match policies unless a flavorgroup of the same name exists,
+//   whose match policies are kept. The flavors are linked to their flavorgroups, and the hosts of these flavorgroups are verified again.
+//
+//   A flavor conflicts with an existing flavor of the same ID or label, unless both have the same digest in which case the existing flavor is used and reported as UNCHANGED.
+//   The conflicts are resolved per the conflict strategy:
+//
+//    | Strategy  | Description |
+//    |-----------|-------------|
+//    | skip      | The existing flavor is kept and the imported flavor is SKIPPED. This is the default strategy. |
+//    | replace   | The existing flavor is deleted, with its flavorgroup links, and the imported flavor is created. |
+//    | supersede | The imported flavor is created and supersedes the existing flavor, which is retired after the supersession grace period. The imported flavor is created with a new ID when it has the ID of the existing flavor, and with its label suffixed by '_' and the first 12 characters of its digest when it has the label of the existing flavor. |
+//
+//   The result lists the import status of each flavor template and flavor, the conflicting flavor and the ID and label a flavor was imported with when they differ from the bundle.
+//
+// x-permissions: flavor_bundles:import
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// consumes:
+//  - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/SignedFlavorBundle"
+// - name: conflictStrategy
+//   description: Strategy resolving the conflicts with existing flavors.
+//   in: query
+//   type: string
+//   enum: [skip, replace, supersede]
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully imported the flavor bundle.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorBundleImportResult"
+//   '400':
+//     description: Invalid request body, conflict strategy or an untrusted bundle or flavor signature
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavor-bundles?conflictStrategy=supersede
+// x-sample-call-input: |
+//    {
+//        "content": "eyJjcmVhdGVkIjoiMjAyMS0wNi0wMlQxMjoxMDo0NS4xMTJaIiwiZmxhdm9yZ3JvdXBzIjpbeyJuYW1lIjoiYXV0b21hdGljIiwic2lnbmVkX2ZsYXZvcnMiOltdfV19",
+//        "signature": "TZty4EZSn3HRfeOv+7nZUY6+jxKQTRWIDP6aseSTsZ/+wD0pSbP5jYH3TNnbzG8v6eOw45U/qKZklMgQkFX7h6nS10dT0yRjCXgT+eUCNCsIrOSwjL8VW0i3Tlcc..."
+//    }
+// x-sample-call-output: |
+//    {
+//        "conflict_strategy": "supersede",
+//        "flavor_templates": [
+//            {
+//                "id": "426912bd-39b0-4daa-ad21-0c6933230b50",
+//                "label": "default-uefi",
+//                "status": "EXISTS"
+//            }
+//        ],
+//        "flavors": [
+//            {
+//                "id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+//                "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TXT_TPM_06-16-2020",
+//                "digest": "54a59b9f22b0b80880d8427e548b7c23abd873486e1f035dce9cd697e85175033caa88e6d57bc35efae0b5afd3145f31",
+//                "imported_id": "0d8c9b4d-6e8f-4a1b-9c2d-3e4f5a6b7c8d",
+//                "imported_label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TXT_TPM_06-16-2020_54a59b9f22b0",
+//                "flavorgroup_names": ["automatic"],
+//                "status": "SUPERSEDED",
+//                "conflict": {
+//                    "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+//                    "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TXT_TPM_06-16-2020",
+//                    "digest": "98a906182cdcfb1eb4eb47117600f68958e2ddd140248b47984f4bde6587b89c8215c3da895a336e94ad1aca39015c40"
+//                }
+//            }
+//        ]
+//    }
+
+// ---
@@ -31,6 +31,20 @@ type SignedFlavorCollection struct {
 	Body hvs.SignedFlavorCollection
 }
 
+// Flavor evaluate API request payload
+// swagger:parameters FlavorEvaluateRequest
+type FlavorEvaluateRequest struct {
+	// in:body
+	Body models.FlavorEvaluateRequest
+}
+
+// Flavor evaluate API response payload
+// swagger:parameters FlavorEvaluationCollection
+type FlavorEvaluationCollection struct {
+	// in:body
+	Body hvs.FlavorEvaluationCollection
+}
+
 // ---
 //
 // swagger:operation GET /flavors Flavors Search-Flavors
@@ -79,6 +93,35 @@ type SignedFlavorCollection struct {
 //   in: query
 //   type: string
 //   required: false
+// - name: sortBy
+//   description: Sorts the flavors by the specified field, the default being "createdAt".
+//   in: query
+//   type: string
+//   enum:
+//     - id
+//     - label
+//     - createdAt
+//   required: false
+// - name: orderBy
+//   description: Orders the flavors in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//     - asc
+//     - desc
+//   required: false
+// - name: limit
+//   description: Maximum number of flavors in the response. When limit or offset is specified, the response also contains the total number of matching flavors and the offset of the next page.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
+// - name: offset
+//   description: Number of matching flavors to skip before the first one in the response, the default being 0.
+//   in: query
+//   type: integer
+//   minimum: 0
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -153,6 +196,8 @@ type SignedFlavorCollection struct {
 //
 //   If generic flavors are created, all hosts in the flavor group will be added to the backend queue, flavor verification process to re-evaluate their trust status. If host unique flavors are created, the individual affected hosts are added to the flavor verification process.
 //
+//   The flavors provided in the request body can hold an "ima" section, whose "allowlist" maps the path of the files measured by the Linux IMA subsystem to the digests they may be measured with, prefixed with their algorithm as in the IMA log (e.g. "sha256:..."). The IMA log reported in the host manifest of the hosts matched with such a flavor is replayed against PCR 10, and the files measured that are not in the allowlist, or with a digest that is not in the allowlist, are reported as faults of the ImaMeasurementLogTrusted rule. The request is rejected with 400 when the allowlist is empty, lists a file without digests or holds a digest that is not a hex encoded digest of a supported algorithm (md5, sha1, sha256, sha384, sha512 or sm3).
+//
 //   The serialized FlavorCreateRequest Go struct object represents the content of the request body.
 //
 //    | Attribute                      | Description                                     |
@@ -164,6 +209,10 @@ type SignedFlavorCollection struct {
 //    | signed_flavors                 | (Optional) This is collection of signed flavors consisting of flavor and signature provided by user. |
 //    | flavorgroup_names              | (Optional) Flavor group names that the created flavor(s) will be associated with. If not provided, created flavor will be associated with automatic flavor group. |
 //    | partial_flavor_types           | (Optional) List array input of flavor types to be imported from a host. Partial flavor type can be any of the following: PLATFORM, OS, ASSET_TAG, HOST_UNIQUE, SOFTWARE. Can be provided with the host connection string. See the product guide for more details on how flavor types are broken down for each host type. |
+//    | superseded_flavor_ids          | (Optional) Ids of existing flavors that are superseded by the created flavors. Exactly one flavor of the same flavor part must be created for each superseded flavor, otherwise the request is rejected with 400. |
+//    |                                | A flavor that is already superseded cannot be superseded again, the request is then rejected with 409 and no flavor is created. |
+//    |                                | A superseded flavor is still matched during its grace period (frs-grace-period, 24h by default) and is deleted automatically once the grace period is over. |
+//    |                                | Deleting the superseding flavor during the grace period restores the superseded flavor. |
 //
 // x-permissions: flavors:create
 // security:
@@ -201,6 +250,8 @@ type SignedFlavorCollection struct {
 //       $ref: "#/definitions/SignedFlavorCollection"
 //   '400':
 //     description: Invalid request body provided
+//   '409':
+//     description: A superseded flavor is already superseded by another flavor
 //   '415':
 //     description: Invalid Content-Type/Accept Header in Request
 //   '500':
@@ -564,3 +615,151 @@ type SignedFlavorCollection struct {
 // x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf
 
 // ---
+
+// swagger:operation POST /flavors/evaluate Flavors Evaluate-Flavors
+// ---
+//
+// description: |
+//   Evaluates candidate flavors against the last host manifests of the selected hosts without importing the flavors.
+//   This is a dry-run of the flavor verification, the flavors and the trust results are not stored and the hosts are
+//   not added to the flavor verification queue. It can be used to learn the impact of a new PLATFORM or OS flavor
+//   before it is created.
+//
+//   The candidate flavors are either provided as unsigned flavors, or they are created from a flavor template and the
+//   last host manifest of a source host. Only the PLATFORM and OS flavors are created from the template.
+//   A host is evaluated as trusted when it is trusted against all the candidate flavors. Hosts that are not connected
+//   or have a host manifest that does not fit the candidate flavors are reported with an error.
+//
+//   The serialized FlavorEvaluateRequest Go struct object represents the content of the request body.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | flavor_collection              | (Optional) A collection of unsigned flavors in the defined flavor format. |
+//    | flavor_template                | (Optional) A flavor template used to create the candidate flavors. Either flavor_collection or flavor_template must be given. |
+//    | source_host_id                 | (Optional) The host whose last host manifest is used to create the flavors from the flavor template. Required with flavor_template. |
+//    | host_selector                  | The hosts to evaluate, with host_ids and/or flavorgroup_names. Hosts matching any of the criteria are evaluated. |
+//
+// x-permissions: flavors:evaluate
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorEvaluateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully evaluated the flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorEvaluationCollection"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/evaluate
+// x-sample-call-input: |
+//    {
+//        "flavor_collection": {
+//            "flavors": [
+//                {
+//                    "flavor": {
+//                        "meta": {
+//                            "description": {
+//                                "flavor_part": "PLATFORM",
+//                                "label": "candidate_platform_flavor",
+//                                "tpm_version": "2.0"
+//                            },
+//                            "vendor": "INTEL"
+//                        },
+//                        "pcrs": [
+//                            {
+//                                "pcr": {
+//                                    "index": 0,
+//                                    "bank": "SHA256"
+//                                },
+//                                "measurement": "a7e1d1d1ba84b8dbd2ec3a6e7a4bb4a4d2a4f7d2dd91e2cbfb8ff5a8d1a4b0c1",
+//                                "pcr_matches": true
+//                            }
+//                        ]
+//                    }
+//                }
+//            ]
+//        },
+//        "host_selector": {
+//            "flavorgroup_names": ["automatic"]
+//        }
+//    }
+// x-sample-call-output: |
+//    {
+//        "flavors": [
+//            {
+//                "flavor": {
+//                    "meta": {
+//                        "id": "3ac9dcd4-5a1c-4bd5-8c8f-5a3f7d5e2a41",
+//                        "description": {
+//                            "flavor_part": "PLATFORM",
+//                            "label": "candidate_platform_flavor",
+//                            "tpm_version": "2.0"
+//                        },
+//                        "vendor": "INTEL"
+//                    },
+//                    "pcrs": [ ... ]
+//                }
+//            }
+//        ],
+//        "host_evaluations": [
+//            {
+//                "host_id": "47a3b602-f321-4e03-b3b2-8f3ca3cde128",
+//                "host_name": "computepurley1",
+//                "trusted": false,
+//                "results": [
+//                    {
+//                        "rule": {
+//                            "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//                            "markers": ["PLATFORM"]
+//                        },
+//                        "flavor_id": "3ac9dcd4-5a1c-4bd5-8c8f-5a3f7d5e2a41",
+//                        "faults": [
+//                            {
+//                                "fault_name": "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256",
+//                                "description": "Host PCR 0 with value 'b9f3…' does not match expected value 'a7e1…'"
+//                            }
+//                        ],
+//                        "trusted": false
+//                    }
+//                ]
+//            },
+//            {
+//                "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                "host_name": "computepurley2",
+//                "trusted": false,
+//                "error": "Host is not in CONNECTED state"
+//            }
+//        ]
+//    }
+
+// ---
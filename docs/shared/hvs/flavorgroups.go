@@ -122,6 +122,34 @@ type FlavorgroupFlavorLinkCollection struct {
 //   in: query
 //   type: boolean
 //   required: false
+// - name: sortBy
+//   description: Sorts the flavor groups by the specified field, the default being "name".
+//   in: query
+//   type: string
+//   enum:
+//     - id
+//     - name
+//   required: false
+// - name: orderBy
+//   description: Orders the flavor groups in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//     - asc
+//     - desc
+//   required: false
+// - name: limit
+//   description: Maximum number of flavor groups in the response. When limit or offset is specified, the response also contains the total number of matching flavor groups and the offset of the next page.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
+// - name: offset
+//   description: Number of matching flavor groups to skip before the first one in the response, the default being 0.
+//   in: query
+//   type: integer
+//   minimum: 0
+//   required: false
 // - name: Accept
 //   required: true
 //   in: header
@@ -198,6 +226,8 @@ type FlavorgroupFlavorLinkCollection struct {
 //    |--------------------------------|------------|
 //    | name                           | Name of the flavorgroup to be created. |
 //    | flavor_match_policy_collection | Collection of flavor match policies. Each flavor match policy contains two <br> parts: <br><b>flavor_part</b>:The type or classification of the flavor.<br> <b>match_policy</b>:The policy which defines how the host is verified against the <br> flavors in the flavor group for the specified flavor part. |
+//    | label_selector                 | Key value labels selecting the hosts of the flavor group. The hosts having all the labels are linked to the flavor group, which cannot then be linked to or unlinked from a host explicitly. |
+//    | report_policy                  | Optional policy of the trust reports of the hosts of the flavor group: <br><b>validity_seconds</b>: The validity of the reports, overriding the configured SAML validity.<br> <b>refresh_interval_seconds</b>: The time after which the reports are refreshed by the HRRS although they are still valid.<br> A host linked to several flavor groups uses the shortest validity and refresh interval of their policies. |
 //
 // x-permissions: flavorgroups:create
 // security:
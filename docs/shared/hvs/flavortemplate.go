@@ -130,6 +130,7 @@ type FlavorTemplate struct {
 //    |--------------------------------|------------|
 //    | Meta                           | Provides the template-author the option to populate arbitrary key/value pairs that will be copied to flavor-part’s “meta/description” entity. |
 //    | PcrRules                       | Instructs the flavor creation engine to copy PCR bank values from the host-manifest to the resulting flavor-part. |
+//    | CustomRules                    | Optional list of rules from the verifier's custom rule registry that are copied to the resulting flavor-part and applied during verification. |
 //
 //   PcrRules: An array of verification rules that will be applied to a PCR.
 //
@@ -140,6 +141,25 @@ type FlavorTemplate struct {
 //    | EventLogEquals                 | Event log equals contains “eventlog_equals” section will update the flavor-part to enforce “PCR Event Log Equals” rules during verification.  The optional “excluding_tags” element can be used to omit events with a one or more “tags” during verification. |
 //    | EventLogIncludes               | EventLogInclude contains “eventlog_includes” section will update the flavor-part to enforce “PCR Event Log Includes” rules during verification. |
 //
+//   CustomRules: An array of rules registered in the verifier's custom rule registry. The template is rejected if a rule is not registered or its parameters are invalid.
+//
+//    | Attribute                      | Description|
+//    |--------------------------------|------------|
+//    | Name                           | Name of the registered rule. Built-in rules are “BiosVersionAtLeast” (parameter “min_version”), “HardwareFeaturesEnabled” (parameter “features”, any of TXT, TPM, CBNT, UEFI, PFR and BMC) and “HostInfoDenylist” (parameters “field”, the json path of the host info field, and “values”) and “SecureBootPolicy” (see below). |
+//    | Parameters                     | Key/value pairs passed to the rule when it is created by the verifier. |
+//
+//   SecureBootPolicy: Verifies the UEFI Secure Boot variables measured in the EV_EFI_VARIABLE_DRIVER_CONFIG events of the PCR 7 event log, which must be reported with their event data.
+//   The event data must match the event digests and the event log must replay to the PCR 7 value. All the parameters are optional, at least one of them is required.
+//
+//    | Parameter                      | Description|
+//    |--------------------------------|------------|
+//    | secure_boot_enabled            | When true, Secure Boot must be enabled (fault “SecureBootNotEnabled”). |
+//    | pk_certificates                | Hex encoded SHA256 fingerprints of the X.509 certificates that must be present in PK (fault “SecureBootCertificateMissing”). |
+//    | kek_certificates               | Hex encoded SHA256 fingerprints of the X.509 certificates that must be present in KEK (fault “SecureBootCertificateMissing”). |
+//    | db_certificates                | Hex encoded SHA256 fingerprints of the X.509 certificates that must be present in db (fault “SecureBootCertificateMissing”). |
+//    | dbx_min_entries                | Minimum number of entries of dbx. The dbx updates are cumulative, a dbx revision is required with its number of entries (fault “SecureBootDbxOutdated”). |
+//    | dbx_hashes                     | Hex encoded SHA256 hashes that must be revoked in dbx, e.g. the bootloaders revoked after BootHole (fault “SecureBootDbxHashMissing”). |
+//
 //   Creates a Flavor template and stores it in the database.
 //
 // x-permissions: flavor-template:create
@@ -4,7 +4,10 @@
  */
 package hvs
 
-import "github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+import (
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+)
 
 // Host response payload
 // swagger:parameters Host
@@ -27,6 +30,27 @@ type HostCreateRequest struct {
 	Body hvs.HostCreateRequest
 }
 
+// HostBulkCreateRequest request payload
+// swagger:parameters HostBulkCreateRequest
+type HostBulkCreateRequest struct {
+	// in:body
+	Body hvs.HostBulkCreateRequest
+}
+
+// HostManifest response payload
+// swagger:parameters HostManifest
+type HostManifest struct {
+	// in:body
+	Body types.HostManifest
+}
+
+// HostEventLog response payload
+// swagger:parameters HostEventLog
+type HostEventLog struct {
+	// in:body
+	Body hvs.HostEventLog
+}
+
 // HostFlavorgroup response payload
 // swagger:parameters HostFlavorgroup
 type HostFlavorgroup struct {
@@ -60,6 +84,13 @@ type HostFlavorgroupCreateRequest struct {
 //   "intel:https://trustagent.server.com:1443"</br>
 //   For VMware, this includes the vCenter and host IP address or DNS host name and credentials. e.g.:
 //   "vmware:https://vCenterServer.com:443/sdk;h=trustagent.server.com;u=vCenterUsername;p=vCenterPassword"</br>
+//   For simulated hosts, this is the absolute path of the host profile on the HVS. e.g.:
+//   "simulator:file:///var/lib/simulator/host1.json"</br>
+//   The profile is a JSON document declaring the host info, the PCR values, the event log and the asset tag of the
+//   host and the keys of its simulated TPM. The quotes of the simulated TPM are signed by its AIK and verified like
+//   the quotes of the Trust Agent, so the AIK of the profile must be certified by the privacy CA of the HVS beforehand
+//   (see ProvisionAik of the simulator package). Simulated hosts are meant for testing and demos only, they are
+//   rejected unless FVS_ENABLE_SIMULATED_HOSTS is set to true in the HVS configuration.</br>
 //   </pre>
 //
 //   <b>Creates a host.</b>
@@ -77,6 +108,7 @@ type HostFlavorgroupCreateRequest struct {
 //    | connection_string | The host connection string. |
 //    | flavorgroup_names | List of flavor group names that the created host will be associated. |
 //    | description       | Host description. |
+//    | labels            | Key value labels of the host. The host is linked to the flavor groups whose label selector matches its labels. |
 //
 // x-permissions: hosts:create
 // security:
@@ -141,6 +173,89 @@ type HostFlavorgroupCreateRequest struct {
 
 // ---
 
+// swagger:operation POST /hosts/bulk Hosts CreateHostsInBulk
+// ---
+//
+// description: |
+//   <b>Registers hosts in bulk.</b>
+//   <pre>
+//   The hosts of the request are validated and a job registering them is started. The job is returned at once and its progress, with the result of each host, can be polled from the /jobs/{job_id} API.</br>
+//   Each host is registered as it would be with the POST /hosts API, connecting to the host and associating it with its flavor groups. The request is rejected as a whole when one of its hosts is invalid or when a host name is repeated. At most 1000 hosts can be registered by a request.</br>
+//   </pre>
+//
+//   The hosts are provided either as a JSON HostBulkCreateRequest holding a list of HostCreateRequest, or as a CSV document. The first record of the CSV document names its columns, the host_name and connection_string columns are required and the flavor group names of a host are separated by ';'.
+//
+//    | Attribute         | Description |
+//    |-------------------|-------------|
+//    | host_name         | HVS name for the host. |
+//    | connection_string | The host connection string. |
+//    | flavorgroup_names | List of flavor group names that the created host will be associated. |
+//    | description       | Host description. |
+//    | labels            | Key value labels of the host. The host is linked to the flavor groups whose label selector matches its labels. |
+//
+// x-permissions: hosts:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// - text/csv
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostBulkCreateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+//     - text/csv
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully started the bulk host registration job.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type or Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/bulk
+// x-sample-call-input: |
+//    host_name,connection_string,flavorgroup_names
+//    Purley host1,intel:https://trustagent1.server.com:1443,
+//    Purley host2,intel:https://trustagent2.server.com:1443,automatic;platform_software
+// x-sample-call-output: |
+//    {
+//        "id": "6b3c2ef4-1c1a-4b0e-9f4b-4a0d2a3e6f11",
+//        "action": "host-bulk-create",
+//        "state": "New",
+//        "created": "2021-06-02T12:10:45.112Z",
+//        "updated": "2021-06-02T12:10:45.112Z",
+//        "total": 2,
+//        "succeeded": 0,
+//        "failed": 0,
+//        "results": []
+//    }
+
+// ---
+
 // swagger:operation GET /hosts/{host_id} Hosts RetrieveHost
 // ---
 //
@@ -218,6 +333,7 @@ type HostFlavorgroupCreateRequest struct {
 //    | connection_string | The host connection string. |
 //    | flavorgroup_names | List of flavor group names that the created host will be associated. |
 //    | description       | Host description. |
+//    | labels            | Key value labels of the host. The host is linked to the flavor groups whose label selector matches its labels. |
 //
 //
 //
@@ -374,6 +490,11 @@ type HostFlavorgroupCreateRequest struct {
 //   in: query
 //   type: string
 //   required: false
+// - name: label
+//   description: Label of the host, as key:value. The parameter can be repeated, the hosts having all the labels are returned.
+//   in: query
+//   type: string
+//   required: false
 // - name: trusted
 //   description: Get host by trust status.
 //   in: query
@@ -389,6 +510,15 @@ type HostFlavorgroupCreateRequest struct {
 //   in: query
 //   type: boolean
 //   required: false
+// - name: sortBy
+//   description: Sorts the host collection by the specified field, the default being "name".
+//   in: query
+//   type: string
+//   enum:
+//      - id
+//      - name
+//      - hostHardwareId
+//   required: false
 // - name: orderBy
 //   description: Orders the host collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
 //   in: query
@@ -397,6 +527,18 @@ type HostFlavorgroupCreateRequest struct {
 //      - asc
 //      - desc
 //   required: false
+// - name: limit
+//   description: Maximum number of hosts in the response. When limit or offset is specified, the response also contains the total number of matching hosts and the offset of the next page.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
+// - name: offset
+//   description: Number of matching hosts to skip before the first host in the response, the default being 0.
+//   in: query
+//   type: integer
+//   minimum: 0
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -437,6 +579,273 @@ type HostFlavorgroupCreateRequest struct {
 //
 // ---
 
+// swagger:operation GET /hosts/{host_id}/manifest Hosts RetrieveHostManifest
+// ---
+//
+// description: |
+//   Retrieves the latest host manifest stored for a host, as retrieved from the host when its trust was last verified.
+//   Returns - The serialized HostManifest Go struct object that was retrieved.
+// x-permissions: hosts:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the host manifest.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostManifest"
+//   '404':
+//     description: Host record not found or no host manifest is stored for the host
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/manifest
+// x-sample-call-output: |
+//    {
+//        "aik_certificate": "MIIDTDCCAbSgAwIBAgIGAXF82oFMMA0GCSqGSIb3DQEBCwUAMBsxGTAXBgNVBAMTEG10d2lsc29u...",
+//        "host_info": {
+//            "os_name": "RedHatEnterprise",
+//            "os_version": "8.1",
+//            "host_name": "computepurley1",
+//            "hardware_uuid": "80ecce40-04b8-e811-906e-00163566263e",
+//            ...
+//        },
+//        "pcr_manifest": {
+//            "sha1pcrs": [...],
+//            "sha2pcrs": [
+//                {
+//                    "index": "pcr_0",
+//                    "value": "e9d8c854b5a0fd4cd894d3ae76e829b3b52300ce3e7606fb4110256af7135212",
+//                    "pcr_bank": "SHA256"
+//                },
+//                ...
+//            ],
+//            "pcr_event_log_map": {
+//                "SHA1": [...],
+//                "SHA256": [...]
+//            }
+//        }
+//    }
+//
+// ---
+
+// swagger:operation GET /hosts/{host_id}/event-log Hosts RetrieveHostEventLog
+// ---
+//
+// description: |
+//   Retrieves the TCG event logs of the latest host manifest stored for a host, to troubleshoot pcr_eventlog_equals faults.
+//   Each event is listed with its type, tags and digest, and with the replay value of the PCR once the event log is replayed up to the event.
+//   The replay value of each PCR is compared with the PCR value of the host manifest.
+//   Returns - The serialized HostEventLog Go struct object that was retrieved.
+// x-permissions: hosts:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: bank
+//   description: PCR bank of the event logs, defaults to SHA256.
+//   in: query
+//   type: string
+//   enum: [SHA1, SHA256, SHA384, SHA512]
+// - name: pcr
+//   description: Index of the PCR whose event log is retrieved, between 0 and 23. All the event logs of the bank are retrieved when not specified.
+//   in: query
+//   type: integer
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the event logs.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostEventLog"
+//   '400':
+//     description: Invalid bank or pcr query parameter
+//   '404':
+//     description: Host record not found or no host manifest is stored for the host
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/event-log?bank=SHA256&pcr=0
+// x-sample-call-output: |
+//    {
+//        "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//        "created": "2021-06-02T12:10:45.112Z",
+//        "pcr_event_logs": [
+//            {
+//                "pcr": {
+//                    "index": 0,
+//                    "bank": "SHA256"
+//                },
+//                "pcr_value": "e9d8c854b5a0fd4cd894d3ae76e829b3b52300ce3e7606fb4110256af7135212",
+//                "replay_value": "e9d8c854b5a0fd4cd894d3ae76e829b3b52300ce3e7606fb4110256af7135212",
+//                "pcr_matches": true,
+//                "events": [
+//                    {
+//                        "type_id": "0x3",
+//                        "type_name": "EV_NO_ACTION",
+//                        "tags": ["StartupLocality3"],
+//                        "measurement": "0000000000000000000000000000000000000000000000000000000000000000",
+//                        "replay_value": "0000000000000000000000000000000000000000000000000000000000000003"
+//                    },
+//                    {
+//                        "type_id": "0x8",
+//                        "type_name": "EV_S_CRTM_VERSION",
+//                        "measurement": "d5a4e7b0d4b3c1e26dd7c2b59e2b1a7c03f2ee8e0b2f0b3b9c6f1f2f0a7d9e11",
+//                        "replay_value": "f02de1ad4a377d89e49ff34f854eebc4764bc94ecdf328a615cbf17f85bfeab4"
+//                    },
+//                    {
+//                        "type_id": "0x1",
+//                        "type_name": "EV_POST_CODE",
+//                        "measurement": "96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+//                        "replay_value": "e9d8c854b5a0fd4cd894d3ae76e829b3b52300ce3e7606fb4110256af7135212"
+//                    }
+//                ]
+//            }
+//        ]
+//    }
+//
+// ---
+
+// swagger:operation GET /hosts/{host_id}/reports Hosts SearchHostReportHistory
+// ---
+//
+// description: |
+//   Searches the report history of a host, to trace back the changes of the trust status of the host.
+//   Every report created for the host is recorded in the report history and kept until it is purged by the
+//   report history purge service, the latest report of the host is always kept.
+//   The reports are returned the most recent first unless requested otherwise.
+//   Returns - The serialized ReportCollection Go struct object that was retrieved.
+// x-permissions: reports:search
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: from
+//   description: Only the reports created at or after this date are returned.
+//                Date formats accepted are (YYYY-MM-DD)|(YYYY-MM-DD hh:mm:ss)|(YYYY-MM-DDThh:mm:ss.000Z)
+//   in: query
+//   type: string
+// - name: to
+//   description: Only the reports created before this date are returned.
+//                Date formats accepted are (YYYY-MM-DD)|(YYYY-MM-DD hh:mm:ss)|(YYYY-MM-DDThh:mm:ss.000Z)
+//   in: query
+//   type: string
+// - name: sortBy
+//   description: Field the reports are sorted by, defaults to createdAt.
+//   in: query
+//   type: string
+//   enum: [id, createdAt, expiration]
+// - name: orderBy
+//   description: Sort order of the reports, defaults to desc.
+//   in: query
+//   type: string
+//   enum: [asc, desc]
+// - name: limit
+//   description: Maximum number of reports returned.
+//   in: query
+//   type: integer
+// - name: offset
+//   description: Number of reports skipped before the first report returned.
+//   in: query
+//   type: integer
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the report history of the host.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/ReportCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '404':
+//     description: Host record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/reports?from=2021-06-01&to=2021-06-03&limit=2
+// x-sample-call-output: |
+//    {
+//        "reports": [
+//            {
+//                "id": "5ecf1d3c-a4ac-4bf4-9f4c-4c3ad1e1b7a4",
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "created": "2021-06-02T12:10:45.112Z",
+//                "expiration": "2021-06-03T12:10:45.112Z",
+//                "trust_information": {
+//                    "OVERALL": true,
+//                    "flavors_trust": {...}
+//                },
+//                "host_info": {...}
+//            },
+//            {
+//                "id": "c0e2b8d1-6d36-4b8f-9c1a-4e9a2f8d5c72",
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "created": "2021-06-02T11:10:40.527Z",
+//                "expiration": "2021-06-03T11:10:40.527Z",
+//                "trust_information": {
+//                    "OVERALL": false,
+//                    "flavors_trust": {...}
+//                },
+//                "host_info": {...}
+//            }
+//        ],
+//        "total": 26,
+//        "next_offset": 2
+//    }
+//
+// ---
+
 // swagger:operation POST /hosts/{host_id}/flavorgroups HostFlavorgroupLinks CreateHostFlavorgroupLink
 // ---
 //
@@ -114,12 +114,37 @@ type HostStatusCollection struct {
 //      minimum: 1
 //      required: false
 //    - name: limit
-//      description: Limits the number of HostStatus records in the response.
+//      description: Limits the number of HostStatus records in the response. When limit or offset is specified, the response also contains the total number of matching records and the offset of the next page.
 //      in: query
 //      type: integer
 //      minimum: 1
 //      default: 10000
 //      required: false
+//    - name: offset
+//      description: Number of matching HostStatus records to skip before the first record in the response.
+//      in: query
+//      type: integer
+//      minimum: 0
+//      default: 0
+//      required: false
+//    - name: sortBy
+//      description: Sorts the HostStatus records by the specified field.
+//      in: query
+//      type: string
+//      enum:
+//        - id
+//        - createdAt
+//      default: createdAt
+//      required: false
+//    - name: orderBy
+//      description: Orders the HostStatus records in ascending/descending order.
+//      in: query
+//      type: string
+//      enum:
+//        - asc
+//        - desc
+//      default: desc
+//      required: false
 //    - name: Accept
 //      description: Accept header
 //      in: header
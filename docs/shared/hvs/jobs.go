@@ -0,0 +1,88 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+
+// Job response payload
+// swagger:parameters Job
+type Job struct {
+	// in:body
+	Body hvs.Job
+}
+
+// ---
+
+// swagger:operation GET /jobs/{job_id} Jobs RetrieveJob
+// ---
+//
+// description: |
+//   Retrieves an asynchronous job, such as a bulk host registration job or a bulk tag certificate provisioning job.
+//
+//   The state of the job is New until it starts, Pending while its hosts are processed and Completed once all of them have been processed. A job interrupted by a restart of HVS is in the Error state, with a message telling why.
+//   The finished jobs are kept for the period configured with JPS_MAX_AGE.
+//   The job counts the hosts that succeeded and failed so far, and lists the result of each of them with the error of the hosts that failed.
+//   The results of a bulk tag certificate provisioning job also hold the hardware UUID of each host and the ID of the Tag Certificate created for it.
+//
+// x-permissions: jobs:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: job_id
+//   description: Unique ID of the job.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the job.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '404':
+//     description: No job with the given ID was found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/jobs/6b3c2ef4-1c1a-4b0e-9f4b-4a0d2a3e6f11
+// x-sample-call-output: |
+//    {
+//        "id": "6b3c2ef4-1c1a-4b0e-9f4b-4a0d2a3e6f11",
+//        "action": "host-bulk-create",
+//        "state": "Completed",
+//        "created": "2021-06-02T12:10:45.112Z",
+//        "updated": "2021-06-02T12:10:52.631Z",
+//        "total": 2,
+//        "succeeded": 1,
+//        "failed": 1,
+//        "results": [
+//            {
+//                "host_name": "Purley host1",
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "status": "SUCCEEDED"
+//            },
+//            {
+//                "host_name": "Purley host2",
+//                "status": "FAILED",
+//                "error": "Host with this name already exist"
+//            }
+//        ]
+//    }
+
+// ---
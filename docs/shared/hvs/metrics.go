@@ -0,0 +1,60 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+// swagger:operation GET /metrics Metrics Retrieve-Metrics
+// ---
+//
+// description: |
+//   Returns the metrics of the host verification pipeline of the Host Verification Service in the Prometheus text exposition format.
+//
+//   The metrics are counted since the service was started, except for the queue depth which is read from the database.
+//   A metric with labels is only returned once it has been recorded. The Go runtime and process metrics of the Prometheus client are returned along with them
+//   - hvs_queue_depth: number of entries in the host verification queue by state
+//   - hvs_host_fetch_duration_seconds: time taken to retrieve the host manifest from a host, by result
+//   - hvs_flavor_verify_duration_seconds: time taken to verify a host manifest against the flavors of the host, by result
+//   - hvs_host_connection_failures_total: number of failed connections to hosts by host state
+//   - hvs_trust_cache_lookups_total: number of quote and flavorgroup trust cache lookups by result
+//   - hvs_trust_cache_hit_ratio: ratio of quote and flavorgroup trust cache lookups that were hits
+//   - hvs_hrrs_refresh_runs_total: number of runs of the host report refresher by result
+//   - hvs_hrrs_refreshed_hosts_total: number of hosts queued for report refresh by the host report refresher
+//   - hvs_saml_signing_duration_seconds: time taken to generate and sign a SAML report, by result
+//
+// x-permissions: metrics:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+//  - text/plain
+// responses:
+//   '200':
+//     description: Successfully retrieved the metrics.
+//     content:
+//       text/plain
+//   '400':
+//     description: Invalid values for request params
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/metrics
+// x-sample-call-output: |
+//    # HELP hvs_host_connection_failures_total Number of failed connections to hosts by host state.
+//    # TYPE hvs_host_connection_failures_total counter
+//    hvs_host_connection_failures_total{host_state="CONNECTION_FAILURE"} 3
+//    # HELP hvs_trust_cache_hit_ratio Ratio of trust cache lookups that were hits.
+//    # TYPE hvs_trust_cache_hit_ratio gauge
+//    hvs_trust_cache_hit_ratio{cache="flavorgroup"} 0.75
+//    hvs_trust_cache_hit_ratio{cache="quote"} 0.5
+//    # HELP hvs_queue_depth Number of entries in the host verification queue by state.
+//    # TYPE hvs_queue_depth gauge
+//    hvs_queue_depth{state="Completed"} 0
+//    hvs_queue_depth{state="ConnectionFailure"} 0
+//    hvs_queue_depth{state="Error"} 0
+//    hvs_queue_depth{state="New"} 2
+//    hvs_queue_depth{state="Pending"} 1
+//    hvs_queue_depth{state="Returned"} 0
+//    hvs_queue_depth{state="Timeout"} 0
+//
+// ---
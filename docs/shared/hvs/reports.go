@@ -21,6 +21,13 @@ type ReportCollection struct {
 	Body hvs.ReportCollection
 }
 
+// ReportDiff response payload
+// swagger:parameters ReportDiff
+type ReportDiff struct {
+	// in:body
+	Body hvs.ReportDiff
+}
+
 // Report request payload
 // swagger:parameters ReportCreateRequest
 type ReportCreateRequest struct {
@@ -41,6 +48,16 @@ type ReportCreateRequest struct {
 //   A report can be returned in JSON format, or it can be returned in SAML format. A SAML report is provided in XML format and contains the same trust information in a specific attribute format.
 //   A SAML report also includes a signature that can be verified by the Host Verification Service’s SAML public key.
 //
+//   A report can also be returned as a JWT by setting the Accept header to application/jwt. The JWT carries the same trust information as the SAML report, its claims are
+//   - trusted: the overall trust status of the host
+//   - flavor_parts: the trust status of each flavor part verified in the report
+//   - asset_tags, hardware_features and host_info: the asset tags, the hardware features and the host information of the host
+//   - aik_certificate and binding_key_certificate: the AIK and binding key certificates of the host
+//   - jti, sub, nbf and exp: the report ID, the hardware UUID of the host and the validity window of the report
+//
+//   The JWT is signed with the SAML key, or with the key configured in the report-token section of the configuration, and can be verified with the VerifyTrustReportToken function of the pkg/lib/eat package.
+//   When several reports are searched, the JWTs are returned one per line.
+//
 //   Reports have a configurable validity period with default period of 24 hours or 86400 seconds. The Host Verification service has a background refresh process that queries for reports where the expiration time is within the next 5 minutes, and triggers generation of a new report for all results.
 //   This is checked every 2 minutes by default, and can be configured by changing property in the configuration. In this way fresh reports are generated before older reports expire.
 //
@@ -51,6 +68,7 @@ type ReportCreateRequest struct {
 //  - bearerAuth: []
 // produces:
 //  - application/json
+//  - application/jwt
 // parameters:
 // - name: id
 //   description: Report ID
@@ -118,11 +136,36 @@ type ReportCreateRequest struct {
 //   required: false
 //   default: true
 // - name: limit
-//   description: This limits the overall number of results (all hosts included).
+//   description: This limits the overall number of results (all hosts included). When limit or offset is specified, the response also contains the total number of matching reports and the offset of the next page.
 //   in: query
 //   type: integer
 //   required: false
 //   default: 2000
+// - name: offset
+//   description: Number of matching reports to skip before the first report in the response.
+//   in: query
+//   type: integer
+//   required: false
+//   default: 0
+// - name: sortBy
+//   description: Sorts the reports by the specified field.
+//   in: query
+//   type: string
+//   enum:
+//     - id
+//     - createdAt
+//     - expiration
+//   required: false
+//   default: createdAt
+// - name: orderBy
+//   description: Orders the reports in ascending/descending order.
+//   in: query
+//   type: string
+//   enum:
+//     - asc
+//     - desc
+//   required: false
+//   default: desc
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -130,6 +173,7 @@ type ReportCreateRequest struct {
 //   required: true
 //   enum:
 //     - application/json
+//     - application/jwt
 // responses:
 //   '200':
 //     description: Successfully retrieved the reports.
@@ -224,6 +268,7 @@ type ReportCreateRequest struct {
 //  - bearerAuth: []
 // produces:
 //  - application/json
+//  - application/jwt
 // consumes:
 // - application/json
 // parameters:
@@ -246,6 +291,7 @@ type ReportCreateRequest struct {
 //   required: true
 //   enum:
 //     - application/json
+//     - application/jwt
 // responses:
 //   '201':
 //     description: Successfully created the report.
@@ -324,6 +370,133 @@ type ReportCreateRequest struct {
 
 // ---
 
+// swagger:operation GET /reports/diff Reports Diff-Reports
+// ---
+//
+// description: |
+//   Compares two reports of the same host and returns what changed between them:
+//   the rules whose trusted state changed, the PCR values that changed per bank and index,
+//   the event log entries that were added or removed and the host info fields that changed (e.g. BIOS and OS version).
+//   Reports that have since been replaced by a newer report of the host can be compared as well.
+//   Returns - The serialized ReportDiff Go struct object.
+// x-permissions: reports:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: from
+//   description: Unique ID of the older Report.
+//   in: query
+//   required: true
+//   type: string
+//   format: uuid
+// - name: to
+//   description: Unique ID of the newer Report.
+//   in: query
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully compared the Reports.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/ReportDiff"
+//   '400':
+//     description: Invalid or missing report ids, or the reports belong to different hosts.
+//   '404':
+//     description: No relevant report record found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/reports/diff?from=2e8bd4a4-7d0b-4ac7-9b0c-7f3c1a7b0b11&to=8a545a4f-d282-4d91-8ec5-bcbe439dcfbc
+// x-sample-call-output: |
+//   {
+//     "host_id": "94824cb6-d6c8-4faf-83b0-125996ceebe2",
+//     "from_report_id": "2e8bd4a4-7d0b-4ac7-9b0c-7f3c1a7b0b11",
+//     "to_report_id": "8a545a4f-d282-4d91-8ec5-bcbe439dcfbc",
+//     "from_created": "2020-06-21T07:18:00.57Z",
+//     "to_created": "2020-06-22T07:18:00.57Z",
+//     "from_trusted": true,
+//     "to_trusted": false,
+//     "rule_changes": [
+//       {
+//         "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//         "markers": [
+//           "PLATFORM"
+//         ],
+//         "flavor_id": "a774ddad-fca1-4670-86b2-605c88a16dab",
+//         "pcr_bank": "SHA256",
+//         "pcr_index": "pcr_0",
+//         "from_trusted": true,
+//         "to_trusted": false,
+//         "faults": [
+//           {
+//             "fault_name": "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256",
+//             "description": "Host PCR 0 with value 'a1fe84b6cd7f8cbc8d0a3ff5ae6d3b0e04b8e4a6ef0f5c3b5a1e3d9e3b7a8f12' does not match expected value 'b9d8fe2c1ad0ff7a0f4fc1a0e8e3c6bd7b5c8d0c1f45cdb1e8b0e0ad8b3b47b2'",
+//             "pcr_index": "pcr_0",
+//             "pcr_bank": "SHA256",
+//             "expected_pcrvalue": "b9d8fe2c1ad0ff7a0f4fc1a0e8e3c6bd7b5c8d0c1f45cdb1e8b0e0ad8b3b47b2",
+//             "actual_pcrvalue": "a1fe84b6cd7f8cbc8d0a3ff5ae6d3b0e04b8e4a6ef0f5c3b5a1e3d9e3b7a8f12"
+//           }
+//         ]
+//       }
+//     ],
+//     "pcr_changes": [
+//       {
+//         "pcr_bank": "SHA256",
+//         "pcr_index": "pcr_0",
+//         "from_value": "b9d8fe2c1ad0ff7a0f4fc1a0e8e3c6bd7b5c8d0c1f45cdb1e8b0e0ad8b3b47b2",
+//         "to_value": "a1fe84b6cd7f8cbc8d0a3ff5ae6d3b0e04b8e4a6ef0f5c3b5a1e3d9e3b7a8f12"
+//       }
+//     ],
+//     "event_log_changes": [
+//       {
+//         "pcr_bank": "SHA256",
+//         "pcr_index": 0,
+//         "added": [
+//           {
+//             "type_id": "0x80000008",
+//             "type_name": "EV_EFI_PLATFORM_FIRMWARE_BLOB",
+//             "tags": [
+//               "BIOS"
+//             ],
+//             "measurement": "c7b8b4f3f4bf2f6b4c3b0c0bd0d4b6f1c29a13d3e2a5c1f1d2e0bd6c2b3a4e5f"
+//           }
+//         ],
+//         "removed": [
+//           {
+//             "type_id": "0x80000008",
+//             "type_name": "EV_EFI_PLATFORM_FIRMWARE_BLOB",
+//             "tags": [
+//               "BIOS"
+//             ],
+//             "measurement": "1ce3d1b4e6b7c5b3a0f7e2d4c6a8b0e2f4d6c8a0b2e4f6d8c0a2b4e6f8d0c2a4"
+//           }
+//         ]
+//       }
+//     ],
+//     "host_info_changes": [
+//       {
+//         "field": "bios_version",
+//         "from": "SE5C620.86B.00.01.0014.070920180847",
+//         "to": "SE5C620.86B.00.01.0015.110720180833"
+//       }
+//     ]
+//   }
+
+// ---
+
 // swagger:operation GET /reports/{report_id} Reports Retrieve-Report
 // ---
 //
@@ -23,6 +23,13 @@ type TagCertificateCreateCriteria struct {
 	Body models.TagCertificateCreateCriteria
 }
 
+// TagCertificateBulkCreateCriteria request payload
+// swagger:parameters TagCertificateBulkCreateCriteria
+type TagCertificateBulkCreateCriteria struct {
+	// in:body
+	Body models.TagCertificateBulkCreateCriteria
+}
+
 // TagCertificateDeploy request payload
 // swagger:parameters TagCertificateDeployCriteria
 type TagCertificateDeployCriteria struct {
@@ -98,12 +105,49 @@ type TagCertificateCollection struct {
 //   type: string
 //   format: date-time
 //   required: false
+// - name: expiresBefore
+//   description: Filters TagCertificates that expire before this date.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
 // - name: hardwareUuid
 //   description: Hardware UUID of the Tag Certificate
 //   in: query
 //   type: string
 //   format: uuid
 //   required: false
+// - name: sortBy
+//   description: Sorts the TagCertificates by the specified field, the default being "subject".
+//   in: query
+//   type: string
+//   enum:
+//     - id
+//     - subject
+//     - issuer
+//     - notBefore
+//     - notAfter
+//   required: false
+// - name: orderBy
+//   description: Orders the TagCertificates in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//     - asc
+//     - desc
+//   required: false
+// - name: limit
+//   description: Maximum number of TagCertificates in the response. When limit or offset is specified, the response also contains the total number of matching TagCertificates and the offset of the next page.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
+// - name: offset
+//   description: Number of matching TagCertificates to skip before the first one in the response, the default being 0.
+//   in: query
+//   type: integer
+//   minimum: 0
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -274,6 +318,177 @@ type TagCertificateCollection struct {
 //     description: Internal server error
 // x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/tag-certificates/fc0cc779-22b6-4741-b0d9-e2e69635ad1e
 
+// ---
+//
+// swagger:operation POST /tag-certificates/bulk TagCertificates CreateTagCertificatesInBulk
+// ---
+//
+// description: |
+//   <b>Provisions the hosts matching a host filter with Tag Certificates.</b>
+//   <pre>
+//   The hosts matching the host filter of the request are resolved and a job provisioning them is started. The job is returned at once and its progress, with the result of each host, can be polled from the /jobs/{job_id} API.</br>
+//   A Tag Certificate holding the tag attributes of the request is created for each host, deployed to the host and an ASSET_TAG flavor is created for the host, as the POST /tag-certificates and POST /rpc/deploy-tag-certificate APIs do. The hosts that failed can be provisioned again with the POST /tag-certificates/bulk/{job_id}/retry API.</br>
+//   The request is rejected when a host name or hardware UUID of the filter matches no host, when the filter matches no host or when it matches more than 1000 hosts.</br>
+//   </pre>
+//
+//   The serialized TagCertificateBulkCreateCriteria Go struct object represents the content of the request body.
+//
+//    | Attribute         | Description |
+//    |-------------------|-------------|
+//    | selection_content | Array of one or more key-value pairs with the tag selection attributes of the hosts. |
+//    | host_filter       | Filter selecting the hosts to provision. Exactly one of host_names, flavorgroup_name, labels or hardware_uuids must be specified. |
+//
+//    | Host filter attribute | Description |
+//    |-----------------------|-------------|
+//    | host_names            | Names of the hosts. |
+//    | flavorgroup_name      | Name of the flavor group whose hosts are provisioned. |
+//    | labels                | Key value labels, the hosts having all the labels are provisioned. |
+//    | hardware_uuids        | Hardware UUIDs of the hosts. |
+//
+// x-permissions: tag_certificates:create, tag_certificates:deploy
+// security:
+//  - bearerAuth: []
+// consumes:
+// - application/json
+// produces:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//     $ref: "#/definitions/TagCertificateBulkCreateCriteria"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully started the bulk tag certificate provisioning job.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: Invalid request body provided or no host matches the host filter
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/tag-certificates/bulk
+// x-sample-call-input: |
+//    {
+//        "selection_content": [
+//            {
+//                "name": "Country",
+//                "value": "US"
+//            },
+//            {
+//                "name": "Zone",
+//                "value": "A"
+//            }
+//        ],
+//        "host_filter": {
+//            "labels": {
+//                "region": "us-west"
+//            }
+//        }
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "0d3b8a57-8c1f-4a4b-8a3e-2f4f5a6b7c8d",
+//        "action": "tag-certificate-bulk-provision",
+//        "state": "New",
+//        "created": "2021-06-02T12:10:45.112Z",
+//        "updated": "2021-06-02T12:10:45.112Z",
+//        "total": 2,
+//        "succeeded": 0,
+//        "failed": 0,
+//        "results": []
+//    }
+
+// ---
+//
+// swagger:operation POST /tag-certificates/bulk/{job_id}/retry TagCertificates RetryTagCertificatesInBulk
+// ---
+//
+// description: |
+//   Provisions again the hosts that failed in a Completed or Error bulk tag certificate provisioning job.
+//
+//   The results of the hosts that succeeded are kept and the job is started again for the hosts that failed, its progress can be polled from the /jobs/{job_id} API.
+//   A job in the Error state, interrupted by a restart of HVS, is also started again for the hosts it did not provision.
+//   The hosts are retrieved again, a host deleted since the job was run is recorded as failed without being provisioned.
+//
+// x-permissions: tag_certificates:create, tag_certificates:deploy
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: job_id
+//   description: Unique ID of the bulk tag certificate provisioning job.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully started the job again.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: The job has no failed host
+//   '404':
+//     description: No bulk tag certificate provisioning job with the given ID was found
+//   '409':
+//     description: The job is still running
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/tag-certificates/bulk/0d3b8a57-8c1f-4a4b-8a3e-2f4f5a6b7c8d/retry
+// x-sample-call-output: |
+//    {
+//        "id": "0d3b8a57-8c1f-4a4b-8a3e-2f4f5a6b7c8d",
+//        "action": "tag-certificate-bulk-provision",
+//        "state": "Pending",
+//        "created": "2021-06-02T12:10:45.112Z",
+//        "updated": "2021-06-02T12:25:03.408Z",
+//        "total": 2,
+//        "succeeded": 1,
+//        "failed": 0,
+//        "results": [
+//            {
+//                "host_name": "Purley host1",
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "status": "SUCCEEDED",
+//                "hardware_uuid": "00ecd3ab-9af4-e711-906e-001560a04062",
+//                "tag_certificate_id": "ec7a9f98-0c79-4856-994c-b1a2087d03d1"
+//            }
+//        ]
+//    }
+
 // ---
 //
 // swagger:operation POST /rpc/deploy-tag-certificate TagCertificates DeployTagCertificate
@@ -0,0 +1,123 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+
+// TrustSummary response payload
+// swagger:parameters TrustSummary
+type TrustSummary struct {
+	// in:body
+	Body hvs.TrustSummary
+}
+
+// ---
+
+// swagger:operation GET /trust-summary TrustSummary Retrieve-TrustSummary
+// ---
+//
+// description: |
+//   Returns the trust summary of all the hosts registered with the Host Verification Service, computed from the latest report and the latest host status of each host.
+//
+//   The trust status of a host is unknown when the host has no report or when its latest report has expired. The summary contains
+//   - the number of trusted, untrusted and unknown hosts
+//   - the number of trusted, untrusted and unknown hosts linked to each flavorgroup
+//   - the number of trusted, untrusted and unknown hosts per flavor part verified in their reports
+//   - the number of trusted, untrusted and unknown hosts per fault name reported in their reports
+//   - the hosts whose reports expire within the requested time window, ordered by expiration
+//   - the hosts that are not connected to the Host Verification Service, grouped by connection state
+//
+// x-permissions: trust_summary:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: expiringWithinMinutes
+//   description: Time window in minutes used to list the reports that are about to expire.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   maximum: 10080
+//   default: 60
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the trust summary.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/TrustSummary"
+//   '400':
+//     description: Invalid values for request params
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/trust-summary?expiringWithinMinutes=60
+// x-sample-call-output: |
+//    {
+//        "hosts": {
+//            "trusted": 1,
+//            "untrusted": 1,
+//            "unknown": 1
+//        },
+//        "flavorgroups": [
+//            {
+//                "id": "826501bd-3c75-4839-a08f-db5f744f8498",
+//                "name": "automatic",
+//                "trusted": 1,
+//                "untrusted": 1,
+//                "unknown": 0
+//            }
+//        ],
+//        "flavor_parts": {
+//            "OS": {
+//                "trusted": 2,
+//                "untrusted": 0,
+//                "unknown": 0
+//            },
+//            "PLATFORM": {
+//                "trusted": 1,
+//                "untrusted": 1,
+//                "unknown": 0
+//            }
+//        },
+//        "faults": {
+//            "fault.PcrValueMismatchSHA256": {
+//                "trusted": 0,
+//                "untrusted": 1,
+//                "unknown": 0
+//            }
+//        },
+//        "expiring_reports": [
+//            {
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "host_name": "Purley host1",
+//                "report_id": "b3ae8d3b-3bd5-4b0a-9ec4-a1bd39ffcb7a",
+//                "trusted": true,
+//                "expiration": "2021-06-02T12:10:45.112Z"
+//            }
+//        ],
+//        "connection_failures": {
+//            "CONNECTION_FAILURE": [
+//                {
+//                    "host_id": "5b5a2f3c-4b31-4e65-9c4f-5c6f1c3b6e2d",
+//                    "host_name": "Purley host3"
+//                }
+//            ]
+//        }
+//    }
+//
+// ---
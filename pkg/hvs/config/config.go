@@ -5,11 +5,18 @@
 package config
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/frs"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/jps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/rhps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/tcrs"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/trustevent"
 	commConfig "github.com/intel-secl/intel-secl/v4/pkg/lib/common/config"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -27,14 +34,17 @@ type Configuration struct {
 
 	TLS           commConfig.TLSCertConfig     `yaml:"tls" mapstructure:"tls"`
 	SAML          SAMLConfig                   `yaml:"saml" mapstructure:"saml"`
+	ReportToken   ReportTokenConfig            `yaml:"report-token" mapstructure:"report-token"`
 	FlavorSigning commConfig.SigningCertConfig `yaml:"flavor-signing" mapstructure:"flavor-signing"`
 
 	PrivacyCA     commConfig.SelfSignedCertConfig `yaml:"privacy-ca" mapstructure:"privacy-ca"`
 	EndorsementCA commConfig.SelfSignedCertConfig `yaml:"endorsement-ca" mapstructure:"endorsement-ca"`
 	TagCA         commConfig.SelfSignedCertConfig `yaml:"tag-ca" mapstructure:"tag-ca"`
 
-	Dek             string `yaml:"data-encryption-key" mapstructure:"data-encryption-key"`
-	AikCertValidity int    `yaml:"aik-certificate-validity-years" mapstructure:"aik-certificate-validity-years"`
+	Dek string `yaml:"data-encryption-key" mapstructure:"data-encryption-key"`
+	// PreviousDeks holds the data encryption keys replaced by a key rotation, until no credential is encrypted with them
+	PreviousDeks    []string `yaml:"previous-data-encryption-keys,omitempty" mapstructure:"previous-data-encryption-keys"`
+	AikCertValidity int      `yaml:"aik-certificate-validity-years" mapstructure:"aik-certificate-validity-years"`
 
 	Server commConfig.ServerConfig `yaml:"server" mapstructure:"server"`
 	Log    commConfig.LogConfig    `yaml:"log" mapstructure:"log"`
@@ -42,7 +52,13 @@ type Configuration struct {
 	HRRS   hrrs.HRRSConfig         `yaml:"hrrs" mapstructure:"hrrs"`
 	FVS    FVSConfig               `yaml:"fvs" mapstructure:"fvs"`
 	VCSS   VCSSConfig              `yaml:"vcss" mapstructure:"vcss"`
+	FRS    frs.FRSConfig           `yaml:"frs" mapstructure:"frs"`
+	RHPS   rhps.RHPSConfig         `yaml:"rhps" mapstructure:"rhps"`
+	JPS    jps.JPSConfig           `yaml:"jps" mapstructure:"jps"`
+	TCRS   tcrs.TCRSConfig         `yaml:"tcrs" mapstructure:"tcrs"`
 	NATS   NatsConfig              `yaml:"nats" mapstructure:"nats"`
+
+	TrustEvents trustevent.TrustEventConfig `yaml:"trust-events" mapstructure:"trust-events"`
 }
 
 type FVSConfig struct {
@@ -50,6 +66,9 @@ type FVSConfig struct {
 	NumberOfDataFetchers            int  `yaml:"number-of-data-fetchers" mapstructure:"number-of-data-fetchers"`
 	SkipFlavorSignatureVerification bool `yaml:"skip-flavor-signature-verification" mapstructure:"skip-flavor-signature-verification"`
 	HostTrustCacheThreshold         int  `yaml:"host-trust-cache-threshold" mapstructure:"host-trust-cache-threshold"`
+	// EnableSimulatedHosts allows the hosts whose TPM is simulated from a host profile file on the HVS, it is meant
+	// for testing only
+	EnableSimulatedHosts bool `yaml:"enable-simulated-hosts" mapstructure:"enable-simulated-hosts"`
 }
 
 type SAMLConfig struct {
@@ -58,6 +77,13 @@ type SAMLConfig struct {
 	ValiditySeconds int                          `yaml:"validity-seconds" mapstructure:"validity-seconds"`
 }
 
+// ReportTokenConfig holds the key signing the JWT trust reports and its certificate, the reports are signed with the
+// SAML key when no key is configured
+type ReportTokenConfig struct {
+	KeyFile  string `yaml:"key-file" mapstructure:"key-file"`
+	CertFile string `yaml:"cert-file" mapstructure:"cert-file"`
+}
+
 type AuditLogConfig struct {
 	MaxRowCount int `yaml:"max-row-count" mapstructure:"max-row-count"`
 	NumRotated  int `yaml:"number-rotated" mapstructure:"number-rotated"`
@@ -97,21 +123,32 @@ func LoadConfiguration() (*Configuration, error) {
 	return &ret, nil
 }
 
+// Save writes the configuration to a temporary file that replaces the config file once written, so that the config
+// file is never left partially written
 func (c *Configuration) Save(filename string) error {
-	configFile, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	configFile, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".*")
 	if err != nil {
 		return errors.Wrap(err, "Failed to create config file")
 	}
 	defer func() {
-		derr := configFile.Close()
-		if derr != nil {
-			log.WithError(derr).Error("Error closing config file")
+		// the temporary file no longer exists once renamed
+		if derr := os.Remove(configFile.Name()); derr != nil && !os.IsNotExist(derr) {
+			log.WithError(derr).Error("Error removing temporary config file")
 		}
 	}()
 
 	err = yaml.NewEncoder(configFile).Encode(c)
+	if err == nil {
+		err = configFile.Sync()
+	}
+	if cerr := configFile.Close(); err == nil {
+		err = cerr
+	}
 	if err != nil {
 		return errors.Wrap(err, "Failed to encode config structure")
 	}
+	if err = os.Rename(configFile.Name(), filename); err != nil {
+		return errors.Wrap(err, "Failed to replace config file")
+	}
 	return nil
 }
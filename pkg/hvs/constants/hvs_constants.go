@@ -47,6 +47,8 @@ const (
 	TrustedJWTSigningCertsDir = ConfigDir + "certs/trustedjwt/"
 	TrustedCaCertsDir         = ConfigDir + "certs/trustedca/"
 	TrustedRootCACertsDir     = TrustedCaCertsDir + "root/"
+	// flavor signing certificates of the HVS instances the flavor bundles are imported from
+	TrustedFlavorSigningCertsDir = ConfigDir + "certs/trustedflavorsigning/"
 
 	TrustedKeysDir = ConfigDir + "trusted-keys/"
 
@@ -60,6 +62,8 @@ const (
 	// flavor signing key and cert
 	FlavorSigningCertFile = TrustedCaCertsDir + "flavor-signing.pem"
 	FlavorSigningKeyFile  = TrustedKeysDir + "flavor-signing.key"
+	// flavor signing certificates replaced by the download-cert-flavor-signing task, still trusted by the verifier
+	PreviousFlavorSigningCertsDir = TrustedCaCertsDir + "flavor-signing-previous/"
 
 	// privacy ca key and cert
 	PrivacyCACertFile = TrustedCaCertsDir + "privacy-ca/privacy-ca-cert.pem"
@@ -159,6 +163,7 @@ const (
 	DefaultFvsNumberOfDataFetchers         = 20
 	DefaultSkipFlavorSignatureVerification = false
 	DefaultHostTrustCacheThreshold         = 100000
+	DefaultEnableSimulatedHosts            = false
 )
 
 //VCSS constants
@@ -171,8 +176,34 @@ const (
 	DefaultMaxRowCount       = 10000
 	DefaultNumRotated        = 10
 	DefaultChannelBufferSize = 5000
+
+	// number of audit log entries fetched from the database at a time while exporting
+	AuditLogExportBatchSize = 1000
+)
+
+// bulk host registration constants
+const (
+	// queue action of the bulk host registration jobs
+	HostBulkCreateAction = "host-bulk-create"
+	// maximum number of hosts registered by a bulk host registration job
+	MaxBulkHostCount = 1000
+	// number of hosts registered concurrently by a bulk host registration job
+	BulkHostCreateWorkers = 20
 )
 
+// bulk tag certificate provisioning constants
+const (
+	// queue action of the bulk tag certificate provisioning jobs
+	TagCertificateBulkProvisionAction = "tag-certificate-bulk-provision"
+	// maximum number of hosts provisioned by a bulk tag certificate provisioning job
+	MaxBulkTagCertificateHostCount = 1000
+	// number of hosts provisioned concurrently by a bulk tag certificate provisioning job
+	BulkTagCertificateProvisionWorkers = 20
+)
+
+// maximum number of labels of a host or of a flavorgroup label selector
+const MaxLabelCount = 64
+
 // Search APIs filter constants
 const (
 	MaxNumDaysSearchLimit = 365
@@ -192,6 +223,22 @@ const (
 	FvsNumberOfDataFetchers            = "fvs-number-of-data-fetchers"
 	FvsSkipFlavorSignatureVerification = "fvs-skip-flavor-signature-verification"
 	FvsHostTrustCacheThreshold         = "fvs-host-trust-cache-threshold"
+	FvsEnableSimulatedHosts            = "fvs-enable-simulated-hosts"
 	HrrsRefreshPeriod                  = "hrrs-refresh-period"
 	VcssRefreshPeriod                  = "vcss-refresh-period"
+	FrsGracePeriod                     = "frs-grace-period"
+	FrsRefreshPeriod                   = "frs-refresh-period"
+	RhpsMaxReports                     = "rhps-max-reports"
+	RhpsMaxAge                         = "rhps-max-age"
+	RhpsRefreshPeriod                  = "rhps-refresh-period"
+	JpsMaxAge                          = "jps-max-age"
+	JpsRefreshPeriod                   = "jps-refresh-period"
+	TcrsRenewBefore                    = "tcrs-renew-before"
+	TcrsDeploy                         = "tcrs-deploy"
+	TcrsRefreshPeriod                  = "tcrs-refresh-period"
+	TrustEventsNatsSubject             = "trust-events-nats-subject"
+	TrustEventsMaxRetries              = "trust-events-max-retries"
+	TrustEventsRetryBackoff            = "trust-events-retry-backoff"
+	ReportTokenKeyFile                 = "report-token-key-file"
+	ReportTokenCertFile                = "report-token-cert-file"
 )
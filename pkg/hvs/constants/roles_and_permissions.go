@@ -39,6 +39,10 @@ const (
 	FlavorRetrieve = "flavors:retrieve"
 	FlavorSearch   = "flavors:search"
 	FlavorDelete   = "flavors:delete"
+	FlavorEvaluate = "flavors:evaluate"
+
+	FlavorBundleExport = "flavor_bundles:export"
+	FlavorBundleImport = "flavor_bundles:import"
 
 	TagFlavorCreate        = "tag_flavors:create"
 	HostUniqueFlavorCreate = "host_unique_flavors:create"
@@ -61,6 +65,16 @@ const (
 	ReportRetrieve = "reports:retrieve"
 	ReportSearch   = "reports:search"
 
+	TrustSummaryRetrieve = "trust_summary:retrieve"
+
+	MetricsRetrieve = "metrics:retrieve"
+
+	JobRetrieve = "jobs:retrieve"
+
+	AuditLogSearch = "audit_logs:search"
+
+	DataEncryptionKeyRotate = "data_encryption_keys:rotate"
+
 	// AssetTagAPI
 	TagCertificateCreate = "tag_certificates:create"
 	TagCertificateDelete = "tag_certificates:delete"
@@ -20,6 +20,11 @@ const (
 	RuleXmlMeasurementLogEquals     = RulePrefix + "XmlMeasurementLogEquals"
 	RulePcrEventLogEqualsExcluding  = RulePrefix + "PcrEventLogEqualsExcluding"
 	RuleXmlMeasurementLogIntegrity  = RulePrefix + "XmlMeasurementLogIntegrity"
+	RuleImaMeasurementLogTrusted    = RulePrefix + "ImaMeasurementLogTrusted"
+	RuleBiosVersionAtLeast          = RulePrefix + CustomRuleBiosVersionAtLeast
+	RuleHardwareFeaturesEnabled     = RulePrefix + CustomRuleHardwareFeaturesEnabled
+	RuleHostInfoDenylist            = RulePrefix + CustomRuleHostInfoDenylist
+	RuleSecureBootPolicy            = RulePrefix + CustomRuleSecureBootPolicy
 )
 
 // Verifier Faults
@@ -45,6 +50,8 @@ const (
 	FaultPcrValueMismatch                           = FaultPrefix + "PcrValueMismatch"
 	FaultPcrValueMismatchSHA1                       = FaultPcrValueMismatch + "SHA1"
 	FaultPcrValueMismatchSHA256                     = FaultPcrValueMismatch + "SHA256"
+	FaultPcrValueMismatchSHA384                     = FaultPcrValueMismatch + "SHA384"
+	FaultPcrValueMismatchSHA512                     = FaultPcrValueMismatch + "SHA512"
 	FaultPcrValueMissing                            = FaultPrefix + "PcrValueMissing"
 	FaultTagCertificateExpired                      = FaultPrefix + "TagCertificateExpired"
 	FaultTagCertificateMissing                      = FaultPrefix + "TagCertificateMissing"
@@ -57,6 +64,24 @@ const (
 	FaultXmlMeasurementLogValueMismatchEntries384   = FaultPrefix + "XmlMeasurementLogValueMismatchEntriesSha384"
 	FaultXmlMeasurementsDigestValueMismatch         = FaultPrefix + "XmlMeasurementsDigestValueMismatch"
 	FaultXmlMeasurementValueMismatch                = FaultPrefix + "XmlMeasurementValueMismatch"
+	FaultImaMeasurementLogMissing                   = FaultPrefix + "ImaMeasurementLogMissing"
+	FaultImaMeasurementLogInvalid                   = FaultPrefix + "ImaMeasurementLogInvalid"
+	FaultImaMeasurementLogContainsUnexpectedEntries = FaultPrefix + "ImaMeasurementLogContainsUnexpectedEntries"
+	FaultImaMeasurementLogValueMismatchEntries      = FaultPrefix + "ImaMeasurementLogValueMismatchEntries"
+	FaultImaAllowlistInvalid                        = FaultPrefix + "ImaAllowlistInvalid"
+	FaultCustomRuleNotRegistered                    = FaultPrefix + "CustomRuleNotRegistered"
+	FaultCustomRuleInvalid                          = FaultPrefix + "CustomRuleInvalid"
+	FaultBiosVersionBelowMinimum                    = FaultPrefix + "BiosVersionBelowMinimum"
+	FaultHardwareFeatureNotEnabled                  = FaultPrefix + "HardwareFeatureNotEnabled"
+	FaultHostInfoFieldMissing                       = FaultPrefix + "HostInfoFieldMissing"
+	FaultHostInfoValueDenied                        = FaultPrefix + "HostInfoValueDenied"
+	FaultSecureBootEventLogInvalid                  = FaultPrefix + "SecureBootEventLogInvalid"
+	FaultSecureBootVariableMissing                  = FaultPrefix + "SecureBootVariableMissing"
+	FaultSecureBootVariableInvalid                  = FaultPrefix + "SecureBootVariableInvalid"
+	FaultSecureBootNotEnabled                       = FaultPrefix + "SecureBootNotEnabled"
+	FaultSecureBootCertificateMissing               = FaultPrefix + "SecureBootCertificateMissing"
+	FaultSecureBootDbxOutdated                      = FaultPrefix + "SecureBootDbxOutdated"
+	FaultSecureBootDbxHashMissing                   = FaultPrefix + "SecureBootDbxHashMissing"
 	PcrEventLogUnexpectedFields                     = "PcrEventLogUnexpectedFields"
 	PcrEventLogMissingFields                        = "PcrEventLogMissingFields"
 )
@@ -73,3 +98,11 @@ const (
 	EventlogIncludesRule = "EventlogIncludes"
 	PCRMatchesRule       = "PCRMatches"
 )
+
+//Custom rule names, as referenced by the custom rules of flavors and flavor templates
+const (
+	CustomRuleBiosVersionAtLeast      = "BiosVersionAtLeast"
+	CustomRuleHardwareFeaturesEnabled = "HardwareFeaturesEnabled"
+	CustomRuleHostInfoDenylist        = "HostInfoDenylist"
+	CustomRuleSecureBootPolicy        = "SecureBootPolicy"
+)
@@ -0,0 +1,234 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// AuditLogController contains logic for handling audit log API requests
+type AuditLogController struct {
+	Store domain.AuditLogEntryStore
+}
+
+var auditLogSearchParams = map[string]bool{"entityId": true, "entityType": true, "action": true, "fromDate": true,
+	"toDate": true, "limit": true, "offset": true}
+
+var auditLogExportParams = map[string]bool{"entityId": true, "entityType": true, "action": true, "fromDate": true,
+	"toDate": true}
+
+var auditLogActions = map[string]bool{"create": true, "update": true, "delete": true,
+	models.TagCertificateRenewalRenew: true, models.TagCertificateRenewalDeploy: true}
+
+// Search returns a page of audit log entries based on the AuditLogEntryFilterCriteria
+func (controller AuditLogController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/audit_log_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/audit_log_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), auditLogSearchParams); err != nil {
+		secLog.Errorf("controllers/audit_log_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getAuditLogFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/audit_log_controller:Search() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid filter criteria"}
+	}
+
+	entries, err := controller.Store.Search(filter)
+	if err != nil {
+		defaultLog.WithError(err).Warn("controllers/audit_log_controller:Search() Audit log search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Audit log search operation failed"}
+	}
+
+	auditLogCollection := hvs.AuditLogEntryCollection{AuditLogEntries: []hvs.AuditLogEntry{}}
+	for _, entry := range entries {
+		auditLogCollection.AuditLogEntries = append(auditLogCollection.AuditLogEntries, convertToAuditLogEntry(entry))
+	}
+	// a full page means there could be more entries after this one
+	if len(entries) == filter.Limit {
+		auditLogCollection.NextOffset = filter.Offset + filter.Limit
+	}
+
+	secLog.Infof("%s: Audit logs searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return auditLogCollection, http.StatusOK, nil
+}
+
+// Export streams all the audit log entries matching the filter criteria as newline delimited JSON. The entries are
+// read from the store in batches so that the complete history is never held in memory.
+func (controller AuditLogController) Export(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/audit_log_controller:Export() Entering")
+	defer defaultLog.Trace("controllers/audit_log_controller:Export() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), auditLogExportParams); err != nil {
+		secLog.Errorf("controllers/audit_log_controller:Export() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getAuditLogFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/audit_log_controller:Export() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid filter criteria"}
+	}
+	filter.Limit = constants.AuditLogExportBatchSize
+
+	// fetch the first batch before writing the headers so that a database failure can still be reported
+	entries, err := controller.Store.Search(filter)
+	if err != nil {
+		defaultLog.WithError(err).Warn("controllers/audit_log_controller:Export() Audit log search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Audit log export operation failed"}
+	}
+
+	w.Header().Set("Content-Type", consts.HTTPMediaTypeNDJson)
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	exported := 0
+	for {
+		for _, entry := range entries {
+			if err := encoder.Encode(convertToAuditLogEntry(entry)); err != nil {
+				defaultLog.WithError(err).Error("controllers/audit_log_controller:Export() Error writing audit log entry to response")
+				return nil, http.StatusOK, nil
+			}
+		}
+		exported += len(entries)
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(entries) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+		entries, err = controller.Store.Search(filter)
+		if err != nil {
+			// the status has already been sent, the client will see a truncated stream
+			defaultLog.WithError(err).Error("controllers/audit_log_controller:Export() Audit log search operation failed during export")
+			return nil, http.StatusOK, nil
+		}
+	}
+
+	secLog.Infof("%s: %d audit log entries exported by: %s", commLogMsg.AuthorizedAccess, exported, r.RemoteAddr)
+	return nil, http.StatusOK, nil
+}
+
+// getAuditLogFilterCriteria checks for set filter params in the request and returns a valid AuditLogEntryFilterCriteria
+func getAuditLogFilterCriteria(params url.Values) (*models.AuditLogEntryFilterCriteria, error) {
+	defaultLog.Trace("controllers/audit_log_controller:getAuditLogFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/audit_log_controller:getAuditLogFilterCriteria() Leaving")
+
+	afc := models.AuditLogEntryFilterCriteria{}
+
+	// Entity ID
+	if strings.TrimSpace(params.Get("entityId")) != "" {
+		entityId, err := uuid.Parse(strings.TrimSpace(params.Get("entityId")))
+		if err != nil {
+			return nil, errors.New("Invalid UUID format of the Entity Identifier specified")
+		}
+		afc.EntityID = entityId
+	}
+
+	// Entity Type
+	entityType := strings.TrimSpace(params.Get("entityType"))
+	if entityType != "" {
+		if err := validation.ValidateNameString(entityType); err != nil {
+			return nil, errors.Wrap(err, "Valid contents for entityType must be specified")
+		}
+		afc.EntityType = entityType
+	}
+
+	// Action
+	action := strings.TrimSpace(strings.ToLower(params.Get("action")))
+	if action != "" {
+		if !auditLogActions[action] {
+			return nil, errors.New("action must be one of create, update, delete, renew or deploy")
+		}
+		afc.Action = action
+	}
+
+	// fromDate
+	fromDate := strings.TrimSpace(params.Get("fromDate"))
+	if fromDate != "" {
+		pTime, err := utils.ParseDateQueryParam(fromDate)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid fromDate specified")
+		}
+		afc.FromDate = pTime
+	}
+
+	// toDate
+	toDate := strings.TrimSpace(params.Get("toDate"))
+	if toDate != "" {
+		pTime, err := utils.ParseDateQueryParam(toDate)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid toDate specified")
+		}
+		afc.ToDate = pTime
+	}
+
+	if !afc.FromDate.IsZero() && !afc.ToDate.IsZero() && afc.ToDate.Before(afc.FromDate) {
+		return nil, errors.New("toDate must not be before fromDate")
+	}
+
+	// rowLimit - defaults per set limit
+	rowLimit := strings.TrimSpace(params.Get("limit"))
+	if rowLimit != "" {
+		rLimit, err := strconv.Atoi(rowLimit)
+		if err != nil || rLimit <= 0 {
+			return nil, errors.New("Limit must be an integer > 0")
+		}
+		afc.Limit = rLimit
+	} else {
+		afc.Limit = constants.DefaultSearchResultRowLimit
+	}
+
+	// offset - defaults to 0
+	offset := strings.TrimSpace(params.Get("offset"))
+	if offset != "" {
+		off, err := strconv.Atoi(offset)
+		if err != nil || off < 0 {
+			return nil, errors.New("Offset must be an integer >= 0")
+		}
+		afc.Offset = off
+	}
+
+	return &afc, nil
+}
+
+func convertToAuditLogEntry(entry models.AuditLogEntry) hvs.AuditLogEntry {
+	columns := make([]hvs.AuditLogColumnChange, 0, len(entry.Data.Columns))
+	for _, col := range entry.Data.Columns {
+		columns = append(columns, hvs.AuditLogColumnChange{
+			Name:      col.Name,
+			Value:     col.Value,
+			IsUpdated: col.IsUpdated,
+		})
+	}
+	return hvs.AuditLogEntry{
+		ID:         entry.ID,
+		EntityID:   entry.EntityID,
+		EntityType: entry.EntityType,
+		CreatedAt:  entry.CreatedAt,
+		Action:     entry.Action,
+		Columns:    columns,
+	}
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditLogController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var auditLogEntryStore *mocks.MockAuditLogEntryStore
+	var auditLogController *controllers.AuditLogController
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		auditLogEntryStore = mocks.NewMockAuditLogEntryStore()
+		auditLogController = &controllers.AuditLogController{Store: auditLogEntryStore}
+	})
+
+	// Specs for HTTP Get to "/audit-logs"
+	Describe("Search audit logs", func() {
+		Context("When no filter arguments are passed", func() {
+			It("All audit log entries are returned", func() {
+				router.Handle("/audit-logs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(
+					auditLogController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var alCollection hvs.AuditLogEntryCollection
+				err = json.Unmarshal(w.Body.Bytes(), &alCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(alCollection.AuditLogEntries)).To(Equal(5))
+				Expect(alCollection.NextOffset).To(Equal(0))
+			})
+		})
+
+		Context("When filtered by entity id and entity type", func() {
+			It("Should return the report entries of the entity", func() {
+				router.Handle("/audit-logs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(
+					auditLogController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs?entityId=ee37c360-7eae-4250-a677-6ee12adce8e2&entityType=report", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var alCollection hvs.AuditLogEntryCollection
+				err = json.Unmarshal(w.Body.Bytes(), &alCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(alCollection.AuditLogEntries)).To(Equal(2))
+				Expect(alCollection.AuditLogEntries[0].Action).To(Equal("create"))
+				Expect(alCollection.AuditLogEntries[1].Action).To(Equal("delete"))
+			})
+		})
+
+		Context("When filtered by action and time range", func() {
+			It("Should return the entries created within the range", func() {
+				router.Handle("/audit-logs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(
+					auditLogController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs?action=create&fromDate=2020-06-21T08:00:00.000Z&toDate=2020-06-21T11:00:00.000Z", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var alCollection hvs.AuditLogEntryCollection
+				err = json.Unmarshal(w.Body.Bytes(), &alCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(alCollection.AuditLogEntries)).To(Equal(2))
+			})
+		})
+
+		Context("When a limit is passed", func() {
+			It("Should return a page of entries and the offset of the next page", func() {
+				router.Handle("/audit-logs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(
+					auditLogController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs?limit=2&offset=2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var alCollection hvs.AuditLogEntryCollection
+				err = json.Unmarshal(w.Body.Bytes(), &alCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(alCollection.AuditLogEntries)).To(Equal(2))
+				Expect(alCollection.AuditLogEntries[0].EntityType).To(Equal("report"))
+				Expect(alCollection.AuditLogEntries[1].EntityType).To(Equal("host_status"))
+				Expect(alCollection.NextOffset).To(Equal(4))
+			})
+		})
+
+		Context("When an invalid action is passed", func() {
+			It("Should get a HTTP bad request status", func() {
+				router.Handle("/audit-logs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(
+					auditLogController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs?action=modify", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("When an unknown query parameter is passed", func() {
+			It("Should get a HTTP bad request status", func() {
+				router.Handle("/audit-logs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(
+					auditLogController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs?hostId=ee37c360-7eae-4250-a677-6ee12adce8e2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/audit-logs/export"
+	Describe("Export audit logs", func() {
+		Context("When filtered by entity type", func() {
+			It("Should stream the matching entries as newline delimited JSON", func() {
+				router.Handle("/audit-logs/export", hvsRoutes.ErrorHandler(hvsRoutes.StreamResponseHandler(
+					auditLogController.Export, consts.HTTPMediaTypeNDJson))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs/export?entityType=host_status", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeNDJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal(consts.HTTPMediaTypeNDJson))
+
+				var entries []hvs.AuditLogEntry
+				scanner := bufio.NewScanner(w.Body)
+				for scanner.Scan() {
+					var entry hvs.AuditLogEntry
+					Expect(json.Unmarshal(scanner.Bytes(), &entry)).To(Succeed())
+					entries = append(entries, entry)
+				}
+				Expect(len(entries)).To(Equal(2))
+				Expect(entries[1].Action).To(Equal("update"))
+			})
+		})
+
+		Context("When an invalid Accept header is passed", func() {
+			It("Should get a HTTP unsupported media type status", func() {
+				router.Handle("/audit-logs/export", hvsRoutes.ErrorHandler(hvsRoutes.StreamResponseHandler(
+					auditLogController.Export, consts.HTTPMediaTypeNDJson))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs/export", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+
+		Context("When paging parameters are passed", func() {
+			It("Should get a HTTP bad request status", func() {
+				router.Handle("/audit-logs/export", hvsRoutes.ErrorHandler(hvsRoutes.StreamResponseHandler(
+					auditLogController.Export, consts.HTTPMediaTypeNDJson))).Methods("GET")
+				req, err := http.NewRequest("GET", "/audit-logs/export?limit=10", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeNDJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
 	libPrivacyca "github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/tpm2utils"
 	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
 	"github.com/pkg/errors"
 	"io/ioutil"
@@ -218,7 +219,7 @@ func (certifyHostAiksController *CertifyHostAiksController) getIdentityProofRequ
 		return taModel.IdentityProofRequest{}, http.StatusBadRequest, err
 	}
 
-	proofReq, err := privacyca.ProcessIdentityRequest(identityChallengePayload.IdentityRequest, ekCert.PublicKey.(*rsa.PublicKey), identityRequestChallenge)
+	proofReq, err := privacyca.ProcessIdentityRequest(identityChallengePayload.IdentityRequest, ekCert.PublicKey, identityRequestChallenge)
 	if err != nil {
 		defaultLog.WithError(err).Error("Unable to generate random bytes for identityRequestChallenge")
 		return taModel.IdentityProofRequest{}, http.StatusInternalServerError, err
@@ -303,18 +304,18 @@ func (certifyHostAiksController *CertifyHostAiksController) getIdentityProofRequ
 		return taModel.IdentityProofRequest{}, http.StatusBadRequest, err
 	}
 
-	n := new(big.Int)
-	n.SetBytes(modulus)
-
-	aikPubKey := rsa.PublicKey{N: n, E: 65537}
+	aikPubKey, err := tpm2utils.GetAikPublicKey(modulus)
+	if err != nil {
+		return taModel.IdentityProofRequest{}, http.StatusBadRequest, errors.Wrap(err, "controllers/certify_host_aiks_controller:getIdentityProofRequestResponse() Invalid Aik public key")
+	}
 	pcaKey := (*certifyHostAiksController.CertStore)[models.CaCertTypesPrivacyCa.String()].Key
 	pcaCert := (*certifyHostAiksController.CertStore)[models.CaCertTypesPrivacyCa.String()].Certificates
-	aikCert, err := certifyHostAiksController.CertifyAik(&aikPubKey, aikName, pcaKey.(*rsa.PrivateKey), &pcaCert[0], certifyHostAiksController.AikCertValidity)
+	aikCert, err := certifyHostAiksController.CertifyAik(aikPubKey, aikName, pcaKey.(*rsa.PrivateKey), &pcaCert[0], certifyHostAiksController.AikCertValidity)
 	if err != nil {
 		return taModel.IdentityProofRequest{}, http.StatusInternalServerError, errors.Wrap(err, "controllers/certify_host_aiks_controller:getIdentityProofRequestResponse() Unable to Certify Aik")
 	}
 
-	proofReq, err := privacycaTpm2.ProcessIdentityRequest(identityChallengePayload.IdentityRequest, ekx509Cert.PublicKey.(*rsa.PublicKey), aikCert)
+	proofReq, err := privacycaTpm2.ProcessIdentityRequest(identityChallengePayload.IdentityRequest, ekx509Cert.PublicKey, aikCert)
 	if err != nil {
 		defaultLog.WithError(err).Error("")
 		return taModel.IdentityProofRequest{}, http.StatusInternalServerError, errors.Wrap(err, "controllers/certify_host_aiks_controller:getIdentityProofRequestResponse() Error while generating identityProofRequest")
@@ -323,7 +324,7 @@ func (certifyHostAiksController *CertifyHostAiksController) getIdentityProofRequ
 	return proofReq, http.StatusOK, nil
 }
 
-func (certifyHostAiksController *CertifyHostAiksController) CertifyAik(aikPubKey *rsa.PublicKey, aikName []byte, privacycaKey *rsa.PrivateKey, privacycaCert *x509.Certificate, validity int) ([]byte, error) {
+func (certifyHostAiksController *CertifyHostAiksController) CertifyAik(aikPubKey crypto.PublicKey, aikName []byte, privacycaKey *rsa.PrivateKey, privacycaCert *x509.Certificate, validity int) ([]byte, error) {
 	defaultLog.Trace("controllers/certify_host_aiks_controller:CertifyAik() Entering")
 	defer defaultLog.Trace("controllers/certify_host_aiks_controller:CertifyAik() Leaving")
 
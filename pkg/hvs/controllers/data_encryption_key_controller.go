@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/dek"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+)
+
+type DataEncryptionKeyController struct {
+	Rotator dek.Rotator
+}
+
+func NewDataEncryptionKeyController(rotator dek.Rotator) *DataEncryptionKeyController {
+	return &DataEncryptionKeyController{rotator}
+}
+
+// Rotate generates a new data encryption key and re-encrypts the stored credentials with it
+func (controller DataEncryptionKeyController) Rotate(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/data_encryption_key_controller:Rotate() Entering")
+	defer defaultLog.Trace("controllers/data_encryption_key_controller:Rotate() Leaving")
+
+	if len(r.URL.Query()) > 0 {
+		secLog.Errorf("controllers/data_encryption_key_controller:Rotate() %s : Unexpected query parameters", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Query parameters are not supported"}
+	}
+
+	rotation, err := controller.Rotator.Rotate()
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/data_encryption_key_controller:Rotate() Error rotating data encryption key")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to rotate data encryption key"}
+	}
+
+	secLog.WithField("key_id", rotation.KeyId).Infof("%s: Data encryption key rotated by: %s", commLogMsg.ConfigChanged, r.RemoteAddr)
+	return rotation, http.StatusOK, nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/dek"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DataEncryptionKeyController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var configDir string
+	var cfg *config.Configuration
+	var keys *models.DataEncryptionKeys
+	var keyStore *mocks.MockDataEncryptionKeyStore
+	var dekController *controllers.DataEncryptionKeyController
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+
+		var err error
+		configDir, err = ioutil.TempDir("", "dek")
+		Expect(err).NotTo(HaveOccurred())
+		cfg = &config.Configuration{Dek: "gcXqH8YwuJZ3Rx4qVzA/zhVvkTw2TL+iRAC9T3E6lII="}
+		keys, err = dek.NewDataEncryptionKeys(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		keyStore = &mocks.MockDataEncryptionKeyStore{}
+		Expect(keyStore.AddCredential("u=admin;p=password", keys)).To(Succeed())
+
+		dekController = controllers.NewDataEncryptionKeyController(
+			dek.NewRotator(cfg, filepath.Join(configDir, "config.yml"), keys, keyStore))
+		router.Handle("/data-encryption-keys/rotate",
+			hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(dekController.Rotate))).Methods("POST")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(configDir)
+	})
+
+	// Specs for HTTP Post to "/data-encryption-keys/rotate"
+	Describe("Rotate the data encryption key", func() {
+		Context("Rotate the data encryption key of the stored credentials", func() {
+			It("Should re-encrypt the credentials with a new key", func() {
+				req, err := http.NewRequest("POST", "/data-encryption-keys/rotate", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var rotation hvs.DataEncryptionKeyRotation
+				Expect(json.Unmarshal(w.Body.Bytes(), &rotation)).To(Succeed())
+				Expect(rotation.HostCredentials).To(Equal(1))
+				Expect(keyStore.Credentials[0].KeyId).To(Equal(rotation.KeyId))
+
+				newKey, err := base64.StdEncoding.DecodeString(cfg.Dek)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(models.GetDataEncryptionKeyId(newKey)).To(Equal(rotation.KeyId))
+				Expect(cfg.PreviousDeks).To(BeEmpty())
+			})
+		})
+		Context("Rotate the data encryption key when the credentials cannot be re-encrypted", func() {
+			It("Should fail and keep the previous key", func() {
+				keyStore.FailAfter = 1
+				Expect(keyStore.AddCredential("u=root;p=secret", keys)).To(Succeed())
+
+				req, err := http.NewRequest("POST", "/data-encryption-keys/rotate", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(cfg.PreviousDeks).To(Equal([]string{"gcXqH8YwuJZ3Rx4qVzA/zhVvkTw2TL+iRAC9T3E6lII="}))
+			})
+		})
+		Context("Rotate the data encryption key with query parameters", func() {
+			It("Should fail to rotate the data encryption key", func() {
+				req, err := http.NewRequest("POST", "/data-encryption-keys/rotate?force=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(cfg.Dek).To(Equal("gcXqH8YwuJZ3Rx4qVzA/zhVvkTw2TL+iRAC9T3E6lII="))
+			})
+		})
+	})
+})
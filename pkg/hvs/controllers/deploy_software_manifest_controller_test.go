@@ -45,7 +45,7 @@ var _ = Describe("DeploySoftwareManifestController", func() {
 
 		hostControllerConfig = domain.HostControllerConfig{
 			HostConnectorProvider: hostConnectorProvider,
-			DataEncryptionKey:     nil,
+			DataEncryptionKeys:    nil,
 			Username:              "fakeuser",
 			Password:              "fakepassword",
 		}
@@ -275,7 +275,7 @@ func validateESXiClusterRequest(esxiCluster hvs.ESXiClusterCreateRequest) error
 		if vc.Vendor != constants.VendorVMware {
 			return errors.New("Only VMWARE connection strings are supported for this API")
 		}
-		if err := utils.ValidateConnectionString(esxiCluster.ConnectionString); err != nil {
+		if err := utils.ValidateConnectionString(esxiCluster.ConnectionString, false); err != nil {
 			return errors.Wrap(err, "Valid Connection string must be specified")
 		}
 	} else {
@@ -12,6 +12,7 @@ import (
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
@@ -49,7 +50,7 @@ var _ = Describe("ESXiClusterController", func() {
 
 		hostControllerConfig := domain.HostControllerConfig{
 			HostConnectorProvider: hostConnectorProvider,
-			DataEncryptionKey:     dek,
+			DataEncryptionKeys:    models.NewDataEncryptionKeys(dek),
 			Username:              "fakeuser",
 			Password:              "fakepassword",
 		}
@@ -0,0 +1,726 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	dm "github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	fc "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	fm "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	fu "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/util"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// flavorTemplateIds is the flavor meta description key of the IDs of the templates a flavor was created from
+const flavorTemplateIds = "flavor_template_ids"
+
+var flavorBundleExportParams = map[string]bool{"flavorgroupId": true, "flavorgroupName": true}
+
+var flavorBundleImportParams = map[string]bool{"conflictStrategy": true}
+
+var flavorBundleConflictStrategies = map[string]bool{hvs.FlavorBundleConflictSkip: true,
+	hvs.FlavorBundleConflictReplace: true, hvs.FlavorBundleConflictSupersede: true}
+
+// FlavorBundleController exports flavorgroups with their flavors and flavor templates as signed flavor bundles, and
+// imports the flavor bundles exported by other HVS instances
+type FlavorBundleController struct {
+	FlavorController *FlavorController
+	// TrustedSigningCertsDir holds the flavor signing certificates of the HVS instances the bundles are imported from
+	TrustedSigningCertsDir string
+}
+
+func NewFlavorBundleController(fc *FlavorController) *FlavorBundleController {
+	return &FlavorBundleController{
+		FlavorController:       fc,
+		TrustedSigningCertsDir: consts.TrustedFlavorSigningCertsDir,
+	}
+}
+
+// Export returns the flavorgroups selected by ID or name with their flavors and the flavor templates of the flavors
+// as a flavor bundle signed with the flavor signing key
+func (controller FlavorBundleController) Export(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:Export() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:Export() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), flavorBundleExportParams); err != nil {
+		secLog.Errorf("controllers/flavor_bundle_controller:Export() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	flavorgroups, err := controller.getExportedFlavorgroups(r.URL.Query()["flavorgroupId"], r.URL.Query()["flavorgroupName"])
+	if err != nil {
+		if badRequestErr, ok := err.(*commErr.BadRequestError); ok {
+			secLog.WithError(err).Errorf("controllers/flavor_bundle_controller:Export() %s Invalid flavorgroup selection", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: badRequestErr.Message}
+		}
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Error retrieving flavorgroups")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve flavorgroups"}
+	}
+
+	bundle, err := controller.newFlavorBundle(flavorgroups)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Error building flavor bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export flavor bundle"}
+	}
+
+	signedBundle, err := controller.signFlavorBundle(bundle)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Error signing flavor bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign flavor bundle"}
+	}
+
+	secLog.Infof("%s: Flavor bundle exported by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return signedBundle, http.StatusOK, nil
+}
+
+// Import verifies a flavor bundle and imports its flavor templates, flavorgroups and flavors. The flavors conflicting
+// with an existing flavor of the same label or ID are skipped, replace the existing flavor or supersede it per the
+// conflict strategy of the request.
+func (controller FlavorBundleController) Import(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:Import() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:Import() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_bundle_controller:Import() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_bundle_controller:Import() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+	if err := utils.ValidateQueryParams(r.URL.Query(), flavorBundleImportParams); err != nil {
+		secLog.Errorf("controllers/flavor_bundle_controller:Import() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	conflictStrategy := r.URL.Query().Get("conflictStrategy")
+	if conflictStrategy == "" {
+		conflictStrategy = hvs.FlavorBundleConflictSkip
+	}
+	if !flavorBundleConflictStrategies[conflictStrategy] {
+		secLog.Errorf("controllers/flavor_bundle_controller:Import() %s Invalid conflict strategy %s", commLogMsg.InvalidInputBadParam, conflictStrategy)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "conflictStrategy must be skip, replace or supersede"}
+	}
+
+	var signedBundle hvs.SignedFlavorBundle
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&signedBundle); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_bundle_controller:Import() %s : Failed to decode request body as flavor bundle", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	bundle, err := controller.verifyFlavorBundle(&signedBundle)
+	if err != nil {
+		if badRequestErr, ok := err.(*commErr.BadRequestError); ok {
+			secLog.WithError(err).Errorf("controllers/flavor_bundle_controller:Import() %s Invalid flavor bundle", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: badRequestErr.Message}
+		}
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Import() Error verifying flavor bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to verify flavor bundle"}
+	}
+
+	result := controller.importFlavorBundle(bundle, conflictStrategy)
+	secLog.Infof("%s: Flavor bundle imported by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return result, http.StatusOK, nil
+}
+
+func (controller FlavorBundleController) getExportedFlavorgroups(ids, names []string) ([]hvs.FlavorGroup, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:getExportedFlavorgroups() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:getExportedFlavorgroups() Leaving")
+
+	if len(ids) == 0 && len(names) == 0 {
+		return nil, &commErr.BadRequestError{Message: "At least one flavorgroupId or flavorgroupName must be provided"}
+	}
+
+	fgStore := controller.FlavorController.FGStore
+	var flavorgroups []hvs.FlavorGroup
+	exported := make(map[uuid.UUID]bool)
+	addFlavorgroup := func(fg hvs.FlavorGroup) error {
+		if fg.Name == dm.FlavorGroupsHostUnique.String() {
+			return &commErr.BadRequestError{Message: "The host_unique flavorgroup cannot be exported"}
+		}
+		if !exported[fg.ID] {
+			exported[fg.ID] = true
+			flavorgroups = append(flavorgroups, fg)
+		}
+		return nil
+	}
+
+	for _, id := range ids {
+		flavorgroupId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, &commErr.BadRequestError{Message: "Invalid flavorgroup ID " + id}
+		}
+		fg, err := fgStore.Retrieve(flavorgroupId)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				return nil, &commErr.BadRequestError{Message: "Flavorgroup with ID " + id + " does not exist"}
+			}
+			return nil, errors.Wrapf(err, "Error retrieving flavorgroup %s", id)
+		}
+		if err = addFlavorgroup(*fg); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		fgs, err := fgStore.Search(&dm.FlavorGroupFilterCriteria{NameEqualTo: name})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error searching flavorgroup %s", name)
+		}
+		if len(fgs) == 0 {
+			return nil, &commErr.BadRequestError{Message: "Flavorgroup with name " + name + " does not exist"}
+		}
+		if err = addFlavorgroup(fgs[0]); err != nil {
+			return nil, err
+		}
+	}
+	return flavorgroups, nil
+}
+
+// newFlavorBundle returns the bundle of the flavorgroups, their flavors and the flavor templates of the flavors. The
+// deleted flavor templates are left out of the bundle.
+func (controller FlavorBundleController) newFlavorBundle(flavorgroups []hvs.FlavorGroup) (*hvs.FlavorBundle, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:newFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:newFlavorBundle() Leaving")
+
+	fcon := controller.FlavorController
+	bundle := &hvs.FlavorBundle{
+		Created:      time.Now().UTC(),
+		Flavorgroups: []hvs.FlavorBundleGroup{},
+	}
+	var templateIds []uuid.UUID
+	exportedTemplates := make(map[uuid.UUID]bool)
+	for _, fg := range flavorgroups {
+		bundleGroup := hvs.FlavorBundleGroup{
+			Name:          fg.Name,
+			MatchPolicies: fg.MatchPolicies,
+			SignedFlavors: []hvs.SignedFlavor{},
+		}
+		flavorIds, err := fcon.FGStore.SearchFlavors(fg.ID)
+		if err != nil && !strings.Contains(err.Error(), commErr.RowsNotFound) {
+			return nil, errors.Wrapf(err, "Error retrieving flavors of flavorgroup %s", fg.Name)
+		}
+		for _, flavorId := range flavorIds {
+			signedFlavor, err := fcon.FStore.Retrieve(flavorId)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error retrieving flavor %s", flavorId)
+			}
+			bundleGroup.SignedFlavors = append(bundleGroup.SignedFlavors, *signedFlavor)
+
+			for _, templateId := range getFlavorTemplateIds(&signedFlavor.Flavor) {
+				if !exportedTemplates[templateId] {
+					exportedTemplates[templateId] = true
+					templateIds = append(templateIds, templateId)
+				}
+			}
+		}
+		bundle.Flavorgroups = append(bundle.Flavorgroups, bundleGroup)
+	}
+
+	for _, templateId := range templateIds {
+		template, err := fcon.FTStore.Retrieve(templateId, false)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/flavor_bundle_controller:newFlavorBundle() Flavor template %s is not exported", templateId)
+			continue
+		}
+		bundle.FlavorTemplates = append(bundle.FlavorTemplates, *template)
+	}
+	return bundle, nil
+}
+
+// getFlavorTemplateIds returns the IDs of the templates a flavor was created from. The IDs are read back through JSON
+// since the meta description holds them as they were decoded from the database.
+func getFlavorTemplateIds(flavor *hvs.Flavor) []uuid.UUID {
+	var templateIds []uuid.UUID
+	value, ok := flavor.Meta.Description[flavorTemplateIds]
+	if !ok || value == nil {
+		return nil
+	}
+	valueJson, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(valueJson, &templateIds)
+	}
+	if err != nil {
+		defaultLog.WithError(err).Warnf("controllers/flavor_bundle_controller:getFlavorTemplateIds() Invalid flavor template IDs of flavor %s", flavor.Meta.ID)
+		return nil
+	}
+	return templateIds
+}
+
+func (controller FlavorBundleController) signFlavorBundle(bundle *hvs.FlavorBundle) (*hvs.SignedFlavorBundle, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:signFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:signFlavorBundle() Leaving")
+
+	content, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling flavor bundle")
+	}
+	signingKey, err := controller.getFlavorSigningKey()
+	if err != nil {
+		return nil, err
+	}
+	digest := sha512.Sum384(content)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, signingKey, crypto.SHA384, digest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "Error signing flavor bundle")
+	}
+	return &hvs.SignedFlavorBundle{
+		Content:   content,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+func (controller FlavorBundleController) getFlavorSigningKey() (*rsa.PrivateKey, error) {
+	key, _, err := (*controller.FlavorController.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving flavor signing key")
+	}
+	signingKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("Flavor signing key is not an RSA key")
+	}
+	return signingKey, nil
+}
+
+// getTrustedSigningCerts returns the flavor signing certificate of the HVS and the trusted flavor signing certificates
+// of the other HVS instances
+func (controller FlavorBundleController) getTrustedSigningCerts() ([]x509.Certificate, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:getTrustedSigningCerts() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:getTrustedSigningCerts() Leaving")
+
+	_, certs, err := (*controller.FlavorController.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving flavor signing certificate")
+	}
+	trustedCerts, err := crypt.GetCertsFromDir(controller.TrustedSigningCertsDir)
+	if err != nil {
+		defaultLog.WithError(err).Warn("controllers/flavor_bundle_controller:getTrustedSigningCerts() No trusted flavor signing certificates loaded")
+	}
+	return append(append([]x509.Certificate{}, certs...), trustedCerts...), nil
+}
+
+// verifyWithTrustedCerts returns true when the signature is verified with the key of one of the certificates that
+// are valid
+func verifyWithTrustedCerts(certs []x509.Certificate, verify func(*rsa.PublicKey) error) bool {
+	now := time.Now()
+	for _, cert := range certs {
+		publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok || now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			continue
+		}
+		if verify(publicKey) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyFlavorBundle verifies the signature of the bundle and of each of its flavors and returns the bundle. No part
+// of a bundle is imported unless all of its signatures are verified.
+func (controller FlavorBundleController) verifyFlavorBundle(signedBundle *hvs.SignedFlavorBundle) (*hvs.FlavorBundle, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:verifyFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:verifyFlavorBundle() Leaving")
+
+	certs, err := controller.getTrustedSigningCerts()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signedBundle.Signature)
+	if err != nil || len(signature) == 0 || len(signedBundle.Content) == 0 {
+		return nil, &commErr.BadRequestError{Message: "Flavor bundle content and signature must be provided"}
+	}
+	digest := sha512.Sum384(signedBundle.Content)
+	if !verifyWithTrustedCerts(certs, func(key *rsa.PublicKey) error {
+		return rsa.VerifyPKCS1v15(key, crypto.SHA384, digest[:], signature)
+	}) {
+		return nil, &commErr.BadRequestError{Message: "Flavor bundle signature verification failed"}
+	}
+
+	var bundle hvs.FlavorBundle
+	dec := json.NewDecoder(bytes.NewReader(signedBundle.Content))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&bundle); err != nil {
+		return nil, &commErr.BadRequestError{Message: "Invalid flavor bundle content"}
+	}
+
+	for _, bundleGroup := range bundle.Flavorgroups {
+		if bundleGroup.Name == "" || bundleGroup.Name == dm.FlavorGroupsHostUnique.String() {
+			return nil, &commErr.BadRequestError{Message: "Invalid flavorgroup name " + bundleGroup.Name + " in flavor bundle"}
+		}
+		for i := range bundleGroup.SignedFlavors {
+			signedFlavor := &bundleGroup.SignedFlavors[i]
+			if err := validateFlavorMetaContent(&signedFlavor.Flavor.Meta); err != nil {
+				return nil, &commErr.BadRequestError{Message: "Invalid flavor " + signedFlavor.Flavor.Meta.ID.String() + " in flavor bundle"}
+			}
+			if err := validateFlavorImaContent(signedFlavor.Flavor.Ima); err != nil {
+				return nil, &commErr.BadRequestError{Message: "Invalid IMA allowlist of flavor " + signedFlavor.Flavor.Meta.ID.String() + " in flavor bundle"}
+			}
+			if !verifyWithTrustedCerts(certs, signedFlavor.Verify) {
+				return nil, &commErr.BadRequestError{Message: "Signature verification failed for flavor " +
+					signedFlavor.Flavor.Meta.ID.String() + " in flavor bundle"}
+			}
+		}
+	}
+	return &bundle, nil
+}
+
+// importFlavorBundle imports the flavor templates, flavorgroups and flavors of the bundle. A flavor linked to several
+// flavorgroups of the bundle is imported once and linked to each of them.
+func (controller FlavorBundleController) importFlavorBundle(bundle *hvs.FlavorBundle, conflictStrategy string) *hvs.FlavorBundleImportResult {
+	defaultLog.Trace("controllers/flavor_bundle_controller:importFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:importFlavorBundle() Leaving")
+
+	fcon := controller.FlavorController
+	result := &hvs.FlavorBundleImportResult{
+		ConflictStrategy: conflictStrategy,
+		FlavorTemplates:  []hvs.FlavorBundleItemResult{},
+		Flavors:          []hvs.FlavorBundleItemResult{},
+	}
+	for _, template := range bundle.FlavorTemplates {
+		result.FlavorTemplates = append(result.FlavorTemplates, controller.importFlavorTemplate(template))
+	}
+
+	var flavorResults []*hvs.FlavorBundleItemResult
+	importedFlavors := make(map[uuid.UUID]*hvs.FlavorBundleItemResult)
+	var flavorgroupsForQueue []hvs.FlavorGroup
+	flavorgroupFlavorMap := make(map[uuid.UUID][]uuid.UUID)
+	for _, bundleGroup := range bundle.Flavorgroups {
+		flavorgroup, err := controller.getOrCreateFlavorgroup(bundleGroup)
+		for _, signedFlavor := range bundleGroup.SignedFlavors {
+			item, ok := importedFlavors[signedFlavor.Flavor.Meta.ID]
+			if !ok {
+				if err != nil {
+					item = newFlavorBundleItemResult(&signedFlavor.Flavor)
+					failFlavorBundleItem(item, "Failed to create flavorgroup "+bundleGroup.Name, err)
+				} else {
+					item = controller.importFlavor(signedFlavor.Flavor, conflictStrategy)
+				}
+				importedFlavors[signedFlavor.Flavor.Meta.ID] = item
+				flavorResults = append(flavorResults, item)
+			}
+			if err != nil || !flavorBundleItemImported(item) {
+				continue
+			}
+
+			flavorId := item.ID
+			if item.ImportedID != nil {
+				flavorId = *item.ImportedID
+			}
+			if _, linkErr := fcon.FGStore.RetrieveFlavor(flavorgroup.ID, flavorId); linkErr == nil {
+				item.Flavorgroups = append(item.Flavorgroups, flavorgroup.Name)
+				continue
+			}
+			if _, linkErr := fcon.FGStore.AddFlavors(flavorgroup.ID, []uuid.UUID{flavorId}); linkErr != nil {
+				failFlavorBundleItem(item, "Failed to link flavor to flavorgroup "+flavorgroup.Name, linkErr)
+				continue
+			}
+			item.Flavorgroups = append(item.Flavorgroups, flavorgroup.Name)
+			if _, ok := flavorgroupFlavorMap[flavorgroup.ID]; !ok {
+				flavorgroupsForQueue = append(flavorgroupsForQueue, *flavorgroup)
+			}
+			flavorgroupFlavorMap[flavorgroup.ID] = append(flavorgroupFlavorMap[flavorgroup.ID], flavorId)
+		}
+	}
+	for _, item := range flavorResults {
+		result.Flavors = append(result.Flavors, *item)
+	}
+
+	// the hosts of the flavorgroups the flavors were linked to are verified against the imported flavors
+	if len(flavorgroupsForQueue) > 0 {
+		go fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorgroupsForQueue, nil, flavorgroupFlavorMap, false)
+	}
+	return result
+}
+
+// importFlavorTemplate creates a flavor template of the bundle with its ID. An existing template is kept as is and is
+// recovered when it was deleted.
+func (controller FlavorBundleController) importFlavorTemplate(template hvs.FlavorTemplate) hvs.FlavorBundleItemResult {
+	defaultLog.Trace("controllers/flavor_bundle_controller:importFlavorTemplate() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:importFlavorTemplate() Leaving")
+
+	ftStore := controller.FlavorController.FTStore
+	item := hvs.FlavorBundleItemResult{ID: template.ID, Label: template.Label}
+	if _, err := ftStore.Retrieve(template.ID, false); err == nil {
+		item.Status = hvs.FlavorBundleImportExists
+		return item
+	}
+	if existing, err := ftStore.Retrieve(template.ID, true); err == nil {
+		if err = ftStore.Recover([]string{existing.Label}); err != nil {
+			failFlavorBundleItem(&item, "Failed to recover flavor template", err)
+			return item
+		}
+		item.Status = hvs.FlavorBundleImportExists
+		return item
+	}
+	if _, err := ftStore.Create(&template); err != nil {
+		failFlavorBundleItem(&item, "Failed to create flavor template", err)
+		return item
+	}
+	item.Status = hvs.FlavorBundleImportCreated
+	return item
+}
+
+// getOrCreateFlavorgroup returns the flavorgroup of the name of the bundle flavorgroup, it is created with the match
+// policies of the bundle flavorgroup when it does not exist. The match policies of an existing flavorgroup are kept.
+func (controller FlavorBundleController) getOrCreateFlavorgroup(bundleGroup hvs.FlavorBundleGroup) (*hvs.FlavorGroup, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:getOrCreateFlavorgroup() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:getOrCreateFlavorgroup() Leaving")
+
+	fgStore := controller.FlavorController.FGStore
+	flavorgroups, err := fgStore.Search(&dm.FlavorGroupFilterCriteria{NameEqualTo: bundleGroup.Name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error searching flavorgroup %s", bundleGroup.Name)
+	}
+	if len(flavorgroups) > 0 {
+		return &flavorgroups[0], nil
+	}
+	if len(bundleGroup.MatchPolicies) == 0 {
+		fg := utils.CreateFlavorGroupByName(bundleGroup.Name)
+		return fgStore.Create(&fg)
+	}
+	return fgStore.Create(&hvs.FlavorGroup{Name: bundleGroup.Name, MatchPolicies: bundleGroup.MatchPolicies})
+}
+
+// importFlavor imports a flavor of the bundle signed with the flavor signing key. The flavor conflicts with an
+// existing flavor of the same ID or label unless both have the same digest, in which case the existing flavor is used.
+func (controller FlavorBundleController) importFlavor(flavor hvs.Flavor, conflictStrategy string) *hvs.FlavorBundleItemResult {
+	defaultLog.Trace("controllers/flavor_bundle_controller:importFlavor() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:importFlavor() Leaving")
+
+	fcon := controller.FlavorController
+	item := newFlavorBundleItemResult(&flavor)
+	existing, err := controller.findExistingFlavor(flavor.Meta.ID, item.Label)
+	if err != nil {
+		failFlavorBundleItem(item, "Failed to search existing flavors", err)
+		return item
+	}
+	if existing == nil {
+		if _, err = controller.createFlavor(flavor); err != nil {
+			failFlavorBundleItem(item, "Failed to create flavor", err)
+			return item
+		}
+		item.Status = hvs.FlavorBundleImportCreated
+		return item
+	}
+
+	existingId := existing.Flavor.Meta.ID
+	existingDigest, err := existing.Flavor.GetFlavorDigest()
+	if err != nil {
+		failFlavorBundleItem(item, "Failed to compute digest of existing flavor", err)
+		return item
+	}
+	if hex.EncodeToString(existingDigest) == item.Digest {
+		if existingId != item.ID {
+			item.ImportedID = &existingId
+		}
+		item.Status = hvs.FlavorBundleImportUnchanged
+		return item
+	}
+
+	existingLabel, _ := existing.Flavor.Meta.Description[fm.Label].(string)
+	item.Conflict = &hvs.FlavorBundleConflict{
+		FlavorID: existingId,
+		Label:    existingLabel,
+		Digest:   hex.EncodeToString(existingDigest),
+	}
+	defaultLog.Infof("Flavor %s (%s) of flavor bundle conflicts with flavor %s (%s), applying %s strategy", item.ID,
+		item.Label, existingId, existingLabel, conflictStrategy)
+
+	switch conflictStrategy {
+	case hvs.FlavorBundleConflictReplace:
+		hostIds, err := utils.GetHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, existing)
+		if err != nil {
+			failFlavorBundleItem(item, "Failed to retrieve hosts associated with replaced flavor", err)
+			return item
+		}
+		// the conflicting flavor is kept when the imported flavor cannot be created
+		if _, err = controller.replaceFlavor(existingId, flavor); err != nil {
+			failFlavorBundleItem(item, "Failed to replace flavor", err)
+			return item
+		}
+		// the hosts verified with the replaced flavor no longer have their flavor
+		if len(hostIds) > 0 {
+			if err = fcon.HTManager.VerifyHostsAsync(hostIds, false, false); err != nil {
+				defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:importFlavor() Host to Flavor Verify Queue addition failed")
+			}
+		}
+		item.Status = hvs.FlavorBundleImportReplaced
+
+	case hvs.FlavorBundleConflictSupersede:
+		supersessions, err := fcon.FStore.SearchSupersessions(&dm.FlavorSupersessionFilterCriteria{FlavorIds: []uuid.UUID{existingId}})
+		if err != nil {
+			failFlavorBundleItem(item, "Failed to retrieve supersessions of conflicting flavor", err)
+			return item
+		}
+		if len(supersessions) > 0 {
+			failFlavorBundleItem(item, "Conflicting flavor "+existingId.String()+" is already superseded", nil)
+			return item
+		}
+		existingFlavorPart, ok := existing.Flavor.Meta.Description[fm.FlavorPart].(string)
+		if !ok {
+			failFlavorBundleItem(item, "Conflicting flavor "+existingId.String()+" has no flavor part", nil)
+			return item
+		}
+		var flavorPart fc.FlavorPart
+		if err = (&flavorPart).Parse(existingFlavorPart); err != nil {
+			failFlavorBundleItem(item, "Failed to parse flavor part of conflicting flavor", err)
+			return item
+		}
+		var importedFlavorPart fc.FlavorPart
+		if part, _ := flavor.Meta.Description[fm.FlavorPart].(string); (&importedFlavorPart).Parse(part) != nil || importedFlavorPart != flavorPart {
+			failFlavorBundleItem(item, "Conflicting flavor "+existingId.String()+" is a "+flavorPart.String()+
+				" flavor, it can only be superseded by a flavor of the same flavor part", nil)
+			return item
+		}
+
+		// the superseding flavor cannot reuse the ID or the label of the flavor it supersedes
+		supersedingFlavor := flavor
+		supersedingFlavor.Meta.Description = make(map[string]interface{}, len(flavor.Meta.Description))
+		for key, value := range flavor.Meta.Description {
+			supersedingFlavor.Meta.Description[key] = value
+		}
+		if existingId == item.ID {
+			supersedingFlavor.Meta.ID = uuid.New()
+			item.ImportedID = &supersedingFlavor.Meta.ID
+		}
+		if existingLabel == item.Label {
+			item.ImportedLabel = supersedingFlavorLabel(item, supersedingFlavor.Meta.ID)
+			supersedingFlavor.Meta.Description[fm.Label] = item.ImportedLabel
+		}
+		// the flavor is not created when the conflicting flavor cannot be superseded
+		signedFlavor, err := controller.signFlavor(supersedingFlavor)
+		if err != nil {
+			failFlavorBundleItem(item, "Failed to create flavor", err)
+			return item
+		}
+		retireAt := time.Now().Add(fcon.SupersessionGracePeriod)
+		_, err = fcon.FStore.CreateSuperseding(signedFlavor, &dm.FlavorSupersession{FlavorId: existingId, RetireAt: retireAt})
+		if err != nil {
+			failFlavorBundleItem(item, "Failed to create flavor superseding conflicting flavor", err)
+			return item
+		}
+		defaultLog.Infof("Flavor %s superseded by flavor %s, it will be retired at %s", existingId,
+			signedFlavor.Flavor.Meta.ID, retireAt.Format(time.RFC3339))
+		fcon.verifySupersededFlavorHosts([]*hvs.SignedFlavor{existing})
+		item.Status = hvs.FlavorBundleImportSuperseded
+
+	default:
+		item.Status = hvs.FlavorBundleImportSkipped
+	}
+	return item
+}
+
+// findExistingFlavor returns the flavor with the ID or else the label of an imported flavor, nil if there is none
+func (controller FlavorBundleController) findExistingFlavor(id uuid.UUID, label string) (*hvs.SignedFlavor, error) {
+	fStore := controller.FlavorController.FStore
+	existing, err := fStore.Retrieve(id)
+	if err == nil {
+		return existing, nil
+	}
+	if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return nil, err
+	}
+
+	flavors, err := fStore.Search(&dm.FlavorVerificationFC{
+		FlavorFC: dm.FlavorFilterCriteria{Key: fm.Label, Value: label},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(flavors) == 0 {
+		return nil, nil
+	}
+	return &flavors[0], nil
+}
+
+// createFlavor signs an imported flavor with the flavor signing key and creates it
+func (controller FlavorBundleController) createFlavor(flavor hvs.Flavor) (*hvs.SignedFlavor, error) {
+	signedFlavor, err := controller.signFlavor(flavor)
+	if err != nil {
+		return nil, err
+	}
+	return controller.FlavorController.FStore.Create(signedFlavor)
+}
+
+// replaceFlavor signs an imported flavor with the flavor signing key and replaces the flavor of flavorId with it
+func (controller FlavorBundleController) replaceFlavor(flavorId uuid.UUID, flavor hvs.Flavor) (*hvs.SignedFlavor, error) {
+	signedFlavor, err := controller.signFlavor(flavor)
+	if err != nil {
+		return nil, err
+	}
+	return controller.FlavorController.FStore.Replace(flavorId, signedFlavor)
+}
+
+func (controller FlavorBundleController) signFlavor(flavor hvs.Flavor) (*hvs.SignedFlavor, error) {
+	signingKey, err := controller.getFlavorSigningKey()
+	if err != nil {
+		return nil, err
+	}
+	signedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&flavor, signingKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error signing flavor")
+	}
+	return signedFlavor, nil
+}
+
+// supersedingFlavorLabel returns the label of an imported flavor superseding a flavor of the same label, which is
+// suffixed with the beginning of its digest, or of its ID when its digest could not be computed
+func supersedingFlavorLabel(item *hvs.FlavorBundleItemResult, flavorId uuid.UUID) string {
+	suffix := item.Digest
+	if suffix == "" {
+		suffix = flavorId.String()
+	}
+	if len(suffix) > 12 {
+		suffix = suffix[:12]
+	}
+	return item.Label + "_" + suffix
+}
+
+func newFlavorBundleItemResult(flavor *hvs.Flavor) *hvs.FlavorBundleItemResult {
+	label, _ := flavor.Meta.Description[fm.Label].(string)
+	item := &hvs.FlavorBundleItemResult{ID: flavor.Meta.ID, Label: label}
+	if digest, err := flavor.GetFlavorDigest(); err == nil {
+		item.Digest = hex.EncodeToString(digest)
+	}
+	return item
+}
+
+func failFlavorBundleItem(item *hvs.FlavorBundleItemResult, message string, err error) {
+	defaultLog.WithError(err).Errorf("controllers/flavor_bundle_controller:failFlavorBundleItem() %s %s: %s", item.ID, item.Label, message)
+	item.Status = hvs.FlavorBundleImportFailed
+	item.Error = message
+}
+
+// flavorBundleItemImported returns true when the flavor of the bundle exists in the HVS after its import
+func flavorBundleItemImported(item *hvs.FlavorBundleItemResult) bool {
+	switch item.Status {
+	case hvs.FlavorBundleImportCreated, hvs.FlavorBundleImportUnchanged, hvs.FlavorBundleImportReplaced,
+		hvs.FlavorBundleImportSuperseded:
+		return true
+	}
+	return false
+}
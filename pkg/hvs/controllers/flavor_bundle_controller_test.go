@@ -0,0 +1,347 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	smocks "github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust/mocks"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	fm "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	fu "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/util"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// newFlavorSigningCertStore returns a certificate store with a generated flavor signing key and certificate
+func newFlavorSigningCertStore() (*models.CertificatesStore, *rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 3072)
+	Expect(err).NotTo(HaveOccurred())
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "HVS Flavor Signing Certificate"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(certDer)
+	Expect(err).NotTo(HaveOccurred())
+
+	certStore := mocks.NewFakeCertificatesStore()
+	(*certStore)[models.CertTypesFlavorSigning.String()].Key = key
+	(*certStore)[models.CertTypesFlavorSigning.String()].Certificates = []x509.Certificate{*cert}
+	return certStore, key, cert
+}
+
+var _ = Describe("FlavorBundleController", func() {
+	var exportRouter, importRouter *mux.Router
+	var w *httptest.ResponseRecorder
+	var sourceFlavorStore, flavorStore *mocks.MockFlavorStore
+	var flavorGroupStore *mocks.MockFlavorgroupStore
+	var flavorTemplateStore *mocks.MockFlavorTemplateStore
+	var sourceFlavors []hvs.SignedFlavor
+	var sourceCert *x509.Certificate
+	var importController *controllers.FlavorBundleController
+	var trustedCertsDir string
+	var hostTrustManager *smocks.MockHostTrustManager
+	flavorgroupId := "ee37c360-7eae-4250-a677-6ee12adce8e2"
+
+	BeforeEach(func() {
+		var sourceKey *rsa.PrivateKey
+		var sourceCertStore *models.CertificatesStore
+		sourceCertStore, sourceKey, sourceCert = newFlavorSigningCertStore()
+
+		// the flavors of the exporting HVS are signed with its flavor signing key, one of them references a template
+		sourceTemplateStore := mocks.NewFakeFlavorTemplateStore()
+		sourceFlavorGroupStore := mocks.NewFakeFlavorgroupStore()
+		sourceFlavorStore = &mocks.MockFlavorStore{}
+		flavors, err := mocks.NewMockFlavorStore().Search(nil)
+		Expect(err).NotTo(HaveOccurred())
+		sourceFlavors = nil
+		for i, signedFlavor := range flavors {
+			if i == 0 {
+				signedFlavor.Flavor.Meta.Description["flavor_template_ids"] = []uuid.UUID{sourceTemplateStore.FlavorTemplates[0].ID}
+			}
+			resignedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&signedFlavor.Flavor, sourceKey)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sourceFlavorStore.Create(resignedFlavor)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sourceFlavorGroupStore.AddFlavors(uuid.MustParse(flavorgroupId), []uuid.UUID{resignedFlavor.Flavor.Meta.ID})
+			Expect(err).NotTo(HaveOccurred())
+			sourceFlavors = append(sourceFlavors, *resignedFlavor)
+		}
+		exportController := controllers.NewFlavorBundleController(&controllers.FlavorController{
+			FStore:    sourceFlavorStore,
+			FGStore:   sourceFlavorGroupStore,
+			HStore:    mocks.NewMockHostStore(),
+			FTStore:   sourceTemplateStore,
+			CertStore: sourceCertStore,
+			HTManager: hostTrustManager,
+		})
+		exportRouter = mux.NewRouter()
+		exportRouter.Handle("/flavor-bundles", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(exportController.Export))).Methods("GET")
+
+		// the importing HVS trusts the flavor signing certificate of the exporting HVS
+		trustedCertsDir, err = ioutil.TempDir("", "trustedflavorsigning")
+		Expect(err).NotTo(HaveOccurred())
+		certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: sourceCert.Raw})
+		Expect(ioutil.WriteFile(filepath.Join(trustedCertsDir, "source-hvs.pem"), certPem, 0600)).To(Succeed())
+
+		certStore, _, _ := newFlavorSigningCertStore()
+		flavorStore = &mocks.MockFlavorStore{}
+		flavorGroupStore = mocks.NewFakeFlavorgroupStore()
+		flavorTemplateStore = &mocks.MockFlavorTemplateStore{}
+		importController = controllers.NewFlavorBundleController(&controllers.FlavorController{
+			FStore:    flavorStore,
+			FGStore:   flavorGroupStore,
+			HStore:    mocks.NewMockHostStore(),
+			FTStore:   flavorTemplateStore,
+			CertStore: certStore,
+			HTManager: hostTrustManager,
+		})
+		importController.TrustedSigningCertsDir = trustedCertsDir
+		importRouter = mux.NewRouter()
+		importRouter.Handle("/flavor-bundles", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(importController.Import))).Methods("POST")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(trustedCertsDir)
+	})
+
+	exportBundle := func(query string) []byte {
+		req, err := http.NewRequest("GET", "/flavor-bundles"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		exportRouter.ServeHTTP(w, req)
+		return w.Body.Bytes()
+	}
+
+	importBundle := func(query string, body []byte) *hvs.FlavorBundleImportResult {
+		req, err := http.NewRequest("POST", "/flavor-bundles"+query, strings.NewReader(string(body)))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		importRouter.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return nil
+		}
+		var result hvs.FlavorBundleImportResult
+		Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+		return &result
+	}
+
+	// conflictingFlavor creates a flavor with the ID and label of the first exported flavor but a different content
+	conflictingFlavor := func() hvs.SignedFlavor {
+		flavor := sourceFlavors[0].Flavor
+		flavor.Meta.Description = map[string]interface{}{}
+		for key, value := range sourceFlavors[0].Flavor.Meta.Description {
+			flavor.Meta.Description[key] = value
+		}
+		flavor.Meta.Description["comment"] = "local flavor"
+		signedFlavor := hvs.SignedFlavor{Flavor: flavor, Signature: "c2lnbmF0dXJl"}
+		_, err := flavorStore.Create(&signedFlavor)
+		Expect(err).NotTo(HaveOccurred())
+		return signedFlavor
+	}
+
+	Describe("Export flavor bundle", func() {
+		Context("When the flavorgroup is selected by ID", func() {
+			It("Should return the flavorgroup, its flavors and their templates signed with the flavor signing key", func() {
+				body := exportBundle("?flavorgroupId=" + flavorgroupId)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var signedBundle hvs.SignedFlavorBundle
+				Expect(json.Unmarshal(body, &signedBundle)).To(Succeed())
+				signature, err := base64.StdEncoding.DecodeString(signedBundle.Signature)
+				Expect(err).NotTo(HaveOccurred())
+				digest := sha512.Sum384(signedBundle.Content)
+				Expect(rsa.VerifyPKCS1v15(sourceCert.PublicKey.(*rsa.PublicKey), crypto.SHA384, digest[:], signature)).To(Succeed())
+
+				var bundle hvs.FlavorBundle
+				Expect(json.Unmarshal(signedBundle.Content, &bundle)).To(Succeed())
+				Expect(bundle.Flavorgroups).To(HaveLen(1))
+				Expect(bundle.Flavorgroups[0].Name).To(Equal("hvs_flavorgroup_test1"))
+				Expect(bundle.Flavorgroups[0].MatchPolicies).To(HaveLen(3))
+				Expect(bundle.Flavorgroups[0].SignedFlavors).To(HaveLen(len(sourceFlavors)))
+				Expect(bundle.FlavorTemplates).To(HaveLen(1))
+				Expect(bundle.FlavorTemplates[0].Label).To(Equal("default-uefi"))
+			})
+		})
+		Context("When no flavorgroup is selected", func() {
+			It("Should fail with 400", func() {
+				exportBundle("")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the selected flavorgroup does not exist", func() {
+			It("Should fail with 400", func() {
+				exportBundle("?flavorgroupName=unknown")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Import flavor bundle", func() {
+		Context("When the bundle is signed by a trusted HVS", func() {
+			It("Should create the templates and the flavors signed with the local flavor signing key", func() {
+				result := importBundle("", exportBundle("?flavorgroupName=hvs_flavorgroup_test1"))
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(result.ConflictStrategy).To(Equal(hvs.FlavorBundleConflictSkip))
+				Expect(result.FlavorTemplates).To(HaveLen(1))
+				Expect(result.FlavorTemplates[0].Status).To(Equal(hvs.FlavorBundleImportCreated))
+				Expect(result.Flavors).To(HaveLen(len(sourceFlavors)))
+				for _, item := range result.Flavors {
+					Expect(item.Status).To(Equal(hvs.FlavorBundleImportCreated))
+					Expect(item.Flavorgroups).To(Equal([]string{"hvs_flavorgroup_test1"}))
+				}
+
+				_, localCerts, err := (*importController.FlavorController.CertStore).GetKeyAndCertificates(models.CertTypesFlavorSigning.String())
+				Expect(err).NotTo(HaveOccurred())
+				imported, err := flavorStore.Retrieve(sourceFlavors[0].Flavor.Meta.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(imported.Verify(localCerts[0].PublicKey.(*rsa.PublicKey))).To(Succeed())
+				flavorIds, err := flavorGroupStore.SearchFlavors(uuid.MustParse(flavorgroupId))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorIds).To(HaveLen(len(sourceFlavors)))
+
+				// the flavors of a bundle imported again are unchanged
+				result = importBundle("", exportBundle("?flavorgroupName=hvs_flavorgroup_test1"))
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(result.FlavorTemplates[0].Status).To(Equal(hvs.FlavorBundleImportExists))
+				for _, item := range result.Flavors {
+					Expect(item.Status).To(Equal(hvs.FlavorBundleImportUnchanged))
+				}
+				flavorIds, err = flavorGroupStore.SearchFlavors(uuid.MustParse(flavorgroupId))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorIds).To(HaveLen(len(sourceFlavors)))
+			})
+		})
+		Context("When the bundle is signed by an untrusted HVS", func() {
+			It("Should fail with 400", func() {
+				body := exportBundle("?flavorgroupId=" + flavorgroupId)
+				Expect(os.Remove(filepath.Join(trustedCertsDir, "source-hvs.pem"))).To(Succeed())
+				importBundle("", body)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(flavorStore.Search(nil)).To(BeEmpty())
+			})
+		})
+		Context("When the content of the bundle is modified", func() {
+			It("Should fail with 400", func() {
+				var signedBundle hvs.SignedFlavorBundle
+				Expect(json.Unmarshal(exportBundle("?flavorgroupId="+flavorgroupId), &signedBundle)).To(Succeed())
+				signedBundle.Content = []byte(strings.Replace(string(signedBundle.Content), "hvs_flavorgroup_test1", "hvs_flavorgroup_test2", 1))
+				body, err := json.Marshal(signedBundle)
+				Expect(err).NotTo(HaveOccurred())
+				importBundle("", body)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the conflict strategy is invalid", func() {
+			It("Should fail with 400", func() {
+				importBundle("?conflictStrategy=merge", exportBundle("?flavorgroupId="+flavorgroupId))
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When a flavor conflicts with an existing flavor and the strategy is skip", func() {
+			It("Should keep the existing flavor and report the conflict", func() {
+				existing := conflictingFlavor()
+				result := importBundle("?conflictStrategy=skip", exportBundle("?flavorgroupId="+flavorgroupId))
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(result.Flavors[0].Status).To(Equal(hvs.FlavorBundleImportSkipped))
+				Expect(result.Flavors[0].Conflict).NotTo(BeNil())
+				Expect(result.Flavors[0].Conflict.FlavorID).To(Equal(existing.Flavor.Meta.ID))
+				Expect(result.Flavors[0].Conflict.Digest).NotTo(Equal(result.Flavors[0].Digest))
+
+				retrieved, err := flavorStore.Retrieve(existing.Flavor.Meta.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(retrieved.Flavor.Meta.Description["comment"]).To(Equal("local flavor"))
+			})
+		})
+		Context("When a flavor conflicts with an existing flavor and the strategy is replace", func() {
+			It("Should replace the existing flavor", func() {
+				existing := conflictingFlavor()
+				result := importBundle("?conflictStrategy=replace", exportBundle("?flavorgroupId="+flavorgroupId))
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(result.Flavors[0].Status).To(Equal(hvs.FlavorBundleImportReplaced))
+
+				retrieved, err := flavorStore.Retrieve(existing.Flavor.Meta.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(retrieved.Flavor.Meta.Description).NotTo(HaveKey("comment"))
+			})
+		})
+		Context("When a flavor conflicts with an existing flavor and the strategy is supersede", func() {
+			It("Should create the flavor with a new ID and label and supersede the existing flavor", func() {
+				existing := conflictingFlavor()
+				result := importBundle("?conflictStrategy=supersede", exportBundle("?flavorgroupId="+flavorgroupId))
+				Expect(w.Code).To(Equal(http.StatusOK))
+				item := result.Flavors[0]
+				Expect(item.Status).To(Equal(hvs.FlavorBundleImportSuperseded))
+				Expect(item.ImportedID).NotTo(BeNil())
+				Expect(item.ImportedLabel).To(Equal(item.Label + "_" + item.Digest[:12]))
+
+				imported, err := flavorStore.Retrieve(*item.ImportedID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(imported.Flavor.Meta.Description[fm.Label]).To(Equal(item.ImportedLabel))
+				supersessions, err := flavorStore.SearchSupersessions(&models.FlavorSupersessionFilterCriteria{
+					FlavorIds: []uuid.UUID{existing.Flavor.Meta.ID}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(supersessions).To(HaveLen(1))
+				Expect(supersessions[0].SupersededBy).To(Equal(*item.ImportedID))
+			})
+		})
+		Context("When a flavor conflicts with an existing flavor of another flavor part and the strategy is supersede", func() {
+			It("Should fail to import the flavor without creating it", func() {
+				existing := conflictingFlavor()
+				otherFlavorPart := "PLATFORM"
+				if existing.Flavor.Meta.Description[fm.FlavorPart] == otherFlavorPart {
+					otherFlavorPart = "OS"
+				}
+				existing.Flavor.Meta.Description[fm.FlavorPart] = otherFlavorPart
+
+				result := importBundle("?conflictStrategy=supersede", exportBundle("?flavorgroupId="+flavorgroupId))
+				Expect(w.Code).To(Equal(http.StatusOK))
+				item := result.Flavors[0]
+				Expect(item.Status).To(Equal(hvs.FlavorBundleImportFailed))
+
+				flavors, err := flavorStore.Search(nil)
+				Expect(err).NotTo(HaveOccurred())
+				for _, flavor := range flavors {
+					Expect(flavor.Flavor.Meta.Description[fm.Label]).NotTo(HavePrefix(item.Label + "_"))
+				}
+				supersessions, err := flavorStore.SearchSupersessions(&models.FlavorSupersessionFilterCriteria{
+					FlavorIds: []uuid.UUID{existing.Flavor.Meta.ID}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(supersessions).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/antchfx/jsonquery"
 	"github.com/google/uuid"
@@ -21,6 +22,7 @@ import (
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	dm "github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/frs"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/auth"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
@@ -35,6 +37,7 @@ import (
 	fType "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/types"
 	fu "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/util"
 	hcType "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/verifier"
 	ct "github.com/intel-secl/intel-secl/v4/pkg/model/aas"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	"github.com/pkg/errors"
@@ -49,12 +52,20 @@ type FlavorController struct {
 	CertStore *dm.CertificatesStore
 	HostCon   HostController
 	FTStore   domain.FlavorTemplateStore
-	IsExsi    bool
+	HSStore   domain.HostStatusStore
+	// FlavorVerifier is used for the dry-run trust evaluation of candidate flavors
+	FlavorVerifier verifier.Verifier
+	// SupersessionGracePeriod is how long a flavor superseded by a created flavor stays active
+	SupersessionGracePeriod time.Duration
+	IsExsi                  bool
 }
 
-var flavorSearchParams = map[string]bool{"id": true, "key": true, "value": true, "flavorgroupId": true, "flavorParts": true}
+var flavorSearchParams = map[string]bool{"id": true, "key": true, "value": true, "flavorgroupId": true, "flavorParts": true,
+	"sortBy": true, "orderBy": true, "limit": true, "offset": true}
 
-func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs domain.HostStore, tcs domain.TagCertificateStore, htm domain.HostTrustManager, certStore *dm.CertificatesStore, hcConfig domain.HostControllerConfig, fts domain.FlavorTemplateStore) *FlavorController {
+var flavorSortFields = map[string]bool{dm.SortById: true, dm.SortByLabel: true, dm.SortByCreatedAt: true}
+
+func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs domain.HostStore, tcs domain.TagCertificateStore, htm domain.HostTrustManager, certStore *dm.CertificatesStore, hcConfig domain.HostControllerConfig, fts domain.FlavorTemplateStore, hss domain.HostStatusStore) *FlavorController {
 	// certStore should have an entry for Flavor Signing CA
 	if _, found := (*certStore)[dm.CertTypesFlavorSigning.String()]; !found {
 		defaultLog.Errorf("controllers/flavor_controller:NewFlavorController() %s : Flavor Signing KeyPair not found in CertStore", commLogMsg.AppRuntimeErr)
@@ -73,15 +84,25 @@ func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs
 		HCConfig: hcConfig,
 	}
 
+	// flavors can still be created without the verifier, only the dry-run evaluation is unavailable. The dry-run
+	// does not verify the flavor signatures, the previous flavor signing certificates are not needed
+	flavorVerifier, err := utils.NewFlavorVerifier(certStore, nil)
+	if err != nil {
+		defaultLog.WithError(err).Warn("controllers/flavor_controller:NewFlavorController() Flavor verifier could not be initialized")
+	}
+
 	return &FlavorController{
-		FStore:    fs,
-		FGStore:   fgs,
-		HStore:    hs,
-		TCStore:   tcs,
-		HTManager: htm,
-		CertStore: certStore,
-		HostCon:   hController,
-		FTStore:   fts,
+		FStore:                  fs,
+		FGStore:                 fgs,
+		HStore:                  hs,
+		TCStore:                 tcs,
+		HTManager:               htm,
+		CertStore:               certStore,
+		HostCon:                 hController,
+		FTStore:                 fts,
+		HSStore:                 hss,
+		FlavorVerifier:          flavorVerifier,
+		SupersessionGracePeriod: frs.DefaultGracePeriod,
 	}
 }
 
@@ -89,7 +110,7 @@ func (fcon *FlavorController) Create(w http.ResponseWriter, r *http.Request) (in
 	defaultLog.Trace("controllers/flavor_controller:Create() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:Create() Leaving")
 
-	flavorCreateReq, err := getFlavorCreateReq(r)
+	flavorCreateReq, err := getFlavorCreateReq(r, fcon.HostCon.HCConfig.SimulatedHostsEnabled)
 	if err != nil {
 		if strings.Contains(err.Error(), "Invalid Content-Type") {
 			return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
@@ -135,6 +156,12 @@ func (fcon *FlavorController) Create(w http.ResponseWriter, r *http.Request) (in
 	signedFlavors, err = fcon.createFlavors(flavorCreateReq)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/flavor_controller:Create() Error creating flavors")
+		if _, ok := err.(*commErr.BadRequestError); ok {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+		}
+		if errors.Cause(err) == dm.ErrFlavorAlreadySuperseded {
+			return nil, http.StatusConflict, &commErr.ResourceError{Message: "A superseded flavor is already superseded by another flavor"}
+		}
 		if strings.Contains(err.Error(), "duplicate key") {
 			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor with same id/label already exists"}
 		}
@@ -169,6 +196,13 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 	var platformFlavor *fType.PlatformFlavor
 	flavorFlavorPartMap := make(map[fc.FlavorPart][]hvs.SignedFlavor)
 
+	// validate the superseded flavors before any flavor is created
+	supersededFlavors, err := fcon.getSupersededFlavors(flavorReq.SupersededFlavorIds, getCreatedFlavorParts(flavorReq))
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:createFlavors() Invalid superseded flavors")
+		return nil, err
+	}
+
 	if flavorReq.ConnectionString != "" {
 		// get flavor from host
 		// get host manifest from the host
@@ -289,7 +323,6 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 			return nil, errors.New("Valid flavor content must be given")
 		}
 	}
-	var err error
 	// add all flavorparts to default flavorgroups if flavorgroup name is not given
 	if flavorReq.FlavorgroupNames == nil && len(flavorReq.FlavorParts) == 0 {
 		for _, flavorPart := range fc.GetFlavorTypes() {
@@ -316,10 +349,122 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 		defaultLog.Error("controllers/flavor_controller:createFlavors() Cannot create flavors")
 		return nil, errors.New("Unable to create Flavors")
 	}
-	return fcon.addFlavorToFlavorgroup(flavorFlavorPartMap, flavorgroups)
+
+	// each superseded flavor is superseded by the created flavor of the same flavor part
+	for flavorPart, supersededFlavor := range supersededFlavors {
+		if len(flavorFlavorPartMap[flavorPart]) != 1 {
+			defaultLog.Errorf("controllers/flavor_controller:createFlavors() %d %s flavors created to supersede flavor %s",
+				len(flavorFlavorPartMap[flavorPart]), flavorPart, supersededFlavor.Flavor.Meta.ID)
+			return nil, &commErr.BadRequestError{Message: "Superseded flavor with ID " + supersededFlavor.Flavor.Meta.ID.String() +
+				" must be superseded by exactly one created " + flavorPart.String() + " flavor"}
+		}
+	}
+
+	signedFlavors, err := fcon.addFlavorToFlavorgroup(flavorFlavorPartMap, flavorgroups, supersededFlavors)
+	if err != nil {
+		return nil, err
+	}
+	return signedFlavors, nil
+}
+
+// getCreatedFlavorParts returns the flavor parts of the flavors to be created, i.e. the flavor parts requested when the
+// flavors are created from a host and the flavor parts of the flavor content otherwise
+func getCreatedFlavorParts(flavorReq dm.FlavorCreateRequest) map[fc.FlavorPart]bool {
+	createdFlavorParts := make(map[fc.FlavorPart]bool)
+	if flavorReq.ConnectionString != "" {
+		flavorParts := flavorReq.FlavorParts
+		if len(flavorParts) == 0 {
+			flavorParts = fc.GetFlavorTypes()
+		}
+		for _, flavorPart := range flavorParts {
+			createdFlavorParts[flavorPart] = true
+		}
+		return createdFlavorParts
+	}
+
+	for _, flavor := range flavorReq.FlavorCollection.Flavors {
+		var flavorPart fc.FlavorPart
+		if part, ok := flavor.Flavor.Meta.Description[fm.FlavorPart].(string); ok && (&flavorPart).Parse(part) == nil {
+			createdFlavorParts[flavorPart] = true
+		}
+	}
+	return createdFlavorParts
+}
+
+// getSupersededFlavors retrieves the flavors to be superseded by the created flavors, mapped by their flavor part.
+// Only one flavor per flavor part can be superseded since it is superseded by the created flavor of the same part, and
+// a flavor can only be superseded when a flavor of its part is created.
+func (fcon *FlavorController) getSupersededFlavors(flavorIds []uuid.UUID, createdFlavorParts map[fc.FlavorPart]bool) (map[fc.FlavorPart]*hvs.SignedFlavor, error) {
+	defaultLog.Trace("controllers/flavor_controller:getSupersededFlavors() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getSupersededFlavors() Leaving")
+
+	supersededFlavors := make(map[fc.FlavorPart]*hvs.SignedFlavor)
+	if len(flavorIds) == 0 {
+		return supersededFlavors, nil
+	}
+
+	supersessions, err := fcon.FStore.SearchSupersessions(&dm.FlavorSupersessionFilterCriteria{FlavorIds: flavorIds})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving flavor supersessions")
+	}
+	if len(supersessions) > 0 {
+		return nil, errors.Wrapf(dm.ErrFlavorAlreadySuperseded, "Flavor with ID %s", supersessions[0].FlavorId)
+	}
+
+	for _, flavorId := range flavorIds {
+		signedFlavor, err := fcon.FStore.Retrieve(flavorId)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				return nil, &commErr.BadRequestError{Message: "Superseded flavor with ID " + flavorId.String() + " does not exist"}
+			}
+			return nil, errors.Wrapf(err, "Error retrieving superseded flavor %s", flavorId)
+		}
+
+		supersededFlavorPart, ok := signedFlavor.Flavor.Meta.Description[fm.FlavorPart].(string)
+		if !ok {
+			return nil, errors.Errorf("Superseded flavor %s has no flavor part", flavorId)
+		}
+		var flavorPart fc.FlavorPart
+		if err := (&flavorPart).Parse(supersededFlavorPart); err != nil {
+			return nil, errors.Wrapf(err, "Error parsing flavor part of superseded flavor %s", flavorId)
+		}
+		if !createdFlavorParts[flavorPart] {
+			return nil, &commErr.BadRequestError{Message: "Superseded flavor with ID " + flavorId.String() + " is a " +
+				flavorPart.String() + " flavor, no " + flavorPart.String() + " flavor is created"}
+		}
+		if _, ok := supersededFlavors[flavorPart]; ok {
+			return nil, &commErr.BadRequestError{Message: "Only one " + flavorPart.String() + " flavor can be superseded"}
+		}
+		supersededFlavors[flavorPart] = signedFlavor
+	}
+	return supersededFlavors, nil
+}
+
+// verifySupersededFlavorHosts adds the hosts associated with the superseded flavors to the flavor verification queue,
+// since their trust cache is no longer valid
+func (fcon *FlavorController) verifySupersededFlavorHosts(supersededFlavors []*hvs.SignedFlavor) {
+	defaultLog.Trace("controllers/flavor_controller:verifySupersededFlavorHosts() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:verifySupersededFlavorHosts() Leaving")
+
+	var hostIdsForQueue []uuid.UUID
+	for _, supersededFlavor := range supersededFlavors {
+		hostIds, err := utils.GetHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, supersededFlavor)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("controllers/flavor_controller:verifySupersededFlavorHosts() Failed to retrieve hosts "+
+				"associated with superseded flavor %s", supersededFlavor.Flavor.Meta.ID)
+			continue
+		}
+		hostIdsForQueue = append(hostIdsForQueue, hostIds...)
+	}
+
+	if len(hostIdsForQueue) >= 1 {
+		if err := fcon.HTManager.VerifyHostsAsync(hostIdsForQueue, false, false); err != nil {
+			defaultLog.WithError(err).Error("controllers/flavor_controller:verifySupersededFlavorHosts() Host to Flavor Verify Queue addition failed")
+		}
+	}
 }
 
-func getFlavorCreateReq(r *http.Request) (dm.FlavorCreateRequest, error) {
+func getFlavorCreateReq(r *http.Request, simulatedHostsEnabled bool) (dm.FlavorCreateRequest, error) {
 	defaultLog.Trace("controllers/flavor_controller:getFlavorCreateReq() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:getFlavorCreateReq() Leaving")
 
@@ -345,7 +490,7 @@ func getFlavorCreateReq(r *http.Request) (dm.FlavorCreateRequest, error) {
 	}
 
 	defaultLog.Debug("Validating create flavor request")
-	err = validateFlavorCreateRequest(flavorCreateReq)
+	err = validateFlavorCreateRequest(flavorCreateReq, simulatedHostsEnabled)
 	if err != nil {
 		secLog.WithError(err).Errorf("controllers/flavor_controller:CreateFlavors() %s Invalid flavor create criteria", commLogMsg.InvalidInputBadParam)
 		return flavorCreateReq, errors.New("Invalid flavor create criteria")
@@ -419,7 +564,10 @@ func (fcon *FlavorController) findTemplatesToApply(hostManifest *hcType.HostMani
 	return filteredTemplates, nil
 }
 
-func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.FlavorPart][]hvs.SignedFlavor, fgs []hvs.FlavorGroup) ([]hvs.SignedFlavor, error) {
+// addFlavorToFlavorgroup creates the flavors and links them to the flavorgroups. A created flavor supersedes the flavor
+// of its flavor part in supersededFlavors, the flavor is created and the other flavor is superseded atomically.
+func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.FlavorPart][]hvs.SignedFlavor, fgs []hvs.FlavorGroup,
+	supersededFlavors map[fc.FlavorPart]*hvs.SignedFlavor) ([]hvs.SignedFlavor, error) {
 	defaultLog.Trace("controllers/flavor_controller:addFlavorToFlavorgroup() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:addFlavorToFlavorgroup() Leaving")
 
@@ -430,12 +578,28 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 	var flavorgroupsForQueue []hvs.FlavorGroup
 	fetchHostData := false
 	var fgHostIds []uuid.UUID
+	retireAt := time.Now().Add(fcon.SupersessionGracePeriod)
+	var superseded []*hvs.SignedFlavor
 
 	for flavorPart, signedFlavors := range flavorFlavorPartMap {
 		defaultLog.Debugf("Creating flavors for fp %s", flavorPart.String())
 		for _, signedFlavor := range signedFlavors {
 			flavorgroups := []hvs.FlavorGroup{}
-			signedFlavorCreated, err := fcon.FStore.Create(&signedFlavor)
+			var signedFlavorCreated *hvs.SignedFlavor
+			var err error
+			if supersededFlavor, ok := supersededFlavors[flavorPart]; ok {
+				signedFlavorCreated, err = fcon.FStore.CreateSuperseding(&signedFlavor, &dm.FlavorSupersession{
+					FlavorId: supersededFlavor.Flavor.Meta.ID,
+					RetireAt: retireAt,
+				})
+				if err == nil {
+					defaultLog.Infof("Flavor %s superseded by flavor %s, it will be retired at %s", supersededFlavor.Flavor.Meta.ID,
+						signedFlavorCreated.Flavor.Meta.ID, retireAt.Format(time.RFC3339))
+					superseded = append(superseded, supersededFlavor)
+				}
+			} else {
+				signedFlavorCreated, err = fcon.FStore.Create(&signedFlavor)
+			}
 			if err != nil {
 				defaultLog.WithError(err).Errorf("controllers/flavor_controller: addFlavorToFlavorgroup() : "+
 					"Unable to create flavors of %s flavorPart", flavorPart.String())
@@ -560,6 +724,9 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 	}
 	// get all the hosts that belong to the same flavor group and add them to flavor-verify queue
 	go fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorgroupsForQueue, fgHostIds, flavorgroupFlavorMap, fetchHostData)
+	if len(superseded) > 0 {
+		fcon.verifySupersededFlavorHosts(superseded)
+	}
 	return returnSignedFlavors, nil
 }
 
@@ -672,6 +839,13 @@ func (fcon *FlavorController) Search(w http.ResponseWriter, r *http.Request) (in
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
 	}
 
+	pageCriteria, err := utils.ParsePageCriteria(r.URL.Query(), flavorSortFields)
+	if err != nil {
+		secLog.Errorf("controllers/flavor_controller:Search()  %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	filterCriteria.PageCriteria = *pageCriteria
+
 	signedFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
 		FlavorFC: *filterCriteria,
 	})
@@ -679,9 +853,21 @@ func (fcon *FlavorController) Search(w http.ResponseWriter, r *http.Request) (in
 		secLog.WithError(err).Error("controllers/flavor_controller:Search() Flavor get all failed")
 		return nil, http.StatusInternalServerError, errors.Errorf("Unable to search Flavors")
 	}
+	signedFlavorCollection := hvs.SignedFlavorCollection{SignedFlavors: signedFlavors}
+
+	if pageCriteria.IsPaged() {
+		total, err := fcon.FStore.Count(&dm.FlavorVerificationFC{
+			FlavorFC: *filterCriteria,
+		})
+		if err != nil {
+			secLog.WithError(err).Error("controllers/flavor_controller:Search() Flavor count failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Unable to search Flavors")
+		}
+		signedFlavorCollection.PageInfo = hvs.NewPageInfo(total, pageCriteria.Offset, len(signedFlavors))
+	}
 
 	secLog.Infof("%s: Return flavor query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
-	return hvs.SignedFlavorCollection{SignedFlavors: signedFlavors}, http.StatusOK, nil
+	return signedFlavorCollection, http.StatusOK, nil
 }
 
 func (fcon *FlavorController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -702,7 +888,7 @@ func (fcon *FlavorController) Delete(w http.ResponseWriter, r *http.Request) (in
 		}
 	}
 
-	hostIdsForQueue, err := getHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, signedFlavor)
+	hostIdsForQueue, err := utils.GetHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, signedFlavor)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/flavor_controller:Delete() Failed to retrieve hosts " +
 			"associated with flavor")
@@ -729,47 +915,6 @@ func (fcon *FlavorController) Delete(w http.ResponseWriter, r *http.Request) (in
 	return nil, http.StatusNoContent, nil
 }
 
-func getHostsAssociatedWithFlavor(hStore domain.HostStore, fgStore domain.FlavorGroupStore, flavor *hvs.SignedFlavor) ([]uuid.UUID, error) {
-	defaultLog.Trace("controllers/flavor_controller:getHostsAssociatedWithFlavor() Entering")
-	defer defaultLog.Trace("controllers/flavor_controller:getHostsAssociatedWithFlavor() Leaving")
-
-	id := flavor.Flavor.Meta.ID
-	flavorGroups, err := fgStore.Search(&dm.FlavorGroupFilterCriteria{FlavorId: &id})
-	if err != nil {
-		return nil, errors.Wrapf(err, "controllers/flavor_controller:getHostsAssociatedWithFlavor() Failed to retrieve flavorgroups "+
-			"associated with flavor %v for trust re-verification", id)
-	}
-
-	var hostIdsForQueue []uuid.UUID
-	for _, flavorGroup := range flavorGroups {
-		//Host unique flavors are associated with only host_unique flavorgroup and associated with only one host uniquely
-		if flavorGroup.Name == dm.FlavorGroupsHostUnique.String() {
-			hardwareUUID, err := uuid.Parse(flavor.Flavor.Meta.Description[fm.HardwareUUID].(string))
-			if err != nil {
-				return nil, errors.Wrap(err, "controllers/flavor_controller:getHostsAssociatedWithFlavor() Failed to parse hardwareUUID")
-			}
-			hosts, err := hStore.Search(&dm.HostFilterCriteria{
-				HostHardwareId: hardwareUUID,
-			}, nil)
-			if err != nil {
-				return nil, errors.Wrapf(err, "controllers/flavor_controller:getHostsAssociatedWithFlavor() Failed to retrieve hosts "+
-					"associated with flavor %v for trust re-verification", id)
-			}
-			if len(hosts) > 0 {
-				hostIdsForQueue = append(hostIdsForQueue, hosts[0].Id)
-				break
-			}
-		}
-		hostIds, err := fgStore.SearchHostsByFlavorGroup(flavorGroup.ID)
-		if err != nil {
-			return nil, errors.Wrapf(err, "controllers/flavor_controller:getHostsAssociatedWithFlavor() Failed to retrieve hosts "+
-				"associated with flavorgroup %v for trust re-verification", flavorGroup.ID)
-		}
-		hostIdsForQueue = append(hostIdsForQueue, hostIds...)
-	}
-	return hostIdsForQueue, nil
-}
-
 func (fcon *FlavorController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/flavor_controller:Retrieve() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:Retrieve() Leaving")
@@ -791,6 +936,280 @@ func (fcon *FlavorController) Retrieve(w http.ResponseWriter, r *http.Request) (
 
 }
 
+// Evaluate verifies candidate flavors against the last host manifests of the selected hosts and returns the trust
+// results of every host. It is a dry-run, neither the flavors nor the results are stored and no host is added to the
+// flavor verify queue.
+func (fcon *FlavorController) Evaluate(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Evaluate() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Evaluate() Leaving")
+
+	evaluateReq, err := getFlavorEvaluateReq(r)
+	if err != nil {
+		if strings.Contains(err.Error(), "Invalid Content-Type") {
+			return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+		}
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	if fcon.FlavorVerifier == nil {
+		defaultLog.Error("controllers/flavor_controller:Evaluate() Flavor verifier is not initialized")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Flavor verifier is not available"}
+	}
+
+	flavors, err := fcon.getCandidateFlavors(evaluateReq)
+	if err != nil {
+		if _, ok := err.(*commErr.BadRequestError); ok {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+		}
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Evaluate() Error creating candidate flavors")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error creating candidate flavors"}
+	}
+
+	hosts, err := fcon.getEvaluationHosts(evaluateReq.HostSelector)
+	if err != nil {
+		if _, ok := err.(*commErr.BadRequestError); ok {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+		}
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Evaluate() Error retrieving the selected hosts")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error retrieving the selected hosts"}
+	}
+
+	evaluationCollection := hvs.FlavorEvaluationCollection{
+		Flavors:         []hvs.Flavors{},
+		HostEvaluations: []hvs.HostFlavorEvaluation{},
+	}
+	for _, flavor := range flavors {
+		evaluationCollection.Flavors = append(evaluationCollection.Flavors, hvs.Flavors{Flavor: flavor})
+	}
+	for _, host := range hosts {
+		evaluationCollection.HostEvaluations = append(evaluationCollection.HostEvaluations, fcon.evaluateHost(host, flavors))
+	}
+
+	secLog.Infof("%s: %d flavors evaluated against %d hosts by: %s", commLogMsg.AuthorizedAccess, len(flavors), len(hosts), r.RemoteAddr)
+	return evaluationCollection, http.StatusOK, nil
+}
+
+func getFlavorEvaluateReq(r *http.Request) (dm.FlavorEvaluateRequest, error) {
+	defaultLog.Trace("controllers/flavor_controller:getFlavorEvaluateReq() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getFlavorEvaluateReq() Leaving")
+
+	var evaluateReq dm.FlavorEvaluateRequest
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_controller:getFlavorEvaluateReq() Invalid Content-Type")
+		return evaluateReq, errors.New("Invalid Content-Type")
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:getFlavorEvaluateReq() The request body is not provided")
+		return evaluateReq, errors.New("The request body is not provided")
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&evaluateReq)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:getFlavorEvaluateReq() %s :  Failed to decode request body as flavor evaluate request", commLogMsg.InvalidInputBadEncoding)
+		return evaluateReq, errors.New("Unable to decode JSON request body")
+	}
+
+	err = validateFlavorEvaluateRequest(evaluateReq)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:getFlavorEvaluateReq() %s Invalid flavor evaluate request", commLogMsg.InvalidInputBadParam)
+		return evaluateReq, err
+	}
+	return evaluateReq, nil
+}
+
+func validateFlavorEvaluateRequest(evaluateReq dm.FlavorEvaluateRequest) error {
+	defaultLog.Trace("controllers/flavor_controller:validateFlavorEvaluateRequest() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorEvaluateRequest() Leaving")
+
+	hasFlavors := len(evaluateReq.FlavorCollection.Flavors) > 0
+	hasTemplate := evaluateReq.FlavorTemplate != nil
+	if hasFlavors == hasTemplate {
+		return errors.New("Either flavor content or a flavor template must be given")
+	}
+	for _, flavor := range evaluateReq.FlavorCollection.Flavors {
+		if err := validateFlavorMetaContent(&flavor.Flavor.Meta); err != nil {
+			return errors.Wrap(err, "Invalid flavor content")
+		}
+		if err := validateFlavorImaContent(flavor.Flavor.Ima); err != nil {
+			return err
+		}
+	}
+	if hasTemplate {
+		if evaluateReq.SourceHostId == uuid.Nil {
+			return errors.New("A source host must be given to create flavors from the flavor template")
+		}
+		if evaluateReq.FlavorTemplate.FlavorParts == nil ||
+			(evaluateReq.FlavorTemplate.FlavorParts.Platform == nil && evaluateReq.FlavorTemplate.FlavorParts.OS == nil) {
+			return errors.New("The flavor template must define a PLATFORM or OS flavor part")
+		}
+	} else if evaluateReq.SourceHostId != uuid.Nil {
+		return errors.New("A source host can only be given with a flavor template")
+	}
+
+	selector := evaluateReq.HostSelector
+	if len(selector.HostIds) == 0 && len(selector.FlavorgroupNames) == 0 {
+		return errors.New("Host IDs or flavorgroup names must be given to select the hosts")
+	}
+	for _, flavorgroup := range selector.FlavorgroupNames {
+		if flavorgroup == "" {
+			return errors.New("Valid Flavorgroup Names must be specified, empty name is not allowed")
+		}
+	}
+	if len(selector.FlavorgroupNames) > 0 {
+		if err := validation.ValidateStrings(selector.FlavorgroupNames); err != nil {
+			return errors.New("Invalid flavorgroup name given as host selector")
+		}
+	}
+	return nil
+}
+
+// getCandidateFlavors returns the flavors of the evaluate request, either the given flavor content or the PLATFORM and
+// OS flavors created from the flavor template and the last host manifest of the source host. The other flavor parts
+// of a template are specific to the source host and are not evaluated.
+func (fcon *FlavorController) getCandidateFlavors(evaluateReq dm.FlavorEvaluateRequest) ([]hvs.Flavor, error) {
+	defaultLog.Trace("controllers/flavor_controller:getCandidateFlavors() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getCandidateFlavors() Leaving")
+
+	var flavors []hvs.Flavor
+	if evaluateReq.FlavorTemplate == nil {
+		for _, flavor := range evaluateReq.FlavorCollection.Flavors {
+			// the flavor id is referenced by the rule results
+			if flavor.Flavor.Meta.ID == uuid.Nil {
+				flavor.Flavor.Meta.ID = uuid.New()
+			}
+			flavors = append(flavors, flavor.Flavor)
+		}
+		return flavors, nil
+	}
+
+	hostManifest, err := fcon.getLastHostManifest(evaluateReq.SourceHostId)
+	if err != nil {
+		return nil, &commErr.BadRequestError{Message: "Unable to create flavors from source host: " + err.Error()}
+	}
+	platformFlavorProvider, err := flavor.NewPlatformFlavorProvider(hostManifest, nil, []hvs.FlavorTemplate{*evaluateReq.FlavorTemplate})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while creating platform flavor instance from source host manifest")
+	}
+	platformFlavor, err := platformFlavorProvider.GetPlatformFlavor()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while creating platform flavors from source host manifest")
+	}
+
+	var flavorParts []fc.FlavorPart
+	if evaluateReq.FlavorTemplate.FlavorParts.Platform != nil {
+		flavorParts = append(flavorParts, fc.FlavorPartPlatform)
+	}
+	if evaluateReq.FlavorTemplate.FlavorParts.OS != nil {
+		flavorParts = append(flavorParts, fc.FlavorPartOs)
+	}
+	for _, flavorPart := range flavorParts {
+		partFlavors, err := (*platformFlavor).GetFlavorPartRaw(flavorPart)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error building a flavor for flavor part %s", flavorPart)
+		}
+		flavors = append(flavors, partFlavors...)
+	}
+	return flavors, nil
+}
+
+// getEvaluationHosts returns the hosts matching any of the criteria of the host selector
+func (fcon *FlavorController) getEvaluationHosts(selector dm.FlavorEvaluateHosts) ([]*hvs.Host, error) {
+	defaultLog.Trace("controllers/flavor_controller:getEvaluationHosts() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getEvaluationHosts() Leaving")
+
+	hostIds := append([]uuid.UUID{}, selector.HostIds...)
+	for _, fgName := range selector.FlavorgroupNames {
+		flavorgroups, err := fcon.FGStore.Search(&dm.FlavorGroupFilterCriteria{NameEqualTo: fgName})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error searching for flavorgroup with name %s", fgName)
+		}
+		if len(flavorgroups) == 0 || flavorgroups[0].ID == uuid.Nil {
+			return nil, &commErr.BadRequestError{Message: "Flavorgroup with name " + fgName + " does not exist"}
+		}
+		fgHostIds, err := fcon.FGStore.SearchHostsByFlavorGroup(flavorgroups[0].ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error retrieving hosts linked to flavorgroup %s", fgName)
+		}
+		hostIds = append(hostIds, fgHostIds...)
+	}
+
+	var hosts []*hvs.Host
+	selected := make(map[uuid.UUID]bool)
+	for _, hostId := range hostIds {
+		if selected[hostId] {
+			continue
+		}
+		selected[hostId] = true
+		host, err := fcon.HStore.Retrieve(hostId, nil)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				return nil, &commErr.BadRequestError{Message: "Host with id " + hostId.String() + " does not exist"}
+			}
+			return nil, errors.Wrapf(err, "Error retrieving host %s", hostId)
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// evaluateHost verifies the candidate flavors against the last host manifest of the host. The host is trusted when
+// all the flavors are trusted.
+func (fcon *FlavorController) evaluateHost(host *hvs.Host, flavors []hvs.Flavor) hvs.HostFlavorEvaluation {
+	defaultLog.Trace("controllers/flavor_controller:evaluateHost() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:evaluateHost() Leaving")
+
+	evaluation := hvs.HostFlavorEvaluation{
+		HostID:   host.Id,
+		HostName: host.HostName,
+	}
+	hostManifest, err := fcon.getLastHostManifest(host.Id)
+	if err != nil {
+		evaluation.Error = err.Error()
+		return evaluation
+	}
+
+	trusted := true
+	var results []hvs.RuleResult
+	for i := range flavors {
+		// the candidate flavors are not signed, the flavor signature can not be verified
+		trustReport, err := fcon.FlavorVerifier.Verify(hostManifest, &hvs.SignedFlavor{Flavor: flavors[i]}, true)
+		if err != nil {
+			defaultLog.WithError(err).Debugf("controllers/flavor_controller:evaluateHost() Error verifying flavor %s against host %s",
+				flavors[i].Meta.ID, host.Id)
+			evaluation.Error = "Flavor " + flavors[i].Meta.ID.String() + " can not be verified against the host"
+			return evaluation
+		}
+		trusted = trusted && trustReport.Trusted
+		results = append(results, trustReport.Results...)
+	}
+	evaluation.Trusted = trusted
+	evaluation.Results = results
+	return evaluation
+}
+
+// getLastHostManifest returns the host manifest stored with the latest status of a connected host
+func (fcon *FlavorController) getLastHostManifest(hostId uuid.UUID) (*hcType.HostManifest, error) {
+	defaultLog.Trace("controllers/flavor_controller:getLastHostManifest() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getLastHostManifest() Leaving")
+
+	hostStatusCollection, err := fcon.HSStore.Search(&dm.HostStatusFilterCriteria{
+		HostId:        hostId,
+		LatestPerHost: true,
+		PageCriteria:  dm.PageCriteria{Limit: 1},
+	})
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/flavor_controller:getLastHostManifest() Error retrieving status of host %s", hostId)
+		return nil, errors.New("Failed to retrieve host status")
+	}
+	if len(hostStatusCollection) == 0 || hostStatusCollection[0].HostStatusInformation.HostState != hvs.HostStateConnected {
+		return nil, errors.New("Host is not in CONNECTED state")
+	}
+	return &hostStatusCollection[0].HostManifest, nil
+}
+
 func validateFlavorFilterCriteria(key, value, flavorgroupId string, ids, flavorParts []string) (*dm.FlavorFilterCriteria, error) {
 	defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Leaving")
@@ -831,7 +1250,7 @@ func validateFlavorFilterCriteria(key, value, flavorgroupId string, ids, flavorP
 	return &filterCriteria, nil
 }
 
-func validateFlavorCreateRequest(criteria dm.FlavorCreateRequest) error {
+func validateFlavorCreateRequest(criteria dm.FlavorCreateRequest, simulatedHostsEnabled bool) error {
 	defaultLog.Trace("controllers/flavor_controller:validateFlavorCreateRequest() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorCreateRequest() Leaving")
 
@@ -840,7 +1259,7 @@ func validateFlavorCreateRequest(criteria dm.FlavorCreateRequest) error {
 		return errors.New("Valid host connection string or flavor content must be given")
 	}
 	if criteria.ConnectionString != "" {
-		err := utils.ValidateConnectionString(criteria.ConnectionString)
+		err := utils.ValidateConnectionString(criteria.ConnectionString, simulatedHostsEnabled)
 		if err != nil {
 			secLog.Error("controllers/flavor_controller: validateFlavorCreateCriteria() Invalid host connection string")
 			return errors.New("Invalid host connection string")
@@ -868,6 +1287,16 @@ func validateFlavorCreateRequest(criteria dm.FlavorCreateRequest) error {
 			return errors.New("Valid flavor parts must be given as a flavor create criteria")
 		}
 	}
+	for _, flavor := range criteria.FlavorCollection.Flavors {
+		if err := validateFlavorImaContent(flavor.Flavor.Ima); err != nil {
+			return err
+		}
+	}
+	for _, signedFlavor := range criteria.SignedFlavorCollection.SignedFlavors {
+		if err := validateFlavorImaContent(signedFlavor.Flavor.Ima); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -886,6 +1315,20 @@ func validateFlavorMetaContent(meta *fm.Meta) error {
 	return nil
 }
 
+// validateFlavorImaContent validates the IMA allowlist of a flavor, the flavors without an IMA section are valid
+func validateFlavorImaContent(ima *fm.Ima) error {
+	defaultLog.Trace("controllers/flavor_controller:validateFlavorImaContent() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorImaContent() Leaving")
+
+	if ima == nil {
+		return nil
+	}
+	if err := ima.Validate(); err != nil {
+		return errors.Wrap(err, "Invalid flavor IMA content")
+	}
+	return nil
+}
+
 func parseFlavorParts(flavorParts []string) ([]fc.FlavorPart, error) {
 	defaultLog.Trace("controllers/flavor_controller:parseFlavorParts() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:parseFlavorParts() Leaving")
@@ -10,20 +10,52 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	hvsConsts "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	comctx "github.com/intel-secl/intel-secl/v4/pkg/lib/common/context"
+	fm "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
 	mocks2 "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/mocks"
+	hcTypes "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/verifier"
+	ct "github.com/intel-secl/intel-secl/v4/pkg/model/aas"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
+// mockFlavorVerifier trusts every flavor except the ones labeled "untrusted"
+type mockFlavorVerifier struct{}
+
+func (v mockFlavorVerifier) Verify(hostManifest *hcTypes.HostManifest, signedFlavor *hvs.SignedFlavor, skipFlavorSignatureVerification bool) (*hvs.TrustReport, error) {
+	result := hvs.RuleResult{
+		Rule:    hvs.RuleInfo{Name: "PcrMatchesConstant"},
+		Trusted: true,
+	}
+	if signedFlavor.Flavor.Meta.Description[fm.Label] == "untrusted" {
+		result.Trusted = false
+		result.Faults = []hvs.Fault{{Name: "PcrValueMismatchSHA256", Description: "Host PCR 0 with value 'aa' does not match expected value 'bb'"}}
+	}
+	return &hvs.TrustReport{
+		Results:      []hvs.RuleResult{result},
+		Trusted:      result.Trusted,
+		HostManifest: *hostManifest,
+	}, nil
+}
+
+func (v mockFlavorVerifier) GetVerifierCerts() verifier.VerifierCertificates {
+	return verifier.VerifierCertificates{}
+}
+
 var _ = Describe("FlavorController", func() {
 	var router *mux.Router
 	var w *httptest.ResponseRecorder
@@ -55,7 +87,7 @@ var _ = Describe("FlavorController", func() {
 		dek, _ := base64.StdEncoding.DecodeString(dekBase64)
 		hostControllerConfig = domain.HostControllerConfig{
 			HostConnectorProvider: hostConnectorProvider,
-			DataEncryptionKey:     dek,
+			DataEncryptionKeys:    models.NewDataEncryptionKeys(dek),
 			Username:              "fakeuser",
 			Password:              "fakepassword",
 		}
@@ -70,13 +102,15 @@ var _ = Describe("FlavorController", func() {
 		}
 
 		flavorController = &controllers.FlavorController{
-			FStore:    flavorStore,
-			FGStore:   flavorGroupStore,
-			HStore:    hostStore,
-			CertStore: certStore,
-			TCStore:   tagCertStore,
-			HTManager: hostTrustManager,
-			HostCon:   hostController,
+			FStore:         flavorStore,
+			FGStore:        flavorGroupStore,
+			HStore:         hostStore,
+			CertStore:      certStore,
+			TCStore:        tagCertStore,
+			HTManager:      hostTrustManager,
+			HostCon:        hostController,
+			HSStore:        hostStatusStore,
+			FlavorVerifier: mockFlavorVerifier{},
 		}
 	})
 	// Specs for HTTP Get to "/flavors"
@@ -94,8 +128,7 @@ var _ = Describe("FlavorController", func() {
 				var sfs *hvs.SignedFlavorCollection
 				err = json.Unmarshal(w.Body.Bytes(), &sfs)
 				Expect(err).ToNot(HaveOccurred())
-				//TODO Requires changes in mock flavor search method for this criteria
-				Expect(len(sfs.SignedFlavors)).To(Equal(0))
+				Expect(len(sfs.SignedFlavors)).To(Equal(2))
 			})
 		})
 		Context("When filtered by Flavor id", func() {
@@ -127,8 +160,7 @@ var _ = Describe("FlavorController", func() {
 				var sfs *hvs.SignedFlavorCollection
 				err = json.Unmarshal(w.Body.Bytes(), &sfs)
 				Expect(err).NotTo(HaveOccurred())
-				//TODO Requires changes in mock flavor search method for this criteria
-				Expect(len(sfs.SignedFlavors)).To(Equal(0))
+				Expect(len(sfs.SignedFlavors)).To(Equal(1))
 			})
 		})
 	})
@@ -820,5 +852,351 @@ var _ = Describe("FlavorController", func() {
 				Expect(w.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
+		Context("Provide a Create request superseding a flavor of a flavor part that is not created", func() {
+			It("Should return 400 Error code", func() {
+				router.Handle("/flavors", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Create))).Methods("POST")
+				softwareFlavorId := uuid.MustParse("5b5cb7f1-3a2a-4d2f-b4a4-5f6ab1e2a9d1")
+				_, err := flavorStore.Create(&hvs.SignedFlavor{
+					Flavor: hvs.Flavor{
+						Meta: fm.Meta{
+							ID: softwareFlavorId,
+							Description: map[string]interface{}{
+								fm.Label:      "software_flavor",
+								fm.FlavorPart: "SOFTWARE",
+							},
+						},
+					},
+					Signature: "signature",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				flavorJson := `{
+					"flavor_collection":{
+					   "flavors":[
+						  {
+							 "flavor":{
+								"meta":{
+								   "id":"0fcb8e8d-6fe6-46ba-9526-32b53bf3df76",
+								   "description":{
+									  "flavor_part":"PLATFORM",
+									  "label":"platform_flavor"
+								   }
+								}
+							 }
+						  }
+					   ]
+					},
+					"flavorgroup_names":[
+					   "Test"
+					],
+					"superseded_flavor_ids":[
+					   "5b5cb7f1-3a2a-4d2f-b4a4-5f6ab1e2a9d1"
+					]
+				 }`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors",
+					strings.NewReader(flavorJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req = comctx.SetUserPermissions(req, []ct.PermissionInfo{{Service: hvsConsts.ServiceName, Rules: []string{hvsConsts.FlavorCreate}}})
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("no SOFTWARE flavor is created"))
+
+				_, err = flavorStore.Retrieve(uuid.MustParse("0fcb8e8d-6fe6-46ba-9526-32b53bf3df76"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+		Context("Provide a Create request superseding an existing flavor", func() {
+			It("Should return 201 Response code and supersede the flavor with the created flavor", func() {
+				router.Handle("/flavors", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Create))).Methods("POST")
+				flavorController.CertStore, _, _ = newFlavorSigningCertStore()
+				platformFlavorId := uuid.MustParse("1c8f3a52-6b7e-4d09-8e2f-3a4b5c6d7e80")
+				_, err := flavorStore.Create(&hvs.SignedFlavor{
+					Flavor: hvs.Flavor{
+						Meta: fm.Meta{
+							ID: platformFlavorId,
+							Description: map[string]interface{}{
+								fm.Label:      "superseded_platform_flavor_1",
+								fm.FlavorPart: "PLATFORM",
+							},
+						},
+					},
+					Signature: "signature",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				flavorJson := `{
+					"flavor_collection":{
+					   "flavors":[
+						  {
+							 "flavor":{
+								"meta":{
+								   "id":"2d9e4b63-7c8f-4e1a-9f30-4b5c6d7e8f91",
+								   "description":{
+									  "flavor_part":"PLATFORM",
+									  "label":"superseding_platform_flavor_1"
+								   }
+								}
+							 }
+						  }
+					   ]
+					},
+					"flavorgroup_names":[
+					   "Test"
+					],
+					"superseded_flavor_ids":[
+					   "1c8f3a52-6b7e-4d09-8e2f-3a4b5c6d7e80"
+					]
+				 }`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors",
+					strings.NewReader(flavorJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req = comctx.SetUserPermissions(req, []ct.PermissionInfo{{Service: hvsConsts.ServiceName, Rules: []string{hvsConsts.FlavorCreate}}})
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				supersessions, err := flavorStore.SearchSupersessions(&models.FlavorSupersessionFilterCriteria{
+					FlavorIds: []uuid.UUID{platformFlavorId}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(supersessions).To(HaveLen(1))
+				Expect(supersessions[0].SupersededBy).To(Equal(uuid.MustParse("2d9e4b63-7c8f-4e1a-9f30-4b5c6d7e8f91")))
+			})
+		})
+		Context("Provide a Create request superseding a flavor that is already superseded", func() {
+			It("Should return 409 Error code without creating the flavor", func() {
+				router.Handle("/flavors", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Create))).Methods("POST")
+				platformFlavorId := uuid.MustParse("3e0f5c74-8d90-4f2b-a041-5c6d7e8f9012")
+				_, err := flavorStore.Create(&hvs.SignedFlavor{
+					Flavor: hvs.Flavor{
+						Meta: fm.Meta{
+							ID: platformFlavorId,
+							Description: map[string]interface{}{
+								fm.Label:      "superseded_platform_flavor_2",
+								fm.FlavorPart: "PLATFORM",
+							},
+						},
+					},
+					Signature: "signature",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				err = flavorStore.Supersede(&models.FlavorSupersession{
+					FlavorId:     platformFlavorId,
+					SupersededBy: uuid.MustParse("5a2b7e96-afb2-4b4d-8263-7e8f90123434"),
+					RetireAt:     time.Now().Add(time.Hour),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				flavorJson := `{
+					"flavor_collection":{
+					   "flavors":[
+						  {
+							 "flavor":{
+								"meta":{
+								   "id":"4f1a6d85-9ea1-4a3c-b152-6d7e8f901223",
+								   "description":{
+									  "flavor_part":"PLATFORM",
+									  "label":"superseding_platform_flavor_2"
+								   }
+								}
+							 }
+						  }
+					   ]
+					},
+					"flavorgroup_names":[
+					   "Test"
+					],
+					"superseded_flavor_ids":[
+					   "3e0f5c74-8d90-4f2b-a041-5c6d7e8f9012"
+					]
+				 }`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors",
+					strings.NewReader(flavorJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req = comctx.SetUserPermissions(req, []ct.PermissionInfo{{Service: hvsConsts.ServiceName, Rules: []string{hvsConsts.FlavorCreate}}})
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusConflict))
+
+				_, err = flavorStore.Retrieve(uuid.MustParse("4f1a6d85-9ea1-4a3c-b152-6d7e8f901223"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+		Context("Provide a manually crafted Flavor request with an invalid IMA allowlist", func() {
+			It("Should return 400 Error code", func() {
+				router.Handle("/flavors", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Create))).Methods("POST")
+				flavorJson := `{
+					"flavor_collection":{
+					   "flavors":[
+						  {
+							 "flavor":{
+								"meta":{
+								   "id":"8e2bc1a4-3f4d-4a51-9d0e-5c1f7b2a6d38",
+								   "description":{
+									  "flavor_part":"OS",
+									  "label":"ima_flavor"
+								   }
+								},
+								"ima":{
+								   "allowlist":{
+									  "/usr/bin/bash":[
+										 "sha256:b2b2"
+									  ]
+								   }
+								}
+							 }
+						  }
+					   ]
+					},
+					"flavorgroup_names":[
+					   "Test"
+					]
+				 }`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors",
+					strings.NewReader(flavorJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req = comctx.SetUserPermissions(req, []ct.PermissionInfo{{Service: hvsConsts.ServiceName, Rules: []string{hvsConsts.FlavorCreate}}})
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+				_, err = flavorStore.Retrieve(uuid.MustParse("8e2bc1a4-3f4d-4a51-9d0e-5c1f7b2a6d38"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/flavors/evaluate"
+	Describe("Evaluate candidate flavors", func() {
+		Context("Provide a candidate flavor and an existing host", func() {
+			It("Should return the trust results of the host", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+					"flavor_collection":{
+						"flavors":[{"flavor":{"meta":{"description":{"flavor_part":"PLATFORM","label":"candidate"},"vendor":"INTEL"}}}]
+					},
+					"host_selector":{"host_ids":["ee37c360-7eae-4250-a677-6ee12adce8e2"]}
+				}`
+				req, err := http.NewRequest("POST", "/flavors/evaluate", strings.NewReader(evaluateJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var evaluation hvs.FlavorEvaluationCollection
+				err = json.Unmarshal(w.Body.Bytes(), &evaluation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(evaluation.Flavors)).To(Equal(1))
+				Expect(evaluation.Flavors[0].Flavor.Meta.ID).NotTo(Equal(uuid.Nil))
+				Expect(len(evaluation.HostEvaluations)).To(Equal(1))
+				Expect(evaluation.HostEvaluations[0].HostName).To(Equal("localhost1"))
+				Expect(evaluation.HostEvaluations[0].Trusted).To(BeTrue())
+				Expect(evaluation.HostEvaluations[0].Error).To(BeEmpty())
+			})
+		})
+		Context("Provide a candidate flavor that does not match the host", func() {
+			It("Should return the faults of the host", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+					"flavor_collection":{
+						"flavors":[{"flavor":{"meta":{"description":{"flavor_part":"OS","label":"untrusted"},"vendor":"INTEL"}}}]
+					},
+					"host_selector":{"host_ids":["ee37c360-7eae-4250-a677-6ee12adce8e2","e57e5ea0-d465-461e-882d-1600090caa0d"]}
+				}`
+				req, err := http.NewRequest("POST", "/flavors/evaluate", strings.NewReader(evaluateJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var evaluation hvs.FlavorEvaluationCollection
+				err = json.Unmarshal(w.Body.Bytes(), &evaluation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(evaluation.HostEvaluations)).To(Equal(2))
+				Expect(evaluation.HostEvaluations[0].Trusted).To(BeFalse())
+				Expect(evaluation.HostEvaluations[0].Results[0].Faults[0].Name).To(Equal("PcrValueMismatchSHA256"))
+				// the second host has no stored host manifest
+				Expect(evaluation.HostEvaluations[1].Trusted).To(BeFalse())
+				Expect(evaluation.HostEvaluations[1].Error).NotTo(BeEmpty())
+			})
+		})
+		Context("Provide both flavor content and a flavor template", func() {
+			It("Should return 400 Response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+					"flavor_collection":{
+						"flavors":[{"flavor":{"meta":{"description":{"flavor_part":"PLATFORM","label":"candidate"}}}}]
+					},
+					"flavor_template":{"label":"candidate-template"},
+					"host_selector":{"host_ids":["ee37c360-7eae-4250-a677-6ee12adce8e2"]}
+				}`
+				req, err := http.NewRequest("POST", "/flavors/evaluate", strings.NewReader(evaluateJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a request without host selector", func() {
+			It("Should return 400 Response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+					"flavor_collection":{
+						"flavors":[{"flavor":{"meta":{"description":{"flavor_part":"PLATFORM","label":"candidate"}}}}]
+					}
+				}`
+				req, err := http.NewRequest("POST", "/flavors/evaluate", strings.NewReader(evaluateJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a non-existent host in the host selector", func() {
+			It("Should return 400 Response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+					"flavor_collection":{
+						"flavors":[{"flavor":{"meta":{"description":{"flavor_part":"PLATFORM","label":"candidate"}}}}]
+					},
+					"host_selector":{"host_ids":["73755fda-c910-46be-821f-e8ddeab189e9"]}
+				}`
+				req, err := http.NewRequest("POST", "/flavors/evaluate", strings.NewReader(evaluateJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 	})
 })
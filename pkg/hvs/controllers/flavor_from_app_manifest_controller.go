@@ -54,7 +54,7 @@ func (controller FlavorFromAppManifestController) CreateSoftwareFlavor(w http.Re
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode XML request body"}
 	}
 
-	err = validateRequest(appManifestRequest)
+	err = validateRequest(appManifestRequest, controller.FlavorController.HostCon.HCConfig.SimulatedHostsEnabled)
 	if err != nil {
 		secLog.WithError(err).Errorf("controllers/flavor_from_app_manifest_controller: CreateSoftwareFlavor() "+
 			"%s : %s", commLogMsg.InvalidInputBadParam, err.Error())
@@ -124,7 +124,7 @@ func (controller FlavorFromAppManifestController) CreateSoftwareFlavor(w http.Re
 	return softwareFlavor, http.StatusCreated, nil
 }
 
-func validateRequest(appManifestRequest *hvs.ManifestRequest) error {
+func validateRequest(appManifestRequest *hvs.ManifestRequest, simulatedHostsEnabled bool) error {
 	defaultLog.Trace("controllers/flavor_from_app_manifest_controller:validateRequest() Entering")
 	defer defaultLog.Trace("controllers/flavor_from_app_manifest_controller:validateRequest() Leaving")
 
@@ -133,7 +133,7 @@ func validateRequest(appManifestRequest *hvs.ManifestRequest) error {
 			return errors.New("Either connection string or host Id must be provided")
 		}
 	} else {
-		err := utils.ValidateConnectionString(appManifestRequest.ConnectionString)
+		err := utils.ValidateConnectionString(appManifestRequest.ConnectionString, simulatedHostsEnabled)
 		if err != nil {
 			return err
 		}
@@ -29,7 +29,10 @@ type FlavorgroupController struct {
 	HTManager        domain.HostTrustManager
 }
 
-var flavorGroupSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "includeFlavorContent": true}
+var flavorGroupSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "includeFlavorContent": true,
+	"sortBy": true, "orderBy": true, "limit": true, "offset": true}
+
+var flavorGroupSortFields = map[string]bool{models.SortById: true, models.SortByName: true}
 
 func (controller FlavorgroupController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/flavorgroup_controller:Create() Entering")
@@ -74,6 +77,13 @@ func (controller FlavorgroupController) Create(w http.ResponseWriter, r *http.Re
 		defaultLog.WithError(err).Error("controllers/flavorgroup_controller:Create() Flavorgroup save failed")
 		return nil, http.StatusInternalServerError, errors.Errorf("Error while inserting a new Flavorgroup")
 	}
+
+	if len(newFlavorGroup.LabelSelector) > 0 {
+		if err := controller.linkLabelSelectedHosts(newFlavorGroup); err != nil {
+			defaultLog.WithError(err).Error("controllers/flavorgroup_controller:Create() Flavorgroup label selected Host association failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Error while associating the Flavorgroup with the hosts selected by its labels")
+		}
+	}
 	secLog.WithField("Name", reqFlavorGroup.Name).Infof("%s: FlavorGroup created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
 	return newFlavorGroup, http.StatusCreated, nil
 }
@@ -119,6 +129,18 @@ func (controller FlavorgroupController) Search(w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	pageCriteria, err := utils.ParsePageCriteria(r.URL.Query(), flavorGroupSortFields)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavorgroup_controller:Search()  %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if *pageCriteria != (models.PageCriteria{}) {
+		if filter == nil {
+			filter = &models.FlavorGroupFilterCriteria{}
+		}
+		filter.PageCriteria = *pageCriteria
+	}
+
 	flavorgroups, err := controller.FlavorGroupStore.Search(filter)
 	if err != nil {
 		secLog.WithError(err).Error("controllers/flavorgroup_controller:Search() Flavorgroup get all failed")
@@ -131,6 +153,15 @@ func (controller FlavorgroupController) Search(w http.ResponseWriter, r *http.Re
 			"associated with flavor group")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{"Unable to search Flavorgroups"}
 	}
+
+	if pageCriteria.IsPaged() {
+		total, err := controller.FlavorGroupStore.Count(filter)
+		if err != nil {
+			secLog.WithError(err).Error("controllers/flavorgroup_controller:Search() Flavorgroup count failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavorgroups"}
+		}
+		flavorgroupCollection.PageInfo = hvs.NewPageInfo(total, pageCriteria.Offset, len(flavorgroups))
+	}
 	secLog.Infof("%s: Return flavorgroup query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return flavorgroupCollection, http.StatusOK, nil
 }
@@ -220,9 +251,44 @@ func ValidateFlavorGroup(flavorGroup hvs.FlavorGroup) error {
 	if len(flavorGroup.MatchPolicies) == 0 {
 		return errors.New("Flavor Type Match Policy Collection must be specified")
 	}
+	if err := utils.ValidateLabels(flavorGroup.LabelSelector); err != nil {
+		return errors.Wrap(err, "Valid FlavorGroup Label Selector must be specified")
+	}
+	if flavorGroup.ReportPolicy != nil {
+		if flavorGroup.ReportPolicy.ValiditySeconds < 0 || flavorGroup.ReportPolicy.RefreshIntervalSeconds < 0 {
+			return errors.New("Report Policy validity and refresh interval must not be negative")
+		}
+	}
 	return nil
 }
 
+// linkLabelSelectedHosts links the flavorgroup to the hosts having all the labels of its selector and queues these
+// hosts for verification
+func (controller FlavorgroupController) linkLabelSelectedHosts(flavorGroup *hvs.FlavorGroup) error {
+	defaultLog.Trace("controllers/flavorgroup_controller:linkLabelSelectedHosts() Entering")
+	defer defaultLog.Trace("controllers/flavorgroup_controller:linkLabelSelectedHosts() Leaving")
+
+	hosts, err := controller.HostStore.Search(&models.HostFilterCriteria{Labels: flavorGroup.LabelSelector}, nil)
+	if err != nil {
+		return errors.Wrap(err, "Could not search hosts selected by the labels")
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	hostIds := make([]uuid.UUID, 0, len(hosts))
+	for _, host := range hosts {
+		defaultLog.Debugf("Linking host %v with flavorgroup %v", host.Id, flavorGroup.ID)
+		if err := controller.HostStore.AddFlavorgroups(host.Id, []uuid.UUID{flavorGroup.ID}); err != nil {
+			return errors.Wrapf(err, "Could not link host %v with flavorgroup", host.Id)
+		}
+		hostIds = append(hostIds, host.Id)
+	}
+
+	defaultLog.Debugf("Adding hosts %+q to flavor-verify queue", hostIds)
+	return controller.HTManager.VerifyHostsAsync(hostIds, false, false)
+}
+
 func ValidateFgCriteria(filterCriteria models.FlavorGroupFilterCriteria) error {
 	defaultLog.Trace("controllers/flavorgroup_controller:ValidateFgCriteria() Entering")
 	defer defaultLog.Trace("controllers/flavorgroup_controller:ValidateFgCriteria() Leaving")
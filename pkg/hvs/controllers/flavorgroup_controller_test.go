@@ -317,6 +317,166 @@ var _ = Describe("FlavorgroupController", func() {
 			})
 		})
 
+		Context("Provide a valid Flavorgroup data with a label selector", func() {
+			It("Should create a new Flavorgroup linked to the hosts selected by its labels", func() {
+				labelledHostId := uuid.MustParse("1ea8a4b8-bc3c-4b5e-9e3b-1d4c2e0b5d3a")
+				_, err := hostStore.Create(&hvs.Host{Id: labelledHostId, HostName: "localhost3",
+					Labels: map[string]string{"rack": "r12", "role": "compute"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_rack12",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								},
+								"label_selector": {
+									"rack": "r12"
+								}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavorgroups",
+					strings.NewReader(flavorgroupJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var flavorgroup hvs.FlavorGroup
+				err = json.Unmarshal(w.Body.Bytes(), &flavorgroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorgroup.LabelSelector).To(Equal(map[string]string{"rack": "r12"}))
+
+				flavorgroupIds, err := hostStore.SearchFlavorgroups(labelledHostId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorgroupIds).To(Equal([]uuid.UUID{flavorgroup.ID}))
+			})
+		})
+
+		Context("Provide a valid Flavorgroup data with a report policy", func() {
+			It("Should create a new Flavorgroup with the report policy", func() {
+				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_production",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								},
+								"report_policy": {
+									"validity_seconds": 3600,
+									"refresh_interval_seconds": 900
+								}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavorgroups",
+					strings.NewReader(flavorgroupJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var flavorgroup hvs.FlavorGroup
+				err = json.Unmarshal(w.Body.Bytes(), &flavorgroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorgroup.ReportPolicy).To(Equal(&hvs.ReportPolicy{ValiditySeconds: 3600, RefreshIntervalSeconds: 900}))
+			})
+		})
+
+		Context("Provide a Flavorgroup data that contains a negative report refresh interval", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_production",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								},
+								"report_policy": {
+									"refresh_interval_seconds": -900
+								}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavorgroups",
+					strings.NewReader(flavorgroupJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide a Flavorgroup data that contains an invalid label selector", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_rack12",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								},
+								"label_selector": {
+									"rack": "r 12"
+								}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavorgroups",
+					strings.NewReader(flavorgroupJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		Context("Provide a Flavorgroup data that contains duplicate flavorgroup name", func() {
 			It("Should get HTTP Status: 400", func() {
 				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
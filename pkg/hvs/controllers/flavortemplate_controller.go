@@ -25,6 +25,7 @@ import (
 	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/verifier/rules"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"github.com/xeipuuv/gojsonschema"
@@ -325,6 +326,18 @@ func (ftc *FlavorTemplateController) ValidateFlavorTemplateCreateRequest(FlvrTem
 		}
 	}
 
+	//Check whether the custom rules are registered in the verifier and have valid parameters.
+	for _, flavorPart := range flavorParts {
+		if flavorPart == nil {
+			continue
+		}
+		for _, customRule := range flavorPart.CustomRules {
+			if err := rules.ValidateCustomRule(customRule); err != nil {
+				return err.Error(), errors.Wrap(err, "controllers/flavortemplate_controller:validateFlavorTemplateCreateRequest() Template has an invalid custom rule")
+			}
+		}
+	}
+
 	return "", nil
 }
 
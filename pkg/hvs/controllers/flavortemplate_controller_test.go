@@ -173,6 +173,153 @@ var _ = Describe("FlavorTemplateController", func() {
 			})
 		})
 
+		Context("Provide a FlavorTemplate data with registered custom rules", func() {
+			It("Should create a new Flavortemplate and get HTTP Status: 201", func() {
+				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Create))).Methods("POST")
+				flavorTemplateJson := `{
+					"label": "test-custom-rules",
+					"condition": [
+						"//host_info/vendor='Linux'"
+					],
+					"flavor_parts": {
+						"PLATFORM": {
+							"meta": {
+								"vendor": "Linux"
+							},
+							"pcr_rules": [
+								{
+									"pcr": {
+										"index": 0,
+										"bank": "SHA256"
+									},
+									"pcr_matches": true
+								}
+							],
+							"custom_rules": [
+								{
+									"name": "BiosVersionAtLeast",
+									"parameters": {
+										"min_version": "SE5C620.86B.00.01.0015"
+									}
+								},
+								{
+									"name": "HardwareFeaturesEnabled",
+									"parameters": {
+										"features": ["TPM", "TXT"]
+									}
+								}
+							]
+						}
+					}
+				}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavor-templates",
+					strings.NewReader(flavorTemplateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("Provide a FlavorTemplate data with a custom rule that is not registered", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Create))).Methods("POST")
+				flavorTemplateJson := `{
+					"label": "test-custom-rules",
+					"condition": [
+						"//host_info/vendor='Linux'"
+					],
+					"flavor_parts": {
+						"PLATFORM": {
+							"meta": {
+								"vendor": "Linux"
+							},
+							"pcr_rules": [
+								{
+									"pcr": {
+										"index": 0,
+										"bank": "SHA256"
+									},
+									"pcr_matches": true
+								}
+							],
+							"custom_rules": [
+								{
+									"name": "UnknownRule"
+								}
+							]
+						}
+					}
+				}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavor-templates",
+					strings.NewReader(flavorTemplateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide a FlavorTemplate data with a custom rule that has invalid parameters", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Create))).Methods("POST")
+				flavorTemplateJson := `{
+					"label": "test-custom-rules",
+					"condition": [
+						"//host_info/vendor='Linux'"
+					],
+					"flavor_parts": {
+						"PLATFORM": {
+							"meta": {
+								"vendor": "Linux"
+							},
+							"pcr_rules": [
+								{
+									"pcr": {
+										"index": 0,
+										"bank": "SHA256"
+									},
+									"pcr_matches": true
+								}
+							],
+							"custom_rules": [
+								{
+									"name": "BiosVersionAtLeast",
+									"parameters": {
+										"version": "SE5C620.86B.00.01.0015"
+									}
+								}
+							]
+						}
+					}
+				}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavor-templates",
+					strings.NewReader(flavorTemplateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		Context("Provide a FlavorTemplate data that contains invalid field key, to validate against schema", func() {
 			It("Should get HTTP Status: 400", func() {
 				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Create))).Methods("POST")
@@ -0,0 +1,285 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	consts "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// columns of the CSV bulk host registration requests, the flavorgroup names of a host are separated by ';'
+const (
+	csvHostName         = "host_name"
+	csvConnectionString = "connection_string"
+	csvFlavorgroupNames = "flavorgroup_names"
+	csvDescription      = "description"
+)
+
+var hostCsvColumns = map[string]bool{csvHostName: true, csvConnectionString: true, csvFlavorgroupNames: true,
+	csvDescription: true}
+
+type HostBulkController struct {
+	HostController *HostController
+	JobStore       domain.JobStore
+	// Workers is the number of hosts registered concurrently by a job
+	Workers int
+}
+
+func NewHostBulkController(hc *HostController, js domain.JobStore) *HostBulkController {
+	return &HostBulkController{
+		HostController: hc,
+		JobStore:       js,
+		Workers:        consts.BulkHostCreateWorkers,
+	}
+}
+
+// Create validates the hosts of the request and starts a job registering them. The job is returned at once and its
+// progress can be polled from the jobs API.
+func (controller HostBulkController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_bulk_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:Create() Leaving")
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_bulk_controller:Create() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	var hosts []hvs.HostCreateRequest
+	switch r.Header.Get("Content-Type") {
+	case constants.HTTPMediaTypeJson:
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+
+		var reqHosts hvs.HostBulkCreateRequest
+		if err := dec.Decode(&reqHosts); err != nil {
+			secLog.WithError(err).Errorf("controllers/host_bulk_controller:Create() %s :  Failed to decode request body as HostBulkCreateRequest", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+		}
+		hosts = reqHosts.Hosts
+	case constants.HTTPMediaTypeCsv:
+		var err error
+		hosts, err = parseHostCsv(r.Body)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/host_bulk_controller:Create() %s :  Failed to decode request body as CSV", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode CSV request body: " + err.Error()}
+		}
+	default:
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if err := validateBulkHosts(hosts, controller.HostController.HCConfig.SimulatedHostsEnabled); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_bulk_controller:Create() %s : Invalid request body", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	hostNames := make([]string, len(hosts))
+	for i, host := range hosts {
+		hostNames[i] = host.HostName
+	}
+	// the connection strings are not stored in the job since they can hold the host credentials, hence a job
+	// interrupted by a restart of HVS cannot be resumed
+	job, err := controller.JobStore.Create(&models.Job{
+		Action: consts.HostBulkCreateAction,
+		Params: newJobParams(hostNames, nil),
+		State:  models.JobStateNew,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_bulk_controller:Create() Job create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create bulk host registration job"}
+	}
+
+	response, err := newJob(job)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_bulk_controller:Create() Error reading job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create bulk host registration job"}
+	}
+
+	go controller.registerHosts(*job, hosts)
+
+	secLog.WithField("job", job.Id).Infof("%s: Bulk registration of %d hosts started by: %s",
+		commLogMsg.PrivilegeModified, len(hosts), r.RemoteAddr)
+	return response, http.StatusAccepted, nil
+}
+
+// registerHosts creates the hosts of the job, recording the result of each host in the job as soon as it is known
+func (controller HostBulkController) registerHosts(job models.Job, hosts []hvs.HostCreateRequest) {
+	defaultLog.Trace("controllers/host_bulk_controller:registerHosts() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:registerHosts() Leaving")
+
+	hostNames := make([]string, len(hosts))
+	for i, host := range hosts {
+		hostNames[i] = host.HostName
+	}
+
+	// the job is only updated by the worker holding the lock so that no result is lost
+	var mtx sync.Mutex
+	results := make([]hvs.JobResult, 0, len(hosts))
+	updateJob := func(state models.JobState) {
+		job.State = state
+		job.Params = newJobParams(hostNames, results)
+		if err := controller.JobStore.Update(&job); err != nil {
+			defaultLog.WithError(err).Errorf("controllers/host_bulk_controller:registerHosts() Failed to update job %s", job.Id)
+		}
+	}
+
+	updateJob(models.JobStatePending)
+
+	workers := controller.Workers
+	if workers <= 0 || workers > len(hosts) {
+		workers = len(hosts)
+	}
+	hostsChan := make(chan hvs.HostCreateRequest)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range hostsChan {
+				result := controller.registerHost(host)
+				mtx.Lock()
+				results = append(results, result)
+				updateJob(models.JobStatePending)
+				mtx.Unlock()
+			}
+		}()
+	}
+	for _, host := range hosts {
+		hostsChan <- host
+	}
+	close(hostsChan)
+	wg.Wait()
+
+	updateJob(models.JobStateCompleted)
+	defaultLog.Infof("controllers/host_bulk_controller:registerHosts() Bulk host registration job %s completed", job.Id)
+}
+
+// registerHost creates a host and returns the result of its registration
+func (controller HostBulkController) registerHost(host hvs.HostCreateRequest) hvs.JobResult {
+	defaultLog.Trace("controllers/host_bulk_controller:registerHost() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:registerHost() Leaving")
+
+	result := hvs.JobResult{HostName: host.HostName}
+	createdHost, _, err := controller.HostController.CreateHost(host)
+	if err != nil {
+		result.Status = hvs.JobResultFailed
+		result.Error = jobResultError(err)
+		return result
+	}
+
+	result.Status = hvs.JobResultSucceeded
+	if createdHost, ok := createdHost.(*hvs.Host); ok {
+		result.HostID = &createdHost.Id
+	}
+	return result
+}
+
+// parseHostCsv reads the hosts of a CSV document whose first record names its columns
+func parseHostCsv(r io.Reader) ([]hvs.HostCreateRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read header")
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if !hostCsvColumns[column] {
+			return nil, errors.Errorf("unknown column %q", column)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns[csvHostName]; !ok {
+		return nil, errors.Errorf("%s column is required", csvHostName)
+	}
+	if _, ok := columns[csvConnectionString]; !ok {
+		return nil, errors.Errorf("%s column is required", csvConnectionString)
+	}
+
+	var hosts []hvs.HostCreateRequest
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return hosts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		host := hvs.HostCreateRequest{
+			HostName:         strings.TrimSpace(record[columns[csvHostName]]),
+			ConnectionString: strings.TrimSpace(record[columns[csvConnectionString]]),
+		}
+		if i, ok := columns[csvDescription]; ok {
+			host.Description = strings.TrimSpace(record[i])
+		}
+		if i, ok := columns[csvFlavorgroupNames]; ok && strings.TrimSpace(record[i]) != "" {
+			for _, flavorgroupName := range strings.Split(record[i], ";") {
+				host.FlavorgroupNames = append(host.FlavorgroupNames, strings.TrimSpace(flavorgroupName))
+			}
+		}
+		hosts = append(hosts, host)
+	}
+}
+
+// validateBulkHosts validates the hosts of a bulk registration before the job is started, so that a request with an
+// invalid host is rejected as a whole
+func validateBulkHosts(hosts []hvs.HostCreateRequest, simulatedHostsEnabled bool) error {
+	defaultLog.Trace("controllers/host_bulk_controller:validateBulkHosts() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:validateBulkHosts() Leaving")
+
+	if len(hosts) == 0 {
+		return errors.New("At least one host must be specified")
+	}
+	if len(hosts) > consts.MaxBulkHostCount {
+		return errors.Errorf("At most %d hosts can be registered at once", consts.MaxBulkHostCount)
+	}
+
+	hostNames := make(map[string]bool, len(hosts))
+	for i, host := range hosts {
+		if host.HostName == "" || host.ConnectionString == "" {
+			return errors.Errorf("Host %d: Host connection string and host name must be specified", i+1)
+		}
+		if err := validateHostCreateCriteria(host, simulatedHostsEnabled); err != nil {
+			return errors.Wrapf(err, "Host %d", i+1)
+		}
+		if hostNames[host.HostName] {
+			return errors.Errorf("Host %d: Host name %s is duplicated", i+1, host.HostName)
+		}
+		hostNames[host.HostName] = true
+	}
+	return nil
+}
+
+// newJobParams returns the params of a bulk host registration job
+func newJobParams(hostNames []string, results []hvs.JobResult) map[string]interface{} {
+	succeeded := 0
+	for _, result := range results {
+		if result.Status == hvs.JobResultSucceeded {
+			succeeded++
+		}
+	}
+	return map[string]interface{}{
+		"host_names": hostNames,
+		"total":      len(hostNames),
+		"succeeded":  succeeded,
+		"failed":     len(results) - succeeded,
+		// the results are copied since the workers keep appending to them
+		"results": append([]hvs.JobResult{}, results...),
+	}
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	smocks "github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust/mocks"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	mocks2 "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostBulkController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var hostStore *mocks.MockHostStore
+	var hostBulkController *controllers.HostBulkController
+	var jobController *controllers.JobController
+	var hostTrustManager *smocks.MockHostTrustManager
+	var hostConnectorProvider mocks2.MockHostConnectorFactory
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		hostStore = mocks.NewMockHostStore()
+
+		dek, err := base64.StdEncoding.DecodeString("gcXqH8YwuJZ3Rx4qVzA/zhVvkTw2TL+iRAC9T3E6lII=")
+		Expect(err).NotTo(HaveOccurred())
+		hostController := &controllers.HostController{
+			HStore:    hostStore,
+			HSStore:   mocks.NewMockHostStatusStore(),
+			FStore:    mocks.NewMockFlavorStore(),
+			FGStore:   mocks.NewFakeFlavorgroupStore(),
+			HCStore:   mocks.NewMockHostCredentialStore(),
+			HTManager: hostTrustManager,
+			HCConfig: domain.HostControllerConfig{
+				HostConnectorProvider: hostConnectorProvider,
+				DataEncryptionKeys:    models.NewDataEncryptionKeys(dek),
+				Username:              "fakeuser",
+				Password:              "fakepassword",
+			},
+		}
+
+		jobStore := mocks.NewMockJobStore()
+		// the mock stores cannot be shared by concurrent workers
+		hostBulkController = &controllers.HostBulkController{
+			HostController: hostController,
+			JobStore:       jobStore,
+			Workers:        1,
+		}
+		jobController = controllers.NewJobController(jobStore)
+
+		router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostBulkController.Create))).Methods("POST")
+		router.Handle("/jobs/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(jobController.Retrieve))).Methods("GET")
+	})
+
+	createJob := func(contentType, body string) *hvs.Job {
+		req, err := http.NewRequest("POST", "/hosts/bulk", strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", contentType)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusAccepted {
+			return nil
+		}
+
+		var job hvs.Job
+		Expect(json.Unmarshal(w.Body.Bytes(), &job)).To(Succeed())
+		return &job
+	}
+
+	retrieveJob := func(job *hvs.Job) *hvs.Job {
+		req, err := http.NewRequest("GET", "/jobs/"+job.ID.String(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var retrievedJob hvs.Job
+		Expect(json.Unmarshal(w.Body.Bytes(), &retrievedJob)).To(Succeed())
+		return &retrievedJob
+	}
+
+	// Specs for HTTP Post to "/hosts/bulk"
+	Describe("Register hosts in bulk", func() {
+		Context("Provide a valid JSON request", func() {
+			It("Should register the hosts and report the result of each host in the job", func() {
+				job := createJob(consts.HTTPMediaTypeJson, `{
+					"hosts": [
+						{
+							"host_name": "localhost3",
+							"connection_string": "intel:https://another.ta.ip.com:1443"
+						},
+						{
+							"host_name": "localhost2",
+							"connection_string": "intel:https://ta.ip.com:1443"
+						}
+					]
+				}`)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(job.Total).To(Equal(2))
+
+				Eventually(func() string {
+					return retrieveJob(job).State
+				}).Should(Equal("Completed"))
+
+				job = retrieveJob(job)
+				Expect(job.Succeeded).To(Equal(1))
+				Expect(job.Failed).To(Equal(1))
+				Expect(job.Results).To(HaveLen(2))
+				for _, result := range job.Results {
+					if result.HostName == "localhost3" {
+						Expect(result.Status).To(Equal(hvs.JobResultSucceeded))
+						Expect(result.HostID).NotTo(BeNil())
+					} else {
+						// localhost2 is already registered
+						Expect(result.Status).To(Equal(hvs.JobResultFailed))
+						Expect(result.Error).To(Equal("Host with this name already exist"))
+					}
+				}
+			})
+		})
+		Context("Provide a valid CSV request", func() {
+			It("Should register the hosts", func() {
+				job := createJob(consts.HTTPMediaTypeCsv, "host_name,connection_string,flavorgroup_names\n"+
+					"localhost3,intel:https://another.ta.ip.com:1443,hvs_flavorgroup_test1;hvs_flavorgroup_test2\n")
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(job.Total).To(Equal(1))
+
+				Eventually(func() string {
+					return retrieveJob(job).State
+				}).Should(Equal("Completed"))
+				Expect(retrieveJob(job).Succeeded).To(Equal(1))
+			})
+		})
+		Context("Provide a request with an invalid host", func() {
+			It("Should fail to start the job", func() {
+				createJob(consts.HTTPMediaTypeJson, `{
+					"hosts": [
+						{
+							"host_name": "localhost3",
+							"connection_string": "intel:https://another.ta.ip.com:1443"
+						},
+						{
+							"host_name": "localhost4"
+						}
+					]
+				}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a request with duplicate host names", func() {
+			It("Should fail to start the job", func() {
+				createJob(consts.HTTPMediaTypeCsv, "host_name,connection_string\n"+
+					"localhost3,intel:https://another.ta.ip.com:1443\n"+
+					"localhost3,intel:https://ta.ip.com:1443\n")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a CSV request with an unknown column", func() {
+			It("Should fail to start the job", func() {
+				createJob(consts.HTTPMediaTypeCsv, "host_name,connection_string,hardware_uuid\n"+
+					"localhost3,intel:https://another.ta.ip.com:1443,00e4d709-8d72-44c3-89ae-c5edc395d6fe\n")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a request with an unsupported content type", func() {
+			It("Should fail to start the job", func() {
+				createJob(consts.HTTPMediaTypePlain, "localhost3")
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/jobs/{id}"
+	Describe("Retrieve a job", func() {
+		Context("Retrieve a job that does not exist", func() {
+			It("Should fail to retrieve the job", func() {
+				req, err := http.NewRequest("GET", "/jobs/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -52,7 +52,10 @@ func NewHostController(hs domain.HostStore, hss domain.HostStatusStore, fs domai
 }
 
 var hostSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "hostHardwareId": true,
-	"key": true, "value": true, "trusted": true, "getTrustStatus": true, "getHostStatus": true, "orderBy": true}
+	"key": true, "value": true, "trusted": true, "label": true, "getTrustStatus": true, "getHostStatus": true, "orderBy": true,
+	"sortBy": true, "limit": true, "offset": true}
+
+var hostSortFields = map[string]bool{models.SortById: true, models.SortByName: true, models.SortByHostHardwareId: true}
 
 var hostRetrieveParams = map[string]bool{"getReport": true, "getHostStatus": true}
 
@@ -144,9 +147,10 @@ func (hc *HostController) Update(w http.ResponseWriter, r *http.Request) (interf
 		Description:      reqHost.Description,
 		ConnectionString: reqHost.ConnectionString,
 		FlavorgroupNames: reqHost.FlavorgroupNames,
+		Labels:           reqHost.Labels,
 	}
 
-	if err := validateHostCreateCriteria(criteria); err != nil {
+	if err := validateHostCreateCriteria(criteria, hc.HCConfig.SimulatedHostsEnabled); err != nil {
 		secLog.WithError(err).Errorf("controllers/host_controller:Update() %s : Invalid request body", commLogMsg.InvalidInputBadParam)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
 	}
@@ -234,6 +238,15 @@ func (hc *HostController) Search(w http.ResponseWriter, r *http.Request) (interf
 	}
 	hostCollection := hvs.HostCollection{Hosts: hosts}
 
+	if hostFilterCriteria.IsPaged() {
+		total, err := hc.HStore.Count(hostFilterCriteria, hostInfoFetchCriteria)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/host_controller:Search() Host count failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Failed to search Hosts")
+		}
+		hostCollection.PageInfo = hvs.NewPageInfo(total, hostFilterCriteria.Offset, len(hosts))
+	}
+
 	secLog.Infof("%s: Hosts searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return hostCollection, http.StatusOK, nil
 }
@@ -247,7 +260,7 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host connection string and host name must be specified"}
 	}
 
-	if err := validateHostCreateCriteria(reqHost); err != nil {
+	if err := validateHostCreateCriteria(reqHost, hc.HCConfig.SimulatedHostsEnabled); err != nil {
 		secLog.WithError(err).Errorf("controllers/host_controller:CreateHost() %s Invalid host data", commLogMsg.InvalidInputBadParam)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid host data"}
 	}
@@ -317,6 +330,7 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 		ConnectionString: csWithoutCredentials,
 		HardwareUuid:     hwUuid,
 		FlavorgroupNames: fgNames,
+		Labels:           reqHost.Labels,
 	}
 
 	createdHost, err := hc.HStore.Create(host)
@@ -348,6 +362,12 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 		}
 	}
 
+	defaultLog.Debugf("Associating host %s with the flavorgroups selecting its labels", reqHost.HostName)
+	if err := hc.linkLabelSelectedFlavorgroups(createdHost); err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host label selected FlavorGroup association failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
+	}
+
 	defaultLog.Debugf("Associating host %s with all host unique flavors", reqHost.HostName)
 	if err := hc.linkHostUniqueFlavorsToHost(createdHost); err != nil {
 		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host Unique flavor association failed")
@@ -434,6 +454,13 @@ func (hc *HostController) UpdateHost(reqHost hvs.Host) (interface{}, int, error)
 		updatedHost.FlavorgroupNames = reqHost.FlavorgroupNames
 	}
 
+	// the labels of the host may have changed, the caller queues the host for verification
+	defaultLog.Debugf("Associating host %s with the flavorgroups selecting its labels", updatedHost.HostName)
+	if err := hc.linkLabelSelectedFlavorgroups(updatedHost); err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:UpdateHost() Host label selected FlavorGroup association failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
+	}
+
 	return updatedHost, http.StatusOK, nil
 }
 
@@ -558,6 +585,53 @@ func (hc *HostController) linkFlavorgroupsToHost(flavorgroupNames []string, host
 	return nil
 }
 
+// linkLabelSelectedFlavorgroups links the host to the flavorgroups whose label selector matches its labels and unlinks
+// it from the other flavorgroups having a label selector
+func (hc *HostController) linkLabelSelectedFlavorgroups(host *hvs.Host) error {
+	defaultLog.Trace("controllers/host_controller:linkLabelSelectedFlavorgroups() Entering")
+	defer defaultLog.Trace("controllers/host_controller:linkLabelSelectedFlavorgroups() Leaving")
+
+	flavorgroups, err := hc.FGStore.Search(nil)
+	if err != nil {
+		return errors.Wrap(err, "Could not search flavorgroups")
+	}
+	linkedFlavorgroupIds, err := hc.HStore.SearchFlavorgroups(host.Id)
+	if err != nil {
+		return errors.Wrap(err, "Could not search host-flavorgroup links")
+	}
+	linked := make(map[uuid.UUID]bool, len(linkedFlavorgroupIds))
+	for _, flavorgroupId := range linkedFlavorgroupIds {
+		linked[flavorgroupId] = true
+	}
+
+	var linkIds, unlinkIds []uuid.UUID
+	for _, flavorgroup := range flavorgroups {
+		if len(flavorgroup.LabelSelector) == 0 {
+			continue
+		}
+		selected := utils.LabelsMatch(flavorgroup.LabelSelector, host.Labels)
+		if selected && !linked[flavorgroup.ID] {
+			linkIds = append(linkIds, flavorgroup.ID)
+		} else if !selected && linked[flavorgroup.ID] {
+			unlinkIds = append(unlinkIds, flavorgroup.ID)
+		}
+	}
+
+	if len(linkIds) > 0 {
+		defaultLog.Debugf("Linking host %v with flavorgroups %+q", host.Id, linkIds)
+		if err := hc.HStore.AddFlavorgroups(host.Id, linkIds); err != nil {
+			return errors.Wrap(err, "Could not create host-flavorgroup links")
+		}
+	}
+	if len(unlinkIds) > 0 {
+		defaultLog.Debugf("Unlinking host %v from flavorgroups %+q", host.Id, unlinkIds)
+		if err := hc.HStore.RemoveFlavorgroups(host.Id, unlinkIds); err != nil {
+			return errors.Wrap(err, "Could not delete host-flavorgroup links")
+		}
+	}
+	return nil
+}
+
 func (hc *HostController) linkHostUniqueFlavorsToHost(newHost *hvs.Host) error {
 	defaultLog.Trace("controllers/host_controller:linkHostUniqueFlavorsToHost() Entering")
 	defer defaultLog.Trace("controllers/host_controller:linkHostUniqueFlavorsToHost() Leaving")
@@ -642,7 +716,7 @@ func (hc *HostController) flavorGroupHostLinkExists(hostId, flavorgroupId uuid.U
 	return true, nil
 }
 
-func validateHostCreateCriteria(host hvs.HostCreateRequest) error {
+func validateHostCreateCriteria(host hvs.HostCreateRequest, simulatedHostsEnabled bool) error {
 	defaultLog.Trace("controllers/host_controller:validateHostCreateCriteria() Entering")
 	defer defaultLog.Trace("controllers/host_controller:validateHostCreateCriteria() Leaving")
 
@@ -652,7 +726,7 @@ func validateHostCreateCriteria(host hvs.HostCreateRequest) error {
 		}
 	}
 	if host.ConnectionString != "" {
-		err := utils.ValidateConnectionString(host.ConnectionString)
+		err := utils.ValidateConnectionString(host.ConnectionString, simulatedHostsEnabled)
 		if err != nil {
 			return errors.Wrap(err, "Invalid host connection string")
 		}
@@ -672,6 +746,9 @@ func validateHostCreateCriteria(host hvs.HostCreateRequest) error {
 			return errors.Wrap(err, "Valid Flavorgroup Names must be specified")
 		}
 	}
+	if err := utils.ValidateLabels(host.Labels); err != nil {
+		return errors.Wrap(err, "Valid Host Labels must be specified")
+	}
 	return nil
 }
 
@@ -725,13 +802,18 @@ func populateHostFilterCriteria(params url.Values) (*models.HostFilterCriteria,
 		criteria.Trusted = &trustStatus
 	}
 
-	if params.Get("orderBy") != "" {
-		orderType, err := models.GetOrderType(params.Get("orderBy"))
-		if err != nil {
-			return nil, errors.New("Invalid orderBy query param value, must be asc/desc")
-		}
-		criteria.OrderBy = orderType
+	// the label filter applies along with the other filters
+	labels, err := utils.ParseLabelQueryParams(params["label"])
+	if err != nil {
+		return nil, errors.Wrap(err, "Valid contents for label must be specified")
 	}
+	criteria.Labels = labels
+
+	pageCriteria, err := utils.ParsePageCriteria(params, hostSortFields)
+	if err != nil {
+		return nil, err
+	}
+	criteria.PageCriteria = *pageCriteria
 
 	return &criteria, nil
 }
@@ -804,7 +886,7 @@ func (hc *HostController) AddFlavorgroup(w http.ResponseWriter, r *http.Request)
 		return nil, status, err
 	}
 
-	_, err = hc.FGStore.Retrieve(reqHostFlavorgroup.FlavorgroupId)
+	flavorgroup, err := hc.FGStore.Retrieve(reqHostFlavorgroup.FlavorgroupId)
 	if err != nil {
 		if strings.Contains(err.Error(), commErr.RowsNotFound) {
 			defaultLog.WithError(err).WithField("id", reqHostFlavorgroup.FlavorgroupId).Error("controllers/host_controller:AddFlavorgroup() Flavorgroup with specified id could not be located")
@@ -815,6 +897,11 @@ func (hc *HostController) AddFlavorgroup(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	if len(flavorgroup.LabelSelector) > 0 {
+		secLog.Errorf("controllers/host_controller:AddFlavorgroup() %s : Flavorgroup hosts are selected by its label selector", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Hosts of a Flavorgroup with a label selector cannot be linked explicitly"}
+	}
+
 	linkExists, err := hc.flavorGroupHostLinkExists(hId, reqHostFlavorgroup.FlavorgroupId)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/host_controller:AddFlavorgroup() Host Flavorgroup link retrieve failed")
@@ -874,6 +961,17 @@ func (hc *HostController) RemoveFlavorgroup(w http.ResponseWriter, r *http.Reque
 		return nil, status, err
 	}
 
+	// the links of a flavorgroup with a label selector follow the labels of the hosts
+	flavorgroup, err := hc.FGStore.Retrieve(fgId)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", fgId).Error("controllers/host_controller:RemoveFlavorgroup() Flavorgroup retrieve failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavorgroup from database"}
+	}
+	if len(flavorgroup.LabelSelector) > 0 {
+		secLog.Errorf("controllers/host_controller:RemoveFlavorgroup() %s : Flavorgroup hosts are selected by its label selector", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Hosts of a Flavorgroup with a label selector cannot be unlinked explicitly"}
+	}
+
 	if err := hc.HStore.RemoveFlavorgroups(hId, []uuid.UUID{fgId}); err != nil {
 		defaultLog.WithError(err).Error("controllers/host_controller:RemoveFlavorgroup() Host Flavorgroup link delete failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete Host Flavorgroup link"}
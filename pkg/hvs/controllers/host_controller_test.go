@@ -7,13 +7,16 @@ package controllers_test
 import (
 	"encoding/base64"
 	"encoding/json"
+	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
 	mocks2 "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	"net/http"
 	"net/http/httptest"
@@ -49,7 +52,7 @@ var _ = Describe("HostController", func() {
 		Expect(err).NotTo(HaveOccurred())
 		hostControllerConfig = domain.HostControllerConfig{
 			HostConnectorProvider: hostConnectorProvider,
-			DataEncryptionKey:     dek,
+			DataEncryptionKeys:    models.NewDataEncryptionKeys(dek),
 			Username:              "fakeuser",
 			Password:              "fakepassword",
 		}
@@ -154,6 +157,28 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
+		Context("Provide a Create request that contains simulator connection string", func() {
+			It("Should fail to create new Host when simulated hosts are not enabled", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
+				hostJson := `{
+								"host_name": "localhost3",
+								"connection_string": "simulator:file:///etc/passwd",
+								"description": "Simulated Host"
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/hosts",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Provide a Create request without hostname", func() {
 			It("Should fail to create new Host", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
@@ -289,6 +314,61 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusOK))
 			})
 		})
+		Context("Provide Host labels selected by the label selector of a Flavorgroup", func() {
+			It("Should link the Host to the Flavorgroup while its labels match the selector", func() {
+				hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				flavorgroup, err := flavorGroupStore.Create(&hvs.FlavorGroup{Name: "hvs_flavorgroup_rack12",
+					LabelSelector: map[string]string{"rack": "r12"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				router.Handle("/hosts/{hId}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Update))).Methods("PUT")
+				updateLabels := func(labels string) {
+					hostJson := `{
+								"host_name": "localhost1",
+								"connection_string": "intel:https://ta.ip.com:1443",
+								"labels": ` + labels + `
+							}`
+					req, err := http.NewRequest("PUT", "/hosts/"+hostId.String(), strings.NewReader(hostJson))
+					Expect(err).NotTo(HaveOccurred())
+					req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+					req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+					w = httptest.NewRecorder()
+					router.ServeHTTP(w, req)
+					Expect(w.Code).To(Equal(http.StatusOK))
+				}
+
+				updateLabels(`{"rack": "r12", "role": "compute"}`)
+				flavorgroupIds, err := hostStore.SearchFlavorgroups(hostId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorgroupIds).To(ContainElement(flavorgroup.ID))
+
+				updateLabels(`{"rack": "r13", "role": "compute"}`)
+				flavorgroupIds, err = hostStore.SearchFlavorgroups(hostId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorgroupIds).NotTo(ContainElement(flavorgroup.ID))
+			})
+		})
+		Context("Provide a Host data that contains invalid labels", func() {
+			It("Should fail to update Host", func() {
+				router.Handle("/hosts/{hId}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Update))).Methods("PUT")
+				hostJson := `{
+								"host_name": "localhost1",
+								"labels": {"rack:": "r12"}
+							}`
+
+				req, err := http.NewRequest(
+					"PUT",
+					"/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Provide a Host data that contains malformed connection string", func() {
 			It("Should fail to update Host", func() {
 				router.Handle("/hosts/{hId}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Update))).Methods("PUT")
@@ -431,6 +511,41 @@ var _ = Describe("HostController", func() {
 				Expect(len(hostCollection.Hosts)).To(Equal(1))
 			})
 		})
+		Context("Get all the Hosts with valid label params", func() {
+			It("Should get list of the Hosts having all the labels", func() {
+				_, err := hostStore.Create(&hvs.Host{Id: uuid.MustParse("1ea8a4b8-bc3c-4b5e-9e3b-1d4c2e0b5d3a"),
+					HostName: "localhost3", Labels: map[string]string{"rack": "r12", "role": "compute"}})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = hostStore.Create(&hvs.Host{Id: uuid.MustParse("7a1c1a55-43b2-4b4e-8e1c-3f0b2d6c8e9f"),
+					HostName: "localhost4", Labels: map[string]string{"rack": "r12", "role": "storage"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?label=rack:r12&label=role:compute", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				err = json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.Hosts[0].HostName).To(Equal("localhost3"))
+			})
+		})
+		Context("Get all the Hosts with invalid label param", func() {
+			It("Should fail to get Hosts", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?label=rack", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Get all the Hosts with valid nameContains param", func() {
 			It("Should get list of all the filtered Hosts", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
@@ -526,6 +641,66 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
+		Context("Get the first page of Hosts", func() {
+			It("Should get a page of Hosts with the total and the next offset", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?limit=1&sortBy=name&orderBy=asc", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				err = json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.PageInfo).NotTo(BeNil())
+				Expect(hostCollection.Total).To(Equal(2))
+				Expect(hostCollection.NextOffset).To(Equal(1))
+			})
+		})
+		Context("Get the last page of Hosts", func() {
+			It("Should get a page of Hosts without the next offset", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?limit=1&offset=1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				err = json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.PageInfo).NotTo(BeNil())
+				Expect(hostCollection.Total).To(Equal(2))
+				Expect(hostCollection.NextOffset).To(Equal(0))
+			})
+		})
+		Context("Get all the Hosts with invalid limit param", func() {
+			It("Should fail to get Hosts", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?limit=0", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Get all the Hosts with invalid sortBy param", func() {
+			It("Should fail to get Hosts", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?sortBy=connectionString", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 	})
 
 	// Specs for HTTP Post to "/hosts/{hId}/flavorgroups"
@@ -550,6 +725,26 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusCreated))
 			})
 		})
+		Context("Provide the Id of a Flavorgroup with a label selector", func() {
+			It("Should fail to create new Host Flavorgroup link", func() {
+				flavorgroup, err := flavorGroupStore.Create(&hvs.FlavorGroup{Name: "hvs_flavorgroup_rack12",
+					LabelSelector: map[string]string{"rack": "r12"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				router.Handle("/hosts/{hId}/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.AddFlavorgroup))).Methods("POST")
+				req, err := http.NewRequest(
+					"POST",
+					"/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2/flavorgroups",
+					strings.NewReader(`{"flavorgroup_id": "`+flavorgroup.ID.String()+`"}`),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Provide a linked Flavorgroup Id", func() {
 			It("Should fail to create new Host Flavorgroup link", func() {
 				router.Handle("/hosts/{hId}/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.AddFlavorgroup))).Methods("POST")
@@ -723,4 +918,159 @@ var _ = Describe("HostController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Get to "/hosts/{hId}/manifest"
+	Describe("Retrieve the host manifest of a Host", func() {
+		BeforeEach(func() {
+			router.Handle("/hosts/{hId}/manifest", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.RetrieveManifest))).Methods("GET")
+		})
+		Context("Retrieve the host manifest of a Host with a stored host manifest", func() {
+			It("Should return the latest host manifest", func() {
+				req, err := http.NewRequest("GET", "/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2/manifest", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostManifest types.HostManifest
+				err = json.Unmarshal(w.Body.Bytes(), &hostManifest)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostManifest.HostInfo.HostName).To(Equal("computepurley1"))
+				Expect(hostManifest.PcrManifest.Sha256Pcrs).NotTo(BeEmpty())
+			})
+		})
+		Context("Retrieve the host manifest of a Host without a stored host manifest", func() {
+			It("Should fail to retrieve the host manifest", func() {
+				_, err := hostStore.Create(&hvs.Host{
+					Id:               uuid.MustParse("13885605-a0ee-41f2-b6fc-fd82edc487ad"),
+					HostName:         "localhost3",
+					ConnectionString: "intel:https://another.ta.ip.com:1443",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				req, err := http.NewRequest("GET", "/hosts/13885605-a0ee-41f2-b6fc-fd82edc487ad/manifest", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Retrieve the host manifest of a non-existent Host", func() {
+			It("Should fail to retrieve the host manifest", func() {
+				req, err := http.NewRequest("GET", "/hosts/73755fda-c910-46be-821f-e8ddeab189e9/manifest", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/hosts/{hId}/event-log"
+	Describe("Retrieve the event log of a Host", func() {
+		BeforeEach(func() {
+			router.Handle("/hosts/{hId}/event-log", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.RetrieveEventLog))).Methods("GET")
+			_, err := hostStore.Create(&hvs.Host{
+				Id:               uuid.MustParse("c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21"),
+				HostName:         "computepurley2",
+				ConnectionString: "intel:https://computepurley2.ta.ip.com:1443",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		Context("Retrieve the SHA256 event logs of all the PCRs", func() {
+			It("Should return the replayed event logs ordered by PCR", func() {
+				req, err := http.NewRequest("GET", "/hosts/c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21/event-log", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostEventLog hvs.HostEventLog
+				err = json.Unmarshal(w.Body.Bytes(), &hostEventLog)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostEventLog.HostId.String()).To(Equal("c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21"))
+				Expect(len(hostEventLog.PcrEventLogs)).To(Equal(2))
+
+				pcr0 := hostEventLog.PcrEventLogs[0]
+				Expect(pcr0.Pcr).To(Equal(types.Pcr{Index: 0, Bank: "SHA256"}))
+				Expect(len(pcr0.Events)).To(Equal(3))
+				Expect(pcr0.Events[0].TypeName).To(Equal("EV_NO_ACTION"))
+				Expect(pcr0.Events[0].Tags).To(Equal([]string{"StartupLocality3"}))
+				Expect(pcr0.Events[0].ReplayValue).To(Equal("0000000000000000000000000000000000000000000000000000000000000003"))
+				Expect(pcr0.Events[1].ReplayValue).To(Equal("f02de1ad4a377d89e49ff34f854eebc4764bc94ecdf328a615cbf17f85bfeab4"))
+				Expect(pcr0.Events[2].ReplayValue).To(Equal("e9d8c854b5a0fd4cd894d3ae76e829b3b52300ce3e7606fb4110256af7135212"))
+				Expect(pcr0.ReplayValue).To(Equal(pcr0.PcrValue))
+				Expect(pcr0.PcrMatches).To(BeTrue())
+
+				pcr17 := hostEventLog.PcrEventLogs[1]
+				Expect(pcr17.Pcr.Index).To(Equal(17))
+				Expect(pcr17.PcrMatches).To(BeFalse())
+			})
+		})
+		Context("Retrieve the event log of a single PCR", func() {
+			It("Should return the event log of the PCR", func() {
+				req, err := http.NewRequest("GET", "/hosts/c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21/event-log?bank=SHA256&pcr=17", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostEventLog hvs.HostEventLog
+				err = json.Unmarshal(w.Body.Bytes(), &hostEventLog)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(hostEventLog.PcrEventLogs)).To(Equal(1))
+				Expect(hostEventLog.PcrEventLogs[0].Pcr.Index).To(Equal(17))
+				Expect(hostEventLog.PcrEventLogs[0].Events[0].TypeName).To(Equal("HASH_START"))
+			})
+		})
+		Context("Retrieve the event logs of a bank without event logs", func() {
+			It("Should return no event log", func() {
+				req, err := http.NewRequest("GET", "/hosts/c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21/event-log?bank=SHA1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostEventLog hvs.HostEventLog
+				err = json.Unmarshal(w.Body.Bytes(), &hostEventLog)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostEventLog.PcrEventLogs).To(BeEmpty())
+			})
+		})
+		Context("Provide an invalid bank", func() {
+			It("Should fail to retrieve the event log", func() {
+				req, err := http.NewRequest("GET", "/hosts/c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21/event-log?bank=MD5", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide an invalid PCR index", func() {
+			It("Should fail to retrieve the event log", func() {
+				req, err := http.NewRequest("GET", "/hosts/c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21/event-log?pcr=24", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Retrieve the event log of a non-existent Host", func() {
+			It("Should fail to retrieve the event log", func() {
+				req, err := http.NewRequest("GET", "/hosts/73755fda-c910-46be-821f-e8ddeab189e9/event-log", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var hostEventLogParams = map[string]bool{"bank": true, "pcr": true}
+
+// RetrieveManifest returns the latest host manifest stored for the host
+func (hc *HostController) RetrieveManifest(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_controller:RetrieveManifest() Entering")
+	defer defaultLog.Trace("controllers/host_controller:RetrieveManifest() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), map[string]bool{}); err != nil {
+		secLog.Errorf("controllers/host_controller:RetrieveManifest() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["hId"])
+	hostStatus, status, err := hc.retrieveLatestHostStatus(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("host_id", id).Infof("%s: Host manifest retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hostStatus.HostManifest, http.StatusOK, nil
+}
+
+// RetrieveEventLog returns the TCG event logs of the latest host manifest stored for the host. Each event holds the
+// value the PCR is extended to by replaying the event log up to the event, so that the mismatches reported by the
+// pcr_eventlog_equals rule can be traced back to the events causing them.
+func (hc *HostController) RetrieveEventLog(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_controller:RetrieveEventLog() Entering")
+	defer defaultLog.Trace("controllers/host_controller:RetrieveEventLog() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), hostEventLogParams); err != nil {
+		secLog.Errorf("controllers/host_controller:RetrieveEventLog() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	bank := types.SHA256
+	if bankParam := r.URL.Query().Get("bank"); bankParam != "" {
+		var err error
+		bank, err = types.GetSHAAlgorithm(strings.ToUpper(bankParam))
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/host_controller:RetrieveEventLog() %s Invalid bank query parameter given",
+				commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid bank query parameter given"}
+		}
+	}
+
+	var pcrIndex types.PcrIndex = types.INVALID_INDEX
+	if pcrParam := r.URL.Query().Get("pcr"); pcrParam != "" {
+		index, err := types.GetPcrIndexFromString(pcrParam)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/host_controller:RetrieveEventLog() %s Invalid pcr query parameter given",
+				commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid pcr query parameter given"}
+		}
+		pcrIndex = index
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["hId"])
+	hostStatus, status, err := hc.retrieveLatestHostStatus(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	pcrEventLogs, err := replayEventLogs(&hostStatus.HostManifest.PcrManifest, bank, pcrIndex)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:RetrieveEventLog() Failed to replay the event log")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to replay the event log"}
+	}
+
+	secLog.WithField("host_id", id).Infof("%s: Host event log retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.HostEventLog{
+		HostId:       id,
+		Created:      hostStatus.Created,
+		PcrEventLogs: pcrEventLogs,
+	}, http.StatusOK, nil
+}
+
+// retrieveLatestHostStatus returns the latest host status of the host, which holds the last host manifest
+// retrieved from the host
+func (hc *HostController) retrieveLatestHostStatus(id uuid.UUID) (*hvs.HostStatus, int, error) {
+	defaultLog.Trace("controllers/host_controller:retrieveLatestHostStatus() Entering")
+	defer defaultLog.Trace("controllers/host_controller:retrieveLatestHostStatus() Leaving")
+
+	_, err := hc.HStore.Retrieve(id, nil)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).Error("controllers/host_controller:retrieveLatestHostStatus() Host with specified id could not be located")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Host with specified id does not exist"}
+		}
+		defaultLog.WithError(err).Error("controllers/host_controller:retrieveLatestHostStatus() Host retrieve failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Host from database"}
+	}
+
+	hostStatuses, err := hc.HSStore.Search(&models.HostStatusFilterCriteria{
+		HostId:        id,
+		LatestPerHost: true,
+		PageCriteria:  models.PageCriteria{Limit: 1},
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:retrieveLatestHostStatus() Host status search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Host status from database"}
+	}
+	if len(hostStatuses) == 0 || hostStatuses[0].HostManifest.PcrManifest.IsEmpty() {
+		defaultLog.Errorf("controllers/host_controller:retrieveLatestHostStatus() No host manifest is stored for host %s", id)
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "No host manifest is stored for the host"}
+	}
+	return &hostStatuses[0], http.StatusOK, nil
+}
+
+// replayEventLogs replays the event logs of the bank, or only the event log of the PCR unless pcrIndex is
+// INVALID_INDEX, and compares the values they replay to with the PCR values of the manifest
+func replayEventLogs(pcrManifest *types.PcrManifest, bank types.SHAAlgorithm, pcrIndex types.PcrIndex) ([]hvs.PcrEventLogEntry, error) {
+	var eventLogs []types.TpmEventLog
+	switch bank {
+	case types.SHA1:
+		eventLogs = pcrManifest.PcrEventLogMap.Sha1EventLogs
+	case types.SHA256:
+		eventLogs = pcrManifest.PcrEventLogMap.Sha256EventLogs
+	case types.SHA384:
+		eventLogs = pcrManifest.PcrEventLogMap.Sha384EventLogs
+	case types.SHA512:
+		eventLogs = pcrManifest.PcrEventLogMap.Sha512EventLogs
+	}
+
+	pcrEventLogs := []hvs.PcrEventLogEntry{}
+	for _, eventLog := range eventLogs {
+		if pcrIndex != types.INVALID_INDEX && eventLog.Pcr.Index != int(pcrIndex) {
+			continue
+		}
+		// the running value of the PCR is the replay of the events measured up to each event
+		replayed := types.TpmEventLog{
+			Pcr: types.Pcr{Index: eventLog.Pcr.Index, Bank: string(bank)},
+		}
+		pcrEventLog := hvs.PcrEventLogEntry{
+			Pcr:    replayed.Pcr,
+			Events: make([]hvs.EventReplay, 0, len(eventLog.TpmEvent)),
+		}
+		for i, event := range eventLog.TpmEvent {
+			replayed.TpmEvent = eventLog.TpmEvent[:i+1]
+			replayValue, err := replayed.Replay()
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to replay the event log of PCR %d", eventLog.Pcr.Index)
+			}
+			pcrEventLog.Events = append(pcrEventLog.Events, hvs.EventReplay{EventLog: event, ReplayValue: replayValue})
+		}
+		if len(pcrEventLog.Events) != 0 {
+			pcrEventLog.ReplayValue = pcrEventLog.Events[len(pcrEventLog.Events)-1].ReplayValue
+		} else {
+			// an empty event log replays to the initial value of the PCR
+			replayValue, err := replayed.Replay()
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to replay the event log of PCR %d", eventLog.Pcr.Index)
+			}
+			pcrEventLog.ReplayValue = replayValue
+		}
+
+		pcr, err := pcrManifest.GetPcrValue(bank, types.PcrIndex(eventLog.Pcr.Index))
+		if err != nil {
+			return nil, err
+		}
+		if pcr != nil {
+			pcrEventLog.PcrValue = pcr.Value
+			pcrEventLog.PcrMatches = strings.EqualFold(pcr.Value, pcrEventLog.ReplayValue)
+		}
+		pcrEventLogs = append(pcrEventLogs, pcrEventLog)
+	}
+
+	sort.Slice(pcrEventLogs, func(i, j int) bool {
+		return pcrEventLogs[i].Pcr.Index < pcrEventLogs[j].Pcr.Index
+	})
+	return pcrEventLogs, nil
+}
@@ -30,7 +30,10 @@ type HostStatusController struct {
 }
 
 var hostStatusSearchParams = map[string]bool{"id": true, "hostId": true, "hostHardwareId": true, "hostName": true, "hostStatus": true,
-	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true}
+	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true, "offset": true,
+	"sortBy": true, "orderBy": true}
+
+var hostStatusSortFields = map[string]bool{models.SortById: true, models.SortByCreatedAt: true}
 
 // Search returns a collection of HostStatus based on HostStatusFilter criteria
 func (controller HostStatusController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -49,14 +52,25 @@ func (controller HostStatusController) Search(w http.ResponseWriter, r *http.Req
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid filter criteria"}
 	}
 
-	hostStatusCollection, err := controller.Store.Search(filter)
+	hostStatuses, err := controller.Store.Search(filter)
 	if err != nil {
 		defaultLog.WithError(err).Warnf("controllers/hoststatus_controller:Search() Host Status search operation failed")
 		return nil, http.StatusInternalServerError, errors.Errorf("Host Status search operation failed")
 	}
+	hostStatusCollection := hvs.HostStatusCollection{HostStatuses: hostStatuses}
+
+	// the total is only returned when a page is requested, the search results are always limited
+	if r.URL.Query().Get("limit") != "" || r.URL.Query().Get("offset") != "" {
+		total, err := controller.Store.Count(filter)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/hoststatus_controller:Search() Host Status count operation failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Host Status search operation failed")
+		}
+		hostStatusCollection.PageInfo = hvs.NewPageInfo(total, filter.Offset, len(hostStatuses))
+	}
 
 	secLog.Infof("%s: Return Host Status Search query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
-	return hvs.HostStatusCollection{HostStatuses: hostStatusCollection}, http.StatusOK, nil
+	return hostStatusCollection, http.StatusOK, nil
 }
 
 // Retrieve returns an existing HostStatus entry from the HostStatusStore
@@ -189,15 +203,14 @@ func getHSFilterCriteria(params url.Values) (*models.HostStatusFilterCriteria, e
 		hfc.NumberOfDays = numDays
 	}
 
+	pageCriteria, err := utils.ParsePageCriteria(params, hostStatusSortFields)
+	if err != nil {
+		return nil, err
+	}
+	hfc.PageCriteria = *pageCriteria
+
 	// rowLimit - defaults per set limit
-	rowLimit := strings.TrimSpace(params.Get("limit"))
-	if rowLimit != "" {
-		rLimit, err := strconv.Atoi(rowLimit)
-		if err != nil || rLimit <= 0 {
-			return nil, errors.New("Limit must be an integer > 0")
-		}
-		hfc.Limit = rLimit
-	} else {
+	if hfc.Limit == 0 {
 		hfc.Limit = constants.DefaultSearchResultRowLimit
 	}
 
@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// jobActions are the actions of the jobs exposed by the jobs API
+var jobActions = map[string]bool{consts.HostBulkCreateAction: true, consts.TagCertificateBulkProvisionAction: true}
+
+type JobController struct {
+	JobStore domain.JobStore
+}
+
+func NewJobController(js domain.JobStore) *JobController {
+	return &JobController{js}
+}
+
+// Retrieve returns the progress of a job and the result of each of its hosts processed so far
+func (controller JobController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/job_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/job_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	storedJob, err := controller.JobStore.Retrieve(id)
+	if err != nil || storedJob == nil || !jobActions[storedJob.Action] {
+		defaultLog.WithError(err).WithField("id", id).Info("controllers/job_controller:Retrieve() Job with given ID does not exist")
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Job with given ID does not exist"}
+	}
+
+	job, err := newJob(storedJob)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/job_controller:Retrieve() Error reading job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Job"}
+	}
+
+	secLog.WithField("job", id).Infof("%s: Job retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return job, http.StatusOK, nil
+}
+
+// newJob builds a Job from the stored job. The params are converted through JSON since the job store returns
+// them either as they were stored or decoded from the database.
+func newJob(job *models.Job) (*hvs.Job, error) {
+	var params struct {
+		Total     int             `json:"total"`
+		Succeeded int             `json:"succeeded"`
+		Failed    int             `json:"failed"`
+		Results   []hvs.JobResult `json:"results"`
+	}
+	paramsJson, err := json.Marshal(job.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling job params")
+	}
+	if err = json.Unmarshal(paramsJson, &params); err != nil {
+		return nil, errors.Wrap(err, "Error unmarshalling job params")
+	}
+	if params.Results == nil {
+		params.Results = []hvs.JobResult{}
+	}
+
+	return &hvs.Job{
+		ID:        job.Id,
+		Action:    job.Action,
+		State:     string(job.State),
+		Message:   job.Message,
+		Created:   job.Created,
+		Updated:   job.Updated,
+		Total:     params.Total,
+		Succeeded: params.Succeeded,
+		Failed:    params.Failed,
+		Results:   params.Results,
+	}, nil
+}
+
+// jobResultError returns the error recorded in the result of a host that failed, the message of a ResourceError is
+// returned as it is meant for the API clients
+func jobResultError(err error) string {
+	if resourceErr, ok := err.(*commErr.ResourceError); ok {
+		return resourceErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsMetrics "github.com/intel-secl/intel-secl/v4/pkg/hvs/metrics"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsController struct {
+	QueueStore domain.QueueStore
+	handler    http.Handler
+}
+
+func NewMetricsController(qs domain.QueueStore) *MetricsController {
+	return &MetricsController{QueueStore: qs, handler: promhttp.Handler()}
+}
+
+// updateQueueDepth sets the number of queue entries of every queue state, including the states without entries
+func (controller *MetricsController) updateQueueDepth() error {
+	counts, err := controller.QueueStore.CountByState()
+	if err != nil {
+		return err
+	}
+	for state := models.QueueStateNew; state <= models.QueueStateError; state++ {
+		hvsMetrics.QueueDepth.WithLabelValues(state.String()).Set(float64(counts[state]))
+	}
+	return nil
+}
+
+// Retrieve updates the queue depth from the database and serves the metrics registered with Prometheus in the text
+// exposition format
+func (controller *MetricsController) Retrieve(w http.ResponseWriter, r *http.Request) error {
+	defaultLog.Trace("controllers/metrics_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/metrics_controller:Retrieve() Leaving")
+
+	if len(r.URL.Query()) > 0 {
+		secLog.Errorf("controllers/metrics_controller:Retrieve() %s : Unexpected query parameters", commLogMsg.InvalidInputBadParam)
+		return &commErr.HandledError{StatusCode: http.StatusBadRequest, Message: "Query parameters are not supported"}
+	}
+
+	if err := controller.updateQueueDepth(); err != nil {
+		defaultLog.WithError(err).Error("controllers/metrics_controller:Retrieve() Error collecting queue depth")
+		return &commErr.HandledError{StatusCode: http.StatusInternalServerError, Message: "Failed to retrieve metrics"}
+	}
+
+	controller.handler.ServeHTTP(w, r)
+	secLog.Infof("%s: Metrics retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return nil
+}
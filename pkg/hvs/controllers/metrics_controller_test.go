@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsMetrics "github.com/intel-secl/intel-secl/v4/pkg/hvs/metrics"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// failingQueueStore is a queue store that cannot count its entries
+type failingQueueStore struct {
+	domain.QueueStore
+}
+
+func (store *failingQueueStore) CountByState() (map[models.QueueState]int, error) {
+	return nil, errors.New("database unavailable")
+}
+
+var _ = Describe("MetricsController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var metricsController *controllers.MetricsController
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+
+		queueStore := mocks.NewQueueStore()
+		for _, state := range []models.QueueState{models.QueueStateNew, models.QueueStateNew, models.QueueStatePending} {
+			_, err := queueStore.Create(&models.Queue{Action: "flavor-verify", Params: map[string]interface{}{"host_id": "x"}, State: state})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		metricsController = controllers.NewMetricsController(queueStore)
+	})
+
+	// Specs for HTTP Get to "/metrics"
+	Describe("Retrieve the metrics", func() {
+		Context("Retrieve the metrics of the verification pipeline", func() {
+			It("Should return the metrics in the Prometheus text exposition format", func() {
+				hvsMetrics.HostConnectionFailures.WithLabelValues("CONNECTION_FAILURE").Inc()
+				hvsMetrics.TrustCacheLookup(hvsMetrics.CacheQuote, true)
+				hvsMetrics.ObserveSince(hvsMetrics.FlavorVerifyDuration, time.Now(), nil)
+
+				router.Handle("/metrics", hvsRoutes.ErrorHandler(metricsController.Retrieve)).Methods("GET")
+				req, err := http.NewRequest("GET", "/metrics", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(HavePrefix("text/plain"))
+
+				body := w.Body.String()
+				Expect(body).To(ContainSubstring("# TYPE hvs_queue_depth gauge\n"))
+				Expect(body).To(ContainSubstring("hvs_queue_depth{state=\"New\"} 2\n"))
+				Expect(body).To(ContainSubstring("hvs_queue_depth{state=\"Pending\"} 1\n"))
+				Expect(body).To(ContainSubstring("hvs_queue_depth{state=\"Error\"} 0\n"))
+				Expect(body).To(ContainSubstring("# TYPE hvs_flavor_verify_duration_seconds histogram\n"))
+				Expect(body).To(MatchRegexp(`hvs_host_connection_failures_total\{host_state="CONNECTION_FAILURE"\} \d+`))
+				Expect(body).To(MatchRegexp(`hvs_trust_cache_hit_ratio\{cache="quote"\} [0-9.]+`))
+			})
+		})
+		Context("Retrieve the metrics with query parameters", func() {
+			It("Should fail to retrieve the metrics", func() {
+				router.Handle("/metrics", hvsRoutes.ErrorHandler(metricsController.Retrieve)).Methods("GET")
+				req, err := http.NewRequest("GET", "/metrics?state=New", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Retrieve the metrics when the queue cannot be read", func() {
+			It("Should fail with an internal server error", func() {
+				metricsController = controllers.NewMetricsController(&failingQueueStore{mocks.NewQueueStore()})
+				router.Handle("/metrics", hvsRoutes.ErrorHandler(metricsController.Retrieve)).Methods("GET")
+				req, err := http.NewRequest("GET", "/metrics", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
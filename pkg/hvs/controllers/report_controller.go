@@ -11,11 +11,13 @@ import (
 	consts "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
 	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/eat"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	"github.com/pkg/errors"
@@ -30,10 +32,14 @@ type ReportController struct {
 	HostStore       domain.HostStore
 	HostStatusStore domain.HostStatusStore
 	HTManager       domain.HostTrustManager
+	// TokenIssuer signs the JWT trust reports
+	TokenIssuer *eat.Issuer
 }
 
+var reportDiffParams = map[string]bool{"from": true, "to": true}
+
 func NewReportController(rs domain.ReportStore, hs domain.HostStore, hsts domain.HostStatusStore, ht domain.HostTrustManager) *ReportController {
-	return &ReportController{rs, hs, hsts, ht}
+	return &ReportController{ReportStore: rs, HostStore: hs, HostStatusStore: hsts, HTManager: ht}
 }
 
 func (controller ReportController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -103,7 +109,7 @@ func (controller ReportController) createReport(rsCriteria hvs.ReportCreateReque
 	hostStatusCollection, err := controller.HostStatusStore.Search(&models.HostStatusFilterCriteria{
 		HostId:        hostId,
 		LatestPerHost: true,
-		Limit:         1,
+		PageCriteria:  models.PageCriteria{Limit: 1},
 	})
 	if len(hostStatusCollection) == 0 || hostStatusCollection[0].HostStatusInformation.HostState != hvs.HostStateConnected {
 		return nil, errors.New("Host is not in CONNECTED state")
@@ -159,6 +165,68 @@ func (controller ReportController) CreateSaml(w http.ResponseWriter, r *http.Req
 	return hvsReport.Saml, http.StatusCreated, nil
 }
 
+// CreateJwt creates a report and returns it as a JWT signed by the HVS
+func (controller ReportController) CreateJwt(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:CreateJwt() Entering")
+	defer defaultLog.Trace("controllers/report_controller:CreateJwt() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+	if r.Header.Get("Accept") != constants.HTTPMediaTypeJwt {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{
+			Message: "Invalid Accept type",
+		}
+	}
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/report_controller:CreateJwt() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var reqReportCreateRequest hvs.ReportCreateRequest
+	// Decode the incoming json data to note struct
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&reqReportCreateRequest)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/report_controller:CreateJwt() %s :  Failed to decode request body as Report Create Criteria", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateReportCreateCriteria(reqReportCreateRequest); err != nil {
+		secLog.WithError(err).Errorf("controllers/report_controller:CreateJwt() %s : Error validating report create criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Bad input given in input request"}
+	}
+
+	hvsReport, err := controller.createReport(reqReportCreateRequest)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:CreateJwt() Error while creating JWT report")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if hvsReport == nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:CreateJwt() The report was not created")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while creating report"}
+	}
+
+	token, err := controller.signReport(hvsReport)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:CreateJwt() Error while signing JWT report")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while signing report"}
+	}
+	secLog.WithField("Host Name", hvsReport.TrustReport.HostManifest.HostInfo.HostName).Infof("%s: jwt report created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	w.Header().Set("Content-Type", constants.HTTPMediaTypeJwt)
+	return token, http.StatusCreated, nil
+}
+
+// signReport returns the JWT of a report
+func (controller ReportController) signReport(hvsReport *models.HVSReport) (string, error) {
+	if controller.TokenIssuer == nil {
+		return "", errors.New("JWT report signing key is not configured")
+	}
+	return controller.TokenIssuer.Sign(hosttrust.GetTrustReportClaims(hvsReport))
+}
+
 func (controller ReportController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/report_controller:Retrieve() Entering")
 	defer defaultLog.Trace("controllers/report_controller:Retrieve() Leaving")
@@ -183,6 +251,80 @@ func (controller ReportController) Retrieve(w http.ResponseWriter, r *http.Reque
 	return report, http.StatusOK, nil
 }
 
+// Diff compares two reports of the same host. The reports are looked up in the report history so that
+// reports that have been replaced by a newer one can be compared as well.
+func (controller ReportController) Diff(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:Diff() Entering")
+	defer defaultLog.Trace("controllers/report_controller:Diff() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportDiffParams); err != nil {
+		secLog.Errorf("controllers/report_controller:Diff() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	fromReport, status, err := controller.getDiffReport(r.URL.Query().Get("from"), "from")
+	if err != nil {
+		return nil, status, err
+	}
+	toReport, status, err := controller.getDiffReport(r.URL.Query().Get("to"), "to")
+	if err != nil {
+		return nil, status, err
+	}
+
+	if fromReport.HostID != toReport.HostID {
+		secLog.Errorf("controllers/report_controller:Diff() %s : Reports %s and %s belong to different hosts", commLogMsg.InvalidInputBadParam, fromReport.ID, toReport.ID)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Reports must belong to the same host"}
+	}
+
+	trustReportDiff, err := hosttrust.DiffTrustReports(&fromReport.TrustReport, &toReport.TrustReport)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:Diff() Failed to compare reports")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to compare reports"}
+	}
+
+	reportDiff := hvs.ReportDiff{
+		HostID:          fromReport.HostID,
+		FromReportID:    fromReport.ID,
+		ToReportID:      toReport.ID,
+		FromCreated:     fromReport.CreatedAt,
+		ToCreated:       toReport.CreatedAt,
+		TrustReportDiff: *trustReportDiff,
+	}
+	secLog.Infof("%s: Reports %s and %s compared by: %s", commLogMsg.AuthorizedAccess, fromReport.ID, toReport.ID, r.RemoteAddr)
+	return reportDiff, http.StatusOK, nil
+}
+
+// getDiffReport retrieves the report referenced by the given query parameter of the report diff request
+func (controller ReportController) getDiffReport(reportId, paramName string) (*models.HVSReport, int, error) {
+	defaultLog.Trace("controllers/report_controller:getDiffReport() Entering")
+	defer defaultLog.Trace("controllers/report_controller:getDiffReport() Leaving")
+
+	if reportId == "" {
+		secLog.Errorf("controllers/report_controller:getDiffReport() %s : %s parameter is missing", commLogMsg.InvalidInputBadParam, paramName)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Query parameter '" + paramName + "' is required"}
+	}
+	id, err := uuid.Parse(reportId)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/report_controller:getDiffReport() %s : Invalid %s report id", commLogMsg.InvalidInputBadParam, paramName)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid " + paramName + " report id given in request"}
+	}
+
+	hvsReport, err := controller.ReportStore.RetrieveHistoric(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Info(
+				"controllers/report_controller:getDiffReport() Report with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Report with given " + paramName + " ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/report_controller:getDiffReport() failed to retrieve Report")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Report"}
+	}
+	return hvsReport, http.StatusOK, nil
+}
+
+var reportSortFields = map[string]bool{models.SortById: true, models.SortByCreatedAt: true, models.SortByExpiration: true}
+
 func (controller ReportController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/report_controller:Search() Entering")
 	defer defaultLog.Trace("controllers/report_controller:Search() Leaving")
@@ -211,6 +353,16 @@ func (controller ReportController) Search(w http.ResponseWriter, r *http.Request
 	for _, hvsReport := range hvsReportCollection {
 		reportCollection.Reports = append(reportCollection.Reports, ConvertToReport(&hvsReport))
 	}
+
+	// the total is only returned when a page is requested, the search results are always limited
+	if r.URL.Query().Get("limit") != "" || r.URL.Query().Get("offset") != "" {
+		total, err := controller.ReportStore.Count(reportFilterCriteria)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/report_controller:Search() HVSReport count operation failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("HVSReport search operation failed")
+		}
+		reportCollection.PageInfo = hvs.NewPageInfo(total, reportFilterCriteria.Offset, len(hvsReportCollection))
+	}
 	secLog.Infof("%s: Reports searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return reportCollection, http.StatusOK, nil
 }
@@ -254,6 +406,51 @@ func (controller ReportController) SearchSaml(w http.ResponseWriter, r *http.Req
 	return samlCollection.String(), http.StatusOK, nil
 }
 
+// SearchJwt returns the reports matching the search criteria as JWTs signed by the HVS, one JWT per line
+func (controller ReportController) SearchJwt(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:SearchJwt() Entering")
+	defer defaultLog.Trace("controllers/report_controller:SearchJwt() Leaving")
+
+	if r.Header.Get("Accept") != constants.HTTPMediaTypeJwt {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{
+			Message: "Invalid Accept type",
+		}
+	}
+
+	//Search params for reports is same as that of host status APIs
+	if err := utils.ValidateQueryParams(r.URL.Query(), hostStatusSearchParams); err != nil {
+		secLog.Errorf("controllers/report_controller:SearchJwt() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	// get the ReportFilterCriteria
+	reportFilterCriteria, err := getReportFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/report_controller:SearchJwt() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid Input given in request"}
+	}
+
+	hvsReportCollection, err := controller.ReportStore.Search(reportFilterCriteria)
+	if err != nil {
+		defaultLog.WithError(err).Warnf("controllers/report_controller:SearchJwt() HVSReport search operation failed")
+		return nil, http.StatusInternalServerError, errors.Errorf("HVSReport search operation failed")
+	}
+
+	var jwtCollection strings.Builder
+	for i := range hvsReportCollection {
+		token, err := controller.signReport(&hvsReportCollection[i])
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/report_controller:SearchJwt() Error while signing JWT report")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while signing reports"}
+		}
+		jwtCollection.WriteString(token + "\n")
+	}
+
+	secLog.Infof("%s: JwtReports searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	w.Header().Set("Content-Type", constants.HTTPMediaTypeJwt)
+	return jwtCollection.String(), http.StatusOK, nil
+}
+
 // getReportFilterCriteria checks for set filter params in the Search request and returns a valid ReportFilterCriteria
 func getReportFilterCriteria(params url.Values) (*models.ReportFilterCriteria, error) {
 	defaultLog.Trace("controllers/report_controller:getReportFilterCriteria() Entering")
@@ -351,14 +548,12 @@ func getReportFilterCriteria(params url.Values) (*models.ReportFilterCriteria, e
 		rfc.NumberOfDays = numDays
 	}
 
-	rowLimit := strings.TrimSpace(params.Get("limit"))
-	if rowLimit != "" {
-		rLimit, err := strconv.Atoi(rowLimit)
-		if err != nil || rLimit <= 0 {
-			return nil, errors.New("Limit must be an integer > 0")
-		}
-		rfc.Limit = rLimit
-	} else {
+	pageCriteria, err := utils.ParsePageCriteria(params, reportSortFields)
+	if err != nil {
+		return nil, err
+	}
+	rfc.PageCriteria = *pageCriteria
+	if rfc.Limit == 0 {
 		rfc.Limit = consts.DefaultSearchResultRowLimit
 	}
 
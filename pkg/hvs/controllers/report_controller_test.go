@@ -5,14 +5,24 @@
 package controllers_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
 	"encoding/xml"
+	"math/big"
+	"time"
+
+	jwt "github.com/Waterdrips/jwt-go"
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
 	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust/mocks"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/eat"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -174,6 +184,66 @@ var _ = Describe("ReportController", func() {
 		})
 	})
 
+	// Specs for HTTP Get to "/reports/diff"
+	Describe("Compare two Reports", func() {
+		Context("Compare a replaced Report with the current Report of the host", func() {
+			It("Should return the differences between the Reports", func() {
+				router.Handle("/reports/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/diff?from=15701f03-7b1d-49f9-ac62-6b9b0728bdb2&to=15701f03-7b1d-49f9-ac62-6b9b0728bdb3", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportDiff hvs.ReportDiff
+				err = json.Unmarshal(w.Body.Bytes(), &reportDiff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reportDiff.FromReportID.String()).To(Equal("15701f03-7b1d-49f9-ac62-6b9b0728bdb2"))
+				Expect(reportDiff.ToReportID.String()).To(Equal("15701f03-7b1d-49f9-ac62-6b9b0728bdb3"))
+				Expect(reportDiff.RuleChanges).To(BeEmpty())
+				Expect(reportDiff.HostInfoChanges).To(HaveLen(1))
+				Expect(reportDiff.HostInfoChanges[0].Field).To(Equal("bios_version"))
+			})
+		})
+
+		Context("Compare Reports of different hosts", func() {
+			It("Should respond with bad request", func() {
+				router.Handle("/reports/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/diff?from=15701f03-7b1d-49f9-ac62-6b9b0728bdb3&to=15701f03-7b1d-49f9-ac62-6b9b0728bdb4", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Compare with a non-existent Report", func() {
+			It("Should fail to compare the Reports", func() {
+				router.Handle("/reports/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/diff?from=73755fda-c910-46be-821f-e8ddeab189e9&to=15701f03-7b1d-49f9-ac62-6b9b0728bdb3", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Compare Reports without the to parameter", func() {
+			It("Should respond with bad request", func() {
+				router.Handle("/reports/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/diff?from=15701f03-7b1d-49f9-ac62-6b9b0728bdb2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/reports"
 	Describe("Search for all the Reports", func() {
 		Context("Get all the Reports", func() {
@@ -347,4 +417,103 @@ var _ = Describe("ReportController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Post and Get to "/reports" for accept:jwt
+	Describe("Create and search JWT Reports", func() {
+		var signingKey *ecdsa.PrivateKey
+
+		BeforeEach(func() {
+			var err error
+			signingKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			template := x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "HVS SAML Certificate"},
+				NotBefore:    time.Now().Add(-time.Hour),
+				NotAfter:     time.Now().Add(time.Hour),
+			}
+			certDer, err := x509.CreateCertificate(rand.Reader, &template, &template, signingKey.Public(), signingKey)
+			Expect(err).NotTo(HaveOccurred())
+			cert, err := x509.ParseCertificate(certDer)
+			Expect(err).NotTo(HaveOccurred())
+			reportController.TokenIssuer, err = eat.NewIssuer(eat.IssuerConfiguration{
+				PrivateKey:  signingKey,
+				Certificate: cert,
+				IssuerName:  "AttestationService-0",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// parseToken verifies the signature of a JWT report, the reports of the mock store have expired
+		parseToken := func(token string) *eat.TrustReportClaims {
+			var claims eat.TrustReportClaims
+			parser := jwt.Parser{SkipClaimsValidation: true}
+			_, err := parser.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+				return signingKey.Public(), nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return &claims
+		}
+
+		Context("Provide a valid Create request", func() {
+			It("Should create a new Report signed as a JWT", func() {
+				router.Handle("/reports", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(reportController.CreateJwt))).Methods("POST")
+				body := `{
+							"host_name": "localhost1"
+						}`
+
+				req, err := http.NewRequest("POST", "/reports", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJwt)
+				req.Header.Set("Content-Type", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(w.Header().Get("Content-Type")).To(Equal(constants.HTTPMediaTypeJwt))
+
+				claims := parseToken(w.Body.String())
+				Expect(claims.Issuer).To(Equal("AttestationService-0"))
+				Expect(claims.Id).To(Equal("15701f03-7b1d-49f9-ac62-6b9b0728bdb3"))
+				Expect(claims.ExpiresAt).To(BeNumerically(">", claims.NotBefore))
+				Expect(claims.HostInfo).NotTo(BeEmpty())
+			})
+		})
+
+		Context("Provide a valid Create request when no JWT signing key is configured", func() {
+			It("Should fail to create Report", func() {
+				reportController.TokenIssuer = nil
+				router.Handle("/reports", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(reportController.CreateJwt))).Methods("POST")
+				body := `{
+							"host_name": "localhost1"
+						}`
+
+				req, err := http.NewRequest("POST", "/reports", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJwt)
+				req.Header.Set("Content-Type", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("Get all the Reports", func() {
+			It("Should get list of all the Reports signed as JWTs", func() {
+				router.Handle("/reports", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(reportController.SearchJwt))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJwt)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal(constants.HTTPMediaTypeJwt))
+
+				tokens := strings.Fields(w.Body.String())
+				Expect(tokens).NotTo(BeEmpty())
+				for _, token := range tokens {
+					Expect(parseToken(token).Id).NotTo(BeEmpty())
+				}
+			})
+		})
+	})
 })
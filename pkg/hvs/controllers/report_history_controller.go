@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type ReportHistoryController struct {
+	HostStore          domain.HostStore
+	ReportHistoryStore domain.ReportHistoryStore
+}
+
+func NewReportHistoryController(hs domain.HostStore, rhs domain.ReportHistoryStore) *ReportHistoryController {
+	return &ReportHistoryController{
+		HostStore:          hs,
+		ReportHistoryStore: rhs,
+	}
+}
+
+var reportHistorySearchParams = map[string]bool{"from": true, "to": true, "sortBy": true, "orderBy": true,
+	"limit": true, "offset": true}
+
+var reportHistorySortFields = map[string]bool{models.SortById: true, models.SortByCreatedAt: true, models.SortByExpiration: true}
+
+// Search returns the reports of the host in the report history, so that the changes of the trust status of the host
+// can be traced back
+func (controller ReportHistoryController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_history_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/report_history_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportHistorySearchParams); err != nil {
+		secLog.Errorf("controllers/report_history_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["hId"])
+	criteria, err := getReportHistoryFilterCriteria(id, r)
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/report_history_controller:Search() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid Input given in request"}
+	}
+
+	if _, err = controller.HostStore.Retrieve(id, nil); err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Info(
+				"controllers/report_history_controller:Search() Host with specified id could not be located")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Host with specified id does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/report_history_controller:Search() Host retrieve failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Host from database"}
+	}
+
+	hvsReports, err := controller.ReportHistoryStore.Search(criteria)
+	if err != nil {
+		defaultLog.WithError(err).Warnf("controllers/report_history_controller:Search() Report history search operation failed")
+		return nil, http.StatusInternalServerError, errors.Errorf("Report history search operation failed")
+	}
+
+	reportCollection := hvs.ReportCollection{
+		Reports: []*hvs.Report{},
+	}
+	for i := range hvsReports {
+		reportCollection.Reports = append(reportCollection.Reports, ConvertToReport(&hvsReports[i]))
+	}
+
+	if criteria.IsPaged() {
+		total, err := controller.ReportHistoryStore.Count(criteria)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/report_history_controller:Search() Report history count operation failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Report history search operation failed")
+		}
+		reportCollection.PageInfo = hvs.NewPageInfo(total, criteria.Offset, len(hvsReports))
+	}
+	secLog.WithField("host_id", id).Infof("%s: Report history searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return reportCollection, http.StatusOK, nil
+}
+
+func getReportHistoryFilterCriteria(hostId uuid.UUID, r *http.Request) (*models.ReportHistoryFilterCriteria, error) {
+	defaultLog.Trace("controllers/report_history_controller:getReportHistoryFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/report_history_controller:getReportHistoryFilterCriteria() Leaving")
+
+	params := r.URL.Query()
+	criteria := models.ReportHistoryFilterCriteria{HostID: hostId}
+
+	if from := strings.TrimSpace(params.Get("from")); from != "" {
+		fromDate, err := utils.ParseDateQueryParam(from)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid from date specified")
+		}
+		criteria.FromDate = fromDate
+	}
+	if to := strings.TrimSpace(params.Get("to")); to != "" {
+		toDate, err := utils.ParseDateQueryParam(to)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid to date specified")
+		}
+		criteria.ToDate = toDate
+	}
+	if !criteria.FromDate.IsZero() && !criteria.ToDate.IsZero() && !criteria.FromDate.Before(criteria.ToDate) {
+		return nil, errors.New("The from date must be before the to date")
+	}
+
+	pageCriteria, err := utils.ParsePageCriteria(params, reportHistorySortFields)
+	if err != nil {
+		return nil, err
+	}
+	criteria.PageCriteria = *pageCriteria
+	return &criteria, nil
+}
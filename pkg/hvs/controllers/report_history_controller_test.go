@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReportHistoryController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var hostStore *mocks.MockHostStore
+	var reportHistoryStore *mocks.MockReportHistoryStore
+	var reportHistoryController *controllers.ReportHistoryController
+
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	created := time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		hostStore = mocks.NewMockHostStore()
+		reportHistoryStore = mocks.NewMockReportHistoryStore()
+		for day := 0; day < 5; day++ {
+			reportHistoryStore.Add(models.HVSReport{ID: uuid.New(), HostID: hostId,
+				CreatedAt: created.AddDate(0, 0, -day), Expiration: created.AddDate(0, 0, 1-day)})
+		}
+		reportHistoryController = controllers.NewReportHistoryController(hostStore, reportHistoryStore)
+		router.Handle("/hosts/{hId}/reports",
+			hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportHistoryController.Search))).Methods("GET")
+	})
+
+	// Specs for HTTP Get to "/hosts/{hId}/reports"
+	Describe("Search the report history of a host", func() {
+		Context("When no filter arguments are passed", func() {
+			It("All the reports of the host are returned, the most recent first", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/reports", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportCollection hvs.ReportCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &reportCollection)).To(Succeed())
+				Expect(reportCollection.Reports).To(HaveLen(5))
+				Expect(reportCollection.Reports[0].CreatedAt).To(BeTemporally("==", created))
+			})
+		})
+		Context("When a date range is passed", func() {
+			It("The reports of the host created in the date range are returned", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/reports?from=2021-03-07&to=2021-03-09", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportCollection hvs.ReportCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &reportCollection)).To(Succeed())
+				Expect(reportCollection.Reports).To(HaveLen(2))
+				Expect(reportCollection.Reports[0].CreatedAt).To(BeTemporally("==", created.AddDate(0, 0, -2)))
+				Expect(reportCollection.Reports[1].CreatedAt).To(BeTemporally("==", created.AddDate(0, 0, -3)))
+			})
+		})
+		Context("When a limit is passed", func() {
+			It("A page of the reports of the host is returned", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/reports?limit=2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportCollection hvs.ReportCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &reportCollection)).To(Succeed())
+				Expect(reportCollection.Reports).To(HaveLen(2))
+				Expect(reportCollection.PageInfo).NotTo(BeNil())
+				Expect(reportCollection.PageInfo.Total).To(Equal(5))
+			})
+		})
+		Context("When an invalid date is passed", func() {
+			It("Should fail to search the report history", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/reports?from=10-03-2021", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the from date is after the to date", func() {
+			It("Should fail to search the report history", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/reports?from=2021-03-09&to=2021-03-07", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the host does not exist", func() {
+			It("Should fail to search the report history", func() {
+				req, err := http.NewRequest("GET", "/hosts/73755fda-c910-46be-821f-e8ddeab189e9/reports", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
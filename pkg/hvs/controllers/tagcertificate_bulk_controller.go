@@ -0,0 +1,455 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	asset_tag "github.com/intel-secl/intel-secl/v4/pkg/lib/asset-tag"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// TagCertificateBulkController provisions the hosts matching a host filter with a TagCertificate holding the same
+// tag attributes. Each host is provisioned as the TagCertificates and Deploy APIs do, by a job tracked with the jobs API.
+type TagCertificateBulkController struct {
+	Provisioner      domain.TagCertificateProvisioner
+	HostStore        domain.HostStore
+	FlavorGroupStore domain.FlavorGroupStore
+	JobStore         domain.JobStore
+	// Workers is the number of hosts provisioned concurrently by a job
+	Workers int
+}
+
+func NewTagCertificateBulkController(tcp domain.TagCertificateProvisioner, hs domain.HostStore,
+	fgs domain.FlavorGroupStore, js domain.JobStore) *TagCertificateBulkController {
+	return &TagCertificateBulkController{
+		Provisioner:      tcp,
+		HostStore:        hs,
+		FlavorGroupStore: fgs,
+		JobStore:         js,
+		Workers:          consts.BulkTagCertificateProvisionWorkers,
+	}
+}
+
+// tagCertificateJobParams are the params of a bulk tag certificate provisioning job read back from the job store
+type tagCertificateJobParams struct {
+	SelectionContent []asset_tag.TagKvAttribute `json:"selection_content"`
+	HostNames        []string                   `json:"host_names"`
+	HostIDs          []uuid.UUID                `json:"host_ids"`
+	Results          []hvs.JobResult            `json:"results"`
+}
+
+// Create resolves the hosts matching the host filter of the request and starts a job creating a TagCertificate,
+// deploying it and creating the ASSET_TAG flavor for each of them. The job is returned at once and its progress
+// can be polled from the jobs API.
+func (controller TagCertificateBulkController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/tagcertificate_bulk_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_bulk_controller:Create() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Errorf("controllers/tagcertificate_bulk_controller:Create() %s : The request body is not provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	var criteria models.TagCertificateBulkCreateCriteria
+	if err := dec.Decode(&criteria); err != nil {
+		secLog.WithError(err).Errorf("controllers/tagcertificate_bulk_controller:Create() %s : Failed to decode request body as TagCertificateBulkCreateCriteria", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateTagCertBulkCreateCriteria(criteria); err != nil {
+		secLog.WithError(err).Errorf("controllers/tagcertificate_bulk_controller:Create() %s : Invalid request body", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	hosts, status, err := controller.searchHosts(criteria.HostFilter)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/tagcertificate_bulk_controller:Create() Host filter search failed")
+		return nil, status, err
+	}
+
+	params := tagCertificateJobParams{
+		SelectionContent: criteria.SelectionContent,
+		HostNames:        make([]string, len(hosts)),
+		HostIDs:          make([]uuid.UUID, len(hosts)),
+	}
+	for i, host := range hosts {
+		params.HostNames[i] = host.HostName
+		params.HostIDs[i] = host.Id
+	}
+	job, err := controller.JobStore.Create(&models.Job{
+		Action: consts.TagCertificateBulkProvisionAction,
+		Params: newTagCertificateJobParams(params),
+		State:  models.JobStateNew,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/tagcertificate_bulk_controller:Create() Job create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create bulk tag certificate provisioning job"}
+	}
+
+	response, err := newJob(job)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/tagcertificate_bulk_controller:Create() Error reading job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create bulk tag certificate provisioning job"}
+	}
+
+	go controller.provisionHosts(*job, params, hosts)
+
+	secLog.WithField("job", job.Id).Infof("%s: Bulk tag certificate provisioning of %d hosts started by: %s",
+		commLogMsg.PrivilegeModified, len(hosts), r.RemoteAddr)
+	return response, http.StatusAccepted, nil
+}
+
+// Retry provisions again the hosts that failed in a finished bulk tag certificate provisioning job, along with the
+// hosts left unprocessed when the job was interrupted by a restart of HVS. The results of the hosts that succeeded are
+// kept and the job is tracked with the jobs API as it was first run.
+func (controller TagCertificateBulkController) Retry(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/tagcertificate_bulk_controller:Retry() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_bulk_controller:Retry() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	job, err := controller.JobStore.Retrieve(id)
+	if err != nil || job == nil || job.Action != consts.TagCertificateBulkProvisionAction {
+		defaultLog.WithError(err).WithField("id", id).Info("controllers/tagcertificate_bulk_controller:Retry() Job with given ID does not exist")
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Job with given ID does not exist"}
+	}
+	if !job.State.Finished() {
+		secLog.WithField("id", id).Errorf("controllers/tagcertificate_bulk_controller:Retry() %s : Job is still running", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusConflict, &commErr.ResourceError{Message: "The job is still running"}
+	}
+
+	params, err := readTagCertificateJobParams(job)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/tagcertificate_bulk_controller:Retry() Error reading job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retry bulk tag certificate provisioning job"}
+	}
+
+	// the hosts are retrieved again since they may have been updated or deleted since the job was run. A host that
+	// cannot be retrieved because of a database error is left failed or unprocessed so that it can be retried later.
+	var hosts []*hvs.Host
+	retrieveFailed := false
+	retrieveHost := func(hostId uuid.UUID) (*hvs.Host, bool) {
+		host, err := controller.HostStore.Retrieve(hostId, nil)
+		if err != nil && strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", hostId).Warn("controllers/tagcertificate_bulk_controller:Retry() Host does not exist")
+			return nil, false
+		}
+		if err != nil {
+			defaultLog.WithError(err).WithField("id", hostId).Error("controllers/tagcertificate_bulk_controller:Retry() Host retrieve failed")
+			retrieveFailed = true
+			return nil, true
+		}
+		return host, true
+	}
+
+	processed := make(map[uuid.UUID]bool)
+	results := make([]hvs.JobResult, 0, len(params.Results))
+	for _, result := range params.Results {
+		if result.HostID != nil {
+			processed[*result.HostID] = true
+		}
+		if result.Status != hvs.JobResultFailed || result.HostID == nil {
+			results = append(results, result)
+			continue
+		}
+		host, exists := retrieveHost(*result.HostID)
+		if host == nil {
+			if !exists {
+				result.Error = "Host does not exist"
+			}
+			results = append(results, result)
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	for i, hostId := range params.HostIDs {
+		if processed[hostId] {
+			continue
+		}
+		host, exists := retrieveHost(hostId)
+		if !exists && i < len(params.HostNames) {
+			hostId := hostId
+			results = append(results, hvs.JobResult{HostName: params.HostNames[i], HostID: &hostId,
+				Status: hvs.JobResultFailed, Error: "Host does not exist"})
+		}
+		if host != nil {
+			hosts = append(hosts, host)
+		}
+	}
+	if len(hosts) == 0 && retrieveFailed {
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Hosts from database"}
+	}
+	if len(hosts) == 0 {
+		secLog.WithField("id", id).Errorf("controllers/tagcertificate_bulk_controller:Retry() %s : Job has no host to retry", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The job has no failed host to retry"}
+	}
+
+	params.Results = results
+	job.Params = newTagCertificateJobParams(*params)
+	restarted, err := controller.JobStore.Restart(job)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/tagcertificate_bulk_controller:Retry() Job update failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retry bulk tag certificate provisioning job"}
+	}
+	// the job was retried concurrently since it was retrieved
+	if !restarted {
+		secLog.WithField("id", id).Errorf("controllers/tagcertificate_bulk_controller:Retry() %s : Job is already retried", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusConflict, &commErr.ResourceError{Message: "The job is still running"}
+	}
+	job.State = models.JobStatePending
+
+	response, err := newJob(job)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/tagcertificate_bulk_controller:Retry() Error reading job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retry bulk tag certificate provisioning job"}
+	}
+
+	go controller.provisionHosts(*job, *params, hosts)
+
+	secLog.WithField("job", id).Infof("%s: Bulk tag certificate provisioning of %d hosts retried by: %s",
+		commLogMsg.PrivilegeModified, len(hosts), r.RemoteAddr)
+	return response, http.StatusAccepted, nil
+}
+
+// searchHosts returns the hosts matching the host filter, it returns the http status code matching the failure
+func (controller TagCertificateBulkController) searchHosts(filter models.TagCertificateHostFilter) ([]*hvs.Host, int, error) {
+	defaultLog.Trace("controllers/tagcertificate_bulk_controller:searchHosts() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_bulk_controller:searchHosts() Leaving")
+
+	var criteria []*models.HostFilterCriteria
+	switch {
+	case len(filter.HostNames) > 0:
+		for _, hostName := range filter.HostNames {
+			criteria = append(criteria, &models.HostFilterCriteria{NameEqualTo: hostName})
+		}
+	case len(filter.HardwareUUIDs) > 0:
+		for _, hardwareUUID := range filter.HardwareUUIDs {
+			criteria = append(criteria, &models.HostFilterCriteria{HostHardwareId: hardwareUUID})
+		}
+	case filter.FlavorgroupName != "":
+		flavorgroups, err := controller.FlavorGroupStore.Search(&models.FlavorGroupFilterCriteria{NameEqualTo: filter.FlavorgroupName})
+		if err != nil {
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavorgroup from database"}
+		}
+		if len(flavorgroups) == 0 {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup " + filter.FlavorgroupName + " does not exist"}
+		}
+		hostIds, err := controller.FlavorGroupStore.SearchHostsByFlavorGroup(flavorgroups[0].ID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve the hosts of the Flavorgroup from database"}
+		}
+		if len(hostIds) > 0 {
+			criteria = append(criteria, &models.HostFilterCriteria{IdList: hostIds})
+		}
+	default:
+		criteria = append(criteria, &models.HostFilterCriteria{Labels: filter.Labels})
+	}
+
+	// a host matched by several names or hardware UUIDs is provisioned once
+	var hosts []*hvs.Host
+	hostIds := make(map[uuid.UUID]bool)
+	for _, hostCriteria := range criteria {
+		matchedHosts, err := controller.HostStore.Search(hostCriteria, nil)
+		if err != nil {
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search Hosts from database"}
+		}
+		if len(matchedHosts) == 0 && hostCriteria.NameEqualTo != "" {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host " + hostCriteria.NameEqualTo + " does not exist"}
+		}
+		if len(matchedHosts) == 0 && hostCriteria.HostHardwareId != uuid.Nil {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host with hardware UUID " +
+				hostCriteria.HostHardwareId.String() + " does not exist"}
+		}
+		for _, host := range matchedHosts {
+			if !hostIds[host.Id] {
+				hostIds[host.Id] = true
+				hosts = append(hosts, host)
+			}
+		}
+	}
+
+	if len(hosts) == 0 {
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "No host matches the host filter"}
+	}
+	if len(hosts) > consts.MaxBulkTagCertificateHostCount {
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The host filter matches more hosts than can be provisioned at once"}
+	}
+	return hosts, http.StatusOK, nil
+}
+
+// provisionHosts provisions the hosts of the job, recording the result of each host in the job as soon as it is
+// known after the results already recorded in params. The params hold all the hosts of the job, including those
+// already provisioned.
+func (controller TagCertificateBulkController) provisionHosts(job models.Job, params tagCertificateJobParams, hosts []*hvs.Host) {
+	defaultLog.Trace("controllers/tagcertificate_bulk_controller:provisionHosts() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_bulk_controller:provisionHosts() Leaving")
+
+	// the job is only updated by the worker holding the lock so that no result is lost
+	var mtx sync.Mutex
+	params.Results = append(make([]hvs.JobResult, 0, len(params.Results)+len(hosts)), params.Results...)
+	updateJob := func(state models.JobState) {
+		job.State = state
+		job.Params = newTagCertificateJobParams(params)
+		if err := controller.JobStore.Update(&job); err != nil {
+			defaultLog.WithError(err).Errorf("controllers/tagcertificate_bulk_controller:provisionHosts() Failed to update job %s", job.Id)
+		}
+	}
+
+	updateJob(models.JobStatePending)
+
+	workers := controller.Workers
+	if workers <= 0 || workers > len(hosts) {
+		workers = len(hosts)
+	}
+	hostsChan := make(chan *hvs.Host)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range hostsChan {
+				result := controller.provisionHost(params.SelectionContent, host)
+				mtx.Lock()
+				params.Results = append(params.Results, result)
+				updateJob(models.JobStatePending)
+				mtx.Unlock()
+			}
+		}()
+	}
+	for _, host := range hosts {
+		hostsChan <- host
+	}
+	close(hostsChan)
+	wg.Wait()
+
+	updateJob(models.JobStateCompleted)
+	defaultLog.Infof("controllers/tagcertificate_bulk_controller:provisionHosts() Bulk tag certificate provisioning job %s completed", job.Id)
+}
+
+// provisionHost creates a TagCertificate for the host, deploys it onto the host along with its ASSET_TAG flavor and
+// returns the result of the provisioning
+func (controller TagCertificateBulkController) provisionHost(selectionContent []asset_tag.TagKvAttribute, host *hvs.Host) hvs.JobResult {
+	defaultLog.Trace("controllers/tagcertificate_bulk_controller:provisionHost() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_bulk_controller:provisionHost() Leaving")
+
+	result := hvs.JobResult{HostName: host.HostName, HostID: &host.Id, HardwareUUID: host.HardwareUuid, Status: hvs.JobResultFailed}
+	if host.HardwareUuid == nil {
+		result.Error = "The hardware UUID of the host is not known"
+		return result
+	}
+
+	tc, err := controller.Provisioner.CreateTagCertificate(models.TagCertificateCreateCriteria{
+		HardwareUUID:     *host.HardwareUuid,
+		SelectionContent: selectionContent,
+	})
+	if err != nil {
+		result.Error = jobResultError(err)
+		return result
+	}
+	result.TagCertificateID = &tc.ID
+
+	if _, _, err = controller.Provisioner.DeployTagCertificate(tc); err != nil {
+		result.Error = jobResultError(err)
+		return result
+	}
+
+	result.Status = hvs.JobResultSucceeded
+	return result
+}
+
+// validateTagCertBulkCreateCriteria validates the tag attributes and the host filter of a bulk tag certificate
+// provisioning before the job is started
+func validateTagCertBulkCreateCriteria(criteria models.TagCertificateBulkCreateCriteria) error {
+	defaultLog.Trace("controllers/tagcertificate_bulk_controller:validateTagCertBulkCreateCriteria() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_bulk_controller:validateTagCertBulkCreateCriteria() Leaving")
+
+	if err := validateTagSelectionContent(criteria.SelectionContent); err != nil {
+		return err
+	}
+
+	filter := criteria.HostFilter
+	filters := 0
+	if len(filter.HostNames) > 0 {
+		filters++
+		if len(filter.HostNames) > consts.MaxBulkTagCertificateHostCount {
+			return errors.Errorf("At most %d host names can be specified", consts.MaxBulkTagCertificateHostCount)
+		}
+		for _, hostName := range filter.HostNames {
+			if err := validation.ValidateHostname(hostName); err != nil {
+				return errors.Wrap(err, "Valid Host Names must be specified")
+			}
+		}
+	}
+	if filter.FlavorgroupName != "" {
+		filters++
+		if err := validation.ValidateStrings([]string{filter.FlavorgroupName}); err != nil {
+			return errors.Wrap(err, "Valid Flavorgroup Name must be specified")
+		}
+	}
+	if len(filter.Labels) > 0 {
+		filters++
+		if err := utils.ValidateLabels(filter.Labels); err != nil {
+			return errors.Wrap(err, "Valid Host Labels must be specified")
+		}
+	}
+	if len(filter.HardwareUUIDs) > 0 {
+		filters++
+		if len(filter.HardwareUUIDs) > consts.MaxBulkTagCertificateHostCount {
+			return errors.Errorf("At most %d hardware UUIDs can be specified", consts.MaxBulkTagCertificateHostCount)
+		}
+		for _, hardwareUUID := range filter.HardwareUUIDs {
+			if hardwareUUID == uuid.Nil {
+				return errors.New("Valid Hardware UUIDs must be specified")
+			}
+		}
+	}
+	if filters != 1 {
+		return errors.New("Exactly one of host_names, flavorgroup_name, labels or hardware_uuids must be specified in the host filter")
+	}
+	return nil
+}
+
+// newTagCertificateJobParams returns the params of a bulk tag certificate provisioning job
+func newTagCertificateJobParams(params tagCertificateJobParams) map[string]interface{} {
+	jobParams := newJobParams(params.HostNames, params.Results)
+	jobParams["selection_content"] = params.SelectionContent
+	jobParams["host_ids"] = params.HostIDs
+	return jobParams
+}
+
+// readTagCertificateJobParams reads the params of a bulk tag certificate provisioning job, they are converted
+// through JSON as in newJob
+func readTagCertificateJobParams(job *models.Job) (*tagCertificateJobParams, error) {
+	var params tagCertificateJobParams
+	paramsJson, err := json.Marshal(job.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling job params")
+	}
+	if err = json.Unmarshal(paramsJson, &params); err != nil {
+		return nil, errors.Wrap(err, "Error unmarshalling job params")
+	}
+	return &params, nil
+}
@@ -0,0 +1,306 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// mockTagCertificateProvisioner issues the tag certificates without signing them and fails to deploy them onto the
+// hosts with an unreachable hardware UUID. When hold is set, the tag certificates are only created once it is closed.
+type mockTagCertificateProvisioner struct {
+	hold        chan struct{}
+	lock        sync.Mutex
+	unreachable map[uuid.UUID]bool
+	created     []models.TagCertificateCreateCriteria
+	deployed    []uuid.UUID
+}
+
+func (provisioner *mockTagCertificateProvisioner) CreateTagCertificate(criteria models.TagCertificateCreateCriteria) (*hvs.TagCertificate, error) {
+	if provisioner.hold != nil {
+		<-provisioner.hold
+	}
+	provisioner.lock.Lock()
+	defer provisioner.lock.Unlock()
+	provisioner.created = append(provisioner.created, criteria)
+	return &hvs.TagCertificate{ID: uuid.New(), HardwareUUID: criteria.HardwareUUID}, nil
+}
+
+func (provisioner *mockTagCertificateProvisioner) DeployTagCertificate(tc *hvs.TagCertificate) (*hvs.SignedFlavor, int, error) {
+	provisioner.lock.Lock()
+	defer provisioner.lock.Unlock()
+	if provisioner.unreachable[tc.HardwareUUID] {
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure: Target Host connection failed"}
+	}
+	provisioner.deployed = append(provisioner.deployed, tc.HardwareUUID)
+	return &hvs.SignedFlavor{}, http.StatusOK, nil
+}
+
+func (provisioner *mockTagCertificateProvisioner) setUnreachable(hardwareUUID uuid.UUID, unreachable bool) {
+	provisioner.lock.Lock()
+	defer provisioner.lock.Unlock()
+	provisioner.unreachable[hardwareUUID] = unreachable
+}
+
+var _ = Describe("TagCertificateBulkController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var provisioner *mockTagCertificateProvisioner
+	var jobStore *mocks.MockJobStore
+
+	// hardware UUIDs of the hosts localhost1 and localhost2 of the mock host store
+	hardwareUUID1 := uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+	hardwareUUID2 := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		provisioner = &mockTagCertificateProvisioner{unreachable: make(map[uuid.UUID]bool)}
+
+		hostStore := mocks.NewMockHostStore()
+		flavorgroupStore := mocks.NewFakeFlavorgroupStore()
+		flavorgroupStore.HostFlavorgroupStore = append(flavorgroupStore.HostFlavorgroupStore, &hvs.HostFlavorgroup{
+			HostId:        uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d"),
+			FlavorgroupId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"),
+		})
+		jobStore = mocks.NewMockJobStore()
+		tagCertificateBulkController := controllers.NewTagCertificateBulkController(provisioner, hostStore,
+			flavorgroupStore, jobStore)
+		jobController := controllers.NewJobController(jobStore)
+
+		router.Handle("/tag-certificates/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(tagCertificateBulkController.Create))).Methods("POST")
+		router.Handle("/tag-certificates/bulk/{id}/retry", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(tagCertificateBulkController.Retry))).Methods("POST")
+		router.Handle("/jobs/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(jobController.Retrieve))).Methods("GET")
+	})
+
+	sendJobRequest := func(path, body string) *hvs.Job {
+		req, err := http.NewRequest("POST", path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusAccepted {
+			return nil
+		}
+
+		var job hvs.Job
+		Expect(json.Unmarshal(w.Body.Bytes(), &job)).To(Succeed())
+		return &job
+	}
+
+	createJob := func(hostFilter string) *hvs.Job {
+		return sendJobRequest("/tag-certificates/bulk", `{
+			"selection_content": [
+				{"name": "Country", "value": "US"},
+				{"name": "Zone", "value": "A"}
+			],
+			"host_filter": `+hostFilter+`
+		}`)
+	}
+
+	retryJob := func(id uuid.UUID) *hvs.Job {
+		return sendJobRequest("/tag-certificates/bulk/"+id.String()+"/retry", "")
+	}
+
+	retrieveCompletedJob := func(job *hvs.Job) *hvs.Job {
+		var retrievedJob hvs.Job
+		Eventually(func() string {
+			req, err := http.NewRequest("GET", "/jobs/"+job.ID.String(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &retrievedJob)).To(Succeed())
+			return retrievedJob.State
+		}).Should(Equal("Completed"))
+		return &retrievedJob
+	}
+
+	// Specs for HTTP Post to "/tag-certificates/bulk"
+	Describe("Provision tag certificates in bulk", func() {
+		Context("Provide a host filter with hardware UUIDs", func() {
+			It("Should create and deploy a tag certificate for each host", func() {
+				job := createJob(`{"hardware_uuids": ["` + hardwareUUID1.String() + `", "` + hardwareUUID2.String() + `"]}`)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(job.Action).To(Equal("tag-certificate-bulk-provision"))
+				Expect(job.Total).To(Equal(2))
+
+				job = retrieveCompletedJob(job)
+				Expect(job.Succeeded).To(Equal(2))
+				Expect(job.Results).To(HaveLen(2))
+				for _, result := range job.Results {
+					Expect(result.Status).To(Equal(hvs.JobResultSucceeded))
+					Expect(result.HostID).NotTo(BeNil())
+					Expect(result.HardwareUUID).NotTo(BeNil())
+					Expect(result.TagCertificateID).NotTo(BeNil())
+				}
+				Expect(provisioner.created).To(HaveLen(2))
+				Expect(provisioner.created[0].SelectionContent).To(HaveLen(2))
+				Expect(provisioner.deployed).To(ConsistOf(hardwareUUID1, hardwareUUID2))
+			})
+		})
+		Context("Provide a host filter with a flavorgroup", func() {
+			It("Should provision the hosts of the flavorgroup", func() {
+				job := createJob(`{"flavorgroup_name": "hvs_flavorgroup_test1"}`)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(job.Total).To(Equal(1))
+
+				job = retrieveCompletedJob(job)
+				Expect(job.Succeeded).To(Equal(1))
+				Expect(job.Results[0].HostName).To(Equal("localhost2"))
+				Expect(provisioner.deployed).To(Equal([]uuid.UUID{hardwareUUID2}))
+			})
+		})
+		Context("Provide a host filter with a host that cannot be reached", func() {
+			It("Should record the failure of the host and provision it again once retried", func() {
+				provisioner.setUnreachable(hardwareUUID2, true)
+				job := createJob(`{"host_names": ["localhost1", "localhost2"]}`)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+
+				job = retrieveCompletedJob(job)
+				Expect(job.Succeeded).To(Equal(1))
+				Expect(job.Failed).To(Equal(1))
+				for _, result := range job.Results {
+					if result.HostName == "localhost2" {
+						Expect(result.Status).To(Equal(hvs.JobResultFailed))
+						Expect(result.Error).To(Equal("Tag Certificate Deploy failure: Target Host connection failed"))
+					}
+				}
+
+				provisioner.setUnreachable(hardwareUUID2, false)
+				retriedJob := retryJob(job.ID)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(retriedJob.Succeeded).To(Equal(1))
+				Expect(retriedJob.Failed).To(Equal(0))
+
+				job = retrieveCompletedJob(job)
+				Expect(job.Total).To(Equal(2))
+				Expect(job.Succeeded).To(Equal(2))
+				Expect(job.Results).To(HaveLen(2))
+				// only the failed host is provisioned again
+				Expect(provisioner.created).To(HaveLen(3))
+				Expect(provisioner.deployed).To(ConsistOf(hardwareUUID1, hardwareUUID2))
+
+				retryJob(job.ID)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a host filter with an unknown host", func() {
+			It("Should fail to start the job", func() {
+				createJob(`{"host_names": ["localhost1", "localhost9"]}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(provisioner.created).To(BeEmpty())
+			})
+		})
+		Context("Provide a host filter with labels matching no host", func() {
+			It("Should fail to start the job", func() {
+				createJob(`{"labels": {"region": "eu-west"}}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a host filter with several filters", func() {
+			It("Should fail to start the job", func() {
+				createJob(`{"host_names": ["localhost1"], "flavorgroup_name": "hvs_flavorgroup_test1"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a request without selection content", func() {
+			It("Should fail to start the job", func() {
+				sendJobRequest("/tag-certificates/bulk", `{"host_filter": {"host_names": ["localhost1"]}}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/tag-certificates/bulk/{id}/retry"
+	Describe("Retry a bulk tag certificate provisioning", func() {
+		// the host IDs of localhost1 and localhost2 of the mock host store, and of a deleted host
+		hostId1 := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+		hostId2 := uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+		deletedHostId := uuid.New()
+
+		// newPendingJob returns a job that provisioned localhost1 and was interrupted before provisioning the other hosts
+		newPendingJob := func() *models.Job {
+			job, err := jobStore.Create(&models.Job{
+				Action: "tag-certificate-bulk-provision",
+				State:  models.JobStatePending,
+				Params: map[string]interface{}{
+					"selection_content": []map[string]string{{"name": "Country", "value": "US"}},
+					"host_names":        []string{"localhost1", "localhost2", "deleted"},
+					"host_ids":          []uuid.UUID{hostId1, hostId2, deletedHostId},
+					"results": []hvs.JobResult{
+						{HostName: "localhost1", HostID: &hostId1, HardwareUUID: &hardwareUUID1, Status: hvs.JobResultSucceeded},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return job
+		}
+
+		Context("Provide an unknown job", func() {
+			It("Should not find the job", func() {
+				retryJob(uuid.New())
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Provide a job that is still running", func() {
+			It("Should fail to retry the job", func() {
+				job := newPendingJob()
+				retryJob(job.Id)
+				Expect(w.Code).To(Equal(http.StatusConflict))
+				Expect(provisioner.created).To(BeEmpty())
+			})
+		})
+		Context("Provide a job interrupted by a restart", func() {
+			It("Should provision the hosts left unprocessed by the job", func() {
+				job := newPendingJob()
+				_, err := jobStore.FailUnfinished("The job was interrupted by a restart of HVS")
+				Expect(err).NotTo(HaveOccurred())
+				provisioner.hold = make(chan struct{})
+
+				retriedJob := retryJob(job.Id)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(retriedJob.State).To(Equal("Pending"))
+
+				// a concurrent retry is rejected until the job is finished
+				retryJob(job.Id)
+				Expect(w.Code).To(Equal(http.StatusConflict))
+				close(provisioner.hold)
+
+				retriedJob = retrieveCompletedJob(retriedJob)
+				Expect(retriedJob.Total).To(Equal(3))
+				Expect(retriedJob.Succeeded).To(Equal(2))
+				Expect(retriedJob.Failed).To(Equal(1))
+				for _, result := range retriedJob.Results {
+					if result.HostName == "deleted" {
+						Expect(result.Error).To(Equal("Host does not exist"))
+					}
+				}
+				Expect(provisioner.deployed).To(Equal([]uuid.UUID{hardwareUUID2}))
+
+				// the deleted host is not provisioned again
+				retryJob(job.Id)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -118,16 +118,30 @@ func (controller TagCertificateController) Create(w http.ResponseWriter, r *http
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Error during Tag Certificate creation - " + err.Error()}
 	}
 
+	newTC, err := controller.CreateTagCertificate(reqTCCriteria)
+	if err != nil {
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: err.Error()}
+	}
+	secLog.WithField("Name", newTC.Subject).Infof("%s: TagCertificate created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return newTC, http.StatusCreated, nil
+}
+
+// CreateTagCertificate issues a TagCertificate for the host with the hardware UUID and the tag attributes of the
+// criteria, and persists it so that it can be deployed onto the host
+func (controller TagCertificateController) CreateTagCertificate(tcCreateCriteria models.TagCertificateCreateCriteria) (*hvs.TagCertificate, error) {
+	defaultLog.Trace("controllers/tagcertificate_controller:CreateTagCertificate() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_controller:CreateTagCertificate() Leaving")
+
 	// get the Tag CA Cert from the certstore
 	tagCA := controller.CertStore[models.CaCertTypesTagCa.String()]
 	var tagCACert = tagCA.Certificates[0]
 
 	// Initialize the TagCertConfig
 	newTCConfig := asset_tag.TagCertConfig{
-		SubjectUUID:       reqTCCriteria.HardwareUUID.String(),
+		SubjectUUID:       tcCreateCriteria.HardwareUUID.String(),
 		PrivateKey:        tagCA.Key,
 		TagCACert:         &tagCACert,
-		TagAttributes:     reqTCCriteria.SelectionContent,
+		TagAttributes:     tcCreateCriteria.SelectionContent,
 		ValidityInSeconds: consts.DefaultTagCertValiditySeconds,
 	}
 
@@ -135,21 +149,21 @@ func (controller TagCertificateController) Create(w http.ResponseWriter, r *http
 	atCreator := asset_tag.NewAssetTag()
 	newAssetTagBytes, err := atCreator.CreateAssetTag(newTCConfig)
 	if err != nil {
-		defaultLog.Errorf("controllers/tagcertificate_controller:Create() %s : Error during Tag Certificate creation: %s", commLogMsg.AppRuntimeErr, err.Error())
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Creation failure"}
+		defaultLog.Errorf("controllers/tagcertificate_controller:CreateTagCertificate() %s : Error during Tag Certificate creation: %s", commLogMsg.AppRuntimeErr, err.Error())
+		return nil, errors.New("Tag Certificate Creation failure")
 	}
 
 	newX509TC, err := x509.ParseCertificate(newAssetTagBytes)
 	if err != nil {
-		defaultLog.Errorf("controllers/tagcertificate_controller:Create() %s : Error during Tag Certificate creation: %s", commLogMsg.AppRuntimeErr, err.Error())
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Creation failure"}
+		defaultLog.Errorf("controllers/tagcertificate_controller:CreateTagCertificate() %s : Error during Tag Certificate creation: %s", commLogMsg.AppRuntimeErr, err.Error())
+		return nil, errors.New("Tag Certificate Creation failure")
 	}
 
 	// put this in an X509AttributeCert to extract the properties easily
 	tempX509AttrCert, err := model.NewX509AttributeCertificate(newX509TC)
 	if err != nil {
-		defaultLog.Errorf("controllers/tagcertificate_controller:Create() %s : Error during Tag Certificate creation: %s", commLogMsg.AppRuntimeErr, err.Error())
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Creation failure"}
+		defaultLog.Errorf("controllers/tagcertificate_controller:CreateTagCertificate() %s : Error during Tag Certificate creation: %s", commLogMsg.AppRuntimeErr, err.Error())
+		return nil, errors.New("Tag Certificate Creation failure")
 	}
 
 	// convert to a TagCertificate
@@ -159,7 +173,7 @@ func (controller TagCertificateController) Create(w http.ResponseWriter, r *http
 		Issuer:       tagCACert.Issuer.String(),
 		NotBefore:    newX509TC.NotBefore.UTC(),
 		NotAfter:     newX509TC.NotAfter.UTC(),
-		HardwareUUID: reqTCCriteria.HardwareUUID,
+		HardwareUUID: tcCreateCriteria.HardwareUUID,
 	}
 
 	// set TagDigest
@@ -168,11 +182,10 @@ func (controller TagCertificateController) Create(w http.ResponseWriter, r *http
 	// persist to DB
 	newTC, err := controller.Store.Create(&newTagCert)
 	if err != nil {
-		defaultLog.WithError(err).Errorf("controllers/tagcertificate_controller:Create() %s : TagCertificate Creation failed", commLogMsg.AppRuntimeErr)
-		return nil, http.StatusInternalServerError, errors.Errorf("Error while persisting TagCertificate to DB")
+		defaultLog.WithError(err).Errorf("controllers/tagcertificate_controller:CreateTagCertificate() %s : TagCertificate Creation failed", commLogMsg.AppRuntimeErr)
+		return nil, errors.New("Error while persisting TagCertificate to DB")
 	}
-	secLog.WithField("Name", newTC.Subject).Infof("%s: TagCertificate created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
-	return newTC, http.StatusCreated, nil
+	return newTC, nil
 }
 
 // Search returns a collection of TagCertificates based on TagCertificateFilterCriteria
@@ -181,7 +194,8 @@ func (controller TagCertificateController) Search(w http.ResponseWriter, r *http
 	defer defaultLog.Trace("controllers/tagcertificate_controller:Search() Leaving")
 
 	var tagCertSearchParams = map[string]bool{"id": true, "hardwareUuid": true, "subjectContains": true, "subjectEqualTo": true,
-		"issuerContains": true, "issuerEqualTo": true, "validOn": true, "validBefore": true, "validAfter": true}
+		"issuerContains": true, "issuerEqualTo": true, "validOn": true, "validBefore": true, "validAfter": true,
+		"expiresBefore": true, "limit": true, "offset": true, "sortBy": true, "orderBy": true}
 
 	if err := utils.ValidateQueryParams(r.URL.Query(), tagCertSearchParams); err != nil {
 		secLog.Errorf("controllers/tagcertificate_controller:Search() %s", err.Error())
@@ -207,6 +221,14 @@ func (controller TagCertificateController) Search(w http.ResponseWriter, r *http
 	}
 
 	tagCertCollection := hvs.TagCertificateCollection{TagCertificates: tagCertResultSet}
+	if filter.IsPaged() {
+		total, err := controller.Store.Count(filter)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("controllers/tagcertificate_controller:Search() %s : TagCertificate count operation failed", commLogMsg.AppRuntimeErr)
+			return nil, http.StatusInternalServerError, errors.Errorf("TagCertificate search operation failed")
+		}
+		tagCertCollection.PageInfo = hvs.NewPageInfo(total, filter.Offset, len(tagCertResultSet))
+	}
 
 	secLog.Infof("%s: Return TagCertificate Search query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return tagCertCollection, http.StatusOK, nil
@@ -255,12 +277,17 @@ func validateTagCertCreateCriteria(tcCreateCriteria models.TagCertificateCreateC
 		return errors.New("Hardware UUID must be specified")
 	}
 
+	return validateTagSelectionContent(tcCreateCriteria.SelectionContent)
+}
+
+// validateTagSelectionContent validates the tag attributes of a TagCertificate
+func validateTagSelectionContent(selectionContent []asset_tag.TagKvAttribute) error {
 	// if Selection content is empty
-	if tcCreateCriteria.SelectionContent == nil {
+	if selectionContent == nil {
 		return errors.New("Tag Selection Content must be specified")
 	}
 
-	for _, tagAttribute := range tcCreateCriteria.SelectionContent {
+	for _, tagAttribute := range selectionContent {
 		if err := validation.ValidateTextString(tagAttribute.Key); err != nil {
 			return errors.New("Valid contents for Key must be specified")
 		}
@@ -272,6 +299,9 @@ func validateTagCertCreateCriteria(tcCreateCriteria models.TagCertificateCreateC
 	return nil
 }
 
+var tagCertSortFields = map[string]bool{models.SortById: true, models.SortBySubject: true, models.SortByIssuer: true,
+	models.SortByNotBefore: true, models.SortByNotAfter: true}
+
 //  getTCFilterCriteria checks for set filter params in the Search request and returns a valid TagCertificateFilterCriteria
 func getTCFilterCriteria(params url.Values) (*models.TagCertificateFilterCriteria, error) {
 	defaultLog.Trace("controllers/tagcertificate_controller:getTCFilterCriteria() Entering")
@@ -349,6 +379,15 @@ func getTCFilterCriteria(params url.Values) (*models.TagCertificateFilterCriteri
 		tagCertFc.ValidAfter = pTime
 	}
 
+	// expiresBefore
+	if param := strings.TrimSpace(params.Get("expiresBefore")); param != "" {
+		pTime, err := utils.ParseDateQueryParam(param)
+		if err != nil {
+			return nil, errors.Wrap(err, "Valid date (YYYY-MM-DD hh:mm:ss) for expiresBefore must be specified")
+		}
+		tagCertFc.ExpiresBefore = pTime
+	}
+
 	// hardwareUuid
 	if param := strings.TrimSpace(params.Get("hardwareUuid")); param != "" {
 		hwUUID, err := uuid.Parse(param)
@@ -358,6 +397,12 @@ func getTCFilterCriteria(params url.Values) (*models.TagCertificateFilterCriteri
 		tagCertFc.HardwareUUID = hwUUID
 	}
 
+	pageCriteria, err := utils.ParsePageCriteria(params, tagCertSortFields)
+	if err != nil {
+		return nil, err
+	}
+	tagCertFc.PageCriteria = *pageCriteria
+
 	return &tagCertFc, nil
 }
 
@@ -408,38 +453,55 @@ func (controller TagCertificateController) Deploy(w http.ResponseWriter, r *http
 			"controllers/tagcertificate_controller:Deploy() %s : Error retrieving TagCertificate", commLogMsg.AppRuntimeErr)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Tag Certificate does not exist"}
 	}
+
+	sf, status, err := controller.DeployTagCertificate(tc)
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("Certid", dtcReq.CertID).WithField("HardwareUUID", tc.HardwareUUID).Infof("%s: TagCertificate deployed by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return sf, http.StatusOK, nil
+}
+
+// DeployTagCertificate verifies the validity of the TagCertificate and deploys it to the host specified in its
+// hardware UUID. Once deployed, an ASSET_TAG flavor is created for the host and the host is queued for flavor
+// verification.
+func (controller TagCertificateController) DeployTagCertificate(tc *hvs.TagCertificate) (*hvs.SignedFlavor, int, error) {
+	defaultLog.Trace("controllers/tagcertificate_controller:DeployTagCertificate() Entering")
+	defer defaultLog.Trace("controllers/tagcertificate_controller:DeployTagCertificate() Leaving")
+
 	tc.SetAssetTagDigest()
 
 	// Ascertain Validity of Tag Certificate
-	log.Debug("controllers/tagcertificate_controller:Deploy() Got tagCertificate with ID {}. Checking validity.", dtcReq.CertID)
+	log.Debug("controllers/tagcertificate_controller:DeployTagCertificate() Got tagCertificate with ID {}. Checking validity.", tc.ID)
 	// verify certificate validity
 	today := time.Now()
-	defaultLog.Debug("controllers/tagcertificate_controller:Deploy() Tag Cert not before: {}", tc.NotBefore)
-	defaultLog.Debug("controllers/tagcertificate_controller:Deploy() Tag Cert not after: {}", tc.NotAfter)
-	defaultLog.Debug("controllers/tagcertificate_controller:Deploy() Time now: {}", today)
+	defaultLog.Debug("controllers/tagcertificate_controller:DeployTagCertificate() Tag Cert not before: {}", tc.NotBefore)
+	defaultLog.Debug("controllers/tagcertificate_controller:DeployTagCertificate() Tag Cert not after: {}", tc.NotAfter)
+	defaultLog.Debug("controllers/tagcertificate_controller:DeployTagCertificate() Time now: {}", today)
 	if today.Before(tc.NotBefore) {
-		secLog.WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() %s : Certificate with Subject %s is not yet valid", commLogMsg.InvalidInputBadParam, tc.Subject)
+		secLog.WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Certificate with Subject %s is not yet valid", commLogMsg.InvalidInputBadParam, tc.Subject)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 	if today.After(tc.NotAfter) {
-		secLog.WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() %s : Certificate with Subject %s has expired", commLogMsg.InvalidInputBadParam, tc.Subject)
+		secLog.WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Certificate with Subject %s has expired", commLogMsg.InvalidInputBadParam, tc.Subject)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 
 	// lookup Host by Host HardwareUUID
-	defaultLog.WithField("HardwareUUID", tc.HardwareUUID).Debug("controllers/tagcertificate_controller:Deploy() Looking up Host")
+	defaultLog.WithField("HardwareUUID", tc.HardwareUUID).Debug("controllers/tagcertificate_controller:DeployTagCertificate() Looking up Host")
 	hosts, err := controller.HostStore.Search(&models.HostFilterCriteria{
 		HostHardwareId: tc.HardwareUUID}, nil)
 
 	// handle zero records returned
 	if len(hosts) == 0 || err != nil {
-		defaultLog.WithError(err).WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() The Host lookup with specified hardware UUID %s failed", tc.HardwareUUID)
+		defaultLog.WithError(err).WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() The Host lookup with specified hardware UUID %s failed", tc.HardwareUUID)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Tag Certificate Deploy failure: Target Host lookup failed"}
 	}
 
 	// Unwrap the first Host record from the collection
 	targetHost := hosts[0]
-	defaultLog.WithField("HardwareUUID", targetHost.HardwareUuid).Debugf("controllers/tagcertificate_controller:Deploy() Found Host with ID %s", targetHost.Id)
+	defaultLog.WithField("HardwareUUID", targetHost.HardwareUuid).Debugf("controllers/tagcertificate_controller:DeployTagCertificate() Found Host with ID %s", targetHost.Id)
 
 	// populate service credentials for AAS
 	hostConnStr := fmt.Sprintf("%s;u=%s;p=%s", targetHost.ConnectionString, controller.Config.ServiceUsername, controller.Config.ServicePassword)
@@ -447,7 +509,7 @@ func (controller TagCertificateController) Deploy(w http.ResponseWriter, r *http
 	// initialize HostConnector and test connectivity
 	hc, err := controller.HostConnectorProvider.NewHostConnector(hostConnStr)
 	if err != nil {
-		defaultLog.WithError(err).WithField("Certid", dtcReq.CertID).Error("controllers/tagcertificate_controller:Deploy() Failed "+
+		defaultLog.WithError(err).WithField("Certid", tc.ID).Error("controllers/tagcertificate_controller:DeployTagCertificate() Failed "+
 			"to initialize HostConnector for host with hardware UUID %s", tc.HardwareUUID.String())
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure: Target Host connection failed"}
 	}
@@ -455,7 +517,7 @@ func (controller TagCertificateController) Deploy(w http.ResponseWriter, r *http
 	// DeployAssetTag
 	err = asset_tag.NewAssetTag().DeployAssetTag(hc, tc.TagCertDigest, targetHost.HardwareUuid.String())
 	if err != nil {
-		defaultLog.WithError(err).WithField("Certid", dtcReq.CertID).Error("controllers/tagcertificate_controller:Deploy() Failed "+
+		defaultLog.WithError(err).WithField("Certid", tc.ID).Error("controllers/tagcertificate_controller:DeployTagCertificate() Failed "+
 			"to deploy Asset Tag on Host %s", targetHost.HardwareUuid)
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
@@ -463,28 +525,28 @@ func (controller TagCertificateController) Deploy(w http.ResponseWriter, r *http
 	// get Host Manifest
 	hmanifest, err := hc.GetHostManifest(nil)
 	if err != nil {
-		defaultLog.WithField("id", dtcReq.CertID).Error("controllers/tagcertificate_controller:Deploy() Failed "+
+		defaultLog.WithField("id", tc.ID).Error("controllers/tagcertificate_controller:DeployTagCertificate() Failed "+
 			"to get the HostManifest from Host %s", targetHost.HardwareUuid.String())
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 
 	newX509TC, err := x509.ParseCertificate(tc.Certificate)
 	if err != nil {
-		defaultLog.WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() %s : Failed to parse x509.Certificate from TagCert %s", commLogMsg.AppRuntimeErr, err.Error())
+		defaultLog.WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Failed to parse x509.Certificate from TagCert %s", commLogMsg.AppRuntimeErr, err.Error())
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 
 	// Create AssetTag Flavor for the Host
 	fProvider, err := flavor.NewPlatformFlavorProvider(&hmanifest, newX509TC, nil)
 	if err != nil {
-		defaultLog.WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() %s : Failed to initialize FlavorProvider %s", commLogMsg.AppRuntimeErr, err.Error())
+		defaultLog.WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Failed to initialize FlavorProvider %s", commLogMsg.AppRuntimeErr, err.Error())
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 
 	// get the asset tag flavor
 	assetTagFlavor, err := fProvider.GetPlatformFlavor()
 	if err != nil {
-		defaultLog.WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() %s : Failed to generate AssetTag Flavor %s", commLogMsg.AppRuntimeErr, err.Error())
+		defaultLog.WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Failed to generate AssetTag Flavor %s", commLogMsg.AppRuntimeErr, err.Error())
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 
@@ -494,13 +556,13 @@ func (controller TagCertificateController) Deploy(w http.ResponseWriter, r *http
 	// get the signed flavor
 	unsignedFlavors, err := (*assetTagFlavor).GetFlavorPartRaw(fc.FlavorPartAssetTag)
 	if err != nil {
-		defaultLog.WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() %s : Error while getting unsigned Flavor %s", commLogMsg.AppRuntimeErr, err.Error())
+		defaultLog.WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Error while getting unsigned Flavor %s", commLogMsg.AppRuntimeErr, err.Error())
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 
 	sf, err := util.PlatformFlavorUtil{}.GetSignedFlavor(&unsignedFlavors[0], flavorSignKey.(*rsa.PrivateKey))
 	if err != nil {
-		defaultLog.WithField("Certid", dtcReq.CertID).Errorf("controllers/tagcertificate_controller:Deploy() %s : Error while getting signed Flavor %s", commLogMsg.AppRuntimeErr, err.Error())
+		defaultLog.WithField("Certid", tc.ID).Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Error while getting signed Flavor %s", commLogMsg.AppRuntimeErr, err.Error())
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Tag Certificate Deploy failure"}
 	}
 
@@ -508,10 +570,10 @@ func (controller TagCertificateController) Deploy(w http.ResponseWriter, r *http
 	var flavorPartMap = make(map[fc.FlavorPart][]hvs.SignedFlavor)
 	flavorPartMap[fc.FlavorPartAssetTag] = []hvs.SignedFlavor{*sf}
 
-	linkedSf, err := controller.FlavorController.addFlavorToFlavorgroup(flavorPartMap, nil)
+	linkedSf, err := controller.FlavorController.addFlavorToFlavorgroup(flavorPartMap, nil, nil)
 	if err != nil || linkedSf == nil {
-		defaultLog.WithError(err).WithField("Certid", dtcReq.CertID).WithField("flavorID", sf.Flavor.Meta.ID).
-			Errorf("controllers/tagcertificate_controller:Deploy() %s : Failed to link SignedFlavor to Host "+
+		defaultLog.WithError(err).WithField("Certid", tc.ID).WithField("flavorID", sf.Flavor.Meta.ID).
+			Errorf("controllers/tagcertificate_controller:DeployTagCertificate() %s : Failed to link SignedFlavor to Host "+
 				"Unique FlavorGroup", commLogMsg.AppRuntimeErr)
 		if strings.Contains(err.Error(), "duplicate key") {
 			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor with same id/label already exists"}
@@ -519,8 +581,6 @@ func (controller TagCertificateController) Deploy(w http.ResponseWriter, r *http
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error during Tag Certificate Deploy"}
 	}
 
-	defaultLog.WithField("Certid", dtcReq.CertID).WithField("flavorID", sf.Flavor.Meta.ID).Debugf("controllers/tagcertificate_controller:Deploy() : Created Asset Tag Deploy Cert")
-
-	secLog.WithField("Certid", dtcReq.CertID).WithField("HardwareUUID", targetHost.HardwareUuid).Infof("%s: TagCertificate deployed by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	defaultLog.WithField("Certid", tc.ID).WithField("flavorID", sf.Flavor.Meta.ID).Debugf("controllers/tagcertificate_controller:DeployTagCertificate() : Created Asset Tag Deploy Cert")
 	return sf, http.StatusOK, nil
 }
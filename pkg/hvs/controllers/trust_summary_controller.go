@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultExpiringWithinMinutes is the time window used to list the expiring reports when none is requested
+	defaultExpiringWithinMinutes = 60
+	// maxExpiringWithinMinutes limits the time window of the expiring reports to a week
+	maxExpiringWithinMinutes = 7 * 24 * 60
+)
+
+var trustSummaryParams = map[string]bool{"expiringWithinMinutes": true}
+
+type TrustSummaryController struct {
+	TrustSummaryStore domain.TrustSummaryStore
+	FlavorGroupStore  domain.FlavorGroupStore
+}
+
+func NewTrustSummaryController(tss domain.TrustSummaryStore, fgs domain.FlavorGroupStore) *TrustSummaryController {
+	return &TrustSummaryController{tss, fgs}
+}
+
+// Retrieve returns the TrustSummary of all the hosts, computed from the latest report and host status of each host
+func (controller TrustSummaryController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/trust_summary_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/trust_summary_controller:Retrieve() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), trustSummaryParams); err != nil {
+		secLog.Errorf("controllers/trust_summary_controller:Retrieve() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	expiringWithinMinutes := defaultExpiringWithinMinutes
+	if param := strings.TrimSpace(r.URL.Query().Get("expiringWithinMinutes")); param != "" {
+		minutes, err := strconv.Atoi(param)
+		if err != nil || minutes <= 0 || minutes > maxExpiringWithinMinutes {
+			secLog.Errorf("controllers/trust_summary_controller:Retrieve() %s : Invalid expiringWithinMinutes", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "expiringWithinMinutes must be an integer > 0 and <= " +
+				strconv.Itoa(maxExpiringWithinMinutes)}
+		}
+		expiringWithinMinutes = minutes
+	}
+
+	trustSummary, err := controller.buildTrustSummary(time.Now(), time.Duration(expiringWithinMinutes)*time.Minute)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/trust_summary_controller:Retrieve() Error building trust summary")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve trust summary"}
+	}
+
+	secLog.Infof("%s: Trust summary retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return trustSummary, http.StatusOK, nil
+}
+
+// buildTrustSummary builds the TrustSummary from the trust status counts aggregated by the trust summary store, only
+// the expiring reports and the connection failures are listed
+func (controller TrustSummaryController) buildTrustSummary(now time.Time, expiringWithin time.Duration) (*hvs.TrustSummary, error) {
+	defaultLog.Trace("controllers/trust_summary_controller:buildTrustSummary() Entering")
+	defer defaultLog.Trace("controllers/trust_summary_controller:buildTrustSummary() Leaving")
+
+	counts, err := controller.TrustSummaryStore.CountTrustStatus(now)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error counting hosts by trust status")
+	}
+
+	flavorGroups, err := controller.FlavorGroupStore.Search(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error searching flavorgroups")
+	}
+	flavorGroupSummaries := make([]hvs.FlavorgroupTrustSummary, 0, len(flavorGroups))
+	for _, flavorGroup := range flavorGroups {
+		flavorGroupSummaries = append(flavorGroupSummaries, hvs.FlavorgroupTrustSummary{
+			ID:          flavorGroup.ID,
+			Name:        flavorGroup.Name,
+			TrustCounts: counts.Flavorgroups[flavorGroup.ID],
+		})
+	}
+
+	expiringReports, err := controller.TrustSummaryStore.SearchExpiringReports(now, now.Add(expiringWithin))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error searching expiring reports")
+	}
+
+	connectionFailures, err := controller.TrustSummaryStore.SearchConnectionFailures()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error searching connection failures")
+	}
+
+	return &hvs.TrustSummary{
+		Hosts:              counts.Hosts,
+		Flavorgroups:       flavorGroupSummaries,
+		FlavorParts:        counts.FlavorParts,
+		Faults:             counts.Faults,
+		ExpiringReports:    expiringReports,
+		ConnectionFailures: connectionFailures,
+	}, nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v4/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TrustSummaryController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var trustSummaryController *controllers.TrustSummaryController
+
+	untrustedHostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	expiredHostId := uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+	unknownStateHostId := uuid.MustParse("60ad2f51-2e5e-4db1-843d-55b885de90fe")
+	flavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+
+		// the report of the first host is untrusted and about to expire, the report of the second one has expired
+		trustSummaryStore := &mocks.MockTrustSummaryStore{
+			Hosts: []hvs.Host{
+				{Id: untrustedHostId, HostName: "localhost1"},
+				{Id: expiredHostId, HostName: "localhost2"},
+				{Id: unknownStateHostId, HostName: "localhost3"},
+			},
+			Reports: []models.HVSReport{
+				{
+					ID:        uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb3"),
+					HostID:    untrustedHostId,
+					CreatedAt: time.Now(),
+					TrustReport: hvs.TrustReport{
+						Results: []hvs.RuleResult{
+							{
+								Rule:    hvs.RuleInfo{Name: "PcrMatchesConstant", Markers: []common.FlavorPart{common.FlavorPartPlatform}},
+								Faults:  []hvs.Fault{{Name: constants.FaultPcrValueMismatchSHA256}},
+								Trusted: false,
+							},
+						},
+					},
+					Expiration: time.Now().Add(30 * time.Minute),
+				},
+				{
+					ID:         uuid.MustParse("fc0cc779-22b6-4741-b0d9-e2e69635ad1e"),
+					HostID:     expiredHostId,
+					CreatedAt:  time.Now().Add(-2 * time.Hour),
+					Expiration: time.Now().Add(-time.Hour),
+				},
+			},
+			HostStatuses: []hvs.HostStatus{
+				{HostID: untrustedHostId, HostStatusInformation: hvs.HostStatusInformation{HostState: hvs.HostStateConnected}},
+				{HostID: unknownStateHostId, HostStatusInformation: hvs.HostStatusInformation{HostState: hvs.HostStateUnknown}},
+			},
+			HostFlavorgroups: []hvs.HostFlavorgroup{
+				{HostId: untrustedHostId, FlavorgroupId: flavorgroupId},
+				{HostId: expiredHostId, FlavorgroupId: flavorgroupId},
+			},
+		}
+
+		trustSummaryController = controllers.NewTrustSummaryController(trustSummaryStore, mocks.NewFakeFlavorgroupStore())
+	})
+
+	// Specs for HTTP Get to "/trust-summary"
+	Describe("Retrieve the trust summary", func() {
+		Context("Retrieve the trust summary of all the hosts", func() {
+			It("Should return the trust status counts, the expiring reports and the connection failures", func() {
+				router.Handle("/trust-summary", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(trustSummaryController.Retrieve))).Methods("GET")
+				req, err := http.NewRequest("GET", "/trust-summary", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var trustSummary hvs.TrustSummary
+				err = json.Unmarshal(w.Body.Bytes(), &trustSummary)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(trustSummary.Hosts).To(Equal(hvs.TrustCounts{Trusted: 0, Untrusted: 1, Unknown: 2}))
+				Expect(trustSummary.Faults[constants.FaultPcrValueMismatchSHA256]).To(Equal(hvs.TrustCounts{Untrusted: 1}))
+				Expect(trustSummary.FlavorParts[common.FlavorPartPlatform.String()].Untrusted).To(Equal(1))
+
+				Expect(trustSummary.Flavorgroups).To(HaveLen(2))
+				for _, flavorgroupSummary := range trustSummary.Flavorgroups {
+					if flavorgroupSummary.ID == flavorgroupId {
+						Expect(flavorgroupSummary.TrustCounts).To(Equal(hvs.TrustCounts{Untrusted: 1, Unknown: 1}))
+					} else {
+						Expect(flavorgroupSummary.TrustCounts).To(Equal(hvs.TrustCounts{}))
+					}
+				}
+
+				Expect(trustSummary.ExpiringReports).To(HaveLen(1))
+				Expect(trustSummary.ExpiringReports[0].HostID).To(Equal(untrustedHostId))
+				Expect(trustSummary.ExpiringReports[0].Trusted).To(BeFalse())
+
+				Expect(trustSummary.ConnectionFailures[hvs.HostStateUnknown.String()]).To(Equal(
+					[]hvs.TrustSummaryHost{{HostID: unknownStateHostId, HostName: "localhost3"}}))
+			})
+		})
+		Context("Retrieve the trust summary with a report expiration window shorter than the report validity", func() {
+			It("Should not return the expiring reports", func() {
+				router.Handle("/trust-summary", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(trustSummaryController.Retrieve))).Methods("GET")
+				req, err := http.NewRequest("GET", "/trust-summary?expiringWithinMinutes=10", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var trustSummary hvs.TrustSummary
+				err = json.Unmarshal(w.Body.Bytes(), &trustSummary)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(trustSummary.ExpiringReports).To(BeEmpty())
+			})
+		})
+		Context("Retrieve the trust summary with an invalid report expiration window", func() {
+			It("Should fail to retrieve the trust summary", func() {
+				router.Handle("/trust-summary", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(trustSummaryController.Retrieve))).Methods("GET")
+				req, err := http.NewRequest("GET", "/trust-summary?expiringWithinMinutes=-1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
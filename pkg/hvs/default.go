@@ -9,7 +9,12 @@ import (
 
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/frs"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/jps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/rhps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/tcrs"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/trustevent"
 	commConfig "github.com/intel-secl/intel-secl/v4/pkg/lib/common/config"
 	"github.com/spf13/viper"
 )
@@ -91,10 +96,28 @@ func init() {
 	viper.SetDefault(constants.FvsNumberOfDataFetchers, constants.DefaultFvsNumberOfDataFetchers)
 	viper.SetDefault(constants.FvsSkipFlavorSignatureVerification, constants.DefaultSkipFlavorSignatureVerification)
 	viper.SetDefault(constants.FvsHostTrustCacheThreshold, constants.DefaultHostTrustCacheThreshold)
+	viper.SetDefault(constants.FvsEnableSimulatedHosts, constants.DefaultEnableSimulatedHosts)
 
 	viper.SetDefault(constants.HrrsRefreshPeriod, hrrs.DefaultRefreshPeriod)
 
 	viper.SetDefault(constants.VcssRefreshPeriod, constants.DefaultVcssRefreshPeriod)
+
+	viper.SetDefault(constants.FrsGracePeriod, frs.DefaultGracePeriod)
+	viper.SetDefault(constants.FrsRefreshPeriod, frs.DefaultRefreshPeriod)
+
+	viper.SetDefault(constants.RhpsMaxReports, rhps.DefaultMaxReports)
+	viper.SetDefault(constants.RhpsMaxAge, rhps.DefaultMaxAge)
+	viper.SetDefault(constants.RhpsRefreshPeriod, rhps.DefaultRefreshPeriod)
+
+	viper.SetDefault(constants.JpsMaxAge, jps.DefaultMaxAge)
+	viper.SetDefault(constants.JpsRefreshPeriod, jps.DefaultRefreshPeriod)
+
+	viper.SetDefault(constants.TcrsRenewBefore, tcrs.DefaultRenewBefore)
+	viper.SetDefault(constants.TcrsDeploy, tcrs.DefaultDeploy)
+	viper.SetDefault(constants.TcrsRefreshPeriod, tcrs.DefaultRefreshPeriod)
+
+	viper.SetDefault(constants.TrustEventsMaxRetries, trustevent.DefaultMaxRetries)
+	viper.SetDefault(constants.TrustEventsRetryBackoff, trustevent.DefaultRetryBackoff)
 }
 
 func defaultConfig() *config.Configuration {
@@ -130,6 +153,10 @@ func defaultConfig() *config.Configuration {
 			Issuer:          viper.GetString("saml-issuer-name"),
 			ValiditySeconds: viper.GetInt("saml-validity-seconds"),
 		},
+		ReportToken: config.ReportTokenConfig{
+			KeyFile:  viper.GetString(constants.ReportTokenKeyFile),
+			CertFile: viper.GetString(constants.ReportTokenCertFile),
+		},
 		FlavorSigning: commConfig.SigningCertConfig{
 			CertFile:   viper.GetString("flavor-signing-cert-file"),
 			KeyFile:    viper.GetString("flavor-signing-key-file"),
@@ -167,11 +194,35 @@ func defaultConfig() *config.Configuration {
 		VCSS: config.VCSSConfig{
 			RefreshPeriod: viper.GetDuration(constants.VcssRefreshPeriod),
 		},
+		FRS: frs.FRSConfig{
+			GracePeriod:   viper.GetDuration(constants.FrsGracePeriod),
+			RefreshPeriod: viper.GetDuration(constants.FrsRefreshPeriod),
+		},
+		RHPS: rhps.RHPSConfig{
+			MaxReports:    viper.GetInt(constants.RhpsMaxReports),
+			MaxAge:        viper.GetDuration(constants.RhpsMaxAge),
+			RefreshPeriod: viper.GetDuration(constants.RhpsRefreshPeriod),
+		},
+		JPS: jps.JPSConfig{
+			MaxAge:        viper.GetDuration(constants.JpsMaxAge),
+			RefreshPeriod: viper.GetDuration(constants.JpsRefreshPeriod),
+		},
+		TCRS: tcrs.TCRSConfig{
+			RenewBefore:   viper.GetDuration(constants.TcrsRenewBefore),
+			Deploy:        viper.GetBool(constants.TcrsDeploy),
+			RefreshPeriod: viper.GetDuration(constants.TcrsRefreshPeriod),
+		},
+		TrustEvents: trustevent.TrustEventConfig{
+			NatsSubject:  viper.GetString(constants.TrustEventsNatsSubject),
+			MaxRetries:   viper.GetInt(constants.TrustEventsMaxRetries),
+			RetryBackoff: viper.GetDuration(constants.TrustEventsRetryBackoff),
+		},
 		FVS: config.FVSConfig{
 			NumberOfVerifiers:               viper.GetInt(constants.FvsNumberOfVerifiers),
 			NumberOfDataFetchers:            viper.GetInt(constants.FvsNumberOfDataFetchers),
 			SkipFlavorSignatureVerification: viper.GetBool(constants.FvsSkipFlavorSignatureVerification),
 			HostTrustCacheThreshold:         viper.GetInt(constants.FvsHostTrustCacheThreshold),
+			EnableSimulatedHosts:            viper.GetBool(constants.FvsEnableSimulatedHosts),
 		},
 	}
 }
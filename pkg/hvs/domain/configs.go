@@ -23,6 +23,7 @@ type HostTrustVerifierConfig struct {
 	SamlIssuerConfig                saml.IssuerConfiguration
 	SkipFlavorSignatureVerification bool
 	HostTrustCache                  *lru.Cache
+	TrustEventPublisher             TrustEventPublisher
 }
 
 type HostTrustMgrConfig struct {
@@ -47,9 +48,11 @@ type HostDataFetcherConfig struct {
 
 type HostControllerConfig struct {
 	HostConnectorProvider host_connector.HostConnectorProvider
-	DataEncryptionKey     []byte
+	DataEncryptionKeys    *models.DataEncryptionKeys
 	Username              string
 	Password              string
+	// SimulatedHostsEnabled allows the connection strings of the simulated hosts
+	SimulatedHostsEnabled bool
 }
 
 type TagCertControllerConfig struct {
@@ -20,6 +20,7 @@ type (
 		Create(*hvs.FlavorGroup) (*hvs.FlavorGroup, error)
 		Retrieve(uuid.UUID) (*hvs.FlavorGroup, error)
 		Search(*models.FlavorGroupFilterCriteria) ([]hvs.FlavorGroup, error)
+		Count(*models.FlavorGroupFilterCriteria) (int, error)
 		Delete(uuid.UUID) error
 		HasAssociatedHosts(uuid.UUID) (bool, error)
 		AddFlavors(uuid.UUID, []uuid.UUID) ([]uuid.UUID, error)
@@ -37,6 +38,7 @@ type (
 		Delete(uuid.UUID) error
 		DeleteByHostName(string) error
 		Search(*models.HostFilterCriteria, *models.HostInfoFetchCriteria) ([]*hvs.Host, error)
+		Count(*models.HostFilterCriteria, *models.HostInfoFetchCriteria) (int, error)
 		AddFlavorgroups(uuid.UUID, []uuid.UUID) error
 		RetrieveFlavorgroup(uuid.UUID, uuid.UUID) (*hvs.HostFlavorgroup, error)
 		RemoveFlavorgroups(uuid.UUID, []uuid.UUID) error
@@ -64,11 +66,22 @@ type (
 		FindByHostName(string) (*models.HostCredential, error)
 	}
 
+	DataEncryptionKeyStore interface {
+		ReEncrypt(*models.DataEncryptionKeys) (*hvs.DataEncryptionKeyRotation, error)
+		RetrieveKeyIds() ([]string, error)
+	}
+
 	FlavorStore interface {
 		Create(*hvs.SignedFlavor) (*hvs.SignedFlavor, error)
 		Retrieve(uuid.UUID) (*hvs.SignedFlavor, error)
 		Search(*models.FlavorVerificationFC) ([]hvs.SignedFlavor, error)
+		Count(*models.FlavorVerificationFC) (int, error)
 		Delete(uuid.UUID) error
+		Replace(uuid.UUID, *hvs.SignedFlavor) (*hvs.SignedFlavor, error)
+		CreateSuperseding(*hvs.SignedFlavor, *models.FlavorSupersession) (*hvs.SignedFlavor, error)
+		Supersede(*models.FlavorSupersession) error
+		SearchSupersessions(*models.FlavorSupersessionFilterCriteria) ([]models.FlavorSupersession, error)
+		UpdateSignature(uuid.UUID, string) error
 	}
 
 	TpmEndorsementStore interface {
@@ -93,6 +106,7 @@ type (
 		Create(*hvs.HostStatus) (*hvs.HostStatus, error)
 		Retrieve(uuid.UUID) (*hvs.HostStatus, error)
 		Search(*models.HostStatusFilterCriteria) ([]hvs.HostStatus, error)
+		Count(*models.HostStatusFilterCriteria) (int, error)
 		Delete(uuid.UUID) error
 		Persist(*hvs.HostStatus) error
 		FindHostIdsByKeyValue(key, value string) ([]uuid.UUID, error)
@@ -104,17 +118,57 @@ type (
 		Update(*models.Queue) error
 		Create(*models.Queue) (*models.Queue, error)
 		Delete(uuid.UUID) error
+		CountByState() (map[models.QueueState]int, error)
+	}
+
+	// JobStore holds the asynchronous jobs, such as the bulk host registrations
+	JobStore interface {
+		Create(*models.Job) (*models.Job, error)
+		Retrieve(uuid.UUID) (*models.Job, error)
+		Update(*models.Job) error
+		// Restart moves a finished job back to the pending state along with its params. It returns false, leaving the
+		// job unchanged, when the job is not finished, such as when it was restarted concurrently.
+		Restart(*models.Job) (bool, error)
+		// FailUnfinished marks the jobs that are not finished as failed with the given message and returns their
+		// number. The jobs are run by the HVS instance that started them, hence the jobs left unfinished when HVS
+		// starts were interrupted.
+		FailUnfinished(string) (int, error)
+		// Purge deletes the finished jobs last updated before the given time and returns their number
+		Purge(time.Time) (int, error)
 	}
 
 	ReportStore interface {
 		Search(*models.ReportFilterCriteria) ([]models.HVSReport, error)
+		Count(*models.ReportFilterCriteria) (int, error)
 		Retrieve(uuid.UUID) (*models.HVSReport, error)
+		RetrieveHistoric(uuid.UUID) (*models.HVSReport, error)
 		Create(*models.HVSReport) (*models.HVSReport, error)
 		Update(*models.HVSReport) (*models.HVSReport, error)
 		Delete(uuid.UUID) error
 		FindHostIdsFromExpiredReports(fromTime time.Time, toTime time.Time) ([]uuid.UUID, error)
 	}
 
+	// TrustSummaryStore aggregates the trust status of the hosts from their latest report and host status
+	TrustSummaryStore interface {
+		// CountTrustStatus returns the number of hosts by trust status at the given time, a host whose report has
+		// expired or that has no report has an unknown trust status
+		CountTrustStatus(time.Time) (*models.TrustStatusCounts, error)
+		// SearchExpiringReports returns the latest reports of the hosts expiring between fromTime and toTime
+		SearchExpiringReports(fromTime time.Time, toTime time.Time) ([]hvs.ExpiringReport, error)
+		// SearchConnectionFailures returns the hosts that are neither connected nor queued, by host state
+		SearchConnectionFailures() (map[string][]hvs.TrustSummaryHost, error)
+	}
+
+	// ReportHistoryStore holds the reports created for the hosts, including the reports replaced by a newer report
+	ReportHistoryStore interface {
+		Retrieve(uuid.UUID) (*models.HVSReport, error)
+		Search(*models.ReportHistoryFilterCriteria) ([]models.HVSReport, error)
+		Count(*models.ReportHistoryFilterCriteria) (int, error)
+		// Purge deletes the reports of each host beyond the most recent maxReports and the reports created before
+		// createdBefore, a zero maxReports or createdBefore disables the limit. The latest report of a host is kept.
+		Purge(maxReports int, createdBefore time.Time) (int, error)
+	}
+
 	ESXiClusterStore interface {
 		Create(*hvs.ESXiCluster) (*hvs.ESXiCluster, error)
 		Retrieve(uuid.UUID) (*hvs.ESXiCluster, error)
@@ -130,6 +184,15 @@ type (
 		Retrieve(uuid.UUID) (*hvs.TagCertificate, error)
 		Delete(uuid.UUID) error
 		Search(*models.TagCertificateFilterCriteria) ([]*hvs.TagCertificate, error)
+		Count(*models.TagCertificateFilterCriteria) (int, error)
+	}
+
+	// TagCertificateProvisioner issues TagCertificates and deploys them onto the hosts
+	TagCertificateProvisioner interface {
+		CreateTagCertificate(models.TagCertificateCreateCriteria) (*hvs.TagCertificate, error)
+		// DeployTagCertificate deploys the TagCertificate onto its host and creates the ASSET_TAG flavor for the
+		// host, it returns the http status code matching the failure
+		DeployTagCertificate(*hvs.TagCertificate) (*hvs.SignedFlavor, int, error)
 	}
 
 	HostTrustManager interface {
@@ -172,6 +235,12 @@ type (
 		Verify(hostId uuid.UUID, hostData *types.HostManifest, newData bool, preferHashMatch bool) (*models.HVSReport, error)
 	}
 
+	TrustEventPublisher interface {
+		// queues the event for delivery, it must not block the caller
+		Publish(*hvs.TrustStateChangeEvent)
+		Stop()
+	}
+
 	AuditLogWriter interface {
 		// creates an entry of auditlog
 		CreateEntry(string, ...interface{}) (*models.AuditLogEntry, error)
@@ -185,5 +254,7 @@ type (
 		Retrieve(*models.AuditLogEntry) ([]models.AuditLogEntry, error)
 		Update(*models.AuditLogEntry) (*models.AuditLogEntry, error)
 		Delete(uuid.UUID) error
+		// Search returns audit log entries from all the rotated partitions, oldest first
+		Search(*models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error)
 	}
 )
@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	"github.com/pkg/errors"
+)
+
+// MockAuditLogEntryStore provides a mocked implementation of interface domain.AuditLogEntryStore
+type MockAuditLogEntryStore struct {
+	entries map[uuid.UUID]models.AuditLogEntry
+}
+
+// Create inserts an AuditLogEntry
+func (store *MockAuditLogEntryStore) Create(e *models.AuditLogEntry) (*models.AuditLogEntry, error) {
+	if e.ID == uuid.Nil {
+		newUuid, err := uuid.NewRandom()
+		if err != nil {
+			return nil, err
+		}
+		e.ID = newUuid
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	store.entries[e.ID] = *e
+	return e, nil
+}
+
+// Retrieve returns the AuditLogEntry records matching the non-empty fields of the given entry
+func (store *MockAuditLogEntryStore) Retrieve(e *models.AuditLogEntry) ([]models.AuditLogEntry, error) {
+	var ret []models.AuditLogEntry
+	for _, v := range store.entries {
+		if (e.ID == uuid.Nil || v.ID == e.ID) &&
+			(e.EntityID == uuid.Nil || v.EntityID == e.EntityID) &&
+			(e.Action == "" || v.Action == e.Action) &&
+			(e.EntityType == "" || v.EntityType == e.EntityType) {
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// Update updates an AuditLogEntry
+func (store *MockAuditLogEntryStore) Update(e *models.AuditLogEntry) (*models.AuditLogEntry, error) {
+	if _, ok := store.entries[e.ID]; !ok {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	store.entries[e.ID] = *e
+	return e, nil
+}
+
+// Delete deletes an AuditLogEntry
+func (store *MockAuditLogEntryStore) Delete(id uuid.UUID) error {
+	if _, ok := store.entries[id]; !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	delete(store.entries, id)
+	return nil
+}
+
+// Search returns a filtered and paged list of AuditLogEntry records ordered by creation time
+func (store *MockAuditLogEntryStore) Search(criteria *models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error) {
+	if criteria == nil {
+		return nil, errors.New("invalid filter criteria")
+	}
+	var matched []models.AuditLogEntry
+	for _, v := range store.entries {
+		if criteria.EntityID != uuid.Nil && v.EntityID != criteria.EntityID {
+			continue
+		}
+		if criteria.EntityType != "" && v.EntityType != criteria.EntityType {
+			continue
+		}
+		if criteria.Action != "" && v.Action != criteria.Action {
+			continue
+		}
+		if !criteria.FromDate.IsZero() && v.CreatedAt.Before(criteria.FromDate) {
+			continue
+		}
+		if !criteria.ToDate.IsZero() && !v.CreatedAt.Before(criteria.ToDate) {
+			continue
+		}
+		matched = append(matched, v)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].ID.String() < matched[j].ID.String()
+		}
+		return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+	})
+	if criteria.Offset >= len(matched) {
+		return []models.AuditLogEntry{}, nil
+	}
+	matched = matched[criteria.Offset:]
+	if criteria.Limit > 0 && criteria.Limit < len(matched) {
+		matched = matched[:criteria.Limit]
+	}
+	return matched, nil
+}
+
+// NewMockAuditLogEntryStore initializes the mock store with report and host status entries for two hosts
+func NewMockAuditLogEntryStore() *MockAuditLogEntryStore {
+	store := &MockAuditLogEntryStore{entries: make(map[uuid.UUID]models.AuditLogEntry)}
+
+	created, _ := time.Parse(time.RFC3339, "2020-06-21T07:18:00Z")
+	entities := []struct {
+		id         string
+		entityType string
+	}{
+		{"ee37c360-7eae-4250-a677-6ee12adce8e2", "report"},
+		{"ee37c360-7eae-4250-a677-6ee12adce8e2", "report"},
+		{"ee37c360-7eae-4250-a677-6ee12adce8e3", "report"},
+		{"afed7372-18c5-4a5e-b5f6-2eed44a5e0f3", "host_status"},
+		{"afed7372-18c5-4a5e-b5f6-2eed44a5e0f3", "host_status"},
+	}
+	actions := []string{"create", "delete", "create", "create", "update"}
+	for i, ent := range entities {
+		_, err := store.Create(&models.AuditLogEntry{
+			EntityID:   uuid.MustParse(ent.id),
+			EntityType: ent.entityType,
+			CreatedAt:  created.Add(time.Duration(i) * time.Hour),
+			Action:     actions[i],
+			Data: models.AuditTableData{
+				Columns: []models.AuditColumnData{
+					{
+						Name:      "id",
+						Value:     ent.id,
+						IsUpdated: false,
+					},
+				},
+			},
+		})
+		if err != nil {
+			defaultLog.WithError(err).Errorf("Error creating audit log entry")
+		}
+	}
+	return store
+}
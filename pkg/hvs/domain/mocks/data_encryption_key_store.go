@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockEncryptedCredential is a credential encrypted with the data encryption key identified by KeyId
+type MockEncryptedCredential struct {
+	CipherText string
+	KeyId      string
+}
+
+// MockDataEncryptionKeyStore provides a mocked implementation of interface domain.DataEncryptionKeyStore
+type MockDataEncryptionKeyStore struct {
+	Credentials []MockEncryptedCredential
+	// FailAfter makes ReEncrypt fail after re-encrypting that many credentials, when positive
+	FailAfter int
+}
+
+// AddCredential encrypts the credential with the current data encryption key
+func (store *MockDataEncryptionKeyStore) AddCredential(plainText string, keys *models.DataEncryptionKeys) error {
+	cipherText, keyId, err := utils.EncryptWithCurrentKey(plainText, keys)
+	if err != nil {
+		return err
+	}
+	store.Credentials = append(store.Credentials, MockEncryptedCredential{CipherText: cipherText, KeyId: keyId})
+	return nil
+}
+
+// ReEncrypt re-encrypts the credentials with the current data encryption key, none of them is updated on failure
+func (store *MockDataEncryptionKeyStore) ReEncrypt(keys *models.DataEncryptionKeys) (*hvs.DataEncryptionKeyRotation, error) {
+	currentKeyId, _ := keys.Current()
+	rotation := hvs.DataEncryptionKeyRotation{KeyId: currentKeyId}
+	credentials := make([]MockEncryptedCredential, len(store.Credentials))
+	copy(credentials, store.Credentials)
+	for i := range credentials {
+		if credentials[i].KeyId == currentKeyId {
+			continue
+		}
+		if store.FailAfter > 0 && rotation.HostCredentials == store.FailAfter {
+			return nil, errors.New("Failed to re-encrypt credentials")
+		}
+		plainText, err := utils.DecryptWithKeyId(credentials[i].CipherText, credentials[i].KeyId, keys)
+		if err != nil {
+			return nil, err
+		}
+		credentials[i].CipherText, credentials[i].KeyId, err = utils.EncryptWithCurrentKey(plainText, keys)
+		if err != nil {
+			return nil, err
+		}
+		rotation.HostCredentials++
+	}
+	store.Credentials = credentials
+	return &rotation, nil
+}
+
+// RetrieveKeyIds returns the ids of the keys the credentials are encrypted with
+func (store *MockDataEncryptionKeyStore) RetrieveKeyIds() ([]string, error) {
+	var keyIds []string
+	for _, credential := range store.Credentials {
+		keyIds = append(keyIds, credential.KeyId)
+	}
+	return keyIds, nil
+}
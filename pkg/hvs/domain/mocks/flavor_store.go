@@ -23,6 +23,7 @@ type MockFlavorStore struct {
 	flavorStore            []hvs.SignedFlavor
 	FlavorFlavorGroupStore map[uuid.UUID][]uuid.UUID
 	FlavorgroupStore       map[uuid.UUID]*hvs.FlavorGroup
+	supersessions          map[uuid.UUID]models.FlavorSupersession
 }
 
 var flavor = ` {
@@ -543,6 +544,12 @@ func (store *MockFlavorStore) Delete(id uuid.UUID) error {
 	for i, f := range store.flavorStore {
 		if f.Flavor.Meta.ID == id {
 			store.flavorStore[i] = hvs.SignedFlavor{}
+			// the flavors superseded by the deleted flavor are no longer superseded
+			for flavorId, supersession := range store.supersessions {
+				if flavorId == id || supersession.SupersededBy == id {
+					delete(store.supersessions, flavorId)
+				}
+			}
 			return nil
 		}
 	}
@@ -567,8 +574,16 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 		return store.flavorStore, nil
 	}
 
+	if criteria.FlavorFC.IsPaged() {
+		allCriteria := *criteria
+		allCriteria.FlavorFC.PageCriteria = models.PageCriteria{}
+		allSfs, err := store.Search(&allCriteria)
+		start, end := pageBounds(criteria.FlavorFC.PageCriteria, len(allSfs))
+		return allSfs[start:end], err
+	}
+
 	// return all entries
-	if reflect.DeepEqual(*criteria, models.FlavorFilterCriteria{}) {
+	if reflect.DeepEqual(*criteria, models.FlavorVerificationFC{}) {
 		return store.flavorStore, nil
 	}
 
@@ -584,6 +599,13 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 			}
 		}
 		sfs = sfFiltered
+	} else if criteria.FlavorFC.Key != "" && criteria.FlavorFC.Value != "" {
+		// Flavor meta description filter
+		for _, f := range store.flavorStore {
+			if value, ok := f.Flavor.Meta.Description[criteria.FlavorFC.Key].(string); ok && value == criteria.FlavorFC.Value {
+				sfs = append(sfs, f)
+			}
+		}
 	} else if criteria.FlavorFC.FlavorgroupID != uuid.Nil ||
 		len(criteria.FlavorFC.FlavorParts) >= 1 || len(criteria.FlavorPartsWithLatest) >= 1 {
 		flavorPartsWithLatestMap := getFlavorPartsWithLatestMap(criteria.FlavorFC.FlavorParts, criteria.FlavorPartsWithLatest)
@@ -608,6 +630,17 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 	return sfs, nil
 }
 
+// Count returns the number of flavors matching the FlavorFilterCriteria
+func (store *MockFlavorStore) Count(criteria *models.FlavorVerificationFC) (int, error) {
+	if criteria != nil {
+		allCriteria := *criteria
+		allCriteria.FlavorFC.PageCriteria = models.PageCriteria{}
+		criteria = &allCriteria
+	}
+	sfs, err := store.Search(criteria)
+	return len(sfs), err
+}
+
 // Create inserts a Flavor
 func (store *MockFlavorStore) Create(sf *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
 	//It is not right way to directly append the pointer, reference will be copied. Copy only the values.
@@ -619,6 +652,75 @@ func (store *MockFlavorStore) Create(sf *hvs.SignedFlavor) (*hvs.SignedFlavor, e
 	return sf, nil
 }
 
+// Replace deletes a Flavor and inserts the Flavor replacing it
+func (store *MockFlavorStore) Replace(id uuid.UUID, sf *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	if err := store.Delete(id); err != nil {
+		return nil, err
+	}
+	return store.Create(sf)
+}
+
+// CreateSuperseding inserts a Flavor superseding another Flavor, the Flavor is not inserted when the other Flavor
+// cannot be superseded
+func (store *MockFlavorStore) CreateSuperseding(sf *hvs.SignedFlavor, supersession *models.FlavorSupersession) (*hvs.SignedFlavor, error) {
+	superseding := *supersession
+	superseding.SupersededBy = sf.Flavor.Meta.ID
+	if err := store.Supersede(&superseding); err != nil {
+		return nil, err
+	}
+	return store.Create(sf)
+}
+
+// Supersede records that a Flavor is superseded by another Flavor
+func (store *MockFlavorStore) Supersede(supersession *models.FlavorSupersession) error {
+	if _, err := store.Retrieve(supersession.FlavorId); err != nil {
+		return err
+	}
+	if _, ok := store.supersessions[supersession.FlavorId]; ok {
+		return errors.Wrapf(models.ErrFlavorAlreadySuperseded, "flavor %s", supersession.FlavorId)
+	}
+	if store.supersessions == nil {
+		store.supersessions = make(map[uuid.UUID]models.FlavorSupersession)
+	}
+	store.supersessions[supersession.FlavorId] = *supersession
+	return nil
+}
+
+// SearchSupersessions returns the supersessions of the Flavors per the provided FlavorSupersessionFilterCriteria
+func (store *MockFlavorStore) SearchSupersessions(criteria *models.FlavorSupersessionFilterCriteria) ([]models.FlavorSupersession, error) {
+	var supersessions []models.FlavorSupersession
+	for flavorId, supersession := range store.supersessions {
+		if criteria != nil && len(criteria.FlavorIds) > 0 {
+			found := false
+			for _, id := range criteria.FlavorIds {
+				if id == flavorId {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if criteria != nil && !criteria.RetiredBy.IsZero() && !supersession.IsRetired(criteria.RetiredBy) {
+			continue
+		}
+		supersessions = append(supersessions, supersession)
+	}
+	return supersessions, nil
+}
+
+// UpdateSignature updates the signature of a Flavor
+func (store *MockFlavorStore) UpdateSignature(id uuid.UUID, signature string) error {
+	for i, f := range store.flavorStore {
+		if f.Flavor.Meta.ID == id {
+			store.flavorStore[i].Signature = signature
+			return nil
+		}
+	}
+	return errors.New(commErr.RowsNotFound)
+}
+
 // NewMockFlavorStore provides one dummy data for Flavors
 func NewMockFlavorStore() *MockFlavorStore {
 	store := &MockFlavorStore{}
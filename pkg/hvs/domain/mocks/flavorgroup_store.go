@@ -46,6 +46,11 @@ func (store *MockFlavorgroupStore) Retrieve(id uuid.UUID) (*hvs.FlavorGroup, err
 
 // Search returns all FlavorGroups
 func (store *MockFlavorgroupStore) Search(criteria *models.FlavorGroupFilterCriteria) ([]hvs.FlavorGroup, error) {
+	if criteria != nil && criteria.PageCriteria != (models.PageCriteria{}) {
+		flvrGroups, err := store.Search(unpagedFlavorGroupFilterCriteria(criteria))
+		start, end := pageBounds(criteria.PageCriteria, len(flvrGroups))
+		return flvrGroups[start:end], err
+	}
 
 	var flvrGroups []hvs.FlavorGroup
 	for _, fg := range store.FlavorgroupStore {
@@ -79,6 +84,26 @@ func (store *MockFlavorgroupStore) Search(criteria *models.FlavorGroupFilterCrit
 	return nil, nil
 }
 
+// Count returns the number of FlavorGroups matching the FlavorGroupFilterCriteria
+func (store *MockFlavorgroupStore) Count(criteria *models.FlavorGroupFilterCriteria) (int, error) {
+	flvrGroups, err := store.Search(unpagedFlavorGroupFilterCriteria(criteria))
+	return len(flvrGroups), err
+}
+
+// unpagedFlavorGroupFilterCriteria returns the filter criteria without its page criteria, or nil when no filter is set
+func unpagedFlavorGroupFilterCriteria(criteria *models.FlavorGroupFilterCriteria) *models.FlavorGroupFilterCriteria {
+	if criteria == nil || (len(criteria.Ids) == 0 && criteria.FlavorId == nil && criteria.NameEqualTo == "" &&
+		criteria.NameContains == "") {
+		return nil
+	}
+	return &models.FlavorGroupFilterCriteria{
+		Ids:          criteria.Ids,
+		FlavorId:     criteria.FlavorId,
+		NameEqualTo:  criteria.NameEqualTo,
+		NameContains: criteria.NameContains,
+	}
+}
+
 // Create inserts a Flavorgroup
 func (store *MockFlavorgroupStore) Create(flavorgroup *hvs.FlavorGroup) (*hvs.FlavorGroup, error) {
 	if flavorgroup.ID == uuid.Nil {
@@ -7,6 +7,7 @@ package mocks
 import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
 	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	"github.com/pkg/errors"
@@ -40,6 +41,10 @@ func (store *MockHostStore) Retrieve(id uuid.UUID, criteria *models.HostInfoFetc
 func (store *MockHostStore) Update(host *hvs.Host) error {
 	for i, h := range store.hostStore {
 		if h.Id == host.Id {
+			// as in the database, the labels are kept when they are not updated
+			if host.Labels == nil {
+				host.Labels = h.Labels
+			}
 			store.hostStore[i] = host
 			return nil
 		}
@@ -70,9 +75,13 @@ func (store *MockHostStore) DeleteByHostName(hostName string) error {
 
 // Search returns a collection of Hosts filtered as per HostFilterCriteria
 func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfoFetchCriteria *models.HostInfoFetchCriteria) ([]*hvs.Host, error) {
-	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) {
+	if criteria == nil {
 		return store.hostStore, nil
 	}
+	if reflect.DeepEqual(*criteria, models.HostFilterCriteria{PageCriteria: criteria.PageCriteria}) {
+		start, end := pageBounds(criteria.PageCriteria, len(store.hostStore))
+		return store.hostStore[start:end], nil
+	}
 
 	var hosts []*hvs.Host
 	if criteria.Id != uuid.Nil {
@@ -86,6 +95,13 @@ func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfo
 				hosts = append(hosts, h)
 			}
 		}
+	} else if len(criteria.IdList) > 0 {
+		for _, id := range criteria.IdList {
+			h, _ := store.Retrieve(id, hostInfoFetchCriteria)
+			if h != nil {
+				hosts = append(hosts, h)
+			}
+		}
 	} else if criteria.NameEqualTo != "" {
 		for _, h := range store.hostStore {
 			if h.HostName == criteria.NameEqualTo {
@@ -98,8 +114,31 @@ func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfo
 				hosts = append(hosts, h)
 			}
 		}
+	} else if len(criteria.Labels) > 0 {
+		hosts = store.hostStore
+	}
+	if len(criteria.Labels) > 0 {
+		var labelledHosts []*hvs.Host
+		for _, h := range hosts {
+			if utils.LabelsMatch(criteria.Labels, h.Labels) {
+				labelledHosts = append(labelledHosts, h)
+			}
+		}
+		hosts = labelledHosts
+	}
+	start, end := pageBounds(criteria.PageCriteria, len(hosts))
+	return hosts[start:end], nil
+}
+
+// Count returns the number of Hosts matching the HostFilterCriteria
+func (store *MockHostStore) Count(criteria *models.HostFilterCriteria, hostInfoFetchCriteria *models.HostInfoFetchCriteria) (int, error) {
+	if criteria != nil {
+		countCriteria := *criteria
+		countCriteria.PageCriteria = models.PageCriteria{}
+		criteria = &countCriteria
 	}
-	return hosts, nil
+	hosts, err := store.Search(criteria, hostInfoFetchCriteria)
+	return len(hosts), err
 }
 
 // AddFlavorgroups associate a Host with specified flavorgroups
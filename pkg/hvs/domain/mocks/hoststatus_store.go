@@ -27,6 +27,7 @@ var (
 	HostStatus3           = `{"id":"82975a73-1041-44b0-b79d-c1dabf4adb63","host_id":"ee37c360-7eae-4250-a677-6ee12adce8e2","status":{"host_state":"CONNECTED"},"created":"` + time.Now().Add(-TimeDuration12Hrs).Format(time.RFC3339) + `","host_manifest":{ "aik_certificate": "MIIDTDCCAbSgAwIBAgIGAXF82oFMMA0GCSqGSIb3DQEBCwUAMBsxGTAXBgNVBAMTEG10d2lsc29uLXBjYS1haWswHhcNMjAwNDE1MDgwMDI2WhcNMzAwNDE1MDgwMDI2WjAAMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA0Ug7M15W3I3LejIOxZOiSvgXboF4+7TxvaY8BbzrNoyGbV8QfyCHjmdYHoyyzwvCUp9CB7wg1tb0btSLAqITLjFnUnTks28Sqz5tZW3et0O0X1fAsSnhJIc3vtkgxnxEIFOx2nsUDrEPXbdH1XOjSs5iRE7K45v2MzN9CO2QCwydPbUmgwauJNI3eQS5AZjF3eVnus9MMhTvYj4PNwbRj3jjuMH6OzJKX4bKeRPm05IHQcT/sEFoq5mShAmGyl+RkkRennIm5VIUnV99jm8mJvfZL3LA43kiHiOkvwiN0ImnDnNADP40IpothFFfIQEhr2L9CYUuUlq/BAkgt9epdwIDAQABozEwLzAtBgNVHREBAf8EIzAhgR8ACxj9Cf0C/f0bOXZ4QSn9JP1LQf13QWAZEV5Bsnz9MA0GCSqGSIb3DQEBCwUAA4IBgQA/SUjxvk2e6zgmTm5VhoV4WMmvvfZWZqEuKNnNB4lIkfySLuETTU7Jw1lc4skgr3KvxoftRM0099WVxhVwQMK/MarE7yNW7JQr2byNLoOrVm6FSkcRowrGFEnvFtC/qiGQ9JQTRkormIxDuPsaZWVjHMEuefEyq9T+hueTP5a1NDJmvtlXD2MjMjwEzeGf7R3TURmXt6tjMotbyO0/uv1n3Q79Wl/yWzb+bs9g5QlIlSrDGaxK7c7I7jGh0ee2gS2BOa/9iS59B9AS1TwACyj47yjFXoSQsvWqZ7XfPPzFVcFvvwtLRLeOzgIZhD+ZXutmY+smqDnkh/PB5BmXM/zDlae4QJ71rBGrmvVVj2cWGdaeZ19JivLLiBw0164yehTcpDzQzZQqyY4X+kX+fQD4fY/f8KxNkdxpq+n7ryJaBU/93ZbBdYtfwIs1r437G9QJfZ1h1rgJeIjPd/MAD3Knb1Q50c0fsEl8cnuzp86mY+imfrU2QKaF4WQzoiMItwU=", "asset_tag_digest": "tHgfRQED1+pYgEZpq3dZC9ONmBCZKdx10LErTZs1k/k=", "host_info": { "os_name": "RedHatEnterprise", "os_version": "8.1", "bios_version": "SE5C620.86B.00.01.6016.032720190737", "vmm_name": "Docker", "vmm_version": "19.03.5", "processor_info": "54 06 05 00 FF FB EB BF", "host_name": "computepurley2", "bios_name": "Intel Corporation", "hardware_uuid": "a624269e-5cb3-4ff2-990c-e31e30845754", "process_flags": "FPU VME DE PSE TSC MSR PAE MCE CX8 APIC SEP MTRR PGE MCA CMOV PAT PSE-36 CLFSH DS ACPI MMX FXSR SSE SSE2 SS HTT TM PBE", "no_of_sockets": "2", "tboot_installed": "true", "is_docker_env": "false", "hardware_features": { "TXT": { "enabled": "true" }, "TPM": { "enabled": "true", "meta": { "tpm_version": "2.0", "pcr_banks": "SHA1_SHA256" } } }, "installed_components": [ "tagent", "wlagent" ] }, "pcr_manifest": { "sha1pcrs": [ { "index": "pcr_0", "value": "6d73d0f4be74794317102e3f9a811fe00f373cc8", "pcr_bank": "SHA1" }, { "index": "pcr_1", "value": "c0b4764a706fd82f44dbd94b27bf1ede7019ca7b", "pcr_bank": "SHA1" }, { "index": "pcr_2", "value": "a196e9d4b283700303db501ed7279af6ec417e2d", "pcr_bank": "SHA1" }, { "index": "pcr_3", "value": "b2a83b0ebf2f8374299a5b2bdfc31ea955ad7236", "pcr_bank": "SHA1" }, { "index": "pcr_18", "value": "86da61107994a14c0d154fd87ca509f82377aa30", "pcr_bank": "SHA1" }, { "index": "pcr_19", "value": "0000000000000000000000000000000000000000", "pcr_bank": "SHA1" }, { "index": "pcr_22", "value": "0000000000000000000000000000000000000000", "pcr_bank": "SHA1" } ], "sha2pcrs": [ { "index": "pcr_0", "value": "95a27f12d848b554f31760f3811b6091788769d08eee450ff6a7e323a02bc973", "pcr_bank": "SHA256" }, { "index": "pcr_1", "value": "1491222c41d2bd84c4ea91a331edf9bb5981f7475fca91ab476bea5294939fba", "pcr_bank": "SHA256" }, { "index": "pcr_2", "value": "0033ef74f1d62b9d95c641bfda24642bafb7a6b54d03d90655d7c5f9b1d47caf", "pcr_bank": "SHA256" }, { "index": "pcr_3", "value": "3d458cfe55cc03ea1f443f1562beec8df51c75e14a9fcf9a7234a13f198e7969", "pcr_bank": "SHA256" }, { "index": "pcr_18", "value": "d9e55bd1c570a6408fb1368f3663ae92747241fc4d2a3622cef0efadae284d75", "pcr_bank": "SHA256" }, { "index": "pcr_19", "value": "0000000000000000000000000000000000000000000000000000000000000000", "pcr_bank": "SHA256" }, { "index": "pcr_22", "value": "0000000000000000000000000000000000000000000000000000000000000000", "pcr_bank": "SHA256" } ], "pcr_event_log_map": { "SHA1": [ { "pcr_index": "pcr_17", "event_log": [ {"value": "7636dbbb8b8f40a9b7b7140e6da43e5bf2f531de", "label": "HASH_START", "info": { "ComponentName": "HASH_START", "EventName": "OpenSource.EventName" } }, {"value": "9dcd8ac722c21e60652f0961ad6fe31938c4cc8f", "label": "BIOSAC_REG_DATA", "info": { "ComponentName": "BIOSAC_REG_DATA", "EventName": "OpenSource.EventName" } }, {"value": "3c585604e87f855973731fea83e21fab9392d2fc", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "9069ca78e7450a285173431b3e52c5c25299e473", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "LCP_DETAILS_HASH", "info": { "ComponentName": "LCP_DETAILS_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "STM_HASH", "info": { "ComponentName": "STM_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0cf169a95bd32a9a1dc4c3499ade207d30ab8895", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ff86d5446b2cc2e7e3319048715c00aabb7dcc4e", "label": "MLE_HASH", "info": { "ComponentName": "MLE_HASH", "EventName": "OpenSource.EventName" } }, {"value": "274f929dbab8b98a7031bbcd9ea5613c2a28e5e6", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ca96de412b4e8c062e570d3013d2fccb4b20250a", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } }, {"value": "5b870664c50ead0421e4a67514724759aa9a9d5b", "label": "vmlinuz", "info": { "ComponentName": "vmlinuz", "EventName": "OpenSource.EventName" } }, {"value": "f5fe4b87cd388943202e05442ebf0973c749cf3e", "label": "initrd", "info": { "ComponentName": "initrd", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA1" }, { "pcr_index": "pcr_18", "event_log": [ {"value": "a395b723712b3711a89c2bb5295386c0db85fe44", "label": "SINIT_PUBKEY_HASH", "info": { "ComponentName": "SINIT_PUBKEY_HASH", "EventName": "OpenSource.EventName" } }, {"value": "3c585604e87f855973731fea83e21fab9392d2fc", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "0cf169a95bd32a9a1dc4c3499ade207d30ab8895", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "9069ca78e7450a285173431b3e52c5c25299e473", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "LCP_AUTHORITIES_HASH", "info": { "ComponentName": "LCP_AUTHORITIES_HASH", "EventName": "OpenSource.EventName" } }, {"value": "274f929dbab8b98a7031bbcd9ea5613c2a28e5e6", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ca96de412b4e8c062e570d3013d2fccb4b20250a", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA1" } ], "SHA256": [ { "pcr_index": "pcr_15", "event_log": [ {"value": "ddbb7fd2b4aa332b6645b07d75e0b0edf4baed5813f879829acdb32c83a0382d", "label": "ISecL_Default_Workload_Flavor_v1.0-b68fd1b2-e34f-4637-b3de-f9da6b7f6511", "info": { "ComponentName": "ISecL_Default_Workload_Flavor_v1.0-b68fd1b2-e34f-4637-b3de-f9da6b7f6511", "EventName": "OpenSource.EventName" } }, {"value": "1d1affd0a6d562848387ee3c36a14a8158a847fb1f32ee54c67b95ea16d4d9c5", "label": "ISecL_Default_Application_Flavor_v1.0_TPM2.0-c2e5999b-8083-4c7f-917d-e979190a4183", "info": { "ComponentName": "ISecL_Default_Application_Flavor_v1.0_TPM2.0-c2e5999b-8083-4c7f-917d-e979190a4183", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" }, { "pcr_index": "pcr_17", "event_log": [ {"value": "5d0220ffbceca9ca4e28215480c0280b1681328326c593743fa183f70ffbe834", "label": "HASH_START", "info": { "ComponentName": "HASH_START", "EventName": "OpenSource.EventName" } }, {"value": "893d8ebf029907725f7deb657e80f7589c4ee52cdffed44547cd315f378f48c6", "label": "BIOSAC_REG_DATA", "info": { "ComponentName": "BIOSAC_REG_DATA", "EventName": "OpenSource.EventName" } }, {"value": "67abdd721024f0ff4e0b3f4c2fc13bc5bad42d0b7851d456d88d203d15aaa450", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "df3f619804a92fdb4057192dc43dd748ea778adc52bc498ce80524c014b81119", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "LCP_DETAILS_HASH", "info": { "ComponentName": "LCP_DETAILS_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "STM_HASH", "info": { "ComponentName": "STM_HASH", "EventName": "OpenSource.EventName" } }, {"value": "d81fe96dc500bc43e1cd5800bef9d72b3d030bdb7e860e10c522e4246b30bd93", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "236043f5120fce826392d2170dc84f2491367cc8d8d403ab3b83ec24ea2ca186", "label": "MLE_HASH", "info": { "ComponentName": "MLE_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0f6e0c7a5944963d7081ea494ddff1e9afa689e148e39f684db06578869ea38b", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "27808f64e6383982cd3bcc10cfcb3457c0b65f465f779d89b668839eaf263a67", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } }, {"value": "348a6284f46123a913681d53a201c05750d4527483ceaa2a2adbc7dda52cf506", "label": "vmlinuz", "info": { "ComponentName": "vmlinuz", "EventName": "OpenSource.EventName" } }, {"value": "d018a266352fee8f1e9453bd6a3977bea33ea9ac79c84c240c6d7e29d93d0115", "label": "initrd", "info": { "ComponentName": "initrd", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" }, { "pcr_index": "pcr_18", "event_log": [ {"value": "da256395df4046319ef0af857d377a729e5bc0693429ac827002ffafe485b2e7", "label": "SINIT_PUBKEY_HASH", "info": { "ComponentName": "SINIT_PUBKEY_HASH", "EventName": "OpenSource.EventName" } }, {"value": "67abdd721024f0ff4e0b3f4c2fc13bc5bad42d0b7851d456d88d203d15aaa450", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "d81fe96dc500bc43e1cd5800bef9d72b3d030bdb7e860e10c522e4246b30bd93", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "df3f619804a92fdb4057192dc43dd748ea778adc52bc498ce80524c014b81119", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "LCP_AUTHORITIES_HASH", "info": { "ComponentName": "LCP_AUTHORITIES_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0f6e0c7a5944963d7081ea494ddff1e9afa689e148e39f684db06578869ea38b", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "27808f64e6383982cd3bcc10cfcb3457c0b65f465f779d89b668839eaf263a67", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" } ] } }, "binding_key_certificate": "MIIFITCCA4mgAwIBAgIJAKrvQp6ScTi1MA0GCSqGSIb3DQEBDAUAMBsxGTAXBgNVBAMTEG10d2lsc29uLXBjYS1haWswHhcNMjAwNDE1MDgwMzE2WhcNMzAwNDEzMDgwMzE2WjAlMSMwIQYDVQQDDBpDTj1CaW5kaW5nX0tleV9DZXJ0aWZpY2F0ZTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoCggEBANJgmnV3e9VBFxZqKQP1FszztRQ0JXAlhE6SEa+1c8oTPbEG83s8nfprQwEaH89WBVm3QOe+Pl+ZS01E3jZ0asFHqkicnXh8nyWcpPY8JKQ8qRJzC68rvw2zgMo1QZKg65enTRIEABO8uFZKqye7xubJZOnanDPMbprer+Q+brdm+muOrHbROmY18utVnY3IciOPC2Hv+IC+4xzcli9PlkUxsUnmNf9pz85sLt2lft6gun4aGMh2ute8YTL6ZLNZ8nvZN8T8+7/IV3/Pklz4qtMyFxtpHIP2UUxlptk6uTvjsS4Nnwt5YdTuYm4yWzIFB7SApQsDbB4WtyPW9oRcRhkCAwEAAaOCAdwwggHYMA4GA1UdDwEB/wQEAwIFIDCBnQYHVQSBBQMCKQSBkf9UQ0eAFwAiAAs8+xFev3D2D4WG6PPhDWJey+Q/rVqgI3NYt79/YbizCwAEAP9VqgAAAAAANrGOAAAABgAAAAEBAAcAPgAMNgAAIgALta+AaKE5Tb3YIl7i/P+7tFLzXKZFlI+aWppdCEXJfw0AIgALScYOkvDeijOdoEy0phrYroOncXXSpNZ9M2JjdylBTlwwggEUBghVBIEFAwIpAQSCAQYAFAALAQBJQMBtwZmONe+QFGtDxzIrcHEg+NoQ8hQVpr+5Vt2knUAEon6gJgqz1gSWm0f0Q8TRzRVOutPxtNZMSvokbfHcdYyjmSwoIMATeK+YDieGuL+4w0ezg30lYjRukFOTxA2fw7arNkL7J/fiXGOAAUqDM+z7k4/y8bfRwBHZiN3uxbroR9SwiniPYmxUMLiIPLNMJVKdDMQLzA6z+PTSc8pxf1d78q7y/L+9OFfrThj+m6B4c5qWNHmZc37JG854QDP41FMJI9/Q1cQK6iZHapZPjTp9ikQuF+aegOxzVfcxeJI+wjkwqcGgeEfL+xFx2nhQ+1MSQrZ/uFiZhggdgqtQMA4GCFUEgQUDAikCBAIAADANBgkqhkiG9w0BAQwFAAOCAYEATBlbRClIKh5a7N0kcdEs94Z/5Vzrql8mizEe9/+xXd+Pp9ndyEGjrq3DSsMiOQyt0zQ39TGDzPOzuBQ5DG6A/w21MGVKGO1w15J7Wxzpez7Gd76HwXGHIiJnJZ5Llz9s7IWDqU5fIra/t4qWZzSxpZOVgpBe/9QzIVjgV44sXtjUahC7pnWusEPXa8kcLrdj+Y9EiMbuAldcDLmduRhDO/ex+StRs0b21BfF6sjCud5Md28r8W5/NEuXOqaKYWIFbGjD5qflCL2stEfbJFnIASiBS9dYYFAPj+fQWJzOTtxtk7lfAIz2PD3TJwHWD+HyMd5PsaHOnTw9GEKz3NDdmSc3juhnfi5RNIlFKAtYUjQ+HQjYvOhNOZTPB0S8U/91XV6ph0bTWdxJh6/KUt9jxnASapeVkoS18Q4K5sEmB/iHU0/HY56oDsrjRibX/sWfh9XG2eB3U8DlQkFtyVGvuuD3ym7cPirhVxTUiSOYa/Z6OJ04Gbaya4rWS7ZLBStD", "measurement_xmls": [ "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?><Measurement xmlns=\"lib:wml:measurements:1.0\" Label=\"ISecL_Default_Workload_Flavor_v1.0\" Uuid=\"b68fd1b2-e34f-4637-b3de-f9da6b7f6511\" DigestAlg=\"SHA384\"><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/workload-agent/bin\">e64e6d5afaad329d94d749e9b72c76e23fd3cb34655db10eadab4f858fb40b25ff08afa2aa6dbfbf081e11defdb58d5a</Dir><File Path=\"/opt/workload-agent/bin/wlagent\">ac8b967514f0a4c0ddcd87ee6cfdd03ffc5e5dd73598d40b8f6b6ef6dd606040a5fc31667908561093dd28317dfa1033</File><CumulativeHash>2ae673d241fed6e55d89e33a3ae8c6d127ed228e4afedfabfc2409c2d7bf51714d469786f948935c0b25c954904a2302</CumulativeHash></Measurement>", "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?><Measurement xmlns=\"lib:wml:measurements:1.0\" Label=\"ISecL_Default_Application_Flavor_v1.0_TPM2.0\" Uuid=\"c2e5999b-8083-4c7f-917d-e979190a4183\" DigestAlg=\"SHA384\"><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/bin\">b0d5cba0bb12d69d8dd3e92bdad09d093a34dd4ea30aea63fb31b9c26d9cbf0e84016fa9a80843b473e1493a427aa63a</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/dracut_files\">1d9c8eb15a49ea65fb96f2b919c42d5dfd30f4e4c1618205287345aeb4669d18113fe5bc87b033aeef2aeadc2e063232</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/initrd_hooks\">77b913422748a8e62f0720d739d54b2fa7856ebeb9e76fab75c41c375f2ad77b7b9ec5849b20d857e24a894a615d2de7</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/lib\">b03eb9d3b6fa0d338fd4ef803a277d523ab31db5c27186a283dd8d1fe0e7afca9bf26b31b1099833b0ba398dbe3c02fb</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/mkinitrd_files\">6928eb666f6971af5da42ad785588fb9464465b12c78f7279f46f9f8e04ae428d4872e7813671a1390cc8ed433366247</Dir><File Path=\"/opt/tbootxm/bin/tpmextend\">b936d9ec4b8c7823efb01d946a7caa074bdfffdbd11dc20108ba771b8ef65d8efc72b559cd605b1ba0d70ef99e84ba55</File><File Path=\"/opt/tbootxm/bin/measure\">c72551ddfdfab6ec901b7ed8dc28a1b093793fd590d2f6c3b685426932013ca11a69aeb3c04a31278829f653a24deeb1</File><File Path=\"/opt/tbootxm/bin/configure_host.sh\">8675ca78238f0cf6e09d0d20290a7a2b9837e2a1c19a4a0a7a8c226820c33b6a6538c2f94bb4eb78867bd1a87a859a2c</File><File Path=\"/opt/tbootxm/bin/generate_initrd.sh\">4708ed8233a81d6a17b2c4b74b955f27612d2cc04730ad8919618964209ce885cea9011e00236de56a2239a524044db4</File><File Path=\"/opt/tbootxm/bin/measure_host\">7455104eb95b1ee1dfb5487d40c8e3a677f057da97e2170d66a52b555239a4b539ca8122ee25b33bb327373aac4e4b7a</File><File Path=\"/opt/tbootxm/bin/tboot-xm-uninstall.sh\">7450bc939548eafc4a3ba9734ad1f96e46e1f46a40e4d12ad5b5f6b5eb2baf1597ade91edb035d8b5c1ecc38bde7ee59</File><File Path=\"/opt/tbootxm/bin/functions.sh\">8526f8aedbe6c4bde3ba331b0ce18051433bdabaf8991a269aff7a5306838b13982f7d1ead941fb74806fc696fef3bf0</File><File Path=\"/opt/tbootxm/dracut_files/check\">6f5949b86d3bf3387eaff8a18bb5d64e60daff9a2568d0c7eb90adde515620b9e5e9cd7d908805c6886cd178e7b382e1</File><File Path=\"/opt/tbootxm/dracut_files/install\">e2fc98a9292838a511d98348b29ba82e73c839cbb02051250c8a8ff85067930b5af2b22de4576793533259fad985df4a</File><File Path=\"/opt/tbootxm/dracut_files/module-setup.sh\">0a27a9e0bff117f30481dcab29bb5120f474f2c3ea10fa2449a9b05123c5d8ce31989fcd986bfa73e6c25c70202c50cb</File><File Path=\"/opt/tbootxm/lib/libwml.so\">56a04d0f073f0eb2a4f851ebcba79f7080553c27fa8d1f7d4a767dc849015c9cc6c9abe937d0e90d73de27814f28e378</File><File Path=\"/opt/tbootxm/lib/create_menuentry.pl\">79770fb02e5a8f6b51678bde4d017f23ac811b1a9f89182a8b7f9871990dbbc07fd9a0578275c405a02ac5223412095e</File><File Path=\"/opt/tbootxm/lib/update_menuentry.pl\">cb6754eb6f2e39e43d420682bc91c83b38d63808b603c068a3087affb856703d3ae564892ac837cd0d4453e41b2a228e</File><File Path=\"/opt/tbootxm/lib/remove_menuentry.pl\">baf4f9b63ab9bb1e8616e3fb037580e38c0ebd4073b3b7b645e0e37cc7f0588f4c5ed8b744e9be7689aa78d23df8ec4c</File><File Path=\"/opt/tbootxm/initrd_hooks/tcb\">430725e0cb08b290897aa850124f765ae0bdf385e6d3b741cdc5ff7dc72119958fbcce3f62d6b6d63c4a10c70c18ca98</File><File Path=\"/opt/tbootxm/mkinitrd_files/setup-measure_host.sh\">2791f12e447bbc88e25020ddbf5a2a8693443c5ca509c0f0020a8c7bed6c813cd62cb4c250c88491f5d540343032addc</File><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/trustagent/bin\">3519466d871c395ce1f5b073a4a3847b6b8f0b3e495337daa0474f967aeecd48f699df29a4d106288f3b0d1705ecef75</Dir><File Path=\"/opt/trustagent/bin/module_analysis.sh\">2327e72fa469bada099c5956f851817b0c8fa2d6c43089566cacd0f573bf62e7e8dd10a2c339205fb16c3956db6518a9</File><File Path=\"/opt/trustagent/bin/module_analysis_da.sh\">2a99c3e80e99d495a6b8cce8e7504af511201f05fcb40b766a41e6af52a54a34ea9fba985d2835aef929e636ad2a6f1d</File><File Path=\"/opt/trustagent/bin/module_analysis_da_tcg.sh\">0f47a757c86e91a3a175cd6ee597a67f84c6fec95936d7f2c9316b0944c27cb72f84e32c587adb456b94e64486d14242</File><CumulativeHash>7425a5806dc8a5aacd508e4d6866655bf475947cc8bb630a03ff42b898ee8a7d8fd3ca71c3e1dacdc0f375bcbaf11efc</CumulativeHash></Measurement>"]}}`
 	HostStatus4           = `{"id":"7099711c-3665-4c4d-a356-58fb492c8aa2","host_id":"60ad2f51-2e5e-4db1-843d-55b885de90fe","status":{"host_state":"UNKNOWN"},"created":"` + time.Now().Add(-TimeDuration90Hrs).Format(time.RFC3339) + `","host_manifest":{ "aik_certificate": "MIIDTDCCAbSgAwIBAgIGAXF82oFMMA0GCSqGSIb3DQEBCwUAMBsxGTAXBgNVBAMTEG10d2lsc29uLXBjYS1haWswHhcNMjAwNDE1MDgwMDI2WhcNMzAwNDE1MDgwMDI2WjAAMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA0Ug7M15W3I3LejIOxZOiSvgXboF4+7TxvaY8BbzrNoyGbV8QfyCHjmdYHoyyzwvCUp9CB7wg1tb0btSLAqITLjFnUnTks28Sqz5tZW3et0O0X1fAsSnhJIc3vtkgxnxEIFOx2nsUDrEPXbdH1XOjSs5iRE7K45v2MzN9CO2QCwydPbUmgwauJNI3eQS5AZjF3eVnus9MMhTvYj4PNwbRj3jjuMH6OzJKX4bKeRPm05IHQcT/sEFoq5mShAmGyl+RkkRennIm5VIUnV99jm8mJvfZL3LA43kiHiOkvwiN0ImnDnNADP40IpothFFfIQEhr2L9CYUuUlq/BAkgt9epdwIDAQABozEwLzAtBgNVHREBAf8EIzAhgR8ACxj9Cf0C/f0bOXZ4QSn9JP1LQf13QWAZEV5Bsnz9MA0GCSqGSIb3DQEBCwUAA4IBgQA/SUjxvk2e6zgmTm5VhoV4WMmvvfZWZqEuKNnNB4lIkfySLuETTU7Jw1lc4skgr3KvxoftRM0099WVxhVwQMK/MarE7yNW7JQr2byNLoOrVm6FSkcRowrGFEnvFtC/qiGQ9JQTRkormIxDuPsaZWVjHMEuefEyq9T+hueTP5a1NDJmvtlXD2MjMjwEzeGf7R3TURmXt6tjMotbyO0/uv1n3Q79Wl/yWzb+bs9g5QlIlSrDGaxK7c7I7jGh0ee2gS2BOa/9iS59B9AS1TwACyj47yjFXoSQsvWqZ7XfPPzFVcFvvwtLRLeOzgIZhD+ZXutmY+smqDnkh/PB5BmXM/zDlae4QJ71rBGrmvVVj2cWGdaeZ19JivLLiBw0164yehTcpDzQzZQqyY4X+kX+fQD4fY/f8KxNkdxpq+n7ryJaBU/93ZbBdYtfwIs1r437G9QJfZ1h1rgJeIjPd/MAD3Knb1Q50c0fsEl8cnuzp86mY+imfrU2QKaF4WQzoiMItwU=", "asset_tag_digest": "tHgfRQED1+pYgEZpq3dZC9ONmBCZKdx10LErTZs1k/k=", "host_info": { "os_name": "RedHatEnterprise", "os_version": "8.1", "bios_version": "SE5C620.86B.00.01.6016.032720190737", "vmm_name": "Docker", "vmm_version": "19.03.5", "processor_info": "54 06 05 00 FF FB EB BF", "host_name": "computepurley3", "bios_name": "Intel Corporation", "hardware_uuid": "650f7802-fcea-4d02-a50f-272263c45b3a", "process_flags": "FPU VME DE PSE TSC MSR PAE MCE CX8 APIC SEP MTRR PGE MCA CMOV PAT PSE-36 CLFSH DS ACPI MMX FXSR SSE SSE2 SS HTT TM PBE", "no_of_sockets": "2", "tboot_installed": "true", "is_docker_env": "false", "hardware_features": { "TXT": { "enabled": "true" }, "TPM": { "enabled": "true", "meta": { "tpm_version": "2.0", "pcr_banks": "SHA1_SHA256" } } }, "installed_components": [ "tagent", "wlagent" ] }, "pcr_manifest": { "sha1pcrs": [ { "index": "pcr_0", "value": "6d73d0f4be74794317102e3f9a811fe00f373cc8", "pcr_bank": "SHA1" }, { "index": "pcr_1", "value": "c0b4764a706fd82f44dbd94b27bf1ede7019ca7b", "pcr_bank": "SHA1" }, { "index": "pcr_2", "value": "a196e9d4b283700303db501ed7279af6ec417e2d", "pcr_bank": "SHA1" }, { "index": "pcr_3", "value": "b2a83b0ebf2f8374299a5b2bdfc31ea955ad7236", "pcr_bank": "SHA1" }, { "index": "pcr_18", "value": "86da61107994a14c0d154fd87ca509f82377aa30", "pcr_bank": "SHA1" }, { "index": "pcr_19", "value": "0000000000000000000000000000000000000000", "pcr_bank": "SHA1" }, { "index": "pcr_22", "value": "0000000000000000000000000000000000000000", "pcr_bank": "SHA1" } ], "sha2pcrs": [ { "index": "pcr_0", "value": "95a27f12d848b554f31760f3811b6091788769d08eee450ff6a7e323a02bc973", "pcr_bank": "SHA256" }, { "index": "pcr_1", "value": "1491222c41d2bd84c4ea91a331edf9bb5981f7475fca91ab476bea5294939fba", "pcr_bank": "SHA256" }, { "index": "pcr_2", "value": "0033ef74f1d62b9d95c641bfda24642bafb7a6b54d03d90655d7c5f9b1d47caf", "pcr_bank": "SHA256" }, { "index": "pcr_3", "value": "3d458cfe55cc03ea1f443f1562beec8df51c75e14a9fcf9a7234a13f198e7969", "pcr_bank": "SHA256" }, { "index": "pcr_18", "value": "d9e55bd1c570a6408fb1368f3663ae92747241fc4d2a3622cef0efadae284d75", "pcr_bank": "SHA256" }, { "index": "pcr_19", "value": "0000000000000000000000000000000000000000000000000000000000000000", "pcr_bank": "SHA256" }, { "index": "pcr_22", "value": "0000000000000000000000000000000000000000000000000000000000000000", "pcr_bank": "SHA256" } ], "pcr_event_log_map": { "SHA1": [ { "pcr_index": "pcr_17", "event_log": [ {"value": "7636dbbb8b8f40a9b7b7140e6da43e5bf2f531de", "label": "HASH_START", "info": { "ComponentName": "HASH_START", "EventName": "OpenSource.EventName" } }, {"value": "9dcd8ac722c21e60652f0961ad6fe31938c4cc8f", "label": "BIOSAC_REG_DATA", "info": { "ComponentName": "BIOSAC_REG_DATA", "EventName": "OpenSource.EventName" } }, {"value": "3c585604e87f855973731fea83e21fab9392d2fc", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "9069ca78e7450a285173431b3e52c5c25299e473", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "LCP_DETAILS_HASH", "info": { "ComponentName": "LCP_DETAILS_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "STM_HASH", "info": { "ComponentName": "STM_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0cf169a95bd32a9a1dc4c3499ade207d30ab8895", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ff86d5446b2cc2e7e3319048715c00aabb7dcc4e", "label": "MLE_HASH", "info": { "ComponentName": "MLE_HASH", "EventName": "OpenSource.EventName" } }, {"value": "274f929dbab8b98a7031bbcd9ea5613c2a28e5e6", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ca96de412b4e8c062e570d3013d2fccb4b20250a", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } }, {"value": "5b870664c50ead0421e4a67514724759aa9a9d5b", "label": "vmlinuz", "info": { "ComponentName": "vmlinuz", "EventName": "OpenSource.EventName" } }, {"value": "f5fe4b87cd388943202e05442ebf0973c749cf3e", "label": "initrd", "info": { "ComponentName": "initrd", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA1" }, { "pcr_index": "pcr_18", "event_log": [ {"value": "a395b723712b3711a89c2bb5295386c0db85fe44", "label": "SINIT_PUBKEY_HASH", "info": { "ComponentName": "SINIT_PUBKEY_HASH", "EventName": "OpenSource.EventName" } }, {"value": "3c585604e87f855973731fea83e21fab9392d2fc", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "0cf169a95bd32a9a1dc4c3499ade207d30ab8895", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "9069ca78e7450a285173431b3e52c5c25299e473", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "LCP_AUTHORITIES_HASH", "info": { "ComponentName": "LCP_AUTHORITIES_HASH", "EventName": "OpenSource.EventName" } }, {"value": "274f929dbab8b98a7031bbcd9ea5613c2a28e5e6", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ca96de412b4e8c062e570d3013d2fccb4b20250a", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA1" } ], "SHA256": [ { "pcr_index": "pcr_15", "event_log": [ {"value": "ddbb7fd2b4aa332b6645b07d75e0b0edf4baed5813f879829acdb32c83a0382d", "label": "ISecL_Default_Workload_Flavor_v1.0-b68fd1b2-e34f-4637-b3de-f9da6b7f6511", "info": { "ComponentName": "ISecL_Default_Workload_Flavor_v1.0-b68fd1b2-e34f-4637-b3de-f9da6b7f6511", "EventName": "OpenSource.EventName" } }, {"value": "1d1affd0a6d562848387ee3c36a14a8158a847fb1f32ee54c67b95ea16d4d9c5", "label": "ISecL_Default_Application_Flavor_v1.0_TPM2.0-c2e5999b-8083-4c7f-917d-e979190a4183", "info": { "ComponentName": "ISecL_Default_Application_Flavor_v1.0_TPM2.0-c2e5999b-8083-4c7f-917d-e979190a4183", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" }, { "pcr_index": "pcr_17", "event_log": [ {"value": "5d0220ffbceca9ca4e28215480c0280b1681328326c593743fa183f70ffbe834", "label": "HASH_START", "info": { "ComponentName": "HASH_START", "EventName": "OpenSource.EventName" } }, {"value": "893d8ebf029907725f7deb657e80f7589c4ee52cdffed44547cd315f378f48c6", "label": "BIOSAC_REG_DATA", "info": { "ComponentName": "BIOSAC_REG_DATA", "EventName": "OpenSource.EventName" } }, {"value": "67abdd721024f0ff4e0b3f4c2fc13bc5bad42d0b7851d456d88d203d15aaa450", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "df3f619804a92fdb4057192dc43dd748ea778adc52bc498ce80524c014b81119", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "LCP_DETAILS_HASH", "info": { "ComponentName": "LCP_DETAILS_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "STM_HASH", "info": { "ComponentName": "STM_HASH", "EventName": "OpenSource.EventName" } }, {"value": "d81fe96dc500bc43e1cd5800bef9d72b3d030bdb7e860e10c522e4246b30bd93", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "236043f5120fce826392d2170dc84f2491367cc8d8d403ab3b83ec24ea2ca186", "label": "MLE_HASH", "info": { "ComponentName": "MLE_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0f6e0c7a5944963d7081ea494ddff1e9afa689e148e39f684db06578869ea38b", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "27808f64e6383982cd3bcc10cfcb3457c0b65f465f779d89b668839eaf263a67", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } }, {"value": "348a6284f46123a913681d53a201c05750d4527483ceaa2a2adbc7dda52cf506", "label": "vmlinuz", "info": { "ComponentName": "vmlinuz", "EventName": "OpenSource.EventName" } }, {"value": "d018a266352fee8f1e9453bd6a3977bea33ea9ac79c84c240c6d7e29d93d0115", "label": "initrd", "info": { "ComponentName": "initrd", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" }, { "pcr_index": "pcr_18", "event_log": [ {"value": "da256395df4046319ef0af857d377a729e5bc0693429ac827002ffafe485b2e7", "label": "SINIT_PUBKEY_HASH", "info": { "ComponentName": "SINIT_PUBKEY_HASH", "EventName": "OpenSource.EventName" } }, {"value": "67abdd721024f0ff4e0b3f4c2fc13bc5bad42d0b7851d456d88d203d15aaa450", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "d81fe96dc500bc43e1cd5800bef9d72b3d030bdb7e860e10c522e4246b30bd93", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "df3f619804a92fdb4057192dc43dd748ea778adc52bc498ce80524c014b81119", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "LCP_AUTHORITIES_HASH", "info": { "ComponentName": "LCP_AUTHORITIES_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0f6e0c7a5944963d7081ea494ddff1e9afa689e148e39f684db06578869ea38b", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "27808f64e6383982cd3bcc10cfcb3457c0b65f465f779d89b668839eaf263a67", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" } ] } }, "binding_key_certificate": "MIIFITCCA4mgAwIBAgIJAKrvQp6ScTi1MA0GCSqGSIb3DQEBDAUAMBsxGTAXBgNVBAMTEG10d2lsc29uLXBjYS1haWswHhcNMjAwNDE1MDgwMzE2WhcNMzAwNDEzMDgwMzE2WjAlMSMwIQYDVQQDDBpDTj1CaW5kaW5nX0tleV9DZXJ0aWZpY2F0ZTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoCggEBANJgmnV3e9VBFxZqKQP1FszztRQ0JXAlhE6SEa+1c8oTPbEG83s8nfprQwEaH89WBVm3QOe+Pl+ZS01E3jZ0asFHqkicnXh8nyWcpPY8JKQ8qRJzC68rvw2zgMo1QZKg65enTRIEABO8uFZKqye7xubJZOnanDPMbprer+Q+brdm+muOrHbROmY18utVnY3IciOPC2Hv+IC+4xzcli9PlkUxsUnmNf9pz85sLt2lft6gun4aGMh2ute8YTL6ZLNZ8nvZN8T8+7/IV3/Pklz4qtMyFxtpHIP2UUxlptk6uTvjsS4Nnwt5YdTuYm4yWzIFB7SApQsDbB4WtyPW9oRcRhkCAwEAAaOCAdwwggHYMA4GA1UdDwEB/wQEAwIFIDCBnQYHVQSBBQMCKQSBkf9UQ0eAFwAiAAs8+xFev3D2D4WG6PPhDWJey+Q/rVqgI3NYt79/YbizCwAEAP9VqgAAAAAANrGOAAAABgAAAAEBAAcAPgAMNgAAIgALta+AaKE5Tb3YIl7i/P+7tFLzXKZFlI+aWppdCEXJfw0AIgALScYOkvDeijOdoEy0phrYroOncXXSpNZ9M2JjdylBTlwwggEUBghVBIEFAwIpAQSCAQYAFAALAQBJQMBtwZmONe+QFGtDxzIrcHEg+NoQ8hQVpr+5Vt2knUAEon6gJgqz1gSWm0f0Q8TRzRVOutPxtNZMSvokbfHcdYyjmSwoIMATeK+YDieGuL+4w0ezg30lYjRukFOTxA2fw7arNkL7J/fiXGOAAUqDM+z7k4/y8bfRwBHZiN3uxbroR9SwiniPYmxUMLiIPLNMJVKdDMQLzA6z+PTSc8pxf1d78q7y/L+9OFfrThj+m6B4c5qWNHmZc37JG854QDP41FMJI9/Q1cQK6iZHapZPjTp9ikQuF+aegOxzVfcxeJI+wjkwqcGgeEfL+xFx2nhQ+1MSQrZ/uFiZhggdgqtQMA4GCFUEgQUDAikCBAIAADANBgkqhkiG9w0BAQwFAAOCAYEATBlbRClIKh5a7N0kcdEs94Z/5Vzrql8mizEe9/+xXd+Pp9ndyEGjrq3DSsMiOQyt0zQ39TGDzPOzuBQ5DG6A/w21MGVKGO1w15J7Wxzpez7Gd76HwXGHIiJnJZ5Llz9s7IWDqU5fIra/t4qWZzSxpZOVgpBe/9QzIVjgV44sXtjUahC7pnWusEPXa8kcLrdj+Y9EiMbuAldcDLmduRhDO/ex+StRs0b21BfF6sjCud5Md28r8W5/NEuXOqaKYWIFbGjD5qflCL2stEfbJFnIASiBS9dYYFAPj+fQWJzOTtxtk7lfAIz2PD3TJwHWD+HyMd5PsaHOnTw9GEKz3NDdmSc3juhnfi5RNIlFKAtYUjQ+HQjYvOhNOZTPB0S8U/91XV6ph0bTWdxJh6/KUt9jxnASapeVkoS18Q4K5sEmB/iHU0/HY56oDsrjRibX/sWfh9XG2eB3U8DlQkFtyVGvuuD3ym7cPirhVxTUiSOYa/Z6OJ04Gbaya4rWS7ZLBStD", "measurement_xmls": [ "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?><Measurement xmlns=\"lib:wml:measurements:1.0\" Label=\"ISecL_Default_Workload_Flavor_v1.0\" Uuid=\"b68fd1b2-e34f-4637-b3de-f9da6b7f6511\" DigestAlg=\"SHA384\"><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/workload-agent/bin\">e64e6d5afaad329d94d749e9b72c76e23fd3cb34655db10eadab4f858fb40b25ff08afa2aa6dbfbf081e11defdb58d5a</Dir><File Path=\"/opt/workload-agent/bin/wlagent\">ac8b967514f0a4c0ddcd87ee6cfdd03ffc5e5dd73598d40b8f6b6ef6dd606040a5fc31667908561093dd28317dfa1033</File><CumulativeHash>2ae673d241fed6e55d89e33a3ae8c6d127ed228e4afedfabfc2409c2d7bf51714d469786f948935c0b25c954904a2302</CumulativeHash></Measurement>", "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?><Measurement xmlns=\"lib:wml:measurements:1.0\" Label=\"ISecL_Default_Application_Flavor_v1.0_TPM2.0\" Uuid=\"c2e5999b-8083-4c7f-917d-e979190a4183\" DigestAlg=\"SHA384\"><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/bin\">b0d5cba0bb12d69d8dd3e92bdad09d093a34dd4ea30aea63fb31b9c26d9cbf0e84016fa9a80843b473e1493a427aa63a</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/dracut_files\">1d9c8eb15a49ea65fb96f2b919c42d5dfd30f4e4c1618205287345aeb4669d18113fe5bc87b033aeef2aeadc2e063232</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/initrd_hooks\">77b913422748a8e62f0720d739d54b2fa7856ebeb9e76fab75c41c375f2ad77b7b9ec5849b20d857e24a894a615d2de7</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/lib\">b03eb9d3b6fa0d338fd4ef803a277d523ab31db5c27186a283dd8d1fe0e7afca9bf26b31b1099833b0ba398dbe3c02fb</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/mkinitrd_files\">6928eb666f6971af5da42ad785588fb9464465b12c78f7279f46f9f8e04ae428d4872e7813671a1390cc8ed433366247</Dir><File Path=\"/opt/tbootxm/bin/tpmextend\">b936d9ec4b8c7823efb01d946a7caa074bdfffdbd11dc20108ba771b8ef65d8efc72b559cd605b1ba0d70ef99e84ba55</File><File Path=\"/opt/tbootxm/bin/measure\">c72551ddfdfab6ec901b7ed8dc28a1b093793fd590d2f6c3b685426932013ca11a69aeb3c04a31278829f653a24deeb1</File><File Path=\"/opt/tbootxm/bin/configure_host.sh\">8675ca78238f0cf6e09d0d20290a7a2b9837e2a1c19a4a0a7a8c226820c33b6a6538c2f94bb4eb78867bd1a87a859a2c</File><File Path=\"/opt/tbootxm/bin/generate_initrd.sh\">4708ed8233a81d6a17b2c4b74b955f27612d2cc04730ad8919618964209ce885cea9011e00236de56a2239a524044db4</File><File Path=\"/opt/tbootxm/bin/measure_host\">7455104eb95b1ee1dfb5487d40c8e3a677f057da97e2170d66a52b555239a4b539ca8122ee25b33bb327373aac4e4b7a</File><File Path=\"/opt/tbootxm/bin/tboot-xm-uninstall.sh\">7450bc939548eafc4a3ba9734ad1f96e46e1f46a40e4d12ad5b5f6b5eb2baf1597ade91edb035d8b5c1ecc38bde7ee59</File><File Path=\"/opt/tbootxm/bin/functions.sh\">8526f8aedbe6c4bde3ba331b0ce18051433bdabaf8991a269aff7a5306838b13982f7d1ead941fb74806fc696fef3bf0</File><File Path=\"/opt/tbootxm/dracut_files/check\">6f5949b86d3bf3387eaff8a18bb5d64e60daff9a2568d0c7eb90adde515620b9e5e9cd7d908805c6886cd178e7b382e1</File><File Path=\"/opt/tbootxm/dracut_files/install\">e2fc98a9292838a511d98348b29ba82e73c839cbb02051250c8a8ff85067930b5af2b22de4576793533259fad985df4a</File><File Path=\"/opt/tbootxm/dracut_files/module-setup.sh\">0a27a9e0bff117f30481dcab29bb5120f474f2c3ea10fa2449a9b05123c5d8ce31989fcd986bfa73e6c25c70202c50cb</File><File Path=\"/opt/tbootxm/lib/libwml.so\">56a04d0f073f0eb2a4f851ebcba79f7080553c27fa8d1f7d4a767dc849015c9cc6c9abe937d0e90d73de27814f28e378</File><File Path=\"/opt/tbootxm/lib/create_menuentry.pl\">79770fb02e5a8f6b51678bde4d017f23ac811b1a9f89182a8b7f9871990dbbc07fd9a0578275c405a02ac5223412095e</File><File Path=\"/opt/tbootxm/lib/update_menuentry.pl\">cb6754eb6f2e39e43d420682bc91c83b38d63808b603c068a3087affb856703d3ae564892ac837cd0d4453e41b2a228e</File><File Path=\"/opt/tbootxm/lib/remove_menuentry.pl\">baf4f9b63ab9bb1e8616e3fb037580e38c0ebd4073b3b7b645e0e37cc7f0588f4c5ed8b744e9be7689aa78d23df8ec4c</File><File Path=\"/opt/tbootxm/initrd_hooks/tcb\">430725e0cb08b290897aa850124f765ae0bdf385e6d3b741cdc5ff7dc72119958fbcce3f62d6b6d63c4a10c70c18ca98</File><File Path=\"/opt/tbootxm/mkinitrd_files/setup-measure_host.sh\">2791f12e447bbc88e25020ddbf5a2a8693443c5ca509c0f0020a8c7bed6c813cd62cb4c250c88491f5d540343032addc</File><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/trustagent/bin\">3519466d871c395ce1f5b073a4a3847b6b8f0b3e495337daa0474f967aeecd48f699df29a4d106288f3b0d1705ecef75</Dir><File Path=\"/opt/trustagent/bin/module_analysis.sh\">2327e72fa469bada099c5956f851817b0c8fa2d6c43089566cacd0f573bf62e7e8dd10a2c339205fb16c3956db6518a9</File><File Path=\"/opt/trustagent/bin/module_analysis_da.sh\">2a99c3e80e99d495a6b8cce8e7504af511201f05fcb40b766a41e6af52a54a34ea9fba985d2835aef929e636ad2a6f1d</File><File Path=\"/opt/trustagent/bin/module_analysis_da_tcg.sh\">0f47a757c86e91a3a175cd6ee597a67f84c6fec95936d7f2c9316b0944c27cb72f84e32c587adb456b94e64486d14242</File><CumulativeHash>7425a5806dc8a5aacd508e4d6866655bf475947cc8bb630a03ff42b898ee8a7d8fd3ca71c3e1dacdc0f375bcbaf11efc</CumulativeHash></Measurement>"]}}`
 	HostStatus5           = `{"id":"7099711c-3665-4c4d-a356-58fb492c8aa2","host_id":"204466f6-8611-4e03-934d-832172a41917","status":{"host_state":"CONNECTED"},"created":"` + time.Now().Add(-TimeDuration90Hrs).Format(time.RFC3339) + `","host_manifest":{ "aik_certificate": "MIIDTDCCAbSgAwIBAgIGAXF82oFMMA0GCSqGSIb3DQEBCwUAMBsxGTAXBgNVBAMTEG10d2lsc29uLXBjYS1haWswHhcNMjAwNDE1MDgwMDI2WhcNMzAwNDE1MDgwMDI2WjAAMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA0Ug7M15W3I3LejIOxZOiSvgXboF4+7TxvaY8BbzrNoyGbV8QfyCHjmdYHoyyzwvCUp9CB7wg1tb0btSLAqITLjFnUnTks28Sqz5tZW3et0O0X1fAsSnhJIc3vtkgxnxEIFOx2nsUDrEPXbdH1XOjSs5iRE7K45v2MzN9CO2QCwydPbUmgwauJNI3eQS5AZjF3eVnus9MMhTvYj4PNwbRj3jjuMH6OzJKX4bKeRPm05IHQcT/sEFoq5mShAmGyl+RkkRennIm5VIUnV99jm8mJvfZL3LA43kiHiOkvwiN0ImnDnNADP40IpothFFfIQEhr2L9CYUuUlq/BAkgt9epdwIDAQABozEwLzAtBgNVHREBAf8EIzAhgR8ACxj9Cf0C/f0bOXZ4QSn9JP1LQf13QWAZEV5Bsnz9MA0GCSqGSIb3DQEBCwUAA4IBgQA/SUjxvk2e6zgmTm5VhoV4WMmvvfZWZqEuKNnNB4lIkfySLuETTU7Jw1lc4skgr3KvxoftRM0099WVxhVwQMK/MarE7yNW7JQr2byNLoOrVm6FSkcRowrGFEnvFtC/qiGQ9JQTRkormIxDuPsaZWVjHMEuefEyq9T+hueTP5a1NDJmvtlXD2MjMjwEzeGf7R3TURmXt6tjMotbyO0/uv1n3Q79Wl/yWzb+bs9g5QlIlSrDGaxK7c7I7jGh0ee2gS2BOa/9iS59B9AS1TwACyj47yjFXoSQsvWqZ7XfPPzFVcFvvwtLRLeOzgIZhD+ZXutmY+smqDnkh/PB5BmXM/zDlae4QJ71rBGrmvVVj2cWGdaeZ19JivLLiBw0164yehTcpDzQzZQqyY4X+kX+fQD4fY/f8KxNkdxpq+n7ryJaBU/93ZbBdYtfwIs1r437G9QJfZ1h1rgJeIjPd/MAD3Knb1Q50c0fsEl8cnuzp86mY+imfrU2QKaF4WQzoiMItwU=", "asset_tag_digest": "tHgfRQED1+pYgEZpq3dZC9ONmBCZKdx10LErTZs1k/k=", "host_info": { "os_name": "RedHatEnterprise", "os_version": "8.1", "bios_version": "SE5C620.86B.00.01.6016.032720190737", "vmm_name": "Docker", "vmm_version": "19.03.5", "processor_info": "54 06 05 00 FF FB EB BF", "host_name": "computepurley3", "bios_name": "Intel Corporation", "hardware_uuid": "650f7802-fcea-4d02-a50f-272263c45b3a", "process_flags": "FPU VME DE PSE TSC MSR PAE MCE CX8 APIC SEP MTRR PGE MCA CMOV PAT PSE-36 CLFSH DS ACPI MMX FXSR SSE SSE2 SS HTT TM PBE", "no_of_sockets": "2", "tboot_installed": "true", "is_docker_env": "false", "hardware_features": { "TXT": { "enabled": "true" }, "TPM": { "enabled": "true", "meta": { "tpm_version": "2.0", "pcr_banks": "SHA1_SHA256" } } }, "installed_components": [ "tagent", "wlagent" ] }, "pcr_manifest": { "sha1pcrs": [ { "index": "pcr_0", "value": "6d73d0f4be74794317102e3f9a811fe00f373cc8", "pcr_bank": "SHA1" }, { "index": "pcr_1", "value": "c0b4764a706fd82f44dbd94b27bf1ede7019ca7b", "pcr_bank": "SHA1" }, { "index": "pcr_2", "value": "a196e9d4b283700303db501ed7279af6ec417e2d", "pcr_bank": "SHA1" }, { "index": "pcr_3", "value": "b2a83b0ebf2f8374299a5b2bdfc31ea955ad7236", "pcr_bank": "SHA1" }, { "index": "pcr_18", "value": "86da61107994a14c0d154fd87ca509f82377aa30", "pcr_bank": "SHA1" }, { "index": "pcr_19", "value": "0000000000000000000000000000000000000000", "pcr_bank": "SHA1" }, { "index": "pcr_22", "value": "0000000000000000000000000000000000000000", "pcr_bank": "SHA1" } ], "sha2pcrs": [ { "index": "pcr_0", "value": "95a27f12d848b554f31760f3811b6091788769d08eee450ff6a7e323a02bc973", "pcr_bank": "SHA256" }, { "index": "pcr_1", "value": "1491222c41d2bd84c4ea91a331edf9bb5981f7475fca91ab476bea5294939fba", "pcr_bank": "SHA256" }, { "index": "pcr_2", "value": "0033ef74f1d62b9d95c641bfda24642bafb7a6b54d03d90655d7c5f9b1d47caf", "pcr_bank": "SHA256" }, { "index": "pcr_3", "value": "3d458cfe55cc03ea1f443f1562beec8df51c75e14a9fcf9a7234a13f198e7969", "pcr_bank": "SHA256" }, { "index": "pcr_18", "value": "d9e55bd1c570a6408fb1368f3663ae92747241fc4d2a3622cef0efadae284d75", "pcr_bank": "SHA256" }, { "index": "pcr_19", "value": "0000000000000000000000000000000000000000000000000000000000000000", "pcr_bank": "SHA256" }, { "index": "pcr_22", "value": "0000000000000000000000000000000000000000000000000000000000000000", "pcr_bank": "SHA256" } ], "pcr_event_log_map": { "SHA1": [ { "pcr_index": "pcr_17", "event_log": [ {"value": "7636dbbb8b8f40a9b7b7140e6da43e5bf2f531de", "label": "HASH_START", "info": { "ComponentName": "HASH_START", "EventName": "OpenSource.EventName" } }, {"value": "9dcd8ac722c21e60652f0961ad6fe31938c4cc8f", "label": "BIOSAC_REG_DATA", "info": { "ComponentName": "BIOSAC_REG_DATA", "EventName": "OpenSource.EventName" } }, {"value": "3c585604e87f855973731fea83e21fab9392d2fc", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "9069ca78e7450a285173431b3e52c5c25299e473", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "LCP_DETAILS_HASH", "info": { "ComponentName": "LCP_DETAILS_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "STM_HASH", "info": { "ComponentName": "STM_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0cf169a95bd32a9a1dc4c3499ade207d30ab8895", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ff86d5446b2cc2e7e3319048715c00aabb7dcc4e", "label": "MLE_HASH", "info": { "ComponentName": "MLE_HASH", "EventName": "OpenSource.EventName" } }, {"value": "274f929dbab8b98a7031bbcd9ea5613c2a28e5e6", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ca96de412b4e8c062e570d3013d2fccb4b20250a", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } }, {"value": "5b870664c50ead0421e4a67514724759aa9a9d5b", "label": "vmlinuz", "info": { "ComponentName": "vmlinuz", "EventName": "OpenSource.EventName" } }, {"value": "f5fe4b87cd388943202e05442ebf0973c749cf3e", "label": "initrd", "info": { "ComponentName": "initrd", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA1" }, { "pcr_index": "pcr_18", "event_log": [ {"value": "a395b723712b3711a89c2bb5295386c0db85fe44", "label": "SINIT_PUBKEY_HASH", "info": { "ComponentName": "SINIT_PUBKEY_HASH", "EventName": "OpenSource.EventName" } }, {"value": "3c585604e87f855973731fea83e21fab9392d2fc", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "0cf169a95bd32a9a1dc4c3499ade207d30ab8895", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "9069ca78e7450a285173431b3e52c5c25299e473", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "5ba93c9db0cff93f52b521d7420e43f6eda2784f", "label": "LCP_AUTHORITIES_HASH", "info": { "ComponentName": "LCP_AUTHORITIES_HASH", "EventName": "OpenSource.EventName" } }, {"value": "274f929dbab8b98a7031bbcd9ea5613c2a28e5e6", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "ca96de412b4e8c062e570d3013d2fccb4b20250a", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA1" } ], "SHA256": [ { "pcr_index": "pcr_15", "event_log": [ {"value": "ddbb7fd2b4aa332b6645b07d75e0b0edf4baed5813f879829acdb32c83a0382d", "label": "ISecL_Default_Workload_Flavor_v1.0-b68fd1b2-e34f-4637-b3de-f9da6b7f6511", "info": { "ComponentName": "ISecL_Default_Workload_Flavor_v1.0-b68fd1b2-e34f-4637-b3de-f9da6b7f6511", "EventName": "OpenSource.EventName" } }, {"value": "1d1affd0a6d562848387ee3c36a14a8158a847fb1f32ee54c67b95ea16d4d9c5", "label": "ISecL_Default_Application_Flavor_v1.0_TPM2.0-c2e5999b-8083-4c7f-917d-e979190a4183", "info": { "ComponentName": "ISecL_Default_Application_Flavor_v1.0_TPM2.0-c2e5999b-8083-4c7f-917d-e979190a4183", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" }, { "pcr_index": "pcr_17", "event_log": [ {"value": "5d0220ffbceca9ca4e28215480c0280b1681328326c593743fa183f70ffbe834", "label": "HASH_START", "info": { "ComponentName": "HASH_START", "EventName": "OpenSource.EventName" } }, {"value": "893d8ebf029907725f7deb657e80f7589c4ee52cdffed44547cd315f378f48c6", "label": "BIOSAC_REG_DATA", "info": { "ComponentName": "BIOSAC_REG_DATA", "EventName": "OpenSource.EventName" } }, {"value": "67abdd721024f0ff4e0b3f4c2fc13bc5bad42d0b7851d456d88d203d15aaa450", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "df3f619804a92fdb4057192dc43dd748ea778adc52bc498ce80524c014b81119", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "LCP_DETAILS_HASH", "info": { "ComponentName": "LCP_DETAILS_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "STM_HASH", "info": { "ComponentName": "STM_HASH", "EventName": "OpenSource.EventName" } }, {"value": "d81fe96dc500bc43e1cd5800bef9d72b3d030bdb7e860e10c522e4246b30bd93", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "236043f5120fce826392d2170dc84f2491367cc8d8d403ab3b83ec24ea2ca186", "label": "MLE_HASH", "info": { "ComponentName": "MLE_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0f6e0c7a5944963d7081ea494ddff1e9afa689e148e39f684db06578869ea38b", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "27808f64e6383982cd3bcc10cfcb3457c0b65f465f779d89b668839eaf263a67", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } }, {"value": "348a6284f46123a913681d53a201c05750d4527483ceaa2a2adbc7dda52cf506", "label": "vmlinuz", "info": { "ComponentName": "vmlinuz", "EventName": "OpenSource.EventName" } }, {"value": "d018a266352fee8f1e9453bd6a3977bea33ea9ac79c84c240c6d7e29d93d0115", "label": "initrd", "info": { "ComponentName": "initrd", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" }, { "pcr_index": "pcr_18", "event_log": [ {"value": "da256395df4046319ef0af857d377a729e5bc0693429ac827002ffafe485b2e7", "label": "SINIT_PUBKEY_HASH", "info": { "ComponentName": "SINIT_PUBKEY_HASH", "EventName": "OpenSource.EventName" } }, {"value": "67abdd721024f0ff4e0b3f4c2fc13bc5bad42d0b7851d456d88d203d15aaa450", "label": "CPU_SCRTM_STAT", "info": { "ComponentName": "CPU_SCRTM_STAT", "EventName": "OpenSource.EventName" } }, {"value": "d81fe96dc500bc43e1cd5800bef9d72b3d030bdb7e860e10c522e4246b30bd93", "label": "OSSINITDATA_CAP_HASH", "info": { "ComponentName": "OSSINITDATA_CAP_HASH", "EventName": "OpenSource.EventName" } }, {"value": "df3f619804a92fdb4057192dc43dd748ea778adc52bc498ce80524c014b81119", "label": "LCP_CONTROL_HASH", "info": { "ComponentName": "LCP_CONTROL_HASH", "EventName": "OpenSource.EventName" } }, {"value": "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d", "label": "LCP_AUTHORITIES_HASH", "info": { "ComponentName": "LCP_AUTHORITIES_HASH", "EventName": "OpenSource.EventName" } }, {"value": "0f6e0c7a5944963d7081ea494ddff1e9afa689e148e39f684db06578869ea38b", "label": "NV_INFO_HASH", "info": { "ComponentName": "NV_INFO_HASH", "EventName": "OpenSource.EventName" } }, {"value": "27808f64e6383982cd3bcc10cfcb3457c0b65f465f779d89b668839eaf263a67", "label": "tb_policy", "info": { "ComponentName": "tb_policy", "EventName": "OpenSource.EventName" } } ], "pcr_bank": "SHA256" } ] } }, "binding_key_certificate": "MIIFITCCA4mgAwIBAgIJAKrvQp6ScTi1MA0GCSqGSIb3DQEBDAUAMBsxGTAXBgNVBAMTEG10d2lsc29uLXBjYS1haWswHhcNMjAwNDE1MDgwMzE2WhcNMzAwNDEzMDgwMzE2WjAlMSMwIQYDVQQDDBpDTj1CaW5kaW5nX0tleV9DZXJ0aWZpY2F0ZTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoCggEBANJgmnV3e9VBFxZqKQP1FszztRQ0JXAlhE6SEa+1c8oTPbEG83s8nfprQwEaH89WBVm3QOe+Pl+ZS01E3jZ0asFHqkicnXh8nyWcpPY8JKQ8qRJzC68rvw2zgMo1QZKg65enTRIEABO8uFZKqye7xubJZOnanDPMbprer+Q+brdm+muOrHbROmY18utVnY3IciOPC2Hv+IC+4xzcli9PlkUxsUnmNf9pz85sLt2lft6gun4aGMh2ute8YTL6ZLNZ8nvZN8T8+7/IV3/Pklz4qtMyFxtpHIP2UUxlptk6uTvjsS4Nnwt5YdTuYm4yWzIFB7SApQsDbB4WtyPW9oRcRhkCAwEAAaOCAdwwggHYMA4GA1UdDwEB/wQEAwIFIDCBnQYHVQSBBQMCKQSBkf9UQ0eAFwAiAAs8+xFev3D2D4WG6PPhDWJey+Q/rVqgI3NYt79/YbizCwAEAP9VqgAAAAAANrGOAAAABgAAAAEBAAcAPgAMNgAAIgALta+AaKE5Tb3YIl7i/P+7tFLzXKZFlI+aWppdCEXJfw0AIgALScYOkvDeijOdoEy0phrYroOncXXSpNZ9M2JjdylBTlwwggEUBghVBIEFAwIpAQSCAQYAFAALAQBJQMBtwZmONe+QFGtDxzIrcHEg+NoQ8hQVpr+5Vt2knUAEon6gJgqz1gSWm0f0Q8TRzRVOutPxtNZMSvokbfHcdYyjmSwoIMATeK+YDieGuL+4w0ezg30lYjRukFOTxA2fw7arNkL7J/fiXGOAAUqDM+z7k4/y8bfRwBHZiN3uxbroR9SwiniPYmxUMLiIPLNMJVKdDMQLzA6z+PTSc8pxf1d78q7y/L+9OFfrThj+m6B4c5qWNHmZc37JG854QDP41FMJI9/Q1cQK6iZHapZPjTp9ikQuF+aegOxzVfcxeJI+wjkwqcGgeEfL+xFx2nhQ+1MSQrZ/uFiZhggdgqtQMA4GCFUEgQUDAikCBAIAADANBgkqhkiG9w0BAQwFAAOCAYEATBlbRClIKh5a7N0kcdEs94Z/5Vzrql8mizEe9/+xXd+Pp9ndyEGjrq3DSsMiOQyt0zQ39TGDzPOzuBQ5DG6A/w21MGVKGO1w15J7Wxzpez7Gd76HwXGHIiJnJZ5Llz9s7IWDqU5fIra/t4qWZzSxpZOVgpBe/9QzIVjgV44sXtjUahC7pnWusEPXa8kcLrdj+Y9EiMbuAldcDLmduRhDO/ex+StRs0b21BfF6sjCud5Md28r8W5/NEuXOqaKYWIFbGjD5qflCL2stEfbJFnIASiBS9dYYFAPj+fQWJzOTtxtk7lfAIz2PD3TJwHWD+HyMd5PsaHOnTw9GEKz3NDdmSc3juhnfi5RNIlFKAtYUjQ+HQjYvOhNOZTPB0S8U/91XV6ph0bTWdxJh6/KUt9jxnASapeVkoS18Q4K5sEmB/iHU0/HY56oDsrjRibX/sWfh9XG2eB3U8DlQkFtyVGvuuD3ym7cPirhVxTUiSOYa/Z6OJ04Gbaya4rWS7ZLBStD", "measurement_xmls": [ "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?><Measurement xmlns=\"lib:wml:measurements:1.0\" Label=\"ISecL_Default_Workload_Flavor_v1.0\" Uuid=\"b68fd1b2-e34f-4637-b3de-f9da6b7f6511\" DigestAlg=\"SHA384\"><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/workload-agent/bin\">e64e6d5afaad329d94d749e9b72c76e23fd3cb34655db10eadab4f858fb40b25ff08afa2aa6dbfbf081e11defdb58d5a</Dir><File Path=\"/opt/workload-agent/bin/wlagent\">ac8b967514f0a4c0ddcd87ee6cfdd03ffc5e5dd73598d40b8f6b6ef6dd606040a5fc31667908561093dd28317dfa1033</File><CumulativeHash>2ae673d241fed6e55d89e33a3ae8c6d127ed228e4afedfabfc2409c2d7bf51714d469786f948935c0b25c954904a2302</CumulativeHash></Measurement>", "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?><Measurement xmlns=\"lib:wml:measurements:1.0\" Label=\"ISecL_Default_Application_Flavor_v1.0_TPM2.0\" Uuid=\"c2e5999b-8083-4c7f-917d-e979190a4183\" DigestAlg=\"SHA384\"><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/bin\">b0d5cba0bb12d69d8dd3e92bdad09d093a34dd4ea30aea63fb31b9c26d9cbf0e84016fa9a80843b473e1493a427aa63a</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/dracut_files\">1d9c8eb15a49ea65fb96f2b919c42d5dfd30f4e4c1618205287345aeb4669d18113fe5bc87b033aeef2aeadc2e063232</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/initrd_hooks\">77b913422748a8e62f0720d739d54b2fa7856ebeb9e76fab75c41c375f2ad77b7b9ec5849b20d857e24a894a615d2de7</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/lib\">b03eb9d3b6fa0d338fd4ef803a277d523ab31db5c27186a283dd8d1fe0e7afca9bf26b31b1099833b0ba398dbe3c02fb</Dir><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/tbootxm/mkinitrd_files\">6928eb666f6971af5da42ad785588fb9464465b12c78f7279f46f9f8e04ae428d4872e7813671a1390cc8ed433366247</Dir><File Path=\"/opt/tbootxm/bin/tpmextend\">b936d9ec4b8c7823efb01d946a7caa074bdfffdbd11dc20108ba771b8ef65d8efc72b559cd605b1ba0d70ef99e84ba55</File><File Path=\"/opt/tbootxm/bin/measure\">c72551ddfdfab6ec901b7ed8dc28a1b093793fd590d2f6c3b685426932013ca11a69aeb3c04a31278829f653a24deeb1</File><File Path=\"/opt/tbootxm/bin/configure_host.sh\">8675ca78238f0cf6e09d0d20290a7a2b9837e2a1c19a4a0a7a8c226820c33b6a6538c2f94bb4eb78867bd1a87a859a2c</File><File Path=\"/opt/tbootxm/bin/generate_initrd.sh\">4708ed8233a81d6a17b2c4b74b955f27612d2cc04730ad8919618964209ce885cea9011e00236de56a2239a524044db4</File><File Path=\"/opt/tbootxm/bin/measure_host\">7455104eb95b1ee1dfb5487d40c8e3a677f057da97e2170d66a52b555239a4b539ca8122ee25b33bb327373aac4e4b7a</File><File Path=\"/opt/tbootxm/bin/tboot-xm-uninstall.sh\">7450bc939548eafc4a3ba9734ad1f96e46e1f46a40e4d12ad5b5f6b5eb2baf1597ade91edb035d8b5c1ecc38bde7ee59</File><File Path=\"/opt/tbootxm/bin/functions.sh\">8526f8aedbe6c4bde3ba331b0ce18051433bdabaf8991a269aff7a5306838b13982f7d1ead941fb74806fc696fef3bf0</File><File Path=\"/opt/tbootxm/dracut_files/check\">6f5949b86d3bf3387eaff8a18bb5d64e60daff9a2568d0c7eb90adde515620b9e5e9cd7d908805c6886cd178e7b382e1</File><File Path=\"/opt/tbootxm/dracut_files/install\">e2fc98a9292838a511d98348b29ba82e73c839cbb02051250c8a8ff85067930b5af2b22de4576793533259fad985df4a</File><File Path=\"/opt/tbootxm/dracut_files/module-setup.sh\">0a27a9e0bff117f30481dcab29bb5120f474f2c3ea10fa2449a9b05123c5d8ce31989fcd986bfa73e6c25c70202c50cb</File><File Path=\"/opt/tbootxm/lib/libwml.so\">56a04d0f073f0eb2a4f851ebcba79f7080553c27fa8d1f7d4a767dc849015c9cc6c9abe937d0e90d73de27814f28e378</File><File Path=\"/opt/tbootxm/lib/create_menuentry.pl\">79770fb02e5a8f6b51678bde4d017f23ac811b1a9f89182a8b7f9871990dbbc07fd9a0578275c405a02ac5223412095e</File><File Path=\"/opt/tbootxm/lib/update_menuentry.pl\">cb6754eb6f2e39e43d420682bc91c83b38d63808b603c068a3087affb856703d3ae564892ac837cd0d4453e41b2a228e</File><File Path=\"/opt/tbootxm/lib/remove_menuentry.pl\">baf4f9b63ab9bb1e8616e3fb037580e38c0ebd4073b3b7b645e0e37cc7f0588f4c5ed8b744e9be7689aa78d23df8ec4c</File><File Path=\"/opt/tbootxm/initrd_hooks/tcb\">430725e0cb08b290897aa850124f765ae0bdf385e6d3b741cdc5ff7dc72119958fbcce3f62d6b6d63c4a10c70c18ca98</File><File Path=\"/opt/tbootxm/mkinitrd_files/setup-measure_host.sh\">2791f12e447bbc88e25020ddbf5a2a8693443c5ca509c0f0020a8c7bed6c813cd62cb4c250c88491f5d540343032addc</File><Dir Exclude=\"\" Include=\".*\" Path=\"/opt/trustagent/bin\">3519466d871c395ce1f5b073a4a3847b6b8f0b3e495337daa0474f967aeecd48f699df29a4d106288f3b0d1705ecef75</Dir><File Path=\"/opt/trustagent/bin/module_analysis.sh\">2327e72fa469bada099c5956f851817b0c8fa2d6c43089566cacd0f573bf62e7e8dd10a2c339205fb16c3956db6518a9</File><File Path=\"/opt/trustagent/bin/module_analysis_da.sh\">2a99c3e80e99d495a6b8cce8e7504af511201f05fcb40b766a41e6af52a54a34ea9fba985d2835aef929e636ad2a6f1d</File><File Path=\"/opt/trustagent/bin/module_analysis_da_tcg.sh\">0f47a757c86e91a3a175cd6ee597a67f84c6fec95936d7f2c9316b0944c27cb72f84e32c587adb456b94e64486d14242</File><CumulativeHash>7425a5806dc8a5aacd508e4d6866655bf475947cc8bb630a03ff42b898ee8a7d8fd3ca71c3e1dacdc0f375bcbaf11efc</CumulativeHash></Measurement>"]}}`
+	HostStatus6           = `{"id":"5f3d9b6e-0a41-4c8e-9b2a-7d1e6c4f8a30","host_id":"c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21","status":{"host_state":"CONNECTED"},"created":"` + time.Now().Add(-TimeDuration30Mins).Format(time.RFC3339) + `","host_manifest":{"host_info":{"os_name":"RedHatEnterprise","host_name":"computepurley2","hardware_uuid":"4d1c8e2a-93b7-4f06-a5e1-2b6c0d9f7e18"},"pcr_manifest":{"sha1pcrs":[],"sha2pcrs":[{"index":"pcr_0","value":"e9d8c854b5a0fd4cd894d3ae76e829b3b52300ce3e7606fb4110256af7135212","pcr_bank":"SHA256"},{"index":"pcr_17","value":"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff","pcr_bank":"SHA256"}],"pcr_event_log_map":{"SHA1":[],"SHA256":[{"pcr":{"index":17,"bank":"SHA256"},"tpm_events":[{"type_id":"0x402","type_name":"HASH_START","tags":["HASH_START"],"measurement":"9b1387306ebb7ff8e795e7be77563666bbf4516e6f2f8e6b8e4b6cbf0b6ed63c"}]},{"pcr":{"index":0,"bank":"SHA256"},"tpm_events":[{"type_id":"0x3","type_name":"EV_NO_ACTION","tags":["StartupLocality3"],"measurement":"0000000000000000000000000000000000000000000000000000000000000000"},{"type_id":"0x8","type_name":"EV_S_CRTM_VERSION","measurement":"d5a4e7b0d4b3c1e26dd7c2b59e2b1a7c03f2ee8e0b2f0b3b9c6f1f2f0a7d9e11"},{"type_id":"0x1","type_name":"EV_POST_CODE","measurement":"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7"}]}]}}}}`
 )
 
 const (
@@ -40,10 +41,10 @@ type MockHostStatusStore struct {
 }
 
 var (
-	hs1, hs2, hs3, hs4, hs5      hvs.HostStatus
-	hsi1, hsi2, hsi3, hsi4, hsi5 []byte
-	hsm1, hsm2, hsm3, hsm4, hsm5 []byte
-	atb                          postgres.PGAuditLogData
+	hs1, hs2, hs3, hs4, hs5, hs6       hvs.HostStatus
+	hsi1, hsi2, hsi3, hsi4, hsi5, hsi6 []byte
+	hsm1, hsm2, hsm3, hsm4, hsm5, hsm6 []byte
+	atb                                postgres.PGAuditLogData
 )
 
 func init() {
@@ -67,6 +68,10 @@ func init() {
 	if err != nil {
 		defaultLog.WithError(err).Errorf("Error creating unmarshalling data")
 	}
+	err = json.Unmarshal([]byte(HostStatus6), &hs6)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("Error creating unmarshalling data")
+	}
 	hsi1, _ = json.Marshal(hs1.HostStatusInformation)
 	hsm1, _ = json.Marshal(hs1.HostManifest)
 	hsi2, _ = json.Marshal(hs2.HostStatusInformation)
@@ -77,6 +82,8 @@ func init() {
 	hsm4, _ = json.Marshal(hs4.HostManifest)
 	hsi5, _ = json.Marshal(hs5.HostStatusInformation)
 	hsm5, _ = json.Marshal(hs5.HostManifest)
+	hsi6, _ = json.Marshal(hs6.HostStatusInformation)
+	hsm6, _ = json.Marshal(hs6.HostManifest)
 	_ = json.Unmarshal([]byte(auditData), &atb)
 }
 
@@ -148,7 +155,7 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 	store.Mock.MatchExpectationsInOrder(false)
 
 	// Search No filters
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created).
 			AddRow(hs2.ID.String(), hs2.HostID.String(), hsi2, hsm2, hs2.Created).
@@ -156,58 +163,64 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 			AddRow(hs4.ID.String(), hs4.HostID.String(), hsi4, hsm4, hs4.Created))
 
 	// Search by ID
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(id = (.+) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(id = (.+) ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created))
 
 	// Search by an existing Host ID
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WithArgs("47a3b602-f321-4e03-b3b2-8f3ca3cde128").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), "47a3b602-f321-4e03-b3b2-8f3ca3cde128", hsi1, hsm1, hs1.Created).
 			AddRow(hs2.ID.String(), "47a3b602-f321-4e03-b3b2-8f3ca3cde128", hsi2, hsm2, hs2.Created))
 
 	// Search by a non-existent Host ID - empty result
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WithArgs("13885605-a0ee-41f2-b6fc-fd82edc487ad").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}))
 
+	// Search by a Host ID with a host manifest holding TCG event logs
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
+		WithArgs("c00a2b5d-4e2f-4c5b-8d1e-6f0e3a9b7c21").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
+			AddRow(hs6.ID.String(), hs6.HostID.String(), hsi6, hsm6, hs6.Created))
+
 	newUuid, err := uuid.NewRandom()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create new UUID")
 	}
 	// Mock query for Reports Controller
 	// Scenario: Host in Connected State
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WithArgs("ee37c360-7eae-4250-a677-6ee12adce8e2").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(newUuid, "e57e5ea0-d465-461e-882d-1600090caa0d", hsi1, hsm1, hs1.Created))
 
 	// Search by existing HostHardareUUID
-	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WithArgs("1ad9c003-b0e0-4319-b2b3-06053dfd1407").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created).
 			AddRow(hs2.ID.String(), hs2.HostID.String(), hsi2, hsm2, hs2.Created))
 
 	// Search by non-existent HostHardareUUID
-	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WithArgs("7f71bff0-3c12-4f92-9a77-d380eb9ad2e2").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}))
 
 	// Search by HostStatus
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(status(.+)host_state(.+)CONNECTED(.+)LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(status(.+)host_state(.+)CONNECTED(.+)ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created).
 			AddRow(hs3.ID.String(), hs3.HostID.String(), hsi3, hsm3, hs3.Created))
 
 	// Search by HostState UNKNOWN
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(status(.+)host_state(.+)UNKNOWN(.+)LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(status(.+)host_state(.+)UNKNOWN(.+)ORDER BY host_status\.created desc,host_status\.id desc LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs4.ID.String(), hs4.HostID.String(), hsi4, hsm4, hs4.Created))
 
 	// Search by HostName
-	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.name = \$1\) LIMIT 10000`).
+	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.name = \$1\) ORDER BY host_status\.created desc,host_status\.id desc LIMIT 10000`).
 		WithArgs("computepurley1").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created).
@@ -227,7 +240,7 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 	}
 	// Search by numberOfDays
 	store.Mock.ExpectQuery(`
-SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY au.Created DESC LIMIT (.+)`).
+SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY au\.created desc,au\.entity_id desc LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id", "entity_type", "created", "action", "data"}).
 			AddRow(newUuid1.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
 			AddRow(newUuid2.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
@@ -246,7 +259,7 @@ SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.cre
 		return nil, errors.Wrap(err, "failed to create new UUID")
 	}
 	// Search by fromDate and toDate
-	store.Mock.ExpectQuery(`SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY au.Created DESC LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY au\.created desc,au\.entity_id desc LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id", "entity_type", "created", "action", "data"}).
 			AddRow(newUuid1.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
 			AddRow(newUuid2.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
@@ -254,6 +267,14 @@ SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.cre
 	return store.HostStatusStore.Search(criteria)
 }
 
+// Count returns the number of HostStatuses matching the HostStatusFilterCriteria
+func (store *MockHostStatusStore) Count(criteria *models.HostStatusFilterCriteria) (int, error) {
+	allCriteria := *criteria
+	allCriteria.PageCriteria = models.PageCriteria{}
+	hostStatuses, err := store.Search(&allCriteria)
+	return len(hostStatuses), err
+}
+
 // FindHostIdsByKeyValue returns host ids for records having key value pair in HostInfo
 func (store *MockHostStatusStore) FindHostIdsByKeyValue(key, value string) ([]uuid.UUID, error) {
 	// Mock Retrieve Host-by-ID
@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	"github.com/pkg/errors"
+)
+
+// MockJobStore provides a mocked implementation of interface domain.JobStore
+type MockJobStore struct {
+	lock sync.Mutex
+	jobs map[uuid.UUID]models.Job
+}
+
+func NewMockJobStore() *MockJobStore {
+	return &MockJobStore{jobs: make(map[uuid.UUID]models.Job)}
+}
+
+// copyJob copies the job with its params so that the callers do not share the map of the store
+func copyJob(job models.Job) *models.Job {
+	cp := job
+	if job.Params != nil {
+		cp.Params = make(map[string]interface{}, len(job.Params))
+		for k, v := range job.Params {
+			cp.Params[k] = v
+		}
+	}
+	return &cp
+}
+
+func (store *MockJobStore) Create(job *models.Job) (*models.Job, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	rec := *copyJob(*job)
+	rec.Id = uuid.New()
+	rec.Created = time.Now()
+	rec.Updated = rec.Created
+	store.jobs[rec.Id] = rec
+	return copyJob(rec), nil
+}
+
+func (store *MockJobStore) Retrieve(id uuid.UUID) (*models.Job, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if job, ok := store.jobs[id]; ok {
+		return copyJob(job), nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+func (store *MockJobStore) Update(job *models.Job) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	rec, ok := store.jobs[job.Id]
+	if !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	rec = *copyJob(*job)
+	rec.Updated = time.Now()
+	store.jobs[job.Id] = rec
+	return nil
+}
+
+func (store *MockJobStore) Restart(job *models.Job) (bool, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	rec, ok := store.jobs[job.Id]
+	if !ok || !rec.State.Finished() {
+		return false, nil
+	}
+	rec = *copyJob(*job)
+	rec.State = models.JobStatePending
+	rec.Message = ""
+	rec.Updated = time.Now()
+	store.jobs[job.Id] = rec
+	return true, nil
+}
+
+func (store *MockJobStore) FailUnfinished(message string) (int, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	failed := 0
+	for id, job := range store.jobs {
+		if !job.State.Finished() {
+			job.State = models.JobStateError
+			job.Message = message
+			job.Updated = time.Now()
+			store.jobs[id] = job
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+func (store *MockJobStore) Purge(updatedBefore time.Time) (int, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	purged := 0
+	for id, job := range store.jobs {
+		if job.State.Finished() && job.Updated.Before(updatedBefore) {
+			delete(store.jobs, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
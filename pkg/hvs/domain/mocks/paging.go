@@ -0,0 +1,20 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import "github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+
+// pageBounds returns the start and end indexes of the requested page in a list of n records
+func pageBounds(pageCriteria models.PageCriteria, n int) (int, int) {
+	start := pageCriteria.Offset
+	if start > n {
+		start = n
+	}
+	end := n
+	if pageCriteria.Limit > 0 && start+pageCriteria.Limit < n {
+		end = start + pageCriteria.Limit
+	}
+	return start, end
+}
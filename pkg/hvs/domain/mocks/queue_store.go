@@ -10,43 +10,65 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"sync"
 	"time"
 )
 
 type qStore struct {
-	m map[uuid.UUID]models.Queue
+	mtx sync.RWMutex
+	m   map[uuid.UUID]models.Queue
 }
 
 func NewQueueStore() domain.QueueStore {
 
-	return &qStore{make(map[uuid.UUID]models.Queue)}
+	return &qStore{m: make(map[uuid.UUID]models.Queue)}
+}
+
+// copyQueue copies the queue entry with its params so that the callers do not share the map of the store
+func copyQueue(queue models.Queue) *models.Queue {
+	cp := queue
+	if queue.Params != nil {
+		cp.Params = make(map[string]interface{}, len(queue.Params))
+		for k, v := range queue.Params {
+			cp.Params[k] = v
+		}
+	}
+	return &cp
 }
 
 func (qs *qStore) Search(criteria *models.QueueFilterCriteria) ([]*models.Queue, error) {
+	qs.mtx.RLock()
+	defer qs.mtx.RUnlock()
 	if criteria == nil || criteria.Id == uuid.Nil {
 		rslt := make([]*models.Queue, 0, len(qs.m))
 		for _, v := range qs.m {
-			rslt = append(rslt, &v)
+			rslt = append(rslt, copyQueue(v))
 		}
 		return rslt, nil
 	}
 	if _, ok := qs.m[criteria.Id]; ok {
-		cp := qs.m[criteria.Id]
-		return []*models.Queue{&cp}, nil
+		return []*models.Queue{copyQueue(qs.m[criteria.Id])}, nil
 	}
 	return nil, errors.New("No Records fouund")
 }
 
 func (qs *qStore) Retrieve(uuid uuid.UUID) (*models.Queue, error) {
+	qs.mtx.RLock()
+	defer qs.mtx.RUnlock()
 	if _, ok := qs.m[uuid]; ok {
-		cp := qs.m[uuid]
-		return &cp, nil
+		return copyQueue(qs.m[uuid]), nil
 	}
 	return nil, errors.New("Record not fouund")
 }
 
 func (qs *qStore) Update(queue *models.Queue) error {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
 	if rec, ok := qs.m[queue.Id]; ok {
+		rec = *copyQueue(rec)
+		if rec.Params == nil {
+			rec.Params = make(map[string]interface{})
+		}
 
 		for k, v := range queue.Params {
 			rec.Params[k] = v
@@ -57,6 +79,9 @@ func (qs *qStore) Update(queue *models.Queue) error {
 		if queue.Action != "" {
 			rec.Action = queue.Action
 		}
+		if queue.Message != "" {
+			rec.Message = queue.Message
+		}
 		rec.Updated = time.Now()
 		qs.m[queue.Id] = rec
 
@@ -66,7 +91,9 @@ func (qs *qStore) Update(queue *models.Queue) error {
 }
 
 func (qs *qStore) Create(queue *models.Queue) (*models.Queue, error) {
-	rec := *queue
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
+	rec := *copyQueue(*queue)
 	newUuid, err := uuid.NewRandom()
 	if err != nil {
 		return nil, errors.New("failed to create new UUID - " + err.Error())
@@ -75,14 +102,25 @@ func (qs *qStore) Create(queue *models.Queue) (*models.Queue, error) {
 	rec.Created = time.Now()
 	rec.Updated = rec.Created
 	qs.m[rec.Id] = rec
-	cp := rec
-	return &cp, nil
+	return copyQueue(rec), nil
 }
 
 func (qs *qStore) Delete(uuid uuid.UUID) error {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
 	if _, ok := qs.m[uuid]; ok {
 		delete(qs.m, uuid)
 		return nil
 	}
 	return errors.New("Record not found")
 }
+
+func (qs *qStore) CountByState() (map[models.QueueState]int, error) {
+	qs.mtx.RLock()
+	defer qs.mtx.RUnlock()
+	counts := make(map[models.QueueState]int)
+	for _, v := range qs.m {
+		counts[v.State]++
+	}
+	return counts, nil
+}
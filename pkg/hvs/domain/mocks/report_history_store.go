@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	"github.com/pkg/errors"
+)
+
+// MockReportHistoryStore provides a mocked implementation of interface domain.ReportHistoryStore
+type MockReportHistoryStore struct {
+	lock    sync.Mutex
+	reports []models.HVSReport
+}
+
+func NewMockReportHistoryStore() *MockReportHistoryStore {
+	return &MockReportHistoryStore{}
+}
+
+// Add records a report in the report history
+func (store *MockReportHistoryStore) Add(report models.HVSReport) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	store.reports = append(store.reports, report)
+}
+
+// Retrieve returns the report with the given id
+func (store *MockReportHistoryStore) Retrieve(id uuid.UUID) (*models.HVSReport, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	for _, report := range store.reports {
+		if report.ID == id {
+			return &report, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns the reports of a host created in the date range of the criteria, the most recent first
+func (store *MockReportHistoryStore) Search(criteria *models.ReportHistoryFilterCriteria) ([]models.HVSReport, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	reports := []models.HVSReport{}
+	for _, report := range store.reports {
+		if report.HostID != criteria.HostID ||
+			(!criteria.FromDate.IsZero() && report.CreatedAt.Before(criteria.FromDate)) ||
+			(!criteria.ToDate.IsZero() && !report.CreatedAt.Before(criteria.ToDate)) {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		if criteria.OrderBy == models.Ascending {
+			return reports[i].CreatedAt.Before(reports[j].CreatedAt)
+		}
+		return reports[i].CreatedAt.After(reports[j].CreatedAt)
+	})
+
+	start, end := pageBounds(criteria.PageCriteria, len(reports))
+	return reports[start:end], nil
+}
+
+// Count returns the number of reports matching the criteria, ignoring its page criteria
+func (store *MockReportHistoryStore) Count(criteria *models.ReportHistoryFilterCriteria) (int, error) {
+	allCriteria := *criteria
+	allCriteria.PageCriteria = models.PageCriteria{}
+	reports, err := store.Search(&allCriteria)
+	return len(reports), err
+}
+
+// Purge deletes the reports of each host beyond the most recent maxReports and the reports created before
+// createdBefore, the most recent report of each host is kept
+func (store *MockReportHistoryStore) Purge(maxReports int, createdBefore time.Time) (int, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	sort.SliceStable(store.reports, func(i, j int) bool {
+		return store.reports[i].CreatedAt.After(store.reports[j].CreatedAt)
+	})
+	positions := make(map[uuid.UUID]int)
+	var kept []models.HVSReport
+	for _, report := range store.reports {
+		positions[report.HostID]++
+		position := positions[report.HostID]
+		if position > 1 && ((maxReports > 0 && position > maxReports) ||
+			(!createdBefore.IsZero() && report.CreatedAt.Before(createdBefore))) {
+			continue
+		}
+		kept = append(kept, report)
+	}
+	purged := len(store.reports) - len(kept)
+	store.reports = kept
+	return purged, nil
+}
@@ -21,7 +21,10 @@ import (
 
 // MockReportStore provides a mocked implementation of interface postgres.ReportStore
 type MockReportStore struct {
-	reportStore map[uuid.UUID]models.HVSReport
+	reportStore   map[uuid.UUID]models.HVSReport
+	reportHistory map[uuid.UUID]models.HVSReport
+	// RefreshIntervals holds the shortest refresh interval of the flavorgroup report policies of a host
+	RefreshIntervals map[uuid.UUID]time.Duration
 }
 
 // Create inserts a HVSReport
@@ -50,6 +53,14 @@ func (store *MockReportStore) Retrieve(id uuid.UUID) (*models.HVSReport, error)
 	return nil, errors.New(commErr.RowsNotFound)
 }
 
+// RetrieveHistoric returns HVSReport, including reports that were replaced by a newer report
+func (store *MockReportStore) RetrieveHistoric(id uuid.UUID) (*models.HVSReport, error) {
+	if rs, found := store.reportHistory[id]; found {
+		return &rs, nil
+	}
+	return store.Retrieve(id)
+}
+
 // Delete deletes HVSReport
 func (store *MockReportStore) Delete(id uuid.UUID) error {
 	for _, t := range store.reportStore {
@@ -121,14 +132,30 @@ func (store *MockReportStore) Search(criteria *models.ReportFilterCriteria) ([]m
 
 	}
 
-	return reports, nil
+	start, end := pageBounds(criteria.PageCriteria, len(reports))
+	return reports[start:end], nil
+}
+
+// Count returns the number of HVSReports matching the ReportFilterCriteria
+func (store *MockReportStore) Count(criteria *models.ReportFilterCriteria) (int, error) {
+	if criteria != nil {
+		allCriteria := *criteria
+		allCriteria.PageCriteria = models.PageCriteria{}
+		criteria = &allCriteria
+	}
+	reports, err := store.Search(criteria)
+	return len(reports), err
 }
 
 func (store *MockReportStore) FindHostIdsFromExpiredReports(fromTime time.Time, toTime time.Time) ([]uuid.UUID, error) {
 	hostIDs := []uuid.UUID{}
 
 	for _, r := range store.reportStore {
-		if r.Expiration.After(fromTime) && r.Expiration.Before(toTime) {
+		refreshTime := r.Expiration
+		if interval, ok := store.RefreshIntervals[r.HostID]; ok && r.CreatedAt.Add(interval).Before(refreshTime) {
+			refreshTime = r.CreatedAt.Add(interval)
+		}
+		if refreshTime.After(fromTime) && refreshTime.Before(toTime) {
 			hostIDs = append(hostIDs, r.HostID)
 		}
 	}
@@ -141,6 +168,7 @@ func NewMockReportStore() *MockReportStore {
 	//TODO add more data
 	store := &MockReportStore{}
 	store.reportStore = make(map[uuid.UUID]models.HVSReport)
+	store.reportHistory = make(map[uuid.UUID]models.HVSReport)
 	saml1text, _ := ioutil.ReadFile("../domain/mocks/resources/saml_report")
 	trustReportBytes, _ := ioutil.ReadFile("../domain/mocks/resources/trust_report.json")
 	var trustReport hvs.TrustReport
@@ -173,11 +201,28 @@ func NewMockReportStore() *MockReportStore {
 	if err != nil {
 		defaultLog.WithError(err).Errorf("Error creating Trust Report")
 	}
+
+	// older report for the first host that has since been replaced
+	var previousTrustReport hvs.TrustReport
+	err = json.Unmarshal(trustReportBytes, &previousTrustReport)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("Error unmarshalling trust report")
+	}
+	previousTrustReport.HostManifest.HostInfo.BiosVersion = "SE5C620.86B.00.01.0014.070920180847"
+	store.reportHistory[uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb2")] = models.HVSReport{
+		ID:          uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb2"),
+		HostID:      uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"),
+		CreatedAt:   created.AddDate(0, 0, -1),
+		Expiration:  expiration.AddDate(0, 0, -1),
+		Saml:        string(saml1text),
+		TrustReport: previousTrustReport,
+	}
 	return store
 }
 
 func NewEmptyMockReportStore() domain.ReportStore {
 	store := MockReportStore{}
 	store.reportStore = make(map[uuid.UUID]models.HVSReport)
+	store.reportHistory = make(map[uuid.UUID]models.HVSReport)
 	return &store
 }
@@ -136,7 +136,7 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 		_ = json.Unmarshal([]byte(v), &tc)
 		allRows.AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter)
 	}
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"   ORDER BY "subject"`).WillReturnRows(allRows)
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"   ORDER BY subject asc,id asc`).WillReturnRows(allRows)
 
 	// search by id
 	for k, v := range tcMap {
@@ -178,49 +178,49 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 		_ = json.Unmarshal([]byte(v), &tc)
 		subjectEqualToRows.AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter)
 	}
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("00ecd3ab-9af4-e711-906e-001560a04062").
 		WillReturnRows(subjectEqualToRows)
 
 	// Search by subjectEqualTo which does not exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("afc82547-0691-4be1-8b14-bcebfce86fd6").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// SubjectContains filter - which exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("%001560a04062%").
 		WillReturnRows(subjectEqualToRows)
 
 	// SubjectContains filter - which does not exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("%7a466a5beff9%").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// IssuerEqualTo filter - which exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("cn=asset-tag-service").
 		WillReturnRows(allRows)
 
 	// IssuerEqualTo filter - which does not exist
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("cn=nonexistent-tag-service").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// IssuerContains filter - which exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("%asset-tag%").
 		WillReturnRows(allRows)
 
 	// IssuerContains filter - which does not exist
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY subject asc,id asc`).
 		WithArgs("%nonexistent-tag-service%").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// ValidOn - with a valid value
 	var tcValidOn1 hvs.TagCertificate
 	_ = json.Unmarshal([]byte(tcMap["7ce60664-faa3-4c2e-8c45-41e209e4f1db"]), &tcValidOn1)
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(notbefore AS TIMESTAMP\) <= CAST\(\$1 AS TIMESTAMP\) AND CAST\(\$2 AS TIMESTAMP\) <= CAST\(notafter AS TIMESTAMP\)\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(notbefore AS TIMESTAMP\) <= CAST\(\$1 AS TIMESTAMP\) AND CAST\(\$2 AS TIMESTAMP\) <= CAST\(notafter AS TIMESTAMP\)\) ORDER BY subject asc,id asc`).
 		WithArgs("2016-09-28T09:08:33.913Z", "2016-09-28T09:08:33.913Z").
 		WillReturnRows(sqlmock.NewRows(tcCols).
 			AddRow(tcValidOn1.ID.String(), tcValidOn1.HardwareUUID.String(), string(tcValidOn1.Certificate), tcValidOn1.Subject, tcValidOn1.Issuer, tcValidOn1.NotBefore, tcValidOn1.NotAfter))
@@ -228,7 +228,7 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 	// ValidBefore - with a valid value
 	var tcValidOn2 hvs.TagCertificate
 	_ = json.Unmarshal([]byte(tcMap["7ce60664-faa3-4c2e-8c45-41e209e4f1db"]), &tcValidOn2)
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) >= notbefore\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) >= notbefore\) ORDER BY subject asc,id asc`).
 		WithArgs("2016-09-28T09:08:33.913Z").
 		WillReturnRows(sqlmock.NewRows(tcCols).
 			AddRow(tcValidOn2.ID.String(), tcValidOn2.HardwareUUID.String(), string(tcValidOn2.Certificate), tcValidOn2.Subject, tcValidOn2.Issuer, tcValidOn2.NotBefore, tcValidOn2.NotAfter))
@@ -236,7 +236,7 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 	// ValidAfter - with a valid value
 	var tcValidOn3 hvs.TagCertificate
 	_ = json.Unmarshal([]byte(tcMap["7ce60664-faa3-4c2e-8c45-41e209e4f1db"]), &tcValidOn3)
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) <= notafter\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) <= notafter\) ORDER BY subject asc,id asc`).
 		WithArgs("2040-09-28T09:08:33.913Z").
 		WillReturnRows(sqlmock.NewRows(tcCols).
 			AddRow(tcValidOn3.ID.String(), tcValidOn3.HardwareUUID.String(), string(tcValidOn3.Certificate), tcValidOn3.Subject, tcValidOn3.Issuer, tcValidOn3.NotBefore, tcValidOn3.NotAfter))
@@ -245,6 +245,17 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 	return store.TagCertificateStore.Search(criteria)
 }
 
+// Count returns the number of TagCertificates matching the TagCertificateFilterCriteria
+func (store *MockTagCertificateStore) Count(criteria *models.TagCertificateFilterCriteria) (int, error) {
+	if criteria != nil {
+		allCriteria := *criteria
+		allCriteria.PageCriteria = models.PageCriteria{}
+		criteria = &allCriteria
+	}
+	tagCertificates, err := store.Search(criteria)
+	return len(tagCertificates), err
+}
+
 // NewMockTagCertificateStore initializes the mock datastore
 func NewMockTagCertificateStore() *MockTagCertificateStore {
 	datastore, mock := postgres.NewSQLMockDataStore()
@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+)
+
+// MockTrustSummaryStore provides a mocked implementation of interface domain.TrustSummaryStore, it aggregates the
+// hosts, latest reports, host statuses and host flavorgroup links it holds
+type MockTrustSummaryStore struct {
+	Hosts            []hvs.Host
+	Reports          []models.HVSReport
+	HostStatuses     []hvs.HostStatus
+	HostFlavorgroups []hvs.HostFlavorgroup
+}
+
+func addTrustStatus(counts map[string]hvs.TrustCounts, key string, status string) {
+	trustCounts := counts[key]
+	switch status {
+	case "trusted":
+		trustCounts.Trusted++
+	case "untrusted":
+		trustCounts.Untrusted++
+	default:
+		trustCounts.Unknown++
+	}
+	counts[key] = trustCounts
+}
+
+// reportTrustStatus returns the trust status of the host at the given time
+func (store *MockTrustSummaryStore) reportTrustStatus(hostId uuid.UUID, at time.Time) (*models.HVSReport, string) {
+	for i, report := range store.Reports {
+		if report.HostID != hostId {
+			continue
+		}
+		if !report.Expiration.After(at) {
+			return &store.Reports[i], "unknown"
+		}
+		if report.TrustReport.IsTrusted() {
+			return &store.Reports[i], "trusted"
+		}
+		return &store.Reports[i], "untrusted"
+	}
+	return nil, "unknown"
+}
+
+func (store *MockTrustSummaryStore) CountTrustStatus(at time.Time) (*models.TrustStatusCounts, error) {
+	hosts := make(map[string]hvs.TrustCounts)
+	flavorgroups := make(map[string]hvs.TrustCounts)
+	counts := models.TrustStatusCounts{
+		Flavorgroups: make(map[uuid.UUID]hvs.TrustCounts),
+		FlavorParts:  make(map[string]hvs.TrustCounts),
+		Faults:       make(map[string]hvs.TrustCounts),
+	}
+
+	for _, host := range store.Hosts {
+		report, status := store.reportTrustStatus(host.Id, at)
+		addTrustStatus(hosts, "", status)
+		if report == nil {
+			continue
+		}
+
+		markers := make(map[string]string)
+		faultNames := make(map[string]bool)
+		for _, result := range report.TrustReport.Results {
+			for _, marker := range result.Rule.Markers {
+				markerStatus := status
+				if status != "unknown" {
+					markerStatus = "untrusted"
+					if report.TrustReport.IsTrustedForMarker(marker.String()) {
+						markerStatus = "trusted"
+					}
+				}
+				markers[marker.String()] = markerStatus
+			}
+			for _, fault := range result.Faults {
+				faultNames[fault.Name] = true
+			}
+		}
+		for marker, markerStatus := range markers {
+			addTrustStatus(counts.FlavorParts, marker, markerStatus)
+		}
+		for faultName := range faultNames {
+			addTrustStatus(counts.Faults, faultName, status)
+		}
+	}
+	counts.Hosts = hosts[""]
+
+	for _, hostFlavorgroup := range store.HostFlavorgroups {
+		_, status := store.reportTrustStatus(hostFlavorgroup.HostId, at)
+		addTrustStatus(flavorgroups, hostFlavorgroup.FlavorgroupId.String(), status)
+	}
+	for flavorgroupId, trustCounts := range flavorgroups {
+		counts.Flavorgroups[uuid.MustParse(flavorgroupId)] = trustCounts
+	}
+	return &counts, nil
+}
+
+func (store *MockTrustSummaryStore) SearchExpiringReports(fromTime time.Time, toTime time.Time) ([]hvs.ExpiringReport, error) {
+	expiringReports := []hvs.ExpiringReport{}
+	for _, host := range store.Hosts {
+		report, _ := store.reportTrustStatus(host.Id, fromTime)
+		if report == nil || !report.Expiration.After(fromTime) || !report.Expiration.Before(toTime) {
+			continue
+		}
+		expiringReports = append(expiringReports, hvs.ExpiringReport{
+			TrustSummaryHost: hvs.TrustSummaryHost{HostID: host.Id, HostName: host.HostName},
+			ReportID:         report.ID,
+			Trusted:          report.TrustReport.IsTrusted(),
+			Expiration:       report.Expiration,
+		})
+	}
+	sort.Slice(expiringReports, func(i, j int) bool {
+		return expiringReports[i].Expiration.Before(expiringReports[j].Expiration)
+	})
+	return expiringReports, nil
+}
+
+func (store *MockTrustSummaryStore) SearchConnectionFailures() (map[string][]hvs.TrustSummaryHost, error) {
+	connectionFailures := make(map[string][]hvs.TrustSummaryHost)
+	for _, host := range store.Hosts {
+		for _, hostStatus := range store.HostStatuses {
+			hostState := hostStatus.HostStatusInformation.HostState
+			if hostStatus.HostID != host.Id || hostState == hvs.HostStateConnected || hostState == hvs.HostStateQueue {
+				continue
+			}
+			connectionFailures[hostState.String()] = append(connectionFailures[hostState.String()],
+				hvs.TrustSummaryHost{HostID: host.Id, HostName: host.HostName})
+		}
+	}
+	return connectionFailures, nil
+}
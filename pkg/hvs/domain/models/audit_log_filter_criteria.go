@@ -0,0 +1,24 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogEntryFilterCriteria holds the filter criteria for the audit log resource used by the Search AuditLog API.
+// Entries are searched across the rotated audit_log_entry_* partitions and ordered by creation time.
+type AuditLogEntryFilterCriteria struct {
+	EntityID   uuid.UUID
+	EntityType string
+	Action     string
+	FromDate   time.Time
+	ToDate     time.Time
+	Limit      int
+	Offset     int
+}
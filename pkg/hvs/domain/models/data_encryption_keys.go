@@ -0,0 +1,154 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DataEncryptionKeyLength is the length of the data encryption keys, 256 bits
+const DataEncryptionKeyLength = 32
+
+// DataEncryptionKeys holds the data encryption key the credentials are encrypted with, and the previous data
+// encryption keys the credentials stored before a key rotation may still be encrypted with. The keys are identified by
+// a digest of the key, which is stored along with the ciphertexts. The keys are shared by the stores and updated in
+// place when the key is rotated.
+type DataEncryptionKeys struct {
+	lock sync.RWMutex
+	// keyIds lists the current key first, followed by the previous keys from the most recent one
+	keyIds []string
+	keys   map[string][]byte
+}
+
+// NewDataEncryptionKeys returns the data encryption keys with the current key and the previous keys
+func NewDataEncryptionKeys(current []byte, previous ...[]byte) *DataEncryptionKeys {
+	keys := &DataEncryptionKeys{keys: map[string][]byte{}}
+	keys.add(current)
+	for _, key := range previous {
+		keys.add(key)
+	}
+	return keys
+}
+
+// GenerateDataEncryptionKey generates a random data encryption key
+func GenerateDataEncryptionKey() ([]byte, error) {
+	key := make([]byte, DataEncryptionKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "Failed to generate data encryption key")
+	}
+	return key, nil
+}
+
+// GetDataEncryptionKeyId returns the id of a data encryption key, the first 8 bytes of its SHA256 digest hex encoded
+func GetDataEncryptionKeyId(key []byte) string {
+	digest := sha256.Sum256(key)
+	return hex.EncodeToString(digest[:8])
+}
+
+func (k *DataEncryptionKeys) add(key []byte) {
+	if len(key) == 0 {
+		return
+	}
+	keyId := GetDataEncryptionKeyId(key)
+	if _, ok := k.keys[keyId]; ok {
+		return
+	}
+	k.keyIds = append(k.keyIds, keyId)
+	k.keys[keyId] = key
+}
+
+// Current returns the id of the current key and the current key, the credentials are encrypted with
+func (k *DataEncryptionKeys) Current() (string, []byte) {
+	if k == nil {
+		return "", nil
+	}
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	if len(k.keyIds) == 0 {
+		return "", nil
+	}
+	return k.keyIds[0], k.keys[k.keyIds[0]]
+}
+
+// Get returns the key with the key id
+func (k *DataEncryptionKeys) Get(keyId string) ([]byte, bool) {
+	if k == nil {
+		return nil, false
+	}
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	key, ok := k.keys[keyId]
+	return key, ok
+}
+
+// All returns the current key followed by the previous keys
+func (k *DataEncryptionKeys) All() [][]byte {
+	if k == nil {
+		return nil
+	}
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	keys := make([][]byte, 0, len(k.keyIds))
+	for _, keyId := range k.keyIds {
+		keys = append(keys, k.keys[keyId])
+	}
+	return keys
+}
+
+// Previous returns the previous keys, from the most recent one
+func (k *DataEncryptionKeys) Previous() [][]byte {
+	keys := k.All()
+	if len(keys) == 0 {
+		return nil
+	}
+	return keys[1:]
+}
+
+// Rotate makes the key the current key, the current key becomes the most recent previous key
+func (k *DataEncryptionKeys) Rotate(key []byte) {
+	if len(key) == 0 {
+		return
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	keyId := GetDataEncryptionKeyId(key)
+	keyIds := []string{keyId}
+	for _, previousKeyId := range k.keyIds {
+		if previousKeyId != keyId {
+			keyIds = append(keyIds, previousKeyId)
+		}
+	}
+	k.keyIds = keyIds
+	k.keys[keyId] = key
+}
+
+// Retain removes the previous keys whose key id is not listed, the current key is always kept
+func (k *DataEncryptionKeys) Retain(keyIds []string) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if len(k.keyIds) == 0 {
+		return
+	}
+	retained := map[string]bool{}
+	for _, keyId := range keyIds {
+		retained[keyId] = true
+	}
+	remaining := []string{k.keyIds[0]}
+	for _, keyId := range k.keyIds[1:] {
+		if retained[keyId] {
+			remaining = append(remaining, keyId)
+		} else {
+			delete(k.keys, keyId)
+		}
+	}
+	k.keyIds = remaining
+}
@@ -6,6 +6,8 @@ package models
 
 import (
 	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
 	cf "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
@@ -21,6 +23,25 @@ type FlavorCreateRequest struct {
 	SignedFlavorCollection hvs.SignedFlavorCollection `json:"signed_flavor_collection,omitempty"`
 	FlavorgroupNames       []string                   `json:"flavorgroup_names,omitempty"`
 	FlavorParts            []cf.FlavorPart            `json:"partial_flavor_types,omitempty"`
+	// SupersededFlavorIds are the flavors superseded by the created flavors, each of them is superseded by the
+	// created flavor of the same flavor part
+	SupersededFlavorIds []uuid.UUID `json:"superseded_flavor_ids,omitempty"`
+}
+
+// FlavorEvaluateRequest holds the candidate flavors of a dry-run trust evaluation and the hosts to evaluate them
+// against. The candidate flavors are either given as unsigned flavors or created from a flavor template and the
+// last host manifest of the source host.
+type FlavorEvaluateRequest struct {
+	FlavorCollection hvs.FlavorCollection `json:"flavor_collection,omitempty"`
+	FlavorTemplate   *hvs.FlavorTemplate  `json:"flavor_template,omitempty"`
+	SourceHostId     uuid.UUID            `json:"source_host_id,omitempty"`
+	HostSelector     FlavorEvaluateHosts  `json:"host_selector"`
+}
+
+// FlavorEvaluateHosts selects the hosts of a dry-run trust evaluation, hosts matching any of the criteria are selected
+type FlavorEvaluateHosts struct {
+	HostIds          []uuid.UUID `json:"host_ids,omitempty"`
+	FlavorgroupNames []string    `json:"flavorgroup_names,omitempty"`
 }
 
 type FlavorFilterCriteria struct {
@@ -29,6 +50,7 @@ type FlavorFilterCriteria struct {
 	Value         string
 	FlavorgroupID uuid.UUID
 	FlavorParts   []cf.FlavorPart
+	PageCriteria
 }
 
 type FlavorVerificationFC struct {
@@ -42,6 +64,28 @@ type FlavorMetaKv struct {
 	Value interface{}
 }
 
+// ErrFlavorAlreadySuperseded is returned when a flavor that is already superseded is superseded again
+var ErrFlavorAlreadySuperseded = errors.New("flavor is already superseded")
+
+// FlavorSupersession records that a flavor has been superseded by a newer flavor. Both flavors stay active until the
+// superseded flavor retires at the end of the grace period.
+type FlavorSupersession struct {
+	FlavorId     uuid.UUID `json:"flavor_id"`
+	SupersededBy uuid.UUID `json:"superseded_by"`
+	RetireAt     time.Time `json:"retire_at"`
+}
+
+// IsRetired returns true when the grace period of the superseded flavor is over
+func (fs FlavorSupersession) IsRetired(now time.Time) bool {
+	return !now.Before(fs.RetireAt)
+}
+
+type FlavorSupersessionFilterCriteria struct {
+	FlavorIds []uuid.UUID
+	// RetiredBy selects the superseded flavors whose grace period is over at the given time
+	RetiredBy time.Time
+}
+
 func (fcr FlavorCreateRequest) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		ConnectionString       string                     `json:"connection_string,omitempty"`
@@ -49,18 +93,20 @@ func (fcr FlavorCreateRequest) MarshalJSON() ([]byte, error) {
 		SignedFlavorCollection hvs.SignedFlavorCollection `json:"signed_flavor_collection,omitempty"`
 		FlavorgroupNames       []string                   `json:"flavorgroup_names,omitempty"`
 		FlavorParts            []cf.FlavorPart            `json:"partial_flavor_types,omitempty"`
+		SupersededFlavorIds    []uuid.UUID                `json:"superseded_flavor_ids,omitempty"`
 	}{
 		ConnectionString:       fcr.ConnectionString,
 		FlavorCollection:       fcr.FlavorCollection,
 		SignedFlavorCollection: fcr.SignedFlavorCollection,
 		FlavorgroupNames:       fcr.FlavorgroupNames,
 		FlavorParts:            fcr.FlavorParts,
+		SupersededFlavorIds:    fcr.SupersededFlavorIds,
 	})
 }
 
 func (fcr *FlavorCreateRequest) UnmarshalJSON(b []byte) error {
 	//Validate the FlavorCreateRequest keys as here it is overridden with custom UnmarshalJSON decoder.DisallowUnknownFields doesnt work
-	validKeys := map[string]bool{"connection_string": true, "flavor_collection": true, "signed_flavor_collection": true, "flavorgroup_names": true, "partial_flavor_types": true}
+	validKeys := map[string]bool{"connection_string": true, "flavor_collection": true, "signed_flavor_collection": true, "flavorgroup_names": true, "partial_flavor_types": true, "superseded_flavor_ids": true}
 	fcrKeysMap := map[string]interface{}{}
 	if err := json.Unmarshal(b, &fcrKeysMap); err != nil {
 		return err
@@ -77,6 +123,7 @@ func (fcr *FlavorCreateRequest) UnmarshalJSON(b []byte) error {
 		SignedFlavorCollection hvs.SignedFlavorCollection `json:"signed_flavor_collection,omitempty"`
 		FlavorgroupNames       []string                   `json:"flavorgroup_names,omitempty"`
 		FlavorParts            []cf.FlavorPart            `json:"partial_flavor_types,omitempty"`
+		SupersededFlavorIds    []uuid.UUID                `json:"superseded_flavor_ids,omitempty"`
 	})
 	err := json.Unmarshal(b, &decoded)
 	if err == nil {
@@ -85,6 +132,7 @@ func (fcr *FlavorCreateRequest) UnmarshalJSON(b []byte) error {
 		fcr.FlavorCollection = decoded.FlavorCollection
 		fcr.SignedFlavorCollection = decoded.SignedFlavorCollection
 		fcr.FlavorParts = decoded.FlavorParts
+		fcr.SupersededFlavorIds = decoded.SupersededFlavorIds
 	}
 	return err
 }
@@ -18,7 +18,9 @@ type HostFilterCriteria struct {
 	Value          string
 	IdList         []uuid.UUID
 	Trusted        *bool
-	OrderBy        OrderType
+	// Labels filters the hosts having all the labels, along with any other filter
+	Labels map[string]string
+	PageCriteria
 }
 
 type OrderType string
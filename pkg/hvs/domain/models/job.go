@@ -0,0 +1,38 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobState is the state of an asynchronous job
+type JobState string
+
+const (
+	JobStateNew       JobState = "New"
+	JobStatePending   JobState = "Pending"
+	JobStateCompleted JobState = "Completed"
+	// JobStateError is the state of the jobs that were interrupted before they completed, such as by a restart of HVS
+	JobStateError JobState = "Error"
+)
+
+// Finished reports whether the job no longer runs
+func (s JobState) Finished() bool {
+	return s == JobStateCompleted || s == JobStateError
+}
+
+// Job is an asynchronous operation run on a set of hosts, its params hold the progress of the job
+type Job struct {
+	Id      uuid.UUID
+	Action  string
+	State   JobState
+	Message string
+	Params  map[string]interface{}
+	Created time.Time
+	Updated time.Time
+}
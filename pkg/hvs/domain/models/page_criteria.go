@@ -0,0 +1,34 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package models
+
+// PageCriteria holds the paging and sorting parameters shared by the search filter criteria. A zero Limit
+// returns all the matching records, an empty SortBy sorts the records by the default field of the resource.
+type PageCriteria struct {
+	Limit   int
+	Offset  int
+	SortBy  string
+	OrderBy OrderType
+}
+
+// IsPaged returns true when the search is restricted to a page of the matching records
+func (pc PageCriteria) IsPaged() bool {
+	return pc.Limit > 0 || pc.Offset > 0
+}
+
+// sortBy values supported by the paged searches
+const (
+	SortById             = "id"
+	SortByName           = "name"
+	SortByHostName       = "hostName"
+	SortByHostHardwareId = "hostHardwareId"
+	SortByLabel          = "label"
+	SortByCreatedAt      = "createdAt"
+	SortByExpiration     = "expiration"
+	SortBySubject        = "subject"
+	SortByIssuer         = "issuer"
+	SortByNotBefore      = "notBefore"
+	SortByNotAfter       = "notAfter"
+)
@@ -68,6 +68,13 @@ func (s QueueState) Valid() bool {
 	return s >= QueueStateNew && s <= QueueStateError
 }
 
+func (s QueueState) String() string {
+	if !s.Valid() {
+		return "Unknown"
+	}
+	return qstatusToString[s]
+}
+
 // MarshalJSON marshals the enum as a quoted json string
 func (s QueueState) MarshalJSON() ([]byte, error) {
 	buffer := bytes.NewBufferString(`"`)
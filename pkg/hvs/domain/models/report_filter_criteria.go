@@ -20,7 +20,16 @@ type ReportFilterCriteria struct {
 	FromDate       time.Time
 	ToDate         time.Time
 	LatestPerHost  bool
-	Limit          int
+	PageCriteria
+}
+
+// ReportHistoryFilterCriteria selects the reports of a host in the report history created from FromDate (inclusive)
+// to ToDate (exclusive)
+type ReportHistoryFilterCriteria struct {
+	HostID   uuid.UUID
+	FromDate time.Time
+	ToDate   time.Time
+	PageCriteria
 }
 
 type ReportLocator struct {
@@ -21,8 +21,10 @@ type TagCertificateFilterCriteria struct {
 	ValidOn         time.Time `json:"validOn"`
 	ValidBefore     time.Time `json:"validBefore"`
 	ValidAfter      time.Time `json:"validAfter"`
+	ExpiresBefore   time.Time `json:"expiresBefore"`
 	// swagger:strfmt uuid
 	HardwareUUID uuid.UUID `json:"hardwareUuid"`
+	PageCriteria `json:"-"`
 }
 
 // TagCertificateCreateCriteria holds the data used to create a TagCertificate
@@ -34,6 +36,25 @@ type TagCertificateCreateCriteria struct {
 	SelectionContent []asset_tag.TagKvAttribute `json:"selection_content,omitempty"`
 }
 
+// TagCertificateBulkCreateCriteria holds the data used to create and deploy a TagCertificate onto every host
+// matching the host filter
+type TagCertificateBulkCreateCriteria struct {
+	// SelectionContent is an array of one or more key-value pairs with the tag selection attributes of the hosts.
+	SelectionContent []asset_tag.TagKvAttribute `json:"selection_content"`
+	// HostFilter selects the hosts provisioned with a TagCertificate
+	HostFilter TagCertificateHostFilter `json:"host_filter"`
+}
+
+// TagCertificateHostFilter selects the hosts of a bulk TagCertificate provisioning, exactly one of its fields
+// must be specified
+type TagCertificateHostFilter struct {
+	HostNames       []string          `json:"host_names,omitempty"`
+	FlavorgroupName string            `json:"flavorgroup_name,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	// swagger:strfmt uuid
+	HardwareUUIDs []uuid.UUID `json:"hardware_uuids,omitempty"`
+}
+
 // TagCertificateDeployCriteria holds the data used to deploy a TagCertificate onto a host
 type TagCertificateDeployCriteria struct {
 	// swagger:strfmt uuid
@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TagCertificateRenewalRenew is the audit log action of the issuance of the TagCertificate renewing an
+	// expiring TagCertificate
+	TagCertificateRenewalRenew = "renew"
+	// TagCertificateRenewalDeploy is the audit log action of the deployment of the renewed TagCertificate to the host
+	// along with the creation of its ASSET_TAG flavor
+	TagCertificateRenewalDeploy = "deploy"
+)
+
+// TagCertificateRenewal records a step of the renewal of a TagCertificate that expires soon in the audit log
+type TagCertificateRenewal struct {
+	// TagCertificateID is the id of the expiring TagCertificate
+	TagCertificateID uuid.UUID
+	HardwareUUID     uuid.UUID
+	// RenewedBy is the id of the TagCertificate issued with the same tag attributes to replace it
+	RenewedBy uuid.UUID
+	// NotAfter is the expiry date of the TagCertificate renewing the expiring TagCertificate
+	NotAfter time.Time
+	// FlavorID is the id of the ASSET_TAG flavor created once the renewed TagCertificate is deployed
+	FlavorID uuid.UUID
+	// Error holds the cause of the failure of the step, empty when the step succeeded
+	Error string
+}
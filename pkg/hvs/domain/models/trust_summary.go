@@ -0,0 +1,20 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+)
+
+// TrustStatusCounts holds the number of trusted, untrusted and unknown hosts, computed from the latest report of each
+// host. The counts of a flavor part or a fault only include the hosts whose report holds the flavor part or the fault.
+type TrustStatusCounts struct {
+	Hosts        hvs.TrustCounts
+	Flavorgroups map[uuid.UUID]hvs.TrustCounts
+	FlavorParts  map[string]hvs.TrustCounts
+	Faults       map[string]hvs.TrustCounts
+}
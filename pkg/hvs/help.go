@@ -37,10 +37,12 @@ Available Tasks for setup:
 	create-default-flavorgroup      Create default flavor groups in database
 	create-default-flavor-template  Create default flavor templates in database
 	create-dek                      Create data encryption key for HVS
+	rotate-dek                      Rotate data encryption key and re-encrypt the stored credentials (not run by 'all')
 	download-ca-cert                Download CMS root CA certificate
 	download-cert-tls               Download CA certificate from CMS for tls
 	download-cert-saml              Download CA certificate from CMS for saml
 	download-cert-flavor-signing    Download CA certificate from CMS for flavor signing
+	resign-flavors                  Re-sign the flavors with the current flavor signing key (not run by 'all')
 	create-endorsement-ca           Generate self-signed endorsement certificate
 	create-privacy-ca               Generate self-signed privacy certificate
 	create-tag-ca                   Generate self-signed tag certificate
@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// metrics package holds the metrics of the HVS verification pipeline exported on the /metrics endpoint
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+
+	CacheQuote       = "quote"
+	CacheFlavorgroup = "flavorgroup"
+
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+var (
+	// QueueDepth is the number of entries in the host verification queue by state, it is read from the database
+	// when the metrics are retrieved
+	QueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hvs_queue_depth",
+		Help: "Number of entries in the host verification queue by state.",
+	}, []string{"state"})
+
+	// HostFetchDuration is the time taken to retrieve the manifest of a host through its host connector
+	HostFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "hvs_host_fetch_duration_seconds",
+		Help: "Time taken to retrieve the host manifest from a host.",
+	}, []string{"result"})
+
+	// FlavorVerifyDuration is the time taken to verify the manifest of a host against its flavors
+	FlavorVerifyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "hvs_flavor_verify_duration_seconds",
+		Help: "Time taken to verify a host manifest against the flavors of the host.",
+	}, []string{"result"})
+
+	// HostConnectionFailures counts the failed connections to hosts by the resulting host state
+	HostConnectionFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hvs_host_connection_failures_total",
+		Help: "Number of failed connections to hosts by host state.",
+	}, []string{"host_state"})
+
+	// TrustCacheLookups counts the hits and misses of the quote and flavorgroup trust caches
+	TrustCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hvs_trust_cache_lookups_total",
+		Help: "Number of trust cache lookups by cache and result.",
+	}, []string{"cache", "result"})
+
+	// TrustCacheHitRatio is the ratio of the lookups of the quote and flavorgroup trust caches that were hits
+	TrustCacheHitRatio = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hvs_trust_cache_hit_ratio",
+		Help: "Ratio of trust cache lookups that were hits.",
+	}, []string{"cache"})
+
+	// HRRSRefreshRuns counts the runs of the host report refresher
+	HRRSRefreshRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hvs_hrrs_refresh_runs_total",
+		Help: "Number of runs of the host report refresher by result.",
+	}, []string{"result"})
+
+	// HRRSRefreshedHosts counts the hosts whose reports were queued for refresh by the host report refresher
+	HRRSRefreshedHosts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hvs_hrrs_refreshed_hosts_total",
+		Help: "Number of hosts queued for report refresh by the host report refresher.",
+	})
+
+	// SamlSigningDuration is the time taken to generate and sign a SAML report
+	SamlSigningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hvs_saml_signing_duration_seconds",
+		Help:    "Time taken to generate and sign a SAML report.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"result"})
+
+	// trustCacheCounts holds the hits and lookups of each trust cache the hit ratio is computed from
+	trustCacheCounts = struct {
+		sync.Mutex
+		hits    map[string]float64
+		lookups map[string]float64
+	}{hits: map[string]float64{}, lookups: map[string]float64{}}
+)
+
+func init() {
+	prometheus.MustRegister(QueueDepth, HostFetchDuration, FlavorVerifyDuration, HostConnectionFailures,
+		TrustCacheLookups, TrustCacheHitRatio, HRRSRefreshRuns, HRRSRefreshedHosts, SamlSigningDuration)
+}
+
+// Result returns the result label of an operation that ended with err
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
+
+// ObserveSince adds the time elapsed since start to the histogram of the result of an operation that ended with err
+func ObserveSince(histogram *prometheus.HistogramVec, start time.Time, err error) {
+	histogram.WithLabelValues(Result(err)).Observe(time.Since(start).Seconds())
+}
+
+// TrustCacheLookup counts a lookup of a trust cache and updates the hit ratio of the cache
+func TrustCacheLookup(cache string, hit bool) {
+	trustCacheCounts.Lock()
+	defer trustCacheCounts.Unlock()
+
+	trustCacheCounts.lookups[cache]++
+	if hit {
+		trustCacheCounts.hits[cache]++
+		TrustCacheLookups.WithLabelValues(cache, cacheHit).Inc()
+	} else {
+		TrustCacheLookups.WithLabelValues(cache, cacheMiss).Inc()
+	}
+	TrustCacheHitRatio.WithLabelValues(cache).Set(trustCacheCounts.hits[cache] / trustCacheCounts.lookups[cache])
+}
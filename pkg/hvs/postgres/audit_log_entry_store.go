@@ -142,3 +142,56 @@ func (as *auditLogEntryStore) FindBetweenTime(from, to time.Time) ([]models.Audi
 	}
 	return ret, nil
 }
+
+// Search returns the audit log entries matching the given criteria. The audit_log_entry table is the parent of the
+// rotated audit_log_entry_* partitions, so querying it pages across all partitions that have not been dropped yet.
+func (as *auditLogEntryStore) Search(criteria *models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error) {
+	defaultLog.Trace("postgres/audit_log_entry_store_store:Search() Entering")
+	defer defaultLog.Trace("postgres/audit_log_entry_store_store:Search() Leaving")
+
+	if criteria == nil {
+		return nil, errors.New("invalid filter criteria for audit_log_entry_store_store:Search()")
+	}
+
+	tx := as.store.Db.Model(&auditLogEntry{})
+	if criteria.EntityID != uuid.Nil {
+		tx = tx.Where("entity_id = ?", criteria.EntityID)
+	}
+	if criteria.EntityType != "" {
+		tx = tx.Where("entity_type = ?", criteria.EntityType)
+	}
+	if criteria.Action != "" {
+		tx = tx.Where("action = ?", criteria.Action)
+	}
+	if !criteria.FromDate.IsZero() {
+		tx = tx.Where("CAST(created AS TIMESTAMP) >= CAST(? AS TIMESTAMP)", criteria.FromDate)
+	}
+	if !criteria.ToDate.IsZero() {
+		tx = tx.Where("CAST(created AS TIMESTAMP) < CAST(? AS TIMESTAMP)", criteria.ToDate)
+	}
+	// order by id as well so that entries created at the same instant are paged deterministically
+	tx = tx.Order("created ASC").Order("id ASC")
+	if criteria.Offset > 0 {
+		tx = tx.Offset(criteria.Offset)
+	}
+	if criteria.Limit > 0 {
+		tx = tx.Limit(criteria.Limit)
+	}
+
+	var matchEntries []auditLogEntry
+	if err := tx.Find(&matchEntries).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to search audit log entries in db")
+	}
+	ret := make([]models.AuditLogEntry, 0, len(matchEntries))
+	for _, e := range matchEntries {
+		ret = append(ret, models.AuditLogEntry{
+			ID:         e.ID,
+			EntityID:   e.EntityID,
+			EntityType: e.EntityType,
+			CreatedAt:  e.CreatedAt,
+			Action:     e.Action,
+			Data:       models.AuditTableData(e.Data),
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// DataEncryptionKeyStore re-encrypts the host credentials and the ESXi cluster connection strings, which are
+// encrypted with the data encryption key
+type DataEncryptionKeyStore struct {
+	Store *DataStore
+}
+
+func NewDataEncryptionKeyStore(store *DataStore) *DataEncryptionKeyStore {
+	return &DataEncryptionKeyStore{store}
+}
+
+// encryptedRecord is a ciphertext of a table and the id of the key it is encrypted with
+type encryptedRecord struct {
+	id         uuid.UUID
+	cipherText string
+	keyId      string
+}
+
+// ReEncrypt re-encrypts with the current data encryption key all the host credentials and ESXi cluster connection
+// strings encrypted with another key, in a single transaction. The tables are locked against concurrent updates
+// until the transaction is committed.
+func (dks *DataEncryptionKeyStore) ReEncrypt(keys *models.DataEncryptionKeys) (*hvs.DataEncryptionKeyRotation, error) {
+	defaultLog.Trace("postgres/data_encryption_key_store:ReEncrypt() Entering")
+	defer defaultLog.Trace("postgres/data_encryption_key_store:ReEncrypt() Leaving")
+
+	currentKeyId, _ := keys.Current()
+	if currentKeyId == "" {
+		return nil, errors.New("postgres/data_encryption_key_store:ReEncrypt() Data encryption key is not defined")
+	}
+	rotation := hvs.DataEncryptionKeyRotation{KeyId: currentKeyId}
+	err := dks.Store.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE host_credential, esxi_cluster IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return errors.Wrap(err, "failed to lock the encrypted tables")
+		}
+
+		var err error
+		rotation.HostCredentials, err = reEncryptColumn(tx.Model(&hostCredential{}), "credential", keys)
+		if err != nil {
+			return errors.Wrap(err, "failed to re-encrypt host credentials")
+		}
+		rotation.ESXiClusters, err = reEncryptColumn(tx.Model(&esxiCluster{}), "connection_string", keys)
+		if err != nil {
+			return errors.Wrap(err, "failed to re-encrypt ESXi cluster connection strings")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/data_encryption_key_store:ReEncrypt() Failed to re-encrypt with the data encryption key")
+	}
+	return &rotation, nil
+}
+
+// reEncryptColumn re-encrypts with the current key the column of the records of the table encrypted with another key,
+// and returns the number of records re-encrypted
+func reEncryptColumn(table *gorm.DB, column string, keys *models.DataEncryptionKeys) (int, error) {
+	currentKeyId, _ := keys.Current()
+	rows, err := table.Select("id, "+column+", key_id").Where("key_id <> ?", currentKeyId).Rows()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to retrieve records from db")
+	}
+	// the records are all read before updating them, as the rows hold the connection of the transaction
+	var records []encryptedRecord
+	for rows.Next() {
+		var record encryptedRecord
+		if err := rows.Scan(&record.id, &record.cipherText, &record.keyId); err != nil {
+			_ = rows.Close()
+			return 0, errors.Wrap(err, "failed to scan record")
+		}
+		records = append(records, record)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, errors.Wrap(err, "failed to close rows")
+	}
+
+	for _, record := range records {
+		plainText, err := utils.DecryptWithKeyId(record.cipherText, record.keyId, keys)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to decrypt record %s", record.id)
+		}
+		cipherText, keyId, err := utils.EncryptWithCurrentKey(plainText, keys)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to encrypt record %s", record.id)
+		}
+		err = table.Where("id = ?", record.id).UpdateColumns(map[string]interface{}{column: cipherText, "key_id": keyId}).Error
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to update record %s", record.id)
+		}
+	}
+	return len(records), nil
+}
+
+// RetrieveKeyIds returns the ids of the data encryption keys the host credentials and ESXi cluster connection strings
+// are encrypted with
+func (dks *DataEncryptionKeyStore) RetrieveKeyIds() ([]string, error) {
+	defaultLog.Trace("postgres/data_encryption_key_store:RetrieveKeyIds() Entering")
+	defer defaultLog.Trace("postgres/data_encryption_key_store:RetrieveKeyIds() Leaving")
+
+	rows, err := dks.Store.Db.Raw("SELECT key_id FROM host_credential UNION SELECT key_id FROM esxi_cluster").Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/data_encryption_key_store:RetrieveKeyIds() Failed to retrieve key ids from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	var keyIds []string
+	for rows.Next() {
+		var keyId string
+		if err := rows.Scan(&keyId); err != nil {
+			return nil, errors.Wrap(err, "postgres/data_encryption_key_store:RetrieveKeyIds() Failed to scan record")
+		}
+		keyIds = append(keyIds, keyId)
+	}
+	return keyIds, nil
+}
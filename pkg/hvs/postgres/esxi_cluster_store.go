@@ -17,12 +17,12 @@ import (
 )
 
 type ESXiClusterStore struct {
-	Store *DataStore
-	Dek   []byte
+	Store              *DataStore
+	DataEncryptionKeys *models.DataEncryptionKeys
 }
 
-func NewESXiCLusterStore(store *DataStore, dek []byte) *ESXiClusterStore {
-	return &ESXiClusterStore{store, dek}
+func NewESXiCLusterStore(store *DataStore, keys *models.DataEncryptionKeys) *ESXiClusterStore {
+	return &ESXiClusterStore{store, keys}
 }
 
 func (e *ESXiClusterStore) Create(esxiCLuster *hvs.ESXiCluster) (*hvs.ESXiCluster, error) {
@@ -37,7 +37,7 @@ func (e *ESXiClusterStore) Create(esxiCLuster *hvs.ESXiCluster) (*hvs.ESXiCluste
 		esxiCLuster.Id = newUuid
 	}
 
-	encCS, err := utils.EncryptString(esxiCLuster.ConnectionString, e.Dek)
+	encCS, keyId, err := utils.EncryptWithCurrentKey(esxiCLuster.ConnectionString, e.DataEncryptionKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/esxi_cluster_store:Create() Failed to encrypt ESXi cluster "+
 			"connection string")
@@ -46,6 +46,7 @@ func (e *ESXiClusterStore) Create(esxiCLuster *hvs.ESXiCluster) (*hvs.ESXiCluste
 		Id:               esxiCLuster.Id,
 		ConnectionString: encCS,
 		ClusterName:      esxiCLuster.ClusterName,
+		KeyId:            keyId,
 	}
 
 	if err := e.Store.Db.Create(&dbESXiCluster).Error; err != nil {
@@ -60,14 +61,15 @@ func (e *ESXiClusterStore) Retrieve(id uuid.UUID) (*hvs.ESXiCluster, error) {
 	defer defaultLog.Trace("postgres/esxi_cluster_store:Retrieve() Leaving")
 
 	cluster := hvs.ESXiCluster{}
+	var keyId string
 
 	row := e.Store.Db.Model(&esxiCluster{}).Where(&esxiCluster{Id: id}).Row()
-	err := row.Scan(&cluster.Id, &cluster.ConnectionString, &cluster.ClusterName)
+	err := row.Scan(&cluster.Id, &cluster.ConnectionString, &cluster.ClusterName, &keyId)
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/esxi_cluster_store:Retrieve() Failed to scan record")
 	}
 
-	decryptedCS, err := utils.DecryptString(cluster.ConnectionString, e.Dek)
+	decryptedCS, err := utils.DecryptWithKeyId(cluster.ConnectionString, keyId, e.DataEncryptionKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/esxi_cluster_store:Retrieve() Failed to decrypt connection string")
 	}
@@ -100,10 +102,11 @@ func (e *ESXiClusterStore) Search(ecFilter *models.ESXiClusterFilterCriteria) ([
 	clusters := []hvs.ESXiCluster{}
 	for rows.Next() {
 		cluster := hvs.ESXiCluster{}
-		if err := rows.Scan(&cluster.Id, &cluster.ConnectionString, &cluster.ClusterName); err != nil {
+		var keyId string
+		if err := rows.Scan(&cluster.Id, &cluster.ConnectionString, &cluster.ClusterName, &keyId); err != nil {
 			return nil, errors.Wrap(err, "postgres/esxi_cluster_store:Search() Failed to scan record")
 		}
-		decryptedCS, err := utils.DecryptString(cluster.ConnectionString, e.Dek)
+		decryptedCS, err := utils.DecryptWithKeyId(cluster.ConnectionString, keyId, e.DataEncryptionKeys)
 		if err != nil {
 			return nil, errors.Wrap(err, "postgres/esxi_cluster_store:Search() Failed to decrypt connection string")
 		}
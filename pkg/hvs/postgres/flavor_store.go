@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
 	fc "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
 	flavormodel "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
@@ -34,62 +35,80 @@ func (f *FlavorStore) Create(signedFlavor *hvs.SignedFlavor) (*hvs.SignedFlavor,
 		return nil, errors.New("postgres/flavor_store:Create()- invalid input : must have content, signature and the label for the flavor")
 	}
 
+	dbf, err := newDbFlavor(signedFlavor)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Create() failed to create new UUID")
+	}
+
+	if err := f.Store.Db.Create(dbf).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Create() failed to create flavor")
+	}
+	return signedFlavor, nil
+}
+
+// replace a flavor, the flavor is deleted and the flavor replacing it is created in a single transaction so that the
+// flavor is kept when the flavor replacing it cannot be created
+func (f *FlavorStore) Replace(flavorId uuid.UUID, signedFlavor *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	defaultLog.Trace("postgres/flavor_store:Replace() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:Replace() Leaving")
+	if signedFlavor == nil || signedFlavor.Signature == "" || signedFlavor.Flavor.Meta.Description[flavormodel.Label].(string) == "" {
+		return nil, errors.New("postgres/flavor_store:Replace()- invalid input : must have content, signature and the label for the flavor")
+	}
+
+	dbf, err := newDbFlavor(signedFlavor)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Replace() failed to create new UUID")
+	}
+
+	err = f.Store.Db.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Where(&flavor{ID: flavorId}).Delete(&flavor{ID: flavorId})
+		if deleted.Error != nil {
+			return errors.Wrap(deleted.Error, "failed to delete replaced flavor")
+		}
+		if deleted.RowsAffected == 0 {
+			return errors.New(commErr.RowsNotFound)
+		}
+		if err := tx.Create(dbf).Error; err != nil {
+			return errors.Wrap(err, "failed to create flavor")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Replace() failed to replace flavor")
+	}
+	return signedFlavor, nil
+}
+
+// newDbFlavor returns the record of a signed flavor, a new ID is assigned to the flavor when it has none
+func newDbFlavor(signedFlavor *hvs.SignedFlavor) (*flavor, error) {
 	if signedFlavor.Flavor.Meta.ID == uuid.Nil {
 		newUuid, err := uuid.NewRandom()
 		if err != nil {
-			return nil, errors.Wrap(err, "postgres/flavor_store:Create() failed to create new UUID")
+			return nil, err
 		}
 		signedFlavor.Flavor.Meta.ID = newUuid
 	}
 
-	dbf := flavor{
+	return &flavor{
 		ID:         signedFlavor.Flavor.Meta.ID,
 		Content:    PGFlavorContent(signedFlavor.Flavor),
 		CreatedAt:  time.Now(),
 		Label:      signedFlavor.Flavor.Meta.Description[flavormodel.Label].(string),
 		FlavorPart: signedFlavor.Flavor.Meta.Description[flavormodel.FlavorPart].(string),
 		Signature:  signedFlavor.Signature,
-	}
-
-	if err := f.Store.Db.Create(&dbf).Error; err != nil {
-		return nil, errors.Wrap(err, "postgres/flavor_store:Create() failed to create flavor")
-	}
-	return signedFlavor, nil
+	}, nil
 }
 
 func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.SignedFlavor, error) {
 	defaultLog.Trace("postgres/flavor_store:Search() Entering")
 	defer defaultLog.Trace("postgres/flavor_store:Search() Leaving")
 
-	var tx *gorm.DB
-	var err error
-
-	tx = f.Store.Db.Table("flavor f").Select("f.id, f.content, f.signature")
-	// build partial query with all the given flavor Id's
-	if len(flavorFilter.FlavorFC.Ids) > 0 {
-		var flavorIds []string
-		for _, fId := range flavorFilter.FlavorFC.Ids {
-			flavorIds = append(flavorIds, fId.String())
-		}
-		tx = tx.Where("f.id IN (?)", flavorFilter.FlavorFC.Ids)
-	}
-	// build partial query with the given key-value pair from falvor description
-	if flavorFilter.FlavorFC.Key != "" && flavorFilter.FlavorFC.Value != "" {
-		tx = tx.Where(convertToPgJsonqueryString("f.content", "meta.description."+flavorFilter.FlavorFC.Key)+" = ?", flavorFilter.FlavorFC.Value)
-	}
-	if flavorFilter.FlavorFC.FlavorgroupID.String() != "" ||
-		len(flavorFilter.FlavorFC.FlavorParts) >= 1 || len(flavorFilter.FlavorPartsWithLatest) >= 1 || flavorFilter.FlavorMeta != nil || len(flavorFilter.FlavorMeta) >= 1 {
-		if len(flavorFilter.FlavorFC.FlavorParts) >= 1 {
-			flavorFilter.FlavorPartsWithLatest = getFlavorPartsWithLatestMap(flavorFilter.FlavorFC.FlavorParts, flavorFilter.FlavorPartsWithLatest)
-		}
-		// add all flavor parts in list of flavor Parts
-		tx = f.buildMultipleFlavorPartQueryString(tx, flavorFilter.FlavorFC.FlavorgroupID, flavorFilter.FlavorMeta, flavorFilter.FlavorPartsWithLatest)
-	}
-
+	tx := f.buildFlavorSearchQuery(flavorFilter)
 	if tx == nil {
 		return nil, errors.New("postgres/flavor_store:Search() Unexpected Error. Could not build gorm query" +
 			" object in flavor Search function")
 	}
+	tx = applyPageCriteria(tx, flavorFilter.FlavorFC.PageCriteria, flavorSortColumns, models.SortByCreatedAt)
 
 	rows, err := tx.Rows()
 	if err != nil {
@@ -114,6 +133,55 @@ func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.S
 	return signedFlavors, nil
 }
 
+// Count returns the number of flavors matching the filter criteria, ignoring its page criteria
+func (f *FlavorStore) Count(flavorFilter *models.FlavorVerificationFC) (int, error) {
+	defaultLog.Trace("postgres/flavor_store:Count() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:Count() Leaving")
+
+	tx := f.buildFlavorSearchQuery(flavorFilter)
+	if tx == nil {
+		return 0, errors.New("postgres/flavor_store:Count() Unexpected Error. Could not build gorm query" +
+			" object in flavor Count function")
+	}
+	return countRows(f.Store.Db, tx)
+}
+
+// flavorSortColumns maps the sortBy values of a flavor search to the flavor table columns
+var flavorSortColumns = map[string]string{
+	models.SortById:        "f.id",
+	models.SortByLabel:     "f.label",
+	models.SortByCreatedAt: "f.created_at",
+}
+
+// buildFlavorSearchQuery is a helper function to build the query object for a flavor search
+func (f *FlavorStore) buildFlavorSearchQuery(flavorFilter *models.FlavorVerificationFC) *gorm.DB {
+	defaultLog.Trace("postgres/flavor_store:buildFlavorSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:buildFlavorSearchQuery() Leaving")
+
+	tx := f.Store.Db.Table("flavor f").Select("f.id, f.content, f.signature")
+	// build partial query with all the given flavor Id's
+	if len(flavorFilter.FlavorFC.Ids) > 0 {
+		var flavorIds []string
+		for _, fId := range flavorFilter.FlavorFC.Ids {
+			flavorIds = append(flavorIds, fId.String())
+		}
+		tx = tx.Where("f.id IN (?)", flavorFilter.FlavorFC.Ids)
+	}
+	// build partial query with the given key-value pair from falvor description
+	if flavorFilter.FlavorFC.Key != "" && flavorFilter.FlavorFC.Value != "" {
+		tx = tx.Where(convertToPgJsonqueryString("f.content", "meta.description."+flavorFilter.FlavorFC.Key)+" = ?", flavorFilter.FlavorFC.Value)
+	}
+	if flavorFilter.FlavorFC.FlavorgroupID.String() != "" ||
+		len(flavorFilter.FlavorFC.FlavorParts) >= 1 || len(flavorFilter.FlavorPartsWithLatest) >= 1 || flavorFilter.FlavorMeta != nil || len(flavorFilter.FlavorMeta) >= 1 {
+		if len(flavorFilter.FlavorFC.FlavorParts) >= 1 {
+			flavorFilter.FlavorPartsWithLatest = getFlavorPartsWithLatestMap(flavorFilter.FlavorFC.FlavorParts, flavorFilter.FlavorPartsWithLatest)
+		}
+		// add all flavor parts in list of flavor Parts
+		tx = f.buildMultipleFlavorPartQueryString(tx, flavorFilter.FlavorFC.FlavorgroupID, flavorFilter.FlavorMeta, flavorFilter.FlavorPartsWithLatest)
+	}
+	return tx
+}
+
 func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.UUID, flavorMetaInfo map[fc.FlavorPart][]models.FlavorMetaKv, flavorPartsWithLatest map[fc.FlavorPart]bool) *gorm.DB {
 	defaultLog.Trace("postgres/flavor_store:buildMultipleFlavorPartQueryString() Entering")
 	defer defaultLog.Trace("postgres/flavor_store:buildMultipleFlavorPartQueryString() Leaving")
@@ -123,6 +191,7 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 	var aTagQuery *gorm.DB
 	var softwareQuery *gorm.DB
 	var hostUniqueQuery *gorm.DB
+	now := time.Now()
 
 	if flavorPartsWithLatest != nil && len(flavorPartsWithLatest) >= 1 {
 		for flavorPart := range flavorPartsWithLatest {
@@ -135,9 +204,10 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, pfQueryAttribute := range pfQueryAttributes {
 					biosQuery = biosQuery.Where(convertToPgJsonqueryString("f.content", pfQueryAttribute.Key)+" = ?", pfQueryAttribute.Value)
 				}
+				biosQuery = excludeRetiredFlavors(biosQuery, now)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartPlatform] {
-					biosQuery = biosQuery.Order("f.created_at desc").Limit(1)
+					biosQuery = f.buildLatestFlavorQuery(biosQuery, now)
 				}
 
 			case fc.FlavorPartOs:
@@ -148,9 +218,10 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, osfQueryAttribute := range osfQueryAttributes {
 					osQuery = osQuery.Where(convertToPgJsonqueryString("f.content", osfQueryAttribute.Key)+" = ?", osfQueryAttribute.Value)
 				}
+				osQuery = excludeRetiredFlavors(osQuery, now)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartOs] {
-					osQuery = osQuery.Order("f.created_at desc").Limit(1)
+					osQuery = f.buildLatestFlavorQuery(osQuery, now)
 				}
 
 			case fc.FlavorPartHostUnique:
@@ -163,9 +234,10 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, hufQueryAttribute := range hufQueryAttributes {
 					hostUniqueQuery = hostUniqueQuery.Where(convertToPgJsonqueryString("f.content", hufQueryAttribute.Key)+" = ?", hufQueryAttribute.Value)
 				}
+				hostUniqueQuery = excludeRetiredFlavors(hostUniqueQuery, now)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartHostUnique] {
-					hostUniqueQuery = hostUniqueQuery.Order("f.created_at desc").Limit(1)
+					hostUniqueQuery = f.buildLatestFlavorQuery(hostUniqueQuery, now)
 				}
 
 			case fc.FlavorPartSoftware:
@@ -176,9 +248,10 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, sfQueryAttribute := range sfQueryAttributes {
 					softwareQuery = softwareQuery.Where("f.label IN (?)", sfQueryAttribute.Value.([]string))
 				}
+				softwareQuery = excludeRetiredFlavors(softwareQuery, now)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartSoftware] {
-					softwareQuery = softwareQuery.Order("f.created_at desc").Limit(1)
+					softwareQuery = f.buildLatestFlavorQuery(softwareQuery, now)
 				}
 
 			case fc.FlavorPartAssetTag:
@@ -190,9 +263,10 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, atfQueryAttribute := range atfQueryAttributes {
 					aTagQuery = aTagQuery.Where(convertToPgJsonqueryString("f.content", atfQueryAttribute.Key)+" = ?", atfQueryAttribute.Value)
 				}
+				aTagQuery = excludeRetiredFlavors(aTagQuery, now)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartAssetTag] {
-					aTagQuery = aTagQuery.Order("f.created_at desc").Limit(1)
+					aTagQuery = f.buildLatestFlavorQuery(aTagQuery, now)
 				}
 
 			default:
@@ -254,6 +328,21 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 	return tx
 }
 
+// excludeRetiredFlavors removes the superseded flavors whose grace period is over from the query, they are deleted
+// by the flavor retirement service
+func excludeRetiredFlavors(tx *gorm.DB, now time.Time) *gorm.DB {
+	return tx.Where("(f.superseded_by IS NULL OR f.retire_at > ?)", now)
+}
+
+// buildLatestFlavorQuery selects the latest flavor that is not superseded, along with the superseded flavors that are
+// still in their grace period. This keeps the hosts that match a superseded flavor trusted while the flavor superseding
+// it is being rolled out.
+func (f *FlavorStore) buildLatestFlavorQuery(tx *gorm.DB, now time.Time) *gorm.DB {
+	latestSubQuery := tx.Where("f.superseded_by IS NULL").Order("f.created_at desc").Limit(1).SubQuery()
+	supersededSubQuery := tx.Where("f.superseded_by IS NOT NULL AND f.retire_at > ?", now).SubQuery()
+	return f.Store.Db.Table("flavor f").Select("f.id").Where("f.id IN ?", latestSubQuery).Or("f.id IN ?", supersededSubQuery)
+}
+
 func convertToPgJsonqueryString(queryHead string, jsonKeyPath string) string {
 	jsonQueryStr := queryHead
 	flavorMetaPath := strings.Split(jsonKeyPath, ".")
@@ -340,3 +429,131 @@ func (f *FlavorStore) Delete(flavorId uuid.UUID) error {
 	}
 	return nil
 }
+
+// update the signature of a flavor, used when the flavors are re-signed with a new flavor signing key
+func (f *FlavorStore) UpdateSignature(flavorId uuid.UUID, signature string) error {
+	defaultLog.Trace("postgres/flavor_store:UpdateSignature() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:UpdateSignature() Leaving")
+
+	if flavorId == uuid.Nil || signature == "" {
+		return errors.New("postgres/flavor_store:UpdateSignature()- invalid input : must have the flavor and its signature")
+	}
+
+	tx := f.Store.Db.Model(&flavor{}).Where("id = ?", flavorId).Update("signature", signature)
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "postgres/flavor_store:UpdateSignature() failed to update flavor signature")
+	}
+	if tx.RowsAffected == 0 {
+		return errors.Errorf("postgres/flavor_store:UpdateSignature() flavor %s does not exist", flavorId)
+	}
+	return nil
+}
+
+// create a flavor superseding another flavor, the flavor is created and the other flavor is superseded in a single
+// transaction so that the flavor is not created when the other flavor cannot be superseded
+func (f *FlavorStore) CreateSuperseding(signedFlavor *hvs.SignedFlavor, supersession *models.FlavorSupersession) (*hvs.SignedFlavor, error) {
+	defaultLog.Trace("postgres/flavor_store:CreateSuperseding() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:CreateSuperseding() Leaving")
+	if signedFlavor == nil || signedFlavor.Signature == "" || signedFlavor.Flavor.Meta.Description[flavormodel.Label].(string) == "" {
+		return nil, errors.New("postgres/flavor_store:CreateSuperseding()- invalid input : must have content, signature and the label for the flavor")
+	}
+	if supersession == nil {
+		return nil, errors.New("postgres/flavor_store:CreateSuperseding()- invalid input : must have the superseded flavor")
+	}
+
+	dbf, err := newDbFlavor(signedFlavor)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:CreateSuperseding() failed to create new UUID")
+	}
+	superseding := *supersession
+	superseding.SupersededBy = signedFlavor.Flavor.Meta.ID
+
+	err = f.Store.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbf).Error; err != nil {
+			return errors.Wrap(err, "failed to create flavor")
+		}
+		return supersedeFlavor(tx, &superseding)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:CreateSuperseding() failed to create superseding flavor")
+	}
+	return signedFlavor, nil
+}
+
+// supersede flavor
+func (f *FlavorStore) Supersede(supersession *models.FlavorSupersession) error {
+	defaultLog.Trace("postgres/flavor_store:Supersede() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:Supersede() Leaving")
+
+	if err := supersedeFlavor(f.Store.Db, supersession); err != nil {
+		return errors.Wrap(err, "postgres/flavor_store:Supersede() failed to supersede flavor")
+	}
+	return nil
+}
+
+// supersedeFlavor records the supersession of a flavor that is not already superseded
+func supersedeFlavor(db *gorm.DB, supersession *models.FlavorSupersession) error {
+	if supersession == nil || supersession.FlavorId == uuid.Nil || supersession.SupersededBy == uuid.Nil {
+		return errors.New("invalid input : must have the flavor and the flavor superseding it")
+	}
+	if supersession.FlavorId == supersession.SupersededBy {
+		return errors.New("invalid input : a flavor cannot supersede itself")
+	}
+
+	tx := db.Model(&flavor{}).Where("id = ? AND superseded_by IS NULL", supersession.FlavorId).
+		Updates(map[string]interface{}{
+			"superseded_by": supersession.SupersededBy,
+			"retire_at":     supersession.RetireAt,
+		})
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "failed to update superseded flavor")
+	}
+	if tx.RowsAffected == 0 {
+		var count int
+		if err := db.Model(&flavor{}).Where("id = ?", supersession.FlavorId).Count(&count).Error; err != nil {
+			return errors.Wrap(err, "failed to retrieve superseded flavor")
+		}
+		if count > 0 {
+			return errors.Wrapf(models.ErrFlavorAlreadySuperseded, "flavor %s", supersession.FlavorId)
+		}
+		return errors.Errorf("flavor %s does not exist", supersession.FlavorId)
+	}
+	return nil
+}
+
+// search flavor supersessions
+func (f *FlavorStore) SearchSupersessions(criteria *models.FlavorSupersessionFilterCriteria) ([]models.FlavorSupersession, error) {
+	defaultLog.Trace("postgres/flavor_store:SearchSupersessions() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:SearchSupersessions() Leaving")
+
+	tx := f.Store.Db.Table("flavor f").Select("f.id, f.superseded_by, f.retire_at").Where("f.superseded_by IS NOT NULL")
+	if criteria != nil {
+		if len(criteria.FlavorIds) > 0 {
+			tx = tx.Where("f.id IN (?)", criteria.FlavorIds)
+		}
+		if !criteria.RetiredBy.IsZero() {
+			tx = tx.Where("f.retire_at <= ?", criteria.RetiredBy)
+		}
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:SearchSupersessions() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	supersessions := []models.FlavorSupersession{}
+	for rows.Next() {
+		supersession := models.FlavorSupersession{}
+		if err := rows.Scan(&supersession.FlavorId, &supersession.SupersededBy, &supersession.RetireAt); err != nil {
+			return nil, errors.Wrap(err, "postgres/flavor_store:SearchSupersessions() failed to scan record")
+		}
+		supersessions = append(supersessions, supersession)
+	}
+	return supersessions, nil
+}
@@ -16,6 +16,8 @@ import (
 	"sync"
 )
 
+const flavorGroupFields = "id, name, flavor_type_match_policy, label_selector, report_policy"
+
 type FlavorGroupStore struct {
 	Store            *DataStore
 	flavorPartsCache sync.Map
@@ -41,6 +43,8 @@ func (f *FlavorGroupStore) Create(fg *hvs.FlavorGroup) (*hvs.FlavorGroup, error)
 		ID:                    fg.ID,
 		Name:                  fg.Name,
 		FlavorTypeMatchPolicy: PGFlavorMatchPolicies(fg.MatchPolicies),
+		LabelSelector:         fg.LabelSelector,
+		ReportPolicy:          PGReportPolicy{fg.ReportPolicy},
 	}
 
 	if err := f.Store.Db.Create(&dbFlavorGroup).Error; err != nil {
@@ -54,10 +58,12 @@ func (f *FlavorGroupStore) Retrieve(flavorGroupId uuid.UUID) (*hvs.FlavorGroup,
 	defer defaultLog.Trace("postgres/flavorgroup_store:Retrieve() Leaving")
 
 	fg := hvs.FlavorGroup{}
-	row := f.Store.Db.Model(&flavorGroup{}).Where(&flavorGroup{ID: flavorGroupId}).Row()
-	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies)); err != nil {
+	var reportPolicy PGReportPolicy
+	row := f.Store.Db.Model(&flavorGroup{}).Select(flavorGroupFields).Where(&flavorGroup{ID: flavorGroupId}).Row()
+	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), (*PGLabels)(&fg.LabelSelector), &reportPolicy); err != nil {
 		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Retrieve() failed to scan record")
 	}
+	fg.ReportPolicy = reportPolicy.ReportPolicy
 	return &fg, nil
 }
 
@@ -84,6 +90,12 @@ func (f *FlavorGroupStore) Search(fgFilter *models.FlavorGroupFilterCriteria) ([
 			" a gorm query object in FlavorGroups Search function.")
 	}
 
+	pageCriteria := models.PageCriteria{}
+	if fgFilter != nil {
+		pageCriteria = fgFilter.PageCriteria
+	}
+	tx = applyPageCriteria(tx, pageCriteria, flavorGroupSortColumns, models.SortByName)
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() failed to retrieve records from db")
@@ -98,15 +110,42 @@ func (f *FlavorGroupStore) Search(fgFilter *models.FlavorGroupFilterCriteria) ([
 	flavorgroupList := []hvs.FlavorGroup{}
 	for rows.Next() {
 		fg := hvs.FlavorGroup{}
-		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies)); err != nil {
+		var reportPolicy PGReportPolicy
+		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), (*PGLabels)(&fg.LabelSelector), &reportPolicy); err != nil {
 			return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() failed to scan record")
 		}
+		fg.ReportPolicy = reportPolicy.ReportPolicy
 		flavorgroupList = append(flavorgroupList, fg)
 	}
 
 	return flavorgroupList, nil
 }
 
+// Count returns the number of flavorgroups matching the filter criteria, ignoring its page criteria
+func (f *FlavorGroupStore) Count(fgFilter *models.FlavorGroupFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/flavorgroup_store:Count() Entering")
+	defer defaultLog.Trace("postgres/flavorgroup_store:Count() Leaving")
+
+	var err error
+	if fgFilter != nil && fgFilter.FlavorId != nil {
+		fgFilter.Ids, err = f.searchFlavorGroups(fgFilter.FlavorId)
+		if err != nil {
+			return 0, errors.New("postgres/flavorgroup_store:Count() Unexpected Error. " +
+				"Error getting associated flavorgroups")
+		}
+		if fgFilter.NameEqualTo == "" && fgFilter.NameContains == "" && len(fgFilter.Ids) == 0 {
+			return 0, nil
+		}
+	}
+	tx := buildFlavorGroupSearchQuery(f.Store.Db, fgFilter)
+
+	if tx == nil {
+		return 0, errors.New("postgres/flavorgroup_store:Count() Unexpected Error. Could not build" +
+			" a gorm query object in FlavorGroups Count function.")
+	}
+	return countRows(f.Store.Db, tx)
+}
+
 func (f *FlavorGroupStore) Delete(flavorGroupId uuid.UUID) error {
 	defaultLog.Trace("postgres/flavorgroup_store:Delete() Entering")
 	defer defaultLog.Trace("postgres/flavorgroup_store:Delete() Leaving")
@@ -139,6 +178,12 @@ func (f *FlavorGroupStore) HasAssociatedHosts(fgId uuid.UUID) (bool, error) {
 	return false, nil
 }
 
+// flavorGroupSortColumns maps the sortBy values of a FlavorGroup search to the flavor_group table columns
+var flavorGroupSortColumns = map[string]string{
+	models.SortById:   "id",
+	models.SortByName: "name",
+}
+
 // helper function to build the query object for a FlavorGroup search.
 func buildFlavorGroupSearchQuery(tx *gorm.DB, fgFilter *models.FlavorGroupFilterCriteria) *gorm.DB {
 	defaultLog.Trace("postgres/flavorgroup_store:buildFlavorGroupSearchQuery() Entering")
@@ -148,7 +193,7 @@ func buildFlavorGroupSearchQuery(tx *gorm.DB, fgFilter *models.FlavorGroupFilter
 		return nil
 	}
 
-	tx = tx.Model(&flavorGroup{})
+	tx = tx.Model(&flavorGroup{}).Select(flavorGroupFields)
 	if fgFilter == nil {
 		return tx
 	}
@@ -13,14 +13,14 @@ import (
 )
 
 type HostCredentialStore struct {
-	Store *DataStore
-	Dek   []byte
+	Store              *DataStore
+	DataEncryptionKeys *models.DataEncryptionKeys
 }
 
-func NewHostCredentialStore(store *DataStore, dek []byte) *HostCredentialStore {
+func NewHostCredentialStore(store *DataStore, keys *models.DataEncryptionKeys) *HostCredentialStore {
 	return &HostCredentialStore{
-		Store: store,
-		Dek:   dek,
+		Store:              store,
+		DataEncryptionKeys: keys,
 	}
 }
 
@@ -28,7 +28,7 @@ func (hcs *HostCredentialStore) Create(hc *models.HostCredential) (*models.HostC
 	defaultLog.Trace("postgres/host_credential_store:Create() Entering")
 	defer defaultLog.Trace("postgres/host_credential_store:Create() Leaving")
 
-	encCred, err := utils.EncryptString(hc.Credential, hcs.Dek)
+	encCred, keyId, err := utils.EncryptWithCurrentKey(hc.Credential, hcs.DataEncryptionKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/host_credential_store:Create() failed to encrypt Host Credential")
 	}
@@ -45,6 +45,7 @@ func (hcs *HostCredentialStore) Create(hc *models.HostCredential) (*models.HostC
 		HardwareUuid: hc.HardwareUuid,
 		Credential:   encCred,
 		CreatedTs:    time.Now(),
+		KeyId:        keyId,
 	}
 
 	if err := hcs.Store.Db.Create(&dbHostCredential).Error; err != nil {
@@ -58,13 +59,14 @@ func (hcs *HostCredentialStore) Retrieve(id uuid.UUID) (*models.HostCredential,
 	defer defaultLog.Trace("postgres/host_credential_store:Retrieve() Leaving")
 
 	hc := models.HostCredential{}
+	var keyId string
 	row := hcs.Store.Db.Model(&hostCredential{}).Where(&hostCredential{Id: id}).Row()
-	if err := row.Scan(&hc.Id, &hc.HostId, &hc.HostName, &hc.HardwareUuid, &hc.Credential, &hc.CreatedTs); err != nil {
+	if err := row.Scan(&hc.Id, &hc.HostId, &hc.HostName, &hc.HardwareUuid, &hc.Credential, &hc.CreatedTs, &keyId); err != nil {
 		return nil, errors.Wrap(err, "postgres/host_credential_store:Retrieve() failed to scan record")
 	}
 
 	var err error
-	hc.Credential, err = utils.DecryptString(hc.Credential, hcs.Dek)
+	hc.Credential, err = utils.DecryptWithKeyId(hc.Credential, keyId, hcs.DataEncryptionKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/host_credential_store:Retrieve() failed to decrypt credentials")
 	}
@@ -75,12 +77,14 @@ func (hcs *HostCredentialStore) Update(hc *models.HostCredential) error {
 	defaultLog.Trace("postgres/host_credential_store:Update() Entering")
 	defer defaultLog.Trace("postgres/host_credential_store:Update() Leaving")
 
+	var keyId string
 	if hc.Credential != "" {
-		encCred, err := utils.EncryptString(hc.Credential, hcs.Dek)
+		encCred, currentKeyId, err := utils.EncryptWithCurrentKey(hc.Credential, hcs.DataEncryptionKeys)
 		if err != nil {
 			return errors.Wrap(err, "postgres/host_credential_store:Update() failed to encrypt Host Credential")
 		}
 		hc.Credential = encCred
+		keyId = currentKeyId
 	}
 
 	dbHostCredential := hostCredential{
@@ -90,6 +94,7 @@ func (hcs *HostCredentialStore) Update(hc *models.HostCredential) error {
 		HardwareUuid: hc.HardwareUuid,
 		Credential:   hc.Credential,
 		CreatedTs:    time.Now(),
+		KeyId:        keyId,
 	}
 
 	if db := hcs.Store.Db.Model(&dbHostCredential).Updates(&dbHostCredential); db.Error != nil || db.RowsAffected != 1 {
@@ -117,13 +122,14 @@ func (hcs *HostCredentialStore) FindByHostId(id uuid.UUID) (*models.HostCredenti
 	defer defaultLog.Trace("postgres/host_credential_store:FindByHostId() Leaving")
 
 	hc := models.HostCredential{}
+	var keyId string
 	row := hcs.Store.Db.Model(&hostCredential{}).Where(&hostCredential{HostId: id}).Row()
-	if err := row.Scan(&hc.Id, &hc.HostId, &hc.HostName, &hc.HardwareUuid, &hc.Credential, &hc.CreatedTs); err != nil {
+	if err := row.Scan(&hc.Id, &hc.HostId, &hc.HostName, &hc.HardwareUuid, &hc.Credential, &hc.CreatedTs, &keyId); err != nil {
 		return nil, errors.Wrap(err, "postgres/host_credential_store:FindByHostId() failed to scan record")
 	}
 
 	var err error
-	hc.Credential, err = utils.DecryptString(hc.Credential, hcs.Dek)
+	hc.Credential, err = utils.DecryptWithKeyId(hc.Credential, keyId, hcs.DataEncryptionKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/host_credential_store:FindByHostId() failed to decrypt credentials")
 	}
@@ -135,13 +141,14 @@ func (hcs *HostCredentialStore) FindByHostName(name string) (*models.HostCredent
 	defer defaultLog.Trace("postgres/host_credential_store:FindByHostName() Leaving")
 
 	hc := models.HostCredential{}
+	var keyId string
 	row := hcs.Store.Db.Model(&hostCredential{}).Where(&hostCredential{HostName: name}).Row()
-	if err := row.Scan(&hc.Id, &hc.HostId, &hc.HostName, &hc.HardwareUuid, &hc.Credential, &hc.CreatedTs); err != nil {
+	if err := row.Scan(&hc.Id, &hc.HostId, &hc.HostName, &hc.HardwareUuid, &hc.Credential, &hc.CreatedTs, &keyId); err != nil {
 		return nil, errors.Wrap(err, "postgres/host_credential_store:FindByHostName() failed to scan record")
 	}
 
 	var err error
-	hc.Credential, err = utils.DecryptString(hc.Credential, hcs.Dek)
+	hc.Credential, err = utils.DecryptWithKeyId(hc.Credential, keyId, hcs.DataEncryptionKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/host_credential_store:FindByHostName() failed to decrypt credentials")
 	}
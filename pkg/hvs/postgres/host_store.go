@@ -12,7 +12,6 @@ import (
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
-	"reflect"
 	"strings"
 )
 
@@ -25,7 +24,7 @@ func NewHostStore(store *DataStore) *HostStore {
 }
 
 const (
-	hostFields = "host.id, host.name, host.description, host.connection_string, host.hardware_uuid"
+	hostFields = "host.id, host.name, host.description, host.connection_string, host.hardware_uuid, host.labels"
 )
 
 func (hs *HostStore) Create(h *hvs.Host) (*hvs.Host, error) {
@@ -42,6 +41,7 @@ func (hs *HostStore) Create(h *hvs.Host) (*hvs.Host, error) {
 		Name:             h.HostName,
 		Description:      h.Description,
 		ConnectionString: h.ConnectionString,
+		Labels:           h.Labels,
 	}
 
 	if h.HardwareUuid != nil {
@@ -68,26 +68,27 @@ func (hs *HostStore) Retrieve(id uuid.UUID, criteria *models.HostInfoFetchCriter
 		row := buildInfoFetchQuery(tx, criteria, nil).Row()
 		if criteria.GetReport && criteria.GetHostStatus {
 			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid,
-				(*PGTrustReport)(&report), (*PGHostStatusInformation)(&connectionStatus)); err != nil {
+				(*PGLabels)(&h.Labels), (*PGTrustReport)(&report), (*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 			}
 			h.Report = &report
 			h.ConnectionStatus = &connectionStatus
 		} else if criteria.GetReport {
 			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid,
-				(*PGTrustReport)(&report)); err != nil {
+				(*PGLabels)(&h.Labels), (*PGTrustReport)(&report)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 			}
 			h.Report = &report
 		} else if criteria.GetHostStatus {
 			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid,
-				(*PGHostStatusInformation)(&connectionStatus)); err != nil {
+				(*PGLabels)(&h.Labels), (*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 			}
 			h.ConnectionStatus = &connectionStatus
 		}
 	} else {
-		if err := tx.Row().Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid); err != nil {
+		if err := tx.Select(hostFields).Row().Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid,
+			(*PGLabels)(&h.Labels)); err != nil {
 			return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 		}
 	}
@@ -104,6 +105,7 @@ func (hs *HostStore) Update(h *hvs.Host) error {
 		Name:             h.HostName,
 		Description:      h.Description,
 		ConnectionString: h.ConnectionString,
+		Labels:           h.Labels,
 	}
 
 	if h.HardwareUuid != nil {
@@ -144,15 +146,17 @@ func (hs *HostStore) Search(filterCriteria *models.HostFilterCriteria, infoFetch
 	defaultLog.Trace("postgres/host_store:Search() Entering")
 	defer defaultLog.Trace("postgres/host_store:Search() Leaving")
 
-	tx := buildHostSearchQuery(hs.Store.Db, filterCriteria)
+	tx := buildHostSearchQuery(hs.Store.Db, filterCriteria, infoFetchCriteria)
 	if tx == nil {
 		return nil, errors.New("postgres/host_store:Search() Unexpected Error. Could not build" +
 			" a gorm query object.")
 	}
 
-	if infoFetchCriteria != nil && (infoFetchCriteria.GetTrustStatus || infoFetchCriteria.GetHostStatus) {
-		tx = buildInfoFetchQuery(tx, infoFetchCriteria, filterCriteria)
+	pageCriteria := models.PageCriteria{}
+	if filterCriteria != nil {
+		pageCriteria = filterCriteria.PageCriteria
 	}
+	tx = applyPageCriteria(tx, pageCriteria, hostSortColumns, models.SortByName)
 
 	rows, err := tx.Rows()
 	if err != nil {
@@ -171,7 +175,8 @@ func (hs *HostStore) Search(filterCriteria *models.HostFilterCriteria, infoFetch
 	} else {
 		for rows.Next() {
 			host := hvs.Host{}
-			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid); err != nil {
+			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid,
+				(*PGLabels)(&host.Labels)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
 			hosts = append(hosts, &host)
@@ -180,8 +185,28 @@ func (hs *HostStore) Search(filterCriteria *models.HostFilterCriteria, infoFetch
 	return hosts, nil
 }
 
+// Count returns the number of hosts matching the filter criteria, ignoring its page criteria
+func (hs *HostStore) Count(filterCriteria *models.HostFilterCriteria, infoFetchCriteria *models.HostInfoFetchCriteria) (int, error) {
+	defaultLog.Trace("postgres/host_store:Count() Entering")
+	defer defaultLog.Trace("postgres/host_store:Count() Leaving")
+
+	tx := buildHostSearchQuery(hs.Store.Db, filterCriteria, infoFetchCriteria)
+	if tx == nil {
+		return 0, errors.New("postgres/host_store:Count() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+	return countRows(hs.Store.Db, tx)
+}
+
+// hostSortColumns maps the sortBy values of a Host search to the host table columns
+var hostSortColumns = map[string]string{
+	models.SortById:             "host.id",
+	models.SortByName:           "host.name",
+	models.SortByHostHardwareId: "host.hardware_uuid",
+}
+
 // helper function to build the query object for a Host search.
-func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gorm.DB {
+func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria, infoFetchCriteria *models.HostInfoFetchCriteria) *gorm.DB {
 	defaultLog.Trace("postgres/host_store:buildHostSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/host_store:buildHostSearchQuery() Leaving")
 
@@ -191,9 +216,8 @@ func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gor
 
 	tx = tx.Model(&host{})
 
-	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) {
-		tx = tx.Order("name asc")
-		return tx
+	if criteria == nil {
+		criteria = &models.HostFilterCriteria{}
 	}
 
 	if criteria.Id != uuid.Nil {
@@ -210,10 +234,22 @@ func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gor
 		tx = tx.Joins("join report on report.host_id = host.id AND report.trusted = ?", criteria.Trusted)
 	}
 
-	if criteria.OrderBy == models.Descending {
-		tx = tx.Order("name desc")
-	} else {
-		tx = tx.Order("name asc")
+	// the label filter applies along with the other filters
+	if len(criteria.Labels) > 0 {
+		labels, err := PGLabels(criteria.Labels).Value()
+		if err != nil {
+			defaultLog.WithError(err).Error("postgres/host_store:buildHostSearchQuery() failed to marshal labels")
+			return nil
+		}
+		tx = tx.Where("host.labels @> ?::jsonb", string(labels.([]byte)))
+	}
+
+	if infoFetchCriteria == nil || !(infoFetchCriteria.GetTrustStatus || infoFetchCriteria.GetHostStatus) {
+		tx = tx.Select(hostFields)
+	}
+
+	if infoFetchCriteria != nil && (infoFetchCriteria.GetTrustStatus || infoFetchCriteria.GetHostStatus) {
+		tx = buildInfoFetchQuery(tx, infoFetchCriteria, criteria)
 	}
 	return tx
 }
@@ -257,18 +293,18 @@ func getAdditionalHostInfo(criteria *models.HostInfoFetchCriteria, rows *sql.Row
 		connectionStatus := hvs.HostStatusInformation{}
 		if criteria.GetTrustStatus && criteria.GetHostStatus {
 			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid,
-				&host.Trusted, (*PGHostStatusInformation)(&connectionStatus)); err != nil {
+				(*PGLabels)(&host.Labels), &host.Trusted, (*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
 			host.ConnectionStatus = &connectionStatus
 		} else if criteria.GetTrustStatus {
 			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid,
-				&host.Trusted); err != nil {
+				(*PGLabels)(&host.Labels), &host.Trusted); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
 		} else if criteria.GetHostStatus {
 			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid,
-				(*PGHostStatusInformation)(&connectionStatus)); err != nil {
+				(*PGLabels)(&host.Labels), (*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
 			host.ConnectionStatus = &connectionStatus
@@ -86,12 +86,23 @@ func (hss *HostStatusStore) Search(hsFilter *models.HostStatusFilterCriteria) ([
 	// setting to empty array
 	hostStatuses := []hvs.HostStatus{}
 
+	// Apply default row limit when called internally
+	if hsFilter.Limit == 0 {
+		hsFilter.Limit = constants.DefaultSearchResultRowLimit
+	}
+	// the most recent host statuses are returned first unless requested otherwise
+	pageCriteria := hsFilter.PageCriteria
+	if pageCriteria.OrderBy == "" {
+		pageCriteria.OrderBy = models.Descending
+	}
+
 	if hsFilter.FromDate.IsZero() && hsFilter.ToDate.IsZero() && hsFilter.LatestPerHost {
 		tx = buildLatestHostStatusSearchQuery(hss.Store.Db, hsFilter)
 		if tx == nil {
 			return nil, errors.New("postgres/hoststatus_store:Search() Unexpected Error. Could not build" +
 				" a gorm query object in HostStatus Search function.")
 		}
+		tx = applyPageCriteria(tx, pageCriteria, latestHostStatusSortColumns, models.SortByCreatedAt)
 		rows, err := tx.Rows()
 		if err != nil {
 			return nil, errors.Wrap(err, "postgres/hoststatus_store:Search() failed to retrieve records from db")
@@ -117,6 +128,7 @@ func (hss *HostStatusStore) Search(hsFilter *models.HostStatusFilterCriteria) ([
 			return nil, errors.New("postgres/hoststatus_store:Search() Unexpected Error. Could not build" +
 				" a gorm query object in HostStatus Search function.")
 		}
+		tx = applyPageCriteria(tx, pageCriteria, hostStatusSortColumns, models.SortByCreatedAt)
 		rows, err := tx.Rows()
 		if err != nil {
 			return nil, errors.Wrap(err, "postgres/hoststatus_store:Search() failed to retrieve records from db")
@@ -147,6 +159,37 @@ func (hss *HostStatusStore) Search(hsFilter *models.HostStatusFilterCriteria) ([
 	return hostStatuses, nil
 }
 
+// Count returns the number of HostStatus records matching the filter criteria, ignoring its page criteria
+func (hss *HostStatusStore) Count(hsFilter *models.HostStatusFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/hoststatus_store:Count() Entering")
+	defer defaultLog.Trace("postgres/hoststatus_store:Count() Leaving")
+
+	var tx *gorm.DB
+	if hsFilter.FromDate.IsZero() && hsFilter.ToDate.IsZero() && hsFilter.LatestPerHost {
+		tx = buildLatestHostStatusSearchQuery(hss.Store.Db, hsFilter)
+	} else {
+		tx = buildHostStatusSearchQuery(hss.Store.Db, hsFilter)
+	}
+	if tx == nil {
+		return 0, errors.New("postgres/hoststatus_store:Count() Unexpected Error. Could not build" +
+			" a gorm query object in HostStatus Count function.")
+	}
+	return countRows(hss.Store.Db, tx)
+}
+
+// latestHostStatusSortColumns maps the sortBy values of a HostStatus search to the host_status table columns
+var latestHostStatusSortColumns = map[string]string{
+	models.SortById:        "host_status.id",
+	models.SortByCreatedAt: "host_status.created",
+}
+
+// hostStatusSortColumns maps the sortBy values of a HostStatus search to the columns of the host statuses in the
+// audit log
+var hostStatusSortColumns = map[string]string{
+	models.SortById:        "au.entity_id",
+	models.SortByCreatedAt: "au.created",
+}
+
 // Persist is used by the HostDataFetcher to update an existing HostStatus record else create one if it does not exist
 func (hss *HostStatusStore) Persist(hs *hvs.HostStatus) error {
 	defaultLog.Trace("postgres/hoststatus_store:Persist() Entering")
@@ -346,17 +389,13 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 			"FROM audit_log_entry auj %s GROUP BY entity_id) a "+
 			"ON a.entity_id = au.entity_id "+
 			"AND a.max_date = au.created", additionalOptionsQueryString)
-		formattedQuery = fmt.Sprintf("%s %s ORDER BY au.Created DESC", formattedQuery, maxDateQueryString)
+		formattedQuery = fmt.Sprintf("%s %s", formattedQuery, maxDateQueryString)
 	} else {
 		formattedQuery = fmt.Sprintf("%s %s", formattedQuery, additionalOptionsQueryString)
 	}
 
-	if hsFilter.Limit == 0 {
-		hsFilter.Limit = constants.DefaultSearchResultRowLimit
-	}
-
 	// finalize query
-	tx = tx.Raw(formattedQuery).Limit(hsFilter.Limit)
+	tx = tx.Raw(formattedQuery)
 
 	return tx
 }
@@ -409,14 +448,6 @@ func buildLatestHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFi
 		tx = tx.Where(`status @> '{"host_state": "` + strings.ToUpper(hsFilter.HostStatus) + `"}'`)
 	}
 
-	// Apply default row limit when called internally
-	if hsFilter.Limit == 0 {
-		hsFilter.Limit = constants.DefaultSearchResultRowLimit
-	}
-
-	// apply result limits
-	tx = tx.Limit(hsFilter.Limit)
-
 	return tx
 }
 
@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/pkg/errors"
+)
+
+const jobFields = "id, action, state, message, params, created, updated"
+
+// JobStore holds the asynchronous jobs in their own table, apart from the flavor verification queue
+type JobStore struct {
+	Store *DataStore
+}
+
+func NewJobStore(store *DataStore) *JobStore {
+	return &JobStore{Store: store}
+}
+
+func (js *JobStore) Create(j *models.Job) (*models.Job, error) {
+	defaultLog.Trace("postgres/job_store:Create() Entering")
+	defer defaultLog.Trace("postgres/job_store:Create() Leaving")
+
+	if j == nil || j.Action == "" || j.State == "" {
+		return nil, errors.New("postgres/job_store:Create() invalid input, the job must have an action and a state")
+	}
+
+	now := time.Now().UTC()
+	dbj := job{
+		Id:        uuid.New(),
+		Action:    j.Action,
+		State:     string(j.State),
+		Message:   j.Message,
+		Params:    PGJsonStrMap(j.Params),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if dbj.Params == nil {
+		dbj.Params = PGJsonStrMap{}
+	}
+	if err := js.Store.Db.Create(&dbj).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Create() failed to create job")
+	}
+
+	created := *j
+	created.Id = dbj.Id
+	created.Created = now
+	created.Updated = now
+	return &created, nil
+}
+
+func (js *JobStore) Retrieve(id uuid.UUID) (*models.Job, error) {
+	defaultLog.Trace("postgres/job_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/job_store:Retrieve() Leaving")
+
+	j := models.Job{}
+	row := js.Store.Db.Model(&job{}).Select(jobFields).Where("id = ?", id).Row()
+	if err := row.Scan(&j.Id, &j.Action, &j.State, &j.Message, (*PGJsonStrMap)(&j.Params), &j.Created, &j.Updated); err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Retrieve() failed to scan record")
+	}
+	return &j, nil
+}
+
+// Update updates the state, the message and the params of the job
+func (js *JobStore) Update(j *models.Job) error {
+	defaultLog.Trace("postgres/job_store:Update() Entering")
+	defer defaultLog.Trace("postgres/job_store:Update() Leaving")
+
+	if j.Id == uuid.Nil {
+		return errors.New("postgres/job_store:Update() Id is invalid")
+	}
+
+	params := PGJsonStrMap(j.Params)
+	if params == nil {
+		params = PGJsonStrMap{}
+	}
+	db := js.Store.Db.Model(&job{}).Where("id = ?", j.Id).Updates(map[string]interface{}{
+		"state":   string(j.State),
+		"message": j.Message,
+		"params":  params,
+		"updated": time.Now().UTC(),
+	})
+	if db.Error != nil {
+		return errors.Wrap(db.Error, "postgres/job_store:Update() failed to update job "+j.Id.String())
+	}
+	if db.RowsAffected != 1 {
+		return errors.New("postgres/job_store:Update() no rows affected, record not found for job " + j.Id.String())
+	}
+	return nil
+}
+
+// Restart updates the job only while it is finished, so that a job is never run twice at the same time
+func (js *JobStore) Restart(j *models.Job) (bool, error) {
+	defaultLog.Trace("postgres/job_store:Restart() Entering")
+	defer defaultLog.Trace("postgres/job_store:Restart() Leaving")
+
+	params := PGJsonStrMap(j.Params)
+	if params == nil {
+		params = PGJsonStrMap{}
+	}
+	db := js.Store.Db.Model(&job{}).Where("id = ? AND state IN (?)", j.Id,
+		[]string{string(models.JobStateCompleted), string(models.JobStateError)}).
+		Updates(map[string]interface{}{
+			"state":   string(models.JobStatePending),
+			"message": "",
+			"params":  params,
+			"updated": time.Now().UTC(),
+		})
+	if db.Error != nil {
+		return false, errors.Wrap(db.Error, "postgres/job_store:Restart() failed to update job "+j.Id.String())
+	}
+	return db.RowsAffected == 1, nil
+}
+
+func (js *JobStore) FailUnfinished(message string) (int, error) {
+	defaultLog.Trace("postgres/job_store:FailUnfinished() Entering")
+	defer defaultLog.Trace("postgres/job_store:FailUnfinished() Leaving")
+
+	db := js.Store.Db.Model(&job{}).Where("state IN (?)", []string{string(models.JobStateNew), string(models.JobStatePending)}).
+		Updates(map[string]interface{}{
+			"state":   string(models.JobStateError),
+			"message": message,
+			"updated": time.Now().UTC(),
+		})
+	if db.Error != nil {
+		return 0, errors.Wrap(db.Error, "postgres/job_store:FailUnfinished() failed to update jobs")
+	}
+	return int(db.RowsAffected), nil
+}
+
+func (js *JobStore) Purge(updatedBefore time.Time) (int, error) {
+	defaultLog.Trace("postgres/job_store:Purge() Entering")
+	defer defaultLog.Trace("postgres/job_store:Purge() Leaving")
+
+	db := js.Store.Db.Where("state IN (?) AND updated < ?",
+		[]string{string(models.JobStateCompleted), string(models.JobStateError)}, updatedBefore).Delete(&job{})
+	if db.Error != nil {
+		return 0, errors.Wrap(db.Error, "postgres/job_store:Purge() failed to delete jobs")
+	}
+	return int(db.RowsAffected), nil
+}
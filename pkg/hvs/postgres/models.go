@@ -21,7 +21,9 @@ import (
 // Define all struct types here
 type (
 	PGJsonStrMap            map[string]interface{}
+	PGLabels                map[string]string
 	PGFlavorMatchPolicies   hvs.FlavorMatchPolicies
+	PGReportPolicy          struct{ *hvs.ReportPolicy }
 	PGHostManifest          types.HostManifest
 	PGHostStatusInformation hvs.HostStatusInformation
 	PGFlavorContent         hvs.Flavor
@@ -31,6 +33,8 @@ type (
 		ID                    uuid.UUID             `json:"id" gorm:"primary_key;type:uuid"`
 		Name                  string                `json:"name" gorm:"type:varchar(255);not null;index:idx_flavorgroup_name"`
 		FlavorTypeMatchPolicy PGFlavorMatchPolicies `json:"flavor_type_match_policy,omitempty" sql:"type:JSONB"`
+		LabelSelector         PGLabels              `json:"label_selector,omitempty" sql:"type:JSONB NOT NULL DEFAULT '{}'::JSONB"`
+		ReportPolicy          PGReportPolicy        `json:"report_policy,omitempty" sql:"type:JSONB"`
 	}
 
 	flavor struct {
@@ -40,6 +44,9 @@ type (
 		Label      string          `gorm:"unique;not null"`
 		FlavorPart string          `json:"flavor_part"`
 		Signature  string          `json:"signature"`
+		// SupersededBy and RetireAt are set when the flavor is superseded by a newer flavor
+		SupersededBy *uuid.UUID `gorm:"type:uuid REFERENCES flavor(Id) ON UPDATE CASCADE ON DELETE SET NULL;index:idx_flavor_superseded_by"`
+		RetireAt     *time.Time
 	}
 
 	host struct {
@@ -48,6 +55,7 @@ type (
 		Description      string
 		ConnectionString string        `gorm:"not null"`
 		HardwareUuid     models.HwUUID `gorm:"type:uuid;index:idx_host_hardware_uuid"`
+		Labels           PGLabels      `sql:"type:JSONB NOT NULL DEFAULT '{}'::JSONB"`
 	}
 
 	hostFlavorgroup struct {
@@ -84,6 +92,7 @@ type (
 		HardwareUuid models.HwUUID `gorm:"type:uuid;index:idx_host_credential_hardware_uuid"`
 		Credential   string
 		CreatedTs    time.Time
+		KeyId        string `gorm:"column:key_id;type:varchar(16);not null;default:''"`
 	}
 
 	// hostStatus holds all the hostStatus records for VS-attested hosts
@@ -99,6 +108,7 @@ type (
 		Id               uuid.UUID `gorm:"primary_key;type:uuid"`
 		ConnectionString string    `gorm:"column:connection_string;not null"`
 		ClusterName      string    `gorm:"column:cluster_name;type:varchar(255);not null;index:idx_esxi_cluster_name"`
+		KeyId            string    `gorm:"column:key_id;type:varchar(16);not null;default:''"`
 	}
 
 	esxiClusterHost struct {
@@ -116,6 +126,16 @@ type (
 		Message   string            `json:"message,omitempty"`
 	}
 
+	job struct {
+		Id        uuid.UUID    `gorm:"primary_key;type:uuid"`
+		Action    string       `gorm:"not null"`
+		State     string       `gorm:"not null;index:idx_job_state"`
+		Message   string       `gorm:"column:message"`
+		Params    PGJsonStrMap `sql:"type:JSONB NOT NULL DEFAULT '{}'::JSONB"`
+		CreatedAt time.Time    `gorm:"column:created;not null"`
+		UpdatedAt time.Time    `gorm:"column:updated;not null"`
+	}
+
 	PGTrustReport hvs.TrustReport
 	report        struct {
 		ID          uuid.UUID     `gorm:"column:id" gorm:"primary_key;"`
@@ -127,6 +147,16 @@ type (
 		Saml        string        `gorm:"column:saml;not null"`
 	}
 
+	reportHistory struct {
+		ID          uuid.UUID     `gorm:"column:id;primary_key;type:uuid"`
+		HostID      uuid.UUID     `gorm:"column:host_id;type:uuid REFERENCES host(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;index:idx_report_history_host_created"`
+		TrustReport PGTrustReport `gorm:"column:trust_report;not null" sql:"type:JSONB"`
+		Trusted     bool          `gorm:"column:trusted;not null"`
+		CreatedAt   time.Time     `gorm:"column:created;not null;index:idx_report_history_host_created"`
+		Expiration  time.Time     `gorm:"column:expiration;not null"`
+		Saml        string        `gorm:"column:saml;not null"`
+	}
+
 	tpmEndorsement struct {
 		ID                uuid.UUID `gorm:"primary_key;type:uuid"`
 		HardwareUUID      uuid.UUID `gorm:"column:hardware_uuid;not null;type:uuid"`
@@ -173,6 +203,23 @@ func (qp *PGJsonStrMap) Scan(value interface{}) error {
 	return json.Unmarshal(b, &qp)
 }
 
+func (l PGLabels) Value() (driver.Value, error) {
+	if l == nil {
+		return json.Marshal(map[string]string{})
+	}
+	return json.Marshal(map[string]string(l))
+}
+
+func (l *PGLabels) Scan(value interface{}) error {
+	// no trace comments here as it is a high frequency function.
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGLabels_Scan() - type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, l)
+}
+
 func (phm PGHostManifest) Value() (driver.Value, error) {
 	return json.Marshal(phm)
 }
@@ -212,6 +259,26 @@ func (fmp *PGFlavorMatchPolicies) Scan(value interface{}) error {
 	return json.Unmarshal(b, &fmp)
 }
 
+// Value stores the optional report policy of a flavorgroup as NULL when it is not set
+func (rp PGReportPolicy) Value() (driver.Value, error) {
+	if rp.ReportPolicy == nil {
+		return nil, nil
+	}
+	return json.Marshal(rp.ReportPolicy)
+}
+
+func (rp *PGReportPolicy) Scan(value interface{}) error {
+	if value == nil {
+		rp.ReportPolicy = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGReportPolicy_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &rp.ReportPolicy)
+}
+
 func (trp PGTrustReport) Value() (driver.Value, error) {
 	return json.Marshal(trp)
 }
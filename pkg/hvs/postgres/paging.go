@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// applyPageCriteria orders the query by the column mapped to the sortBy field of the page criteria, or by the
+// column of defaultSortBy when it is not set, and restricts it to the requested page. The records are also ordered
+// by the id column so that the pages are stable when the sort column has duplicate values.
+func applyPageCriteria(tx *gorm.DB, pageCriteria models.PageCriteria, sortColumns map[string]string, defaultSortBy string) *gorm.DB {
+	defaultLog.Trace("postgres/paging:applyPageCriteria() Entering")
+	defer defaultLog.Trace("postgres/paging:applyPageCriteria() Leaving")
+
+	sortColumn, ok := sortColumns[pageCriteria.SortBy]
+	if !ok {
+		sortColumn = sortColumns[defaultSortBy]
+	}
+	tx = tx.Order(sortColumn + " " + pageCriteria.OrderBy.String())
+	if idColumn := sortColumns[models.SortById]; idColumn != "" && idColumn != sortColumn {
+		tx = tx.Order(idColumn + " " + pageCriteria.OrderBy.String())
+	}
+
+	if pageCriteria.Limit > 0 {
+		tx = tx.Limit(pageCriteria.Limit)
+	}
+	if pageCriteria.Offset > 0 {
+		tx = tx.Offset(pageCriteria.Offset)
+	}
+	return tx
+}
+
+// countRows returns the number of records returned by the query, which must not be ordered or paged
+func countRows(db *gorm.DB, tx *gorm.DB) (int, error) {
+	defaultLog.Trace("postgres/paging:countRows() Entering")
+	defer defaultLog.Trace("postgres/paging:countRows() Leaving")
+
+	var count int
+	if err := db.Raw("SELECT count(*) FROM ? AS count_table", tx.SubQuery()).Row().Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "postgres/paging:countRows() failed to count records")
+	}
+	return count, nil
+}
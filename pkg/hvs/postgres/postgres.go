@@ -143,8 +143,8 @@ func (ds *DataStore) Migrate() {
 	defer defaultLog.Trace("postgres/postgres:Migrate() Leaving")
 
 	ds.Db.AutoMigrate(flavorGroup{}, host{}, flavor{}, trustCache{}, hostuniqueFlavor{}, flavorgroupFlavor{}, hostStatus{}, esxiCluster{},
-		esxiClusterHost{}, tagCertificate{}, tpmEndorsement{}, report{}, hostCredential{}, hostFlavorgroup{}, auditLogEntry{},
-		queue{}, flavorTemplate{})
+		esxiClusterHost{}, tagCertificate{}, tpmEndorsement{}, report{}, reportHistory{}, hostCredential{}, hostFlavorgroup{}, auditLogEntry{},
+		queue{}, flavorTemplate{}, job{})
 }
 
 func (ds *DataStore) Close() {
@@ -139,6 +139,34 @@ func (qr *QueueStore) Delete(id uuid.UUID) error {
 	return nil
 }
 
+// CountByState returns the number of queue entries in each state
+func (qr *QueueStore) CountByState() (map[models.QueueState]int, error) {
+	defaultLog.Trace("postgres/queue_store:CountByState() Entering")
+	defer defaultLog.Trace("postgres/queue_store:CountByState() Leaving")
+
+	rows, err := qr.store.Db.Model(&queue{}).Select("state, count(*)").Group("state").Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/queue_store:CountByState() failed to count queue entries")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	counts := make(map[models.QueueState]int)
+	for rows.Next() {
+		var state models.QueueState
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, errors.Wrap(err, "postgres/queue_store:CountByState() - Could not scan record")
+		}
+		counts[state] = count
+	}
+	return counts, nil
+}
+
 // helper function to build the query object for a Queue search.
 func buildQueueSearchQuery(tx *gorm.DB, qf *models.QueueFilterCriteria) *gorm.DB {
 	defaultLog.Trace("postgres/queue_store:buildQueueSearchQuery() Entering")
@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+const reportHistoryFields = "id, host_id, trust_report, created, expiration, saml"
+
+// ReportHistoryStore holds the reports of the hosts, the reports are recorded in the report history by
+// ReportStore.Create and kept there once they are replaced by a newer report until they are purged.
+type ReportHistoryStore struct {
+	Store *DataStore
+}
+
+func NewReportHistoryStore(store *DataStore) *ReportHistoryStore {
+	return &ReportHistoryStore{Store: store}
+}
+
+// reportHistorySortColumns maps the sortBy values of a report history search to the report history table columns
+var reportHistorySortColumns = map[string]string{
+	models.SortById:         "id",
+	models.SortByCreatedAt:  "created",
+	models.SortByExpiration: "expiration",
+}
+
+// Retrieve fetches the report with the given Id from the report history
+func (r *ReportHistoryStore) Retrieve(reportId uuid.UUID) (*models.HVSReport, error) {
+	defaultLog.Trace("postgres/report_history_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/report_history_store:Retrieve() Leaving")
+
+	re := models.HVSReport{}
+	row := r.Store.Db.Model(&reportHistory{}).Select(reportHistoryFields).Where("id = ?", reportId).Row()
+	if err := row.Scan(&re.ID, &re.HostID, (*PGTrustReport)(&re.TrustReport), &re.CreatedAt, &re.Expiration, &re.Saml); err != nil {
+		return nil, errors.Wrap(err, "postgres/report_history_store:Retrieve() failed to scan record")
+	}
+	return &re, nil
+}
+
+// Search retrieves the reports of a host in the report history, the most recent reports are returned first unless
+// requested otherwise
+func (r *ReportHistoryStore) Search(criteria *models.ReportHistoryFilterCriteria) ([]models.HVSReport, error) {
+	defaultLog.Trace("postgres/report_history_store:Search() Entering")
+	defer defaultLog.Trace("postgres/report_history_store:Search() Leaving")
+
+	pageCriteria := criteria.PageCriteria
+	if pageCriteria.OrderBy == "" {
+		pageCriteria.OrderBy = models.Descending
+	}
+	tx := applyPageCriteria(r.buildSearchQuery(criteria), pageCriteria, reportHistorySortColumns, models.SortByCreatedAt)
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_history_store:Search() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	reports := []models.HVSReport{}
+	for rows.Next() {
+		re := models.HVSReport{}
+		if err := rows.Scan(&re.ID, &re.HostID, (*PGTrustReport)(&re.TrustReport), &re.CreatedAt, &re.Expiration, &re.Saml); err != nil {
+			return nil, errors.Wrap(err, "postgres/report_history_store:Search() failed to scan record")
+		}
+		reports = append(reports, re)
+	}
+	return reports, nil
+}
+
+// Count returns the number of reports matching the filter criteria, ignoring its page criteria
+func (r *ReportHistoryStore) Count(criteria *models.ReportHistoryFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/report_history_store:Count() Entering")
+	defer defaultLog.Trace("postgres/report_history_store:Count() Leaving")
+
+	return countRows(r.Store.Db, r.buildSearchQuery(criteria))
+}
+
+func (r *ReportHistoryStore) buildSearchQuery(criteria *models.ReportHistoryFilterCriteria) *gorm.DB {
+	tx := r.Store.Db.Model(&reportHistory{}).Select(reportHistoryFields).Where("host_id = ?", criteria.HostID)
+	if !criteria.FromDate.IsZero() {
+		tx = tx.Where("CAST(created AS TIMESTAMP) >= CAST(? AS TIMESTAMP)", criteria.FromDate)
+	}
+	if !criteria.ToDate.IsZero() {
+		tx = tx.Where("CAST(created AS TIMESTAMP) < CAST(? AS TIMESTAMP)", criteria.ToDate)
+	}
+	return tx
+}
+
+// Purge deletes the reports of each host beyond the most recent maxReports and the reports created before
+// createdBefore. The reports still in the report table, that is the latest report of each host, are kept.
+func (r *ReportHistoryStore) Purge(maxReports int, createdBefore time.Time) (int, error) {
+	defaultLog.Trace("postgres/report_history_store:Purge() Entering")
+	defer defaultLog.Trace("postgres/report_history_store:Purge() Leaving")
+
+	var conditions []string
+	var args []interface{}
+	if maxReports > 0 {
+		conditions = append(conditions, "rh.position > ?")
+		args = append(args, maxReports)
+	}
+	if !createdBefore.IsZero() {
+		conditions = append(conditions, "CAST(rh.created AS TIMESTAMP) < CAST(? AS TIMESTAMP)")
+		args = append(args, createdBefore)
+	}
+	if len(conditions) == 0 {
+		return 0, nil
+	}
+
+	result := r.Store.Db.Exec("DELETE FROM report_history WHERE id IN ("+
+		"SELECT rh.id FROM (SELECT id, created, ROW_NUMBER() OVER (PARTITION BY host_id ORDER BY created DESC) AS position "+
+		"FROM report_history) rh WHERE "+strings.Join(conditions, " OR ")+") "+
+		"AND id NOT IN (SELECT id FROM report)", args...)
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, "postgres/report_history_store:Purge() failed to delete reports")
+	}
+	return int(result.RowsAffected), nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
@@ -47,6 +48,46 @@ func (r *ReportStore) Retrieve(reportId uuid.UUID) (*models.HVSReport, error) {
 	return &re, nil
 }
 
+// RetrieveHistoric fetches report for a given Id. Reports that have since been replaced by a
+// newer report for the same host are no longer in the report table, so they are retrieved
+// from the report history or, once purged from the history, rebuilt from the audit log entry
+// recorded when they were created.
+func (r *ReportStore) RetrieveHistoric(reportId uuid.UUID) (*models.HVSReport, error) {
+	defaultLog.Trace("postgres/report_store:RetrieveHistoric() Entering")
+	defer defaultLog.Trace("postgres/report_store:RetrieveHistoric() Leaving")
+
+	re, err := r.Retrieve(reportId)
+	if err == nil {
+		return re, nil
+	}
+	if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return nil, errors.Wrap(err, "postgres/report_store:RetrieveHistoric() failed to retrieve report")
+	}
+
+	re, err = NewReportHistoryStore(r.Store).Retrieve(reportId)
+	if err == nil {
+		return re, nil
+	}
+	if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return nil, errors.Wrap(err, "postgres/report_store:RetrieveHistoric() failed to retrieve report from history")
+	}
+
+	auditEntry := models.AuditLogEntry{}
+	row := r.Store.Db.Table("audit_log_entry au").Select("au.*").
+		Where("au.entity_type = 'report' AND au.action = 'create' AND au.entity_id = ?", reportId).Row()
+	if err := row.Scan(&auditEntry.ID, &auditEntry.EntityID, &auditEntry.EntityType, &auditEntry.CreatedAt, &auditEntry.Action, (*PGAuditLogData)(&auditEntry.Data)); err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:RetrieveHistoric() failed to scan record")
+	}
+	if len(auditEntry.Data.Columns) == 0 {
+		return nil, errors.New("postgres/report_store:RetrieveHistoric() audit log entry does not contain report data")
+	}
+	re, err = auditlogEntryToReport(auditEntry)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:RetrieveHistoric() convert auditlog entry into report")
+	}
+	return re, nil
+}
+
 // Update method is called after completion of flavor verification process by the flavor verify queue
 func (r *ReportStore) Update(re *models.HVSReport) (*models.HVSReport, error) {
 	defaultLog.Trace("postgres/report_store:Update() Entering")
@@ -104,7 +145,22 @@ func (r *ReportStore) Create(re *models.HVSReport) (*models.HVSReport, error) {
 		TrustReport: PGTrustReport(re.TrustReport),
 		Trusted:     re.TrustReport.Trusted,
 	}
-	if err := r.Store.Db.Create(&dbReport).Error; err != nil {
+	// the report is recorded in the report history, where it is kept once it is replaced by a newer report
+	err = r.Store.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&dbReport).Error; err != nil {
+			return err
+		}
+		return tx.Create(&reportHistory{
+			ID:          dbReport.ID,
+			HostID:      dbReport.HostID,
+			TrustReport: dbReport.TrustReport,
+			Trusted:     dbReport.Trusted,
+			CreatedAt:   dbReport.CreatedAt,
+			Expiration:  dbReport.Expiration,
+			Saml:        dbReport.Saml,
+		}).Error
+	})
+	if err != nil {
 		return nil, errors.Wrap(err, "postgres/report_store:Create() failed to create HVSReport")
 	}
 	// log to audit log
@@ -122,54 +178,24 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 	defaultLog.Trace("postgres/report_store:Search() Entering")
 	defer defaultLog.Trace("postgres/report_store:Search() Leaving")
 
-	var reportID uuid.UUID
-	var hostID uuid.UUID
-	var hostName string
-	var hostHardwareUUID uuid.UUID
-	var hostStatus string
-	var latestPerHost bool
-	var toDate time.Time
-	var fromDate time.Time
-
-	if criteria.ID != uuid.Nil {
-		reportID = criteria.ID
-	}
-	if criteria.HostID != uuid.Nil {
-		hostID = criteria.HostID
-	}
-	if criteria.HostHardwareID != uuid.Nil {
-		hostHardwareUUID = criteria.HostHardwareID
-	}
-	if criteria.HostStatus != "" {
-		hostStatus = criteria.HostStatus
-	}
-	if criteria.HostName != "" {
-		hostName = criteria.HostName
-	}
-	if !criteria.ToDate.IsZero() {
-		toDate = criteria.ToDate
-	}
-	if !criteria.FromDate.IsZero() {
-		fromDate = criteria.FromDate
-	}
 	if criteria.Limit == 0 && criteria.LatestPerHost {
 		criteria.Limit = consts.DefaultSearchResultRowLimit
 	}
-	latestPerHost = criteria.LatestPerHost
 
-	if criteria.NumberOfDays != 0 {
-		toDate = time.Now().UTC()
-		fromDate = toDate.AddDate(0, 0, -(criteria.NumberOfDays)).UTC()
+	// the most recent reports are returned first unless requested otherwise
+	pageCriteria := criteria.PageCriteria
+	if pageCriteria.OrderBy == "" {
+		pageCriteria.OrderBy = models.Descending
 	}
 
-	var tx *gorm.DB
-	if fromDate.IsZero() && toDate.IsZero() && criteria.LatestPerHost {
-		tx = buildLatestReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus, criteria.Limit)
+	tx, fromReportTable := r.buildSearchQuery(criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object in HVSReport Search function.")
+	}
 
-		if tx == nil {
-			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
-				" a gorm query object in HVSReport Search function.")
-		}
+	if fromReportTable {
+		tx = applyPageCriteria(tx, pageCriteria, latestReportSortColumns, models.SortByCreatedAt)
 
 		rows, err := tx.Rows()
 		if err != nil {
@@ -195,11 +221,7 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 
 		return reports, nil
 	} else {
-		tx = buildReportSearchQuery(r.Store.Db, hostID, hostHardwareUUID, hostName, hostStatus, fromDate, toDate, latestPerHost, criteria.Limit)
-		if tx == nil {
-			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
-				" a gorm query object in HVSReport Search function.")
-		}
+		tx = applyPageCriteria(tx, pageCriteria, reportSortColumns, models.SortByCreatedAt)
 
 		rows, err := tx.Rows()
 		if err != nil {
@@ -232,16 +254,98 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 	}
 }
 
-// FindHostIdsFromExpiredReports searches the report table for reports that have an
-// 'expiration' between 'fromTime' and 'toTime'.
+// Count returns the number of reports matching the filter criteria, ignoring its page criteria
+func (r *ReportStore) Count(criteria *models.ReportFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/report_store:Count() Entering")
+	defer defaultLog.Trace("postgres/report_store:Count() Leaving")
+
+	tx, _ := r.buildSearchQuery(criteria)
+	if tx == nil {
+		return 0, errors.New("postgres/report_store:Count() Unexpected Error. Could not build" +
+			" a gorm query object in HVSReport Count function.")
+	}
+	return countRows(r.Store.Db, tx)
+}
+
+// latestReportSortColumns maps the sortBy values of a report search to the report table columns
+var latestReportSortColumns = map[string]string{
+	models.SortById:         "report.id",
+	models.SortByCreatedAt:  "report.created",
+	models.SortByExpiration: "report.expiration",
+}
+
+// reportSortColumns maps the sortBy values of a report search to the columns of the reports in the audit log
+var reportSortColumns = map[string]string{
+	models.SortById:         "au.entity_id",
+	models.SortByCreatedAt:  "au.created",
+	models.SortByExpiration: "au.data -> 'Columns' -> 4 ->> 'Value'",
+}
+
+// buildSearchQuery builds the query object for a report search. The latest reports are searched in the report table
+// unless a date range is given, in which case the reports are searched in the audit log. The returned flag is set when
+// the query is on the report table.
+func (r *ReportStore) buildSearchQuery(criteria *models.ReportFilterCriteria) (*gorm.DB, bool) {
+	defaultLog.Trace("postgres/report_store:buildSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/report_store:buildSearchQuery() Leaving")
+
+	var reportID uuid.UUID
+	var hostID uuid.UUID
+	var hostName string
+	var hostHardwareUUID uuid.UUID
+	var hostStatus string
+	var latestPerHost bool
+	var toDate time.Time
+	var fromDate time.Time
+
+	if criteria.ID != uuid.Nil {
+		reportID = criteria.ID
+	}
+	if criteria.HostID != uuid.Nil {
+		hostID = criteria.HostID
+	}
+	if criteria.HostHardwareID != uuid.Nil {
+		hostHardwareUUID = criteria.HostHardwareID
+	}
+	if criteria.HostStatus != "" {
+		hostStatus = criteria.HostStatus
+	}
+	if criteria.HostName != "" {
+		hostName = criteria.HostName
+	}
+	if !criteria.ToDate.IsZero() {
+		toDate = criteria.ToDate
+	}
+	if !criteria.FromDate.IsZero() {
+		fromDate = criteria.FromDate
+	}
+	latestPerHost = criteria.LatestPerHost
+
+	if criteria.NumberOfDays != 0 {
+		toDate = time.Now().UTC()
+		fromDate = toDate.AddDate(0, 0, -(criteria.NumberOfDays)).UTC()
+	}
+
+	if fromDate.IsZero() && toDate.IsZero() && criteria.LatestPerHost {
+		return buildLatestReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus), true
+	}
+	return buildReportSearchQuery(r.Store.Db, hostID, hostHardwareUUID, hostName, hostStatus, fromDate, toDate, latestPerHost), false
+}
+
+// FindHostIdsFromExpiredReports searches the report table for reports that are due for a refresh between 'fromTime'
+// and 'toTime'. A report is due when it expires or, for a host linked to flavorgroups with a report policy, once the
+// shortest refresh interval of these flavorgroups has elapsed since the report was created.
 // It also discovers hosts that do not have a corresponding report in the table.
 func (r *ReportStore) FindHostIdsFromExpiredReports(fromTime time.Time, toTime time.Time) ([]uuid.UUID, error) {
 
 	var tx *gorm.DB
 	tx = r.Store.Db.Raw("SELECT h.id from host h "+
 		"INNER JOIN report r ON h.id = r.host_id "+
-		"WHERE CAST(expiration AS TIMESTAMP) > CAST(? AS TIMESTAMP) "+
-		"AND CAST(expiration AS TIMESTAMP) <= CAST(? AS TIMESTAMP) "+
+		"LEFT JOIN (SELECT hf.host_id, MIN(CAST(fg.report_policy ->> 'refresh_interval_seconds' AS INTEGER)) AS refresh_interval "+
+		"FROM host_flavorgroup hf INNER JOIN flavor_group fg ON fg.id = hf.flavorgroup_id "+
+		"WHERE CAST(fg.report_policy ->> 'refresh_interval_seconds' AS INTEGER) > 0 "+
+		"GROUP BY hf.host_id) p ON h.id = p.host_id "+
+		"WHERE CAST(LEAST(r.expiration, r.created + p.refresh_interval * INTERVAL '1 second') AS TIMESTAMP) > CAST(? AS TIMESTAMP) "+
+		"AND CAST(LEAST(r.expiration, r.created + p.refresh_interval * INTERVAL '1 second') AS TIMESTAMP) <= CAST(? AS TIMESTAMP) "+
 		"AND h.id NOT IN (SELECT CAST(params ->> 'host_id' AS uuid) from queue) "+
 		"UNION "+
 		"SELECT h.id FROM host h LEFT JOIN report r ON h.id = r.host_id "+
@@ -343,7 +447,7 @@ func (r *ReportStore) Delete(reportId uuid.UUID) error {
 }
 
 // buildReportSearchQuery is a helper function to build the query object for a report search.
-func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostName, hostState string, fromDate, toDate time.Time, latestPerHost bool, limit int) *gorm.DB {
+func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostName, hostState string, fromDate, toDate time.Time, latestPerHost bool) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Leaving")
 	if tx == nil {
@@ -361,7 +465,6 @@ func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostN
 		tx = tx.Table("audit_log_entry au").Select("au.*")
 		tx = buildReportSearchQueryWithCriteria(tx, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate)
 	}
-	tx = tx.Limit(limit)
 	return tx
 }
 
@@ -408,7 +511,7 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 }
 
 // buildLatestReportSearchQuery is a helper function to build the query object for a latest report search.
-func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string, limit int) *gorm.DB {
+func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Leaving")
 
@@ -443,7 +546,5 @@ func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID
 	if hostID != uuid.Nil {
 		tx = tx.Where("host_id = ?", hostID.String())
 	}
-
-	tx = tx.Limit(limit)
 	return tx
 }
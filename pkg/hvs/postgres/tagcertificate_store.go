@@ -66,6 +66,12 @@ func (tcs *TagCertificateStore) Search(tcFilter *models.TagCertificateFilterCrit
 			" a gorm query object in TagCertificate Search function.")
 	}
 
+	pageCriteria := models.PageCriteria{}
+	if tcFilter != nil {
+		pageCriteria = tcFilter.PageCriteria
+	}
+	tx = applyPageCriteria(tx, pageCriteria, tagCertificateSortColumns, models.SortBySubject)
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/tagcertificate_store:Search() failed to retrieve records from db")
@@ -88,6 +94,20 @@ func (tcs *TagCertificateStore) Search(tcFilter *models.TagCertificateFilterCrit
 	return tcResultSet, nil
 }
 
+// Count returns the number of TagCertificates records matching the filter criteria, ignoring its page criteria
+func (tcs *TagCertificateStore) Count(tcFilter *models.TagCertificateFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/tagcertificate_store:Count() Entering")
+	defer defaultLog.Trace("postgres/tagcertificate_store:Count() Leaving")
+
+	tx := buildTagCertificateSearchQuery(tcs.Store.Db, tcFilter)
+
+	if tx == nil {
+		return 0, errors.New("postgres/tagcertificate_store:Count() Unexpected Error. Could not build" +
+			" a gorm query object in TagCertificate Count function.")
+	}
+	return countRows(tcs.Store.Db, tx)
+}
+
 // Retrieve returns a single TagCertificate record by unique ID
 func (tcs *TagCertificateStore) Retrieve(tagCertId uuid.UUID) (*hvs.TagCertificate, error) {
 	defaultLog.Trace("postgres/tagcertificate_store:Retrieve() Entering")
@@ -124,7 +144,7 @@ func buildTagCertificateSearchQuery(tx *gorm.DB, tcFilter *models.TagCertificate
 	if tcFilter == nil {
 		defaultLog.Info("postgres/tagcertificate_store:buildTagCertificateSearchQuery() No criteria specified in search query" +
 			". Returning all rows.")
-		return tx.Order("subject")
+		return tx
 	}
 
 	// Tag Certificate ID
@@ -171,9 +191,19 @@ func buildTagCertificateSearchQuery(tx *gorm.DB, tcFilter *models.TagCertificate
 		validAfterTs := tcFilter.ValidAfter.Format(constants.ParamDateTimeFormatUTC)
 		tx = tx.Where("CAST(? as timestamp) <= notafter", validAfterTs)
 	}
-
-	// ORDER BY
-	tx = tx.Order("subject")
+	if !tcFilter.ExpiresBefore.IsZero() {
+		expiresBeforeTs := tcFilter.ExpiresBefore.Format(constants.ParamDateTimeFormatUTC)
+		tx = tx.Where("notafter < CAST(? as timestamp)", expiresBeforeTs)
+	}
 
 	return tx
 }
+
+// tagCertificateSortColumns maps the sortBy values of a TagCertificate search to the tag_certificate table columns
+var tagCertificateSortColumns = map[string]string{
+	models.SortById:        "id",
+	models.SortBySubject:   "subject",
+	models.SortByIssuer:    "issuer",
+	models.SortByNotBefore: "notbefore",
+	models.SortByNotAfter:  "notafter",
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	trustStatusTrusted   = "trusted"
+	trustStatusUntrusted = "untrusted"
+
+	// reportTrustStatus is the trust status of a host from its report r, it is unknown when the host has no report or
+	// when its report has expired
+	reportTrustStatus = "CASE WHEN r.id IS NULL OR CAST(r.expiration AS TIMESTAMP) <= CAST(? AS TIMESTAMP) THEN 'unknown' " +
+		"WHEN r.trusted THEN 'trusted' ELSE 'untrusted' END"
+
+	// reportResults are the rule results of the report r, a report without results holds a null array
+	reportResults = "jsonb_array_elements(CASE WHEN jsonb_typeof(r.trust_report -> 'results') = 'array' " +
+		"THEN r.trust_report -> 'results' ELSE '[]'::JSONB END) res(result)"
+)
+
+// TrustSummaryStore counts the hosts by trust status from the report table, which holds the latest report of each
+// host, and lists the hosts to be acted upon from the report and host_status tables
+type TrustSummaryStore struct {
+	Store *DataStore
+}
+
+func NewTrustSummaryStore(store *DataStore) *TrustSummaryStore {
+	return &TrustSummaryStore{Store: store}
+}
+
+// CountTrustStatus counts the hosts by trust status in total and per flavorgroup, flavor part and fault name. The
+// trust status of a flavor part is computed from the rule results holding its marker and a fault is counted once per
+// host even when it is reported by several rules.
+func (tss *TrustSummaryStore) CountTrustStatus(at time.Time) (*models.TrustStatusCounts, error) {
+	defaultLog.Trace("postgres/trust_summary_store:CountTrustStatus() Entering")
+	defer defaultLog.Trace("postgres/trust_summary_store:CountTrustStatus() Leaving")
+
+	counts := models.TrustStatusCounts{
+		Flavorgroups: make(map[uuid.UUID]hvs.TrustCounts),
+		FlavorParts:  make(map[string]hvs.TrustCounts),
+		Faults:       make(map[string]hvs.TrustCounts),
+	}
+
+	err := scanTrustStatusCounts(tss.Store.Db.Raw("SELECT '', "+reportTrustStatus+" AS status, COUNT(*) "+
+		"FROM host h LEFT JOIN report r ON r.host_id = h.id GROUP BY status", at),
+		func(_ string, trustCounts hvs.TrustCounts) {
+			counts.Hosts = trustCounts
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/trust_summary_store:CountTrustStatus() failed to count hosts")
+	}
+
+	err = scanTrustStatusCounts(tss.Store.Db.Raw("SELECT CAST(hf.flavorgroup_id AS VARCHAR), "+reportTrustStatus+" AS status, COUNT(*) "+
+		"FROM host_flavorgroup hf LEFT JOIN report r ON r.host_id = hf.host_id GROUP BY hf.flavorgroup_id, status", at),
+		func(flavorgroupId string, trustCounts hvs.TrustCounts) {
+			counts.Flavorgroups[uuid.MustParse(flavorgroupId)] = trustCounts
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/trust_summary_store:CountTrustStatus() failed to count hosts per flavorgroup")
+	}
+
+	err = scanTrustStatusCounts(tss.Store.Db.Raw("SELECT p.marker, CASE WHEN p.status = 'unknown' THEN 'unknown' "+
+		"WHEN p.trusted THEN 'trusted' ELSE 'untrusted' END AS marker_status, COUNT(*) "+
+		"FROM (SELECT r.host_id, m.marker, "+reportTrustStatus+" AS status, BOOL_AND(CAST(res.result ->> 'trusted' AS BOOLEAN)) AS trusted "+
+		"FROM report r, "+reportResults+", jsonb_array_elements_text(res.result -> 'rule' -> 'markers') m(marker) "+
+		"GROUP BY r.host_id, m.marker, status) p GROUP BY p.marker, marker_status", at),
+		func(flavorPart string, trustCounts hvs.TrustCounts) {
+			counts.FlavorParts[flavorPart] = trustCounts
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/trust_summary_store:CountTrustStatus() failed to count hosts per flavor part")
+	}
+
+	err = scanTrustStatusCounts(tss.Store.Db.Raw("SELECT f.fault ->> 'fault_name' AS fault_name, "+reportTrustStatus+" AS status, "+
+		"COUNT(DISTINCT r.host_id) FROM report r, "+reportResults+", jsonb_array_elements(res.result -> 'faults') f(fault) "+
+		"GROUP BY fault_name, status", at),
+		func(faultName string, trustCounts hvs.TrustCounts) {
+			counts.Faults[faultName] = trustCounts
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/trust_summary_store:CountTrustStatus() failed to count hosts per fault")
+	}
+
+	return &counts, nil
+}
+
+// SearchExpiringReports returns the reports expiring after fromTime and before toTime, the reports expiring first are
+// returned first
+func (tss *TrustSummaryStore) SearchExpiringReports(fromTime time.Time, toTime time.Time) ([]hvs.ExpiringReport, error) {
+	defaultLog.Trace("postgres/trust_summary_store:SearchExpiringReports() Entering")
+	defer defaultLog.Trace("postgres/trust_summary_store:SearchExpiringReports() Leaving")
+
+	rows, err := tss.Store.Db.Raw("SELECT r.host_id, h.name, r.id, r.trusted, r.expiration FROM report r "+
+		"INNER JOIN host h ON h.id = r.host_id "+
+		"WHERE CAST(r.expiration AS TIMESTAMP) > CAST(? AS TIMESTAMP) AND CAST(r.expiration AS TIMESTAMP) < CAST(? AS TIMESTAMP) "+
+		"ORDER BY r.expiration", fromTime, toTime).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/trust_summary_store:SearchExpiringReports() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	expiringReports := []hvs.ExpiringReport{}
+	for rows.Next() {
+		var expiringReport hvs.ExpiringReport
+		if err := rows.Scan(&expiringReport.HostID, &expiringReport.HostName, &expiringReport.ReportID,
+			&expiringReport.Trusted, &expiringReport.Expiration); err != nil {
+			return nil, errors.Wrap(err, "postgres/trust_summary_store:SearchExpiringReports() failed to scan record")
+		}
+		expiringReports = append(expiringReports, expiringReport)
+	}
+	return expiringReports, nil
+}
+
+// SearchConnectionFailures returns the hosts whose host status is neither CONNECTED nor QUEUE, by host state
+func (tss *TrustSummaryStore) SearchConnectionFailures() (map[string][]hvs.TrustSummaryHost, error) {
+	defaultLog.Trace("postgres/trust_summary_store:SearchConnectionFailures() Entering")
+	defer defaultLog.Trace("postgres/trust_summary_store:SearchConnectionFailures() Leaving")
+
+	rows, err := tss.Store.Db.Raw("SELECT hs.host_id, h.name, hs.status ->> 'host_state' FROM host_status hs "+
+		"INNER JOIN host h ON h.id = hs.host_id WHERE hs.status ->> 'host_state' NOT IN (?) ORDER BY h.name",
+		[]string{hvs.HostStateConnected.String(), hvs.HostStateQueue.String()}).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/trust_summary_store:SearchConnectionFailures() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	connectionFailures := make(map[string][]hvs.TrustSummaryHost)
+	for rows.Next() {
+		var host hvs.TrustSummaryHost
+		var hostState string
+		if err := rows.Scan(&host.HostID, &host.HostName, &hostState); err != nil {
+			return nil, errors.Wrap(err, "postgres/trust_summary_store:SearchConnectionFailures() failed to scan record")
+		}
+		connectionFailures[hostState] = append(connectionFailures[hostState], host)
+	}
+	return connectionFailures, nil
+}
+
+// scanTrustStatusCounts reads the rows of a query selecting a key, a trust status and a number of hosts and passes
+// the counts of each key to add
+func scanTrustStatusCounts(tx *gorm.DB, add func(string, hvs.TrustCounts)) error {
+	rows, err := tx.Rows()
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	counts := make(map[string]hvs.TrustCounts)
+	var keys []string
+	for rows.Next() {
+		var key, status string
+		var count int
+		if err := rows.Scan(&key, &status, &count); err != nil {
+			return errors.Wrap(err, "failed to scan record")
+		}
+		trustCounts, ok := counts[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		switch status {
+		case trustStatusTrusted:
+			trustCounts.Trusted += count
+		case trustStatusUntrusted:
+			trustCounts.Untrusted += count
+		default:
+			trustCounts.Unknown += count
+		}
+		counts[key] = trustCounts
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "failed to read records")
+	}
+	for _, key := range keys {
+		add(key, counts[key])
+	}
+	return nil
+}
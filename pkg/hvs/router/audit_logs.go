@@ -0,0 +1,30 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+)
+
+// SetAuditLogRoutes registers routes for audit log search and export
+func SetAuditLogRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/audit_logs:SetAuditLogRoutes() Entering")
+	defer defaultLog.Trace("router/audit_logs:SetAuditLogRoutes() Leaving")
+
+	auditLogEntryStore := postgres.NewAuditLogEntryStore(store)
+	auditLogController := controllers.AuditLogController{Store: auditLogEntryStore}
+
+	router.Handle("/audit-logs/export", ErrorHandler(permissionsHandler(StreamResponseHandler(auditLogController.Export,
+		consts.HTTPMediaTypeNDJson), []string{constants.AuditLogSearch}))).Methods("GET")
+
+	router.Handle("/audit-logs", ErrorHandler(permissionsHandler(JsonResponseHandler(auditLogController.Search),
+		[]string{constants.AuditLogSearch}))).Methods("GET")
+
+	return router
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/dek"
+)
+
+// SetDataEncryptionKeyRoutes registers routes for the rotation of the data encryption key
+func SetDataEncryptionKeyRoutes(router *mux.Router, cfg *config.Configuration, store *postgres.DataStore, hostControllerConfig domain.HostControllerConfig) *mux.Router {
+	defaultLog.Trace("router/data_encryption_key:SetDataEncryptionKeyRoutes() Entering")
+	defer defaultLog.Trace("router/data_encryption_key:SetDataEncryptionKeyRoutes() Leaving")
+
+	rotator := dek.NewRotator(cfg, constants.DefaultConfigFilePath, hostControllerConfig.DataEncryptionKeys,
+		postgres.NewDataEncryptionKeyStore(store))
+	dekController := controllers.NewDataEncryptionKeyController(rotator)
+
+	router.Handle("/data-encryption-keys/rotate", ErrorHandler(permissionsHandler(JsonResponseHandler(dekController.Rotate),
+		[]string{constants.DataEncryptionKeyRotate}))).Methods("POST")
+
+	return router
+}
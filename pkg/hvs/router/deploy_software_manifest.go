@@ -24,7 +24,7 @@ func SetDeploySoftwareManifestRoute(router *mux.Router, store *postgres.DataStor
 	hostStore := postgres.NewHostStore(store)
 	hostStatusStore := postgres.NewHostStatusStore(store)
 
-	hostCredentialStore := postgres.NewHostCredentialStore(store, hcConfig.DataEncryptionKey)
+	hostCredentialStore := postgres.NewHostCredentialStore(store, hcConfig.DataEncryptionKeys)
 	hc := controllers.NewHostController(hostStore, hostStatusStore, flavorStore,
 		flavorGroupStore, hostCredentialStore, htm, hcConfig)
 	dsmController := controllers.NewDeploySoftwareManifestController(flavorStore, *hc)
@@ -23,12 +23,12 @@ func SetESXiClusterRoutes(router *mux.Router, store *postgres.DataStore,
 	defaultLog.Trace("router/esxi_cluster:SetESXiClusterRoutes() Entering")
 	defer defaultLog.Trace("router/esxi_cluster:SetESXiClusterRoutes() Leaving")
 
-	esxiClusterStore := postgres.NewESXiCLusterStore(store, hostControllerConfig.DataEncryptionKey)
+	esxiClusterStore := postgres.NewESXiCLusterStore(store, hostControllerConfig.DataEncryptionKeys)
 	hostStore := postgres.NewHostStore(store)
 	hostStatusStore := postgres.NewHostStatusStore(store)
 	flavorStore := postgres.NewFlavorStore(store)
 	flavorGroupStore := postgres.NewFlavorGroupStore(store)
-	hostCredentialStore := postgres.NewHostCredentialStore(store, hostControllerConfig.DataEncryptionKey)
+	hostCredentialStore := postgres.NewHostCredentialStore(store, hostControllerConfig.DataEncryptionKeys)
 	hc := controllers.NewHostController(hostStore, hostStatusStore, flavorStore,
 		flavorGroupStore, hostCredentialStore, hostTrustManager, hostControllerConfig)
 	esxiClusterController := controllers.NewESXiClusterController(esxiClusterStore, *hc)
@@ -6,6 +6,7 @@ package router
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
@@ -17,7 +18,7 @@ import (
 )
 
 // SetFlavorRoutes registers routes for flavors
-func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupStore *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, flavorControllerConfig domain.HostControllerConfig) *mux.Router {
+func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupStore *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, flavorControllerConfig domain.HostControllerConfig, supersessionGracePeriod time.Duration) *mux.Router {
 	defaultLog.Trace("router/flavors:SetFlavorRoutes() Entering")
 	defer defaultLog.Trace("router/flavors:SetFlavorRoutes() Leaving")
 
@@ -25,7 +26,12 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 	flavorStore := postgres.NewFlavorStore(store)
 	tagCertStore := postgres.NewTagCertificateStore(store)
 	flavorTemplateStore := postgres.NewFlavorTemplateStore(store)
-	flavorController := controllers.NewFlavorController(flavorStore, flavorGroupStore, hostStore, tagCertStore, hostTrustManager, certStore, flavorControllerConfig, flavorTemplateStore)
+	hostStatusStore := postgres.NewHostStatusStore(store)
+	flavorController := controllers.NewFlavorController(flavorStore, flavorGroupStore, hostStore, tagCertStore, hostTrustManager, certStore, flavorControllerConfig, flavorTemplateStore, hostStatusStore)
+	// configurations created before flavor supersession was introduced keep the default grace period
+	if supersessionGracePeriod > 0 {
+		flavorController.SupersessionGracePeriod = supersessionGracePeriod
+	}
 
 	flavorIdExpr := fmt.Sprintf("%s%s", "/flavors/", validation.IdReg)
 
@@ -38,6 +44,10 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Search),
 			[]string{constants.FlavorSearch}))).Methods("GET")
 
+	router.Handle("/flavors/evaluate",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Evaluate),
+			[]string{constants.FlavorEvaluate}))).Methods("POST")
+
 	router.Handle(flavorIdExpr,
 		ErrorHandler(permissionsHandler(ResponseHandler(flavorController.Delete),
 			[]string{constants.FlavorDelete}))).Methods("DELETE")
@@ -46,5 +56,15 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Retrieve),
 			[]string{constants.FlavorRetrieve}))).Methods("GET")
 
+	flavorBundleController := controllers.NewFlavorBundleController(flavorController)
+
+	router.Handle("/flavor-bundles",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorBundleController.Export),
+			[]string{constants.FlavorBundleExport}))).Methods("GET")
+
+	router.Handle("/flavor-bundles",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorBundleController.Import),
+			[]string{constants.FlavorBundleImport}))).Methods("POST")
+
 	return router
 }
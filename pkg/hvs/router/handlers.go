@@ -96,6 +96,26 @@ func XMLResponseHandler(h func(http.ResponseWriter, *http.Request) (interface{},
 	}
 }
 
+// StreamResponseHandler is used for handler functions that write a streamed response body themselves. The
+// response is only written here when the handler fails before it starts streaming.
+func StreamResponseHandler(h func(http.ResponseWriter, *http.Request) (interface{}, int, error), mediaType string) endpointHandler {
+	defaultLog.Trace("router/handlers:StreamResponseHandler() Entering")
+	defer defaultLog.Trace("router/handlers:StreamResponseHandler() Leaving")
+
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Header.Get("Accept") != mediaType {
+			return errorFormatter(&commErr.EndpointError{
+				Message: "Invalid Accept type",
+			}, http.StatusUnsupportedMediaType)
+		}
+		_, status, err := h(w, r) // execute application handler
+		if err != nil {
+			return errorFormatter(err, status)
+		}
+		return nil
+	}
+}
+
 func errorFormatter(err error, status int) error {
 	defaultLog.Trace("router/handlers:errorFormatter() Entering")
 	defer defaultLog.Trace("router/handlers:errorFormatter() Leaving")
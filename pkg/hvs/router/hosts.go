@@ -24,19 +24,27 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 	hostStatusStore := postgres.NewHostStatusStore(store)
 	flavorStore := postgres.NewFlavorStore(store)
 	flavorGroupStore := postgres.NewFlavorGroupStore(store)
-	hostCredentialStore := postgres.NewHostCredentialStore(store, hostControllerConfig.DataEncryptionKey)
+	hostCredentialStore := postgres.NewHostCredentialStore(store, hostControllerConfig.DataEncryptionKeys)
 
 	hostController := controllers.NewHostController(hostStore, hostStatusStore,
 		flavorStore, flavorGroupStore, hostCredentialStore,
 		hostTrustManager, hostControllerConfig)
+	hostBulkController := controllers.NewHostBulkController(hostController, postgres.NewJobStore(store))
+	reportHistoryController := controllers.NewReportHistoryController(hostStore, postgres.NewReportHistoryStore(store))
 
 	hostExpr := "/hosts"
+	hostBulkExpr := fmt.Sprintf("%s/bulk", hostExpr)
 	hostIdExpr := fmt.Sprintf("%s/{hId:%s}", hostExpr, validation.UUIDReg)
+	manifestExpr := fmt.Sprintf("%s/manifest", hostIdExpr)
+	eventLogExpr := fmt.Sprintf("%s/event-log", hostIdExpr)
+	reportsExpr := fmt.Sprintf("%s/reports", hostIdExpr)
 	flavorgroupExpr := fmt.Sprintf("%s/flavorgroups", hostIdExpr)
 	flavorgroupIdExpr := fmt.Sprintf("%s/{fgId:%s}", flavorgroupExpr, validation.UUIDReg)
 
 	router.Handle(hostExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.Create),
 		[]string{constants.HostCreate}))).Methods("POST")
+	router.Handle(hostBulkExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostBulkController.Create),
+		[]string{constants.HostCreate}))).Methods("POST")
 	router.Handle(hostIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.Retrieve),
 		[]string{constants.HostRetrieve}))).Methods("GET")
 	router.Handle(hostIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.Update),
@@ -46,6 +54,13 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 	router.Handle(hostExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.Search),
 		[]string{constants.HostSearch}))).Methods("GET")
 
+	router.Handle(manifestExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.RetrieveManifest),
+		[]string{constants.HostRetrieve}))).Methods("GET")
+	router.Handle(eventLogExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.RetrieveEventLog),
+		[]string{constants.HostRetrieve}))).Methods("GET")
+	router.Handle(reportsExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(reportHistoryController.Search),
+		[]string{constants.ReportSearch}))).Methods("GET")
+
 	router.Handle(flavorgroupExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.AddFlavorgroup),
 		[]string{constants.HostCreate}))).Methods("POST")
 	router.Handle(flavorgroupIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.RetrieveFlavorgroup),
@@ -0,0 +1,26 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
+)
+
+// SetMetricsRoutes registers routes for the metrics of the verification pipeline
+func SetMetricsRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/metrics:SetMetricsRoutes() Entering")
+	defer defaultLog.Trace("router/metrics:SetMetricsRoutes() Leaving")
+
+	metricsController := controllers.NewMetricsController(postgres.NewDBQueueStore(store))
+
+	router.Handle("/metrics",
+		ErrorHandler(permissionsHandler(metricsController.Retrieve,
+			[]string{constants.MetricsRetrieve}))).Methods("GET")
+
+	return router
+}
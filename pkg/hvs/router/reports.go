@@ -8,16 +8,21 @@ import (
 	"fmt"
 
 	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
 	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/eat"
+	"github.com/pkg/errors"
 )
 
 // SetReportRoutes registers routes for reports
-func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManager domain.HostTrustManager) *mux.Router {
+func SetReportRoutes(router *mux.Router, cfg *config.Configuration, store *postgres.DataStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager) *mux.Router {
 	defaultLog.Trace("router/reports:SetReportRoutes() Entering")
 	defer defaultLog.Trace("router/reports:SetReportRoutes() Leaving")
 
@@ -25,6 +30,11 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 	hostStore := postgres.NewHostStore(store)
 	hostStatusStore := postgres.NewHostStatusStore(store)
 	reportController := controllers.NewReportController(reportStore, hostStore, hostStatusStore, hostTrustManager)
+	tokenIssuer, err := newReportTokenIssuer(cfg, certStore)
+	if err != nil {
+		defaultLog.WithError(err).Error("router/reports:SetReportRoutes() Could not initialize the JWT report signing key, JWT reports are not available")
+	}
+	reportController.TokenIssuer = tokenIssuer
 
 	reportIdExpr := fmt.Sprintf("%s%s", "/reports/", validation.IdReg)
 
@@ -32,6 +42,10 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 		ErrorHandler(permissionsHandler(ResponseHandler(reportController.CreateSaml),
 			[]string{constants.ReportCreate}))).Methods("POST").Headers("Accept", consts.HTTPMediaTypeSaml)
 
+	router.Handle("/reports",
+		ErrorHandler(permissionsHandler(ResponseHandler(reportController.CreateJwt),
+			[]string{constants.ReportCreate}))).Methods("POST").Headers("Accept", consts.HTTPMediaTypeJwt)
+
 	router.Handle("/reports",
 		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Create),
 			[]string{constants.ReportCreate}))).Methods("POST")
@@ -40,6 +54,14 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 		ErrorHandler(permissionsHandler(ResponseHandler(reportController.SearchSaml),
 			[]string{constants.ReportSearch}))).Methods("GET").Headers("Accept", consts.HTTPMediaTypeSaml)
 
+	router.Handle("/reports",
+		ErrorHandler(permissionsHandler(ResponseHandler(reportController.SearchJwt),
+			[]string{constants.ReportSearch}))).Methods("GET").Headers("Accept", consts.HTTPMediaTypeJwt)
+
+	router.Handle("/reports/diff",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Diff),
+			[]string{constants.ReportRetrieve}))).Methods("GET")
+
 	router.Handle(reportIdExpr,
 		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Retrieve),
 			[]string{constants.ReportRetrieve}))).Methods("GET")
@@ -50,3 +72,27 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 
 	return router
 }
+
+// newReportTokenIssuer returns the issuer of the JWT reports, signing them with the configured key or with the SAML key
+func newReportTokenIssuer(cfg *config.Configuration, certStore *models.CertificatesStore) (*eat.Issuer, error) {
+	defaultLog.Trace("router/reports:newReportTokenIssuer() Entering")
+	defer defaultLog.Trace("router/reports:newReportTokenIssuer() Leaving")
+
+	ic := eat.IssuerConfiguration{IssuerName: cfg.SAML.Issuer}
+	if cfg.ReportToken.KeyFile != "" {
+		cert, key, err := crypt.LoadX509CertAndPrivateKey(cfg.ReportToken.CertFile, cfg.ReportToken.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error loading the JWT report signing key")
+		}
+		ic.Certificate = cert
+		ic.PrivateKey = key
+	} else {
+		samlCert := (*certStore)[models.CertTypesSaml.String()]
+		if samlCert == nil || len(samlCert.Certificates) == 0 {
+			return nil, errors.New("SAML certificate is not loaded")
+		}
+		ic.Certificate = &samlCert.Certificates[0]
+		ic.PrivateKey = samlCert.Key
+	}
+	return eat.NewIssuer(ic)
+}
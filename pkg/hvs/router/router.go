@@ -75,13 +75,18 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 		cacheTime))
 	subRouter = SetFlavorGroupRoutes(subRouter, dataStore, fgs, hostTrustManager)
 	subRouter = SetFlavorTemplateRoutes(subRouter, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
-	subRouter = SetFlavorRoutes(subRouter, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	subRouter = SetFlavorRoutes(subRouter, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, cfg.FRS.GracePeriod)
 	subRouter = SetTpmEndorsementRoutes(subRouter, dataStore)
 	subRouter = SetCertifyAiksRoutes(subRouter, dataStore, certStore, cfg.AikCertValidity)
 	subRouter = SetHostStatusRoutes(subRouter, dataStore)
 	subRouter = SetCertifyHostKeysRoutes(subRouter, certStore)
 	subRouter = SetHostRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
-	subRouter = SetReportRoutes(subRouter, dataStore, hostTrustManager)
+	subRouter = SetReportRoutes(subRouter, cfg, dataStore, certStore, hostTrustManager)
+	subRouter = SetTrustSummaryRoutes(subRouter, dataStore)
+	subRouter = SetMetricsRoutes(subRouter, dataStore)
+	subRouter = SetJobRoutes(subRouter, dataStore)
+	subRouter = SetAuditLogRoutes(subRouter, dataStore)
+	subRouter = SetDataEncryptionKeyRoutes(subRouter, cfg, dataStore, hostControllerConfig)
 	subRouter = SetCreateCaCertificatesRoutes(subRouter, certStore)
 	subRouter = SetTagCertificateRoutes(subRouter, cfg, fgs, certStore, hostTrustManager, dataStore)
 	subRouter = SetESXiClusterRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
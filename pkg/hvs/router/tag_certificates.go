@@ -21,6 +21,7 @@ import (
 
 const (
 	TagCertificateEndpointPath       = "/tag-certificates"
+	TagCertificateBulkEndpointPath   = "/tag-certificates/bulk"
 	TagCertificateDeployEndpointPath = "/rpc/deploy-tag-certificate"
 )
 
@@ -32,7 +33,7 @@ func SetTagCertificateRoutes(router *mux.Router, cfg *config.Configuration, flav
 	// set up the HostConnectorProvider for the Controller
 	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()].Certificates
 	var hcp hostConnector.HostConnectorProvider
-	hcp = hostConnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs, cfg.NATS.Servers)
+	hcp = hostConnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs, cfg.NATS.Servers, cfg.FVS.EnableSimulatedHosts)
 
 	if hcp == nil {
 		defaultLog.Errorf("router/tag_certificates:SetTagCertificateRoutes() %s : Error initializing the Host Connector Factory", commLogMsg.AppRuntimeErr)
@@ -69,6 +70,17 @@ func SetTagCertificateRoutes(router *mux.Router, cfg *config.Configuration, flav
 		router.Handle(TagCertificateDeployEndpointPath,
 			ErrorHandler(permissionsHandler(JsonResponseHandler(tagCertificateController.Deploy),
 				[]string{constants.TagCertificateDeploy}))).Methods("POST")
+
+		// bulk provisioning both creates and deploys the tag certificates, hence it requires both permissions
+		tagCertificateBulkController := controllers.NewTagCertificateBulkController(tagCertificateController, hostStore,
+			flavorGroupStore, postgres.NewJobStore(store))
+		tagCertificateBulkRetryExpr := fmt.Sprintf("%s/{id:%s}/retry", TagCertificateBulkEndpointPath, validation.UUIDReg)
+		router.Handle(TagCertificateBulkEndpointPath,
+			ErrorHandler(permissionsHandler(permissionsHandler(JsonResponseHandler(tagCertificateBulkController.Create),
+				[]string{constants.TagCertificateCreate}), []string{constants.TagCertificateDeploy}))).Methods("POST")
+		router.Handle(tagCertificateBulkRetryExpr,
+			ErrorHandler(permissionsHandler(permissionsHandler(JsonResponseHandler(tagCertificateBulkController.Retry),
+				[]string{constants.TagCertificateCreate}), []string{constants.TagCertificateDeploy}))).Methods("POST")
 	}
 	return router
 }
@@ -0,0 +1,27 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
+)
+
+// SetTrustSummaryRoutes registers routes for the trust summary
+func SetTrustSummaryRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/trust_summary:SetTrustSummaryRoutes() Entering")
+	defer defaultLog.Trace("router/trust_summary:SetTrustSummaryRoutes() Leaving")
+
+	trustSummaryController := controllers.NewTrustSummaryController(postgres.NewTrustSummaryStore(store),
+		postgres.NewFlavorGroupStore(store))
+
+	router.Handle("/trust-summary",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(trustSummaryController.Retrieve),
+			[]string{constants.TrustSummaryRetrieve}))).Methods("GET")
+
+	return router
+}
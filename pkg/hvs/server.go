@@ -8,7 +8,6 @@ import (
 	"context"
 	"crypto/rsa"
 	"crypto/tls"
-	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
@@ -22,16 +21,21 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/auditlog"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/dek"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/frs"
 	hostfetcher "github.com/intel-secl/intel-secl/v4/pkg/hvs/services/host-fetcher"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hosttrust"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hrrs"
-	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/jps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/rhps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/tcrs"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/trustevent"
 	hostconnector "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/saml"
-	"github.com/intel-secl/intel-secl/v4/pkg/lib/verifier"
 
 	"github.com/gorilla/handlers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
@@ -74,9 +78,22 @@ func (a *App) startServer() error {
 	// Load Certificates
 	certStore := utils.LoadCertificates(a.loadCertPathStore())
 
+	// Initialize trust state change event publisher, only when there is a target to deliver the events to
+	var trustEventPublisher domain.TrustEventPublisher
+	if len(c.TrustEvents.Webhooks) > 0 || c.TrustEvents.NatsSubject != "" {
+		trustEventPublisher, err = trustevent.NewTrustEventPublisher(c.TrustEvents, c.NATS.Servers)
+		if err != nil {
+			return errors.Wrap(err, "An error occurred while initializing trust event publisher")
+		}
+	}
+
+	// The data encryption keys are shared by the stores of the encrypted credentials, so that they all use the new key
+	// once it is rotated
+	dataEncryptionKeys := getDataEncryptionKeys(c)
+
 	// Initialize Host trust manager
 	fgs := postgres.NewFlavorGroupStore(dataStore)
-	hostTrustManager := initHostTrustManager(c, dataStore, fgs, certStore, alw)
+	hostTrustManager := initHostTrustManager(c, dataStore, fgs, certStore, dataEncryptionKeys, alw, trustEventPublisher)
 	go hostTrustManager.ProcessQueue()
 
 	// create an instance of the HRRS and start it...
@@ -92,8 +109,65 @@ func (a *App) startServer() error {
 		return errors.Wrap(err, "An error occurred while initializing Report Refresher")
 	}
 
+	// create an instance of the FRS and start it...
+	flavorRetirer, err := frs.NewFlavorRetirer(c.FRS, postgres.NewFlavorStore(dataStore), fgs, postgres.NewHostStore(dataStore), hostTrustManager)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing FRS")
+	}
+
+	err = flavorRetirer.Run()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing Flavor Retirer")
+	}
+
+	// create an instance of the RHPS and start it...
+	reportHistoryPurger, err := rhps.NewReportHistoryPurger(c.RHPS, postgres.NewReportHistoryStore(dataStore))
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing RHPS")
+	}
+
+	err = reportHistoryPurger.Run()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing Report History Purger")
+	}
+
+	// The jobs are run by the instance that started them, the jobs left unfinished by the previous run were
+	// interrupted and are marked as failed
+	jobStore := postgres.NewJobStore(dataStore)
+	failedJobs, err := jobStore.FailUnfinished("The job was interrupted by a restart of HVS")
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while failing the unfinished jobs")
+	}
+	if failedJobs > 0 {
+		defaultLog.Warnf("%d jobs interrupted by a restart of HVS were marked as failed", failedJobs)
+	}
+
+	// create an instance of the JPS and start it...
+	jobPurger, err := jps.NewJobPurger(c.JPS, jobStore)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing JPS")
+	}
+
+	err = jobPurger.Run()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing Job Purger")
+	}
+
 	// Initialize Host controller config
-	hostControllerConfig := initHostControllerConfig(c, certStore)
+	hostControllerConfig := initHostControllerConfig(c, certStore, dataEncryptionKeys)
+
+	// create an instance of the TCRS and start it...
+	tagCertificateProvisioner := initTagCertificateProvisioner(c, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	tagCertificateRenewer, err := tcrs.NewTagCertificateRenewer(c.TCRS, postgres.NewTagCertificateStore(dataStore),
+		tagCertificateProvisioner, alw)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing TCRS")
+	}
+
+	err = tagCertificateRenewer.Run()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing Tag Certificate Renewer")
+	}
 
 	//Create an instance of VCSS and start the service
 	vcenterClusterSyncer, err := vcss.NewVCenterClusterSyncer(c.VCSS, hostControllerConfig, dataStore, hostTrustManager)
@@ -158,49 +232,90 @@ func (a *App) startServer() error {
 		return errors.Wrap(err, "An error occurred while stopping Report Refresher")
 	}
 
+	err = flavorRetirer.Stop()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while stopping Flavor Retirer")
+	}
+
+	err = reportHistoryPurger.Stop()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while stopping Report History Purger")
+	}
+
+	err = jobPurger.Stop()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while stopping Job Purger")
+	}
+
+	err = tagCertificateRenewer.Stop()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while stopping Tag Certificate Renewer")
+	}
+
 	if err := h.Shutdown(ctx); err != nil {
 		defaultLog.WithError(err).Info("Failed to gracefully shutdown webserver")
 		return err
 	}
+	if trustEventPublisher != nil {
+		trustEventPublisher.Stop()
+	}
 	secLog.Info(commLogMsg.ServiceStop)
 	return nil
 }
 
-func initHostControllerConfig(cfg *config.Configuration, certStore *models.CertificatesStore) domain.HostControllerConfig {
+func initHostControllerConfig(cfg *config.Configuration, certStore *models.CertificatesStore, dataEncryptionKeys *models.DataEncryptionKeys) domain.HostControllerConfig {
 	defaultLog.Trace("server:initHostControllerConfig() Entering")
 	defer defaultLog.Trace("server:initHostControllerConfig() Leaving")
 
 	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
-	hcProvider := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers)
+	hcProvider := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers,
+		cfg.FVS.EnableSimulatedHosts)
 
 	hcc := domain.HostControllerConfig{
 		HostConnectorProvider: hcProvider,
-		DataEncryptionKey:     getDecodedDek(cfg),
+		DataEncryptionKeys:    dataEncryptionKeys,
 		Username:              cfg.HVS.Username,
 		Password:              cfg.HVS.Password,
+		SimulatedHostsEnabled: cfg.FVS.EnableSimulatedHosts,
 	}
 	return hcc
 }
 
-func getDecodedDek(cfg *config.Configuration) []byte {
-	dekBase64 := cfg.Dek
-	if dekBase64 == "" {
-		defaultLog.Warn("Data encryption key is not defined")
+// initTagCertificateProvisioner returns nil when the Tag CA or the flavor signing key are not available
+func initTagCertificateProvisioner(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, htm domain.HostTrustManager, hcc domain.HostControllerConfig) domain.TagCertificateProvisioner {
+	defaultLog.Trace("server:initTagCertificateProvisioner() Entering")
+	defer defaultLog.Trace("server:initTagCertificateProvisioner() Leaving")
+
+	tcConfig := domain.TagCertControllerConfig{
+		AASApiUrl:       cfg.AASApiUrl,
+		ServiceUsername: cfg.HVS.Username,
+		ServicePassword: cfg.HVS.Password,
 	}
-	dek, err := base64.StdEncoding.DecodeString(dekBase64)
+	tagCertificateController := controllers.NewTagCertificateController(tcConfig, *certStore,
+		postgres.NewTagCertificateStore(dataStore), htm, postgres.NewHostStore(dataStore),
+		postgres.NewFlavorStore(dataStore), fgs, hcc.HostConnectorProvider)
+	if tagCertificateController == nil {
+		return nil
+	}
+	return tagCertificateController
+}
+
+func getDataEncryptionKeys(cfg *config.Configuration) *models.DataEncryptionKeys {
+	dataEncryptionKeys, err := dek.NewDataEncryptionKeys(cfg)
 	if err != nil {
-		defaultLog.WithError(err).Warn("Data encryption key is not base64 encoded")
+		defaultLog.WithError(err).Warn("Failed to load data encryption key")
+		return models.NewDataEncryptionKeys(nil)
 	}
-	return dek
+	return dataEncryptionKeys
 }
 
-func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, alw domain.AuditLogWriter) domain.HostTrustManager {
+func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, dataEncryptionKeys *models.DataEncryptionKeys, alw domain.AuditLogWriter, tep domain.TrustEventPublisher) domain.HostTrustManager {
 	defaultLog.Trace("server:InitHostTrustManager() Entering")
 	defer defaultLog.Trace("server:InitHostTrustManager() Leaving")
 
 	//Load store
 	hs := postgres.NewHostStore(dataStore)
-	hc := postgres.NewHostCredentialStore(dataStore, getDecodedDek(cfg))
+	hc := postgres.NewHostCredentialStore(dataStore, dataEncryptionKeys)
 	fs := postgres.NewFlavorStore(dataStore)
 	qs := postgres.NewDBQueueStore(dataStore)
 	hss := postgres.NewHostStatusStore(dataStore)
@@ -210,22 +325,11 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 
 	//Load certificates
 	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
-	tagCAs := (*certStore)[models.CaCertTypesTagCa.String()]
 	samlCert := (*certStore)[models.CertTypesSaml.String()]
-	privacyCAs := (*certStore)[models.CaCertTypesPrivacyCa.String()]
-	signingCerts := (*certStore)[models.CertTypesFlavorSigning.String()]
-	rootCApool := crypt.GetCertPool(rootCAs.Certificates)
-	for _, val := range signingCerts.Certificates[1:] {
-		rootCApool.AddCert(&val) //Add intermediate CA
-	}
-
-	verifierCerts := verifier.VerifierCertificates{
-		PrivacyCACertificates:    crypt.GetCertPool(privacyCAs.Certificates),
-		AssetTagCACertificates:   crypt.GetCertPool(tagCAs.Certificates),
-		FlavorSigningCertificate: &signingCerts.Certificates[0],
-		FlavorCACertificates:     rootCApool,
+	libVerifier, err := utils.NewFlavorVerifier(certStore, utils.LoadPreviousFlavorSigningCertificates(constants.PreviousFlavorSigningCertsDir))
+	if err != nil {
+		defaultLog.WithError(err).Fatal("Error initializing flavor verifier")
 	}
-	libVerifier, _ := verifier.NewVerifier(verifierCerts)
 	samlKey := samlCert.Key.(*rsa.PrivateKey)
 	samlIssuerConfig := saml.IssuerConfiguration{
 		IssuerName:        cfg.SAML.Issuer,
@@ -249,10 +353,12 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 		SamlIssuerConfig:                samlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.FVS.SkipFlavorSignatureVerification,
 		HostTrustCache:                  hostQuoteTrustCache,
+		TrustEventPublisher:             tep,
 	}
 
 	// Initialize Host Fetcher service
-	htcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers)
+	htcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers,
+		cfg.FVS.EnableSimulatedHosts)
 
 	c := domain.HostDataFetcherConfig{
 		HostConnectorProvider: htcFactory,
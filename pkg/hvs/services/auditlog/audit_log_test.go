@@ -74,6 +74,19 @@ func (me *mockEntryStore) Delete(id uuid.UUID) error {
 	return nil
 }
 
+func (me *mockEntryStore) Search(c *models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error) {
+	var ret []models.AuditLogEntry
+	for _, v := range me.data {
+		if (c.EntityID == uuid.Nil || v.EntityID == c.EntityID) &&
+			(c.Action == "" || v.Action == c.Action) &&
+			(c.EntityType == "" || v.EntityType == c.EntityType) {
+			ret = append(ret, *v)
+		}
+	}
+	me.t.Log("Search", c)
+	return ret, nil
+}
+
 func TestAuditLogService(t *testing.T) {
 	store := &mockEntryStore{
 		t:    t,
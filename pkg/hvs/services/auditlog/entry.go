@@ -53,6 +53,8 @@ func (alp *auditLogDB) CreateEntry(action string, values ...interface{}) (*model
 		}
 		cols = append(cols, report2Cols(base, diff)...)
 		return entryHelper(base.ID, "report", action, cols), nil
+	case *models.TagCertificateRenewal:
+		return entryHelper(base.TagCertificateID, "tag_certificate", action, tagCertificateRenewal2Cols(base)), nil
 	}
 }
 
@@ -131,3 +133,36 @@ func hostStatus2Cols(old, current *hvs.HostStatus) []models.AuditColumnData {
 		},
 	}
 }
+
+func tagCertificateRenewal2Cols(renewal *models.TagCertificateRenewal) []models.AuditColumnData {
+	return []models.AuditColumnData{
+		{
+			Name:  "id",
+			Value: renewal.TagCertificateID,
+		},
+		{
+			Name:  "hardware_uuid",
+			Value: renewal.HardwareUUID,
+		},
+		{
+			Name:      "renewed_by",
+			Value:     renewal.RenewedBy,
+			IsUpdated: renewal.RenewedBy != uuid.Nil,
+		},
+		{
+			Name:      "not_after",
+			Value:     renewal.NotAfter,
+			IsUpdated: !renewal.NotAfter.IsZero(),
+		},
+		{
+			Name:      "flavor_id",
+			Value:     renewal.FlavorID,
+			IsUpdated: renewal.FlavorID != uuid.Nil,
+		},
+		{
+			Name:      "error",
+			Value:     renewal.Error,
+			IsUpdated: renewal.Error != "",
+		},
+	}
+}
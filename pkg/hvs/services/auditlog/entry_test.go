@@ -93,3 +93,24 @@ func TestStructDiff(t *testing.T) {
 	t.Log(report2Cols(rx, ry))
 	t.Log(hostStatus2Cols(hssx, hssy))
 }
+
+func TestTagCertificateRenewalEntry(t *testing.T) {
+	renewal := &models.TagCertificateRenewal{
+		TagCertificateID: uuid.New(),
+		HardwareUUID:     uuid.New(),
+		RenewedBy:        uuid.New(),
+	}
+	entry, err := (&auditLogDB{}).CreateEntry(models.TagCertificateRenewalRenew, renewal)
+	assert.NoError(t, err)
+	assert.Equal(t, renewal.TagCertificateID, entry.EntityID)
+	assert.Equal(t, "tag_certificate", entry.EntityType)
+	assert.Equal(t, models.TagCertificateRenewalRenew, entry.Action)
+
+	updated := map[string]bool{}
+	for _, column := range entry.Data.Columns {
+		updated[column.Name] = column.IsUpdated
+	}
+	assert.True(t, updated["renewed_by"])
+	assert.False(t, updated["flavor_id"])
+	assert.False(t, updated["error"])
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package dek
+
+import (
+	"encoding/base64"
+	"sync"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+// rotationLock serializes the rotations, the rotators of a process all update the same configuration file
+var rotationLock sync.Mutex
+
+// Rotator replaces the data encryption key the host credentials are encrypted with. The new key is saved to the
+// configuration along with the keys it replaces before any credential is re-encrypted, so that the credentials can
+// still be decrypted if the rotation is interrupted. The credentials are then re-encrypted in a single transaction and
+// the replaced keys are removed from the configuration once no credential is encrypted with them.
+type Rotator interface {
+	Rotate() (*hvs.DataEncryptionKeyRotation, error)
+}
+
+func NewRotator(cfg *config.Configuration, configFile string, keys *models.DataEncryptionKeys, store domain.DataEncryptionKeyStore) Rotator {
+	return &rotatorImpl{
+		cfg:        cfg,
+		configFile: configFile,
+		keys:       keys,
+		store:      store,
+	}
+}
+
+// NewDataEncryptionKeys returns the current and previous data encryption keys of the configuration
+func NewDataEncryptionKeys(cfg *config.Configuration) (*models.DataEncryptionKeys, error) {
+	if cfg.Dek == "" {
+		return nil, errors.New("Data encryption key is not defined")
+	}
+	current, err := decodeKey(cfg.Dek)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid data encryption key")
+	}
+	var previous [][]byte
+	for _, encodedKey := range cfg.PreviousDeks {
+		key, err := decodeKey(encodedKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid previous data encryption key")
+		}
+		previous = append(previous, key)
+	}
+	return models.NewDataEncryptionKeys(current, previous...), nil
+}
+
+func decodeKey(encodedKey string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Data encryption key is not base64 encoded")
+	}
+	if len(key) != models.DataEncryptionKeyLength {
+		return nil, errors.Errorf("Data encryption key must be %d bytes long", models.DataEncryptionKeyLength)
+	}
+	return key, nil
+}
+
+type rotatorImpl struct {
+	cfg        *config.Configuration
+	configFile string
+	keys       *models.DataEncryptionKeys
+	store      domain.DataEncryptionKeyStore
+}
+
+func (r *rotatorImpl) Rotate() (*hvs.DataEncryptionKeyRotation, error) {
+	defaultLog.Trace("services/dek/rotator:Rotate() Entering")
+	defer defaultLog.Trace("services/dek/rotator:Rotate() Leaving")
+
+	rotationLock.Lock()
+	defer rotationLock.Unlock()
+
+	if _, currentKey := r.keys.Current(); currentKey == nil {
+		return nil, errors.New("services/dek/rotator:Rotate() Data encryption key is not defined")
+	}
+	newKey, err := models.GenerateDataEncryptionKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "services/dek/rotator:Rotate() Failed to generate data encryption key")
+	}
+
+	// the new key is only used once it is saved with the keys it replaces
+	if err = r.saveKeys(newKey, r.keys.All()); err != nil {
+		return nil, errors.Wrap(err, "services/dek/rotator:Rotate() Failed to save the new data encryption key")
+	}
+	r.keys.Rotate(newKey)
+	newKeyId, _ := r.keys.Current()
+	defaultLog.Infof("services/dek/rotator:Rotate() Data encryption key %s saved, re-encrypting credentials", newKeyId)
+
+	rotation, err := r.store.ReEncrypt(r.keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "services/dek/rotator:Rotate() Failed to re-encrypt the credentials with the new data encryption key")
+	}
+
+	// the credentials created while the credentials were being re-encrypted may still use a previous key
+	keyIds, err := r.store.RetrieveKeyIds()
+	if err != nil {
+		return nil, errors.Wrap(err, "services/dek/rotator:Rotate() Failed to retrieve the data encryption keys in use")
+	}
+	r.keys.Retain(keyIds)
+	if err = r.saveKeys(newKey, r.keys.Previous()); err != nil {
+		return nil, errors.Wrap(err, "services/dek/rotator:Rotate() Failed to remove the previous data encryption keys")
+	}
+	defaultLog.Infof("services/dek/rotator:Rotate() Re-encrypted %d host credential(s) and %d ESXi cluster(s) with data encryption key %s",
+		rotation.HostCredentials, rotation.ESXiClusters, rotation.KeyId)
+	return rotation, nil
+}
+
+// saveKeys saves the current and previous keys to the configuration file, the configuration is left unchanged if it
+// cannot be saved
+func (r *rotatorImpl) saveKeys(current []byte, previous [][]byte) error {
+	dek, previousDeks := r.cfg.Dek, r.cfg.PreviousDeks
+	r.cfg.Dek = base64.StdEncoding.EncodeToString(current)
+	r.cfg.PreviousDeks = nil
+	for _, key := range previous {
+		r.cfg.PreviousDeks = append(r.cfg.PreviousDeks, base64.StdEncoding.EncodeToString(key))
+	}
+	if err := r.cfg.Save(r.configFile); err != nil {
+		r.cfg.Dek, r.cfg.PreviousDeks = dek, previousDeks
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package dek
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const testDek = "gcXqH8YwuJZ3Rx4qVzA/zhVvkTw2TL+iRAC9T3E6lII="
+
+// newTestRotator returns a rotator of the data encryption key of a configuration saved to a temporary directory,
+// with a store holding the credentials encrypted with the key
+func newTestRotator(t *testing.T, credentials ...string) (*rotatorImpl, *mocks.MockDataEncryptionKeyStore, string) {
+	configDir, err := ioutil.TempDir("", "dek")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(configDir) })
+	configFile := filepath.Join(configDir, "config.yml")
+	cfg := &config.Configuration{Dek: testDek}
+	assert.NoError(t, cfg.Save(configFile))
+
+	keys, err := NewDataEncryptionKeys(cfg)
+	assert.NoError(t, err)
+	store := &mocks.MockDataEncryptionKeyStore{}
+	for _, credential := range credentials {
+		assert.NoError(t, store.AddCredential(credential, keys))
+	}
+	return NewRotator(cfg, configFile, keys, store).(*rotatorImpl), store, configFile
+}
+
+func loadTestConfig(t *testing.T, configFile string) *config.Configuration {
+	configYaml, err := ioutil.ReadFile(configFile)
+	assert.NoError(t, err)
+	var cfg config.Configuration
+	assert.NoError(t, yaml.Unmarshal(configYaml, &cfg))
+	return &cfg
+}
+
+func TestRotateReEncryptsCredentials(t *testing.T) {
+	rotator, store, configFile := newTestRotator(t, "u=admin;p=password", "u=root;p=secret")
+
+	rotation, err := rotator.Rotate()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rotation.HostCredentials)
+
+	// the new key is saved and the previous key removed once the credentials are re-encrypted
+	cfg := loadTestConfig(t, configFile)
+	assert.NotEqual(t, testDek, cfg.Dek)
+	assert.Empty(t, cfg.PreviousDeks)
+	newKey, err := base64.StdEncoding.DecodeString(cfg.Dek)
+	assert.NoError(t, err)
+	assert.Equal(t, models.GetDataEncryptionKeyId(newKey), rotation.KeyId)
+
+	// the credentials decrypt with the new key only
+	keys, err := NewDataEncryptionKeys(cfg)
+	assert.NoError(t, err)
+	for i, credential := range []string{"u=admin;p=password", "u=root;p=secret"} {
+		assert.Equal(t, rotation.KeyId, store.Credentials[i].KeyId)
+		plainText, err := utils.DecryptWithKeyId(store.Credentials[i].CipherText, store.Credentials[i].KeyId, keys)
+		assert.NoError(t, err)
+		assert.Equal(t, credential, plainText)
+		oldKey, _ := base64.StdEncoding.DecodeString(testDek)
+		_, err = utils.DecryptString(store.Credentials[i].CipherText, oldKey)
+		assert.Error(t, err)
+	}
+	assert.Len(t, rotator.keys.All(), 1)
+}
+
+func TestRotateKeepsPreviousKeyOnFailure(t *testing.T) {
+	rotator, store, configFile := newTestRotator(t, "u=admin;p=password", "u=root;p=secret")
+	store.FailAfter = 1
+
+	_, err := rotator.Rotate()
+	assert.Error(t, err)
+
+	// the credentials are left encrypted with the previous key, which is kept in the configuration
+	cfg := loadTestConfig(t, configFile)
+	assert.NotEqual(t, testDek, cfg.Dek)
+	assert.Equal(t, []string{testDek}, cfg.PreviousDeks)
+	keys, err := NewDataEncryptionKeys(cfg)
+	assert.NoError(t, err)
+	for _, credential := range store.Credentials {
+		_, err := utils.DecryptWithKeyId(credential.CipherText, credential.KeyId, keys)
+		assert.NoError(t, err)
+	}
+
+	// the rotation can be run again
+	store.FailAfter = 0
+	rotation, err := rotator.Rotate()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rotation.HostCredentials)
+	cfg = loadTestConfig(t, configFile)
+	assert.Empty(t, cfg.PreviousDeks)
+}
+
+func TestRotateCredentialsWithoutKeyId(t *testing.T) {
+	rotator, store, _ := newTestRotator(t)
+	// credentials stored before the key ids were recorded
+	oldKey, _ := base64.StdEncoding.DecodeString(testDek)
+	cipherText, err := utils.EncryptString("u=admin;p=password", oldKey)
+	assert.NoError(t, err)
+	store.Credentials = append(store.Credentials, mocks.MockEncryptedCredential{CipherText: cipherText})
+
+	rotation, err := rotator.Rotate()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rotation.HostCredentials)
+	plainText, err := utils.DecryptWithKeyId(store.Credentials[0].CipherText, store.Credentials[0].KeyId, rotator.keys)
+	assert.NoError(t, err)
+	assert.Equal(t, "u=admin;p=password", plainText)
+}
+
+func TestRotateFailsWithoutConfigFile(t *testing.T) {
+	rotator, store, configFile := newTestRotator(t, "u=admin;p=password")
+	rotator.configFile = filepath.Join(filepath.Dir(configFile), "missing", "config.yml")
+
+	_, err := rotator.Rotate()
+	assert.Error(t, err)
+
+	// the key is not rotated when it cannot be saved
+	assert.Equal(t, testDek, rotator.cfg.Dek)
+	currentKeyId, _ := rotator.keys.Current()
+	assert.Equal(t, currentKeyId, store.Credentials[0].KeyId)
+	assert.Len(t, rotator.keys.All(), 1)
+}
+
+func TestNewDataEncryptionKeys(t *testing.T) {
+	_, err := NewDataEncryptionKeys(&config.Configuration{})
+	assert.Error(t, err)
+	_, err = NewDataEncryptionKeys(&config.Configuration{Dek: base64.StdEncoding.EncodeToString([]byte("short"))})
+	assert.Error(t, err)
+	_, err = NewDataEncryptionKeys(&config.Configuration{Dek: testDek, PreviousDeks: []string{"not base64"}})
+	assert.Error(t, err)
+
+	previousKey, err := models.GenerateDataEncryptionKey()
+	assert.NoError(t, err)
+	keys, err := NewDataEncryptionKeys(&config.Configuration{Dek: testDek,
+		PreviousDeks: []string{base64.StdEncoding.EncodeToString(previousKey)}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{previousKey}, keys.Previous())
+	_, ok := keys.Get(models.GetDataEncryptionKeyId(previousKey))
+	assert.True(t, ok)
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package frs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	"github.com/pkg/errors"
+)
+
+// FlavorRetirer runs in the background and periodically deletes the superseded
+// flavors whose grace period is over.  The hosts associated with a retired flavor
+// are passed to the HostTrustManager queue to be verified again.
+type FlavorRetirer interface {
+	Run() error
+	Stop() error
+}
+
+func NewFlavorRetirer(cfg FRSConfig, flavorStore domain.FlavorStore, flavorGroupStore domain.FlavorGroupStore,
+	hostStore domain.HostStore, hostTrustManager domain.HostTrustManager) (FlavorRetirer, error) {
+
+	return &flavorRetirerImpl{
+		flavorStore:      flavorStore,
+		flavorGroupStore: flavorGroupStore,
+		hostStore:        hostStore,
+		hostTrustManager: hostTrustManager,
+		cfg:              cfg,
+	}, nil
+}
+
+var (
+	defaultLog = commLog.GetDefaultLogger()
+)
+
+type flavorRetirerImpl struct {
+	flavorStore      domain.FlavorStore
+	flavorGroupStore domain.FlavorGroupStore
+	hostStore        domain.HostStore
+	hostTrustManager domain.HostTrustManager
+	cfg              FRSConfig
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (retirer *flavorRetirerImpl) Run() error {
+
+	defaultLog.Infof("FRS is starting with refresh period '%s'", retirer.cfg.RefreshPeriod)
+
+	if retirer.cfg.RefreshPeriod == 0 {
+		defaultLog.Info("The FRS refresh period is zero.  FRS will now exit")
+		return nil
+	}
+
+	retirer.ctx, retirer.cancel = context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			err := retirer.retireFlavors()
+			if err != nil {
+				// log any errors, but do not stop trying to retire flavors
+				defaultLog.Errorf("FRS encountered an error while retiring flavors...\n%+v\n", err)
+			}
+
+			select {
+			case <-time.After(retirer.cfg.RefreshPeriod):
+				// continue with the loop and retire flavors again
+			case <-retirer.ctx.Done():
+				defaultLog.Info("The FRS has been stopped and will now exit")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (retirer *flavorRetirerImpl) Stop() error {
+	if retirer.cancel != nil {
+		retirer.cancel()
+	} else {
+		defaultLog.Debug("The FRS is not running")
+	}
+
+	return nil
+}
+
+// Deletes the superseded flavors whose grace period is over and queues the hosts
+// associated with them, the same way a flavor deleted through the API is handled.
+func (retirer *flavorRetirerImpl) retireFlavors() error {
+
+	supersessions, err := retirer.flavorStore.SearchSupersessions(&models.FlavorSupersessionFilterCriteria{
+		RetiredBy: time.Now(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while FRS searched for retired flavors")
+	}
+
+	defaultLog.Debugf("FRS found %d flavors to retire", len(supersessions))
+
+	hostIds := make(map[uuid.UUID]bool)
+	for _, supersession := range supersessions {
+		signedFlavor, err := retirer.flavorStore.Retrieve(supersession.FlavorId)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				continue
+			}
+			return errors.Wrapf(err, "An error occurred while FRS retrieved flavor %s", supersession.FlavorId)
+		}
+
+		flavorHostIds, err := utils.GetHostsAssociatedWithFlavor(retirer.hostStore, retirer.flavorGroupStore, signedFlavor)
+		if err != nil {
+			return errors.Wrapf(err, "An error occurred while FRS searched for hosts associated with flavor %s", supersession.FlavorId)
+		}
+
+		if err = retirer.flavorStore.Delete(supersession.FlavorId); err != nil {
+			return errors.Wrapf(err, "An error occurred while FRS deleted flavor %s", supersession.FlavorId)
+		}
+		defaultLog.Infof("FRS retired flavor %s superseded by flavor %s", supersession.FlavorId, supersession.SupersededBy)
+
+		for _, hostId := range flavorHostIds {
+			hostIds[hostId] = true
+		}
+	}
+
+	if len(hostIds) > 0 {
+		hostIdsForQueue := make([]uuid.UUID, 0, len(hostIds))
+		for hostId := range hostIds {
+			hostIdsForQueue = append(hostIdsForQueue, hostId)
+		}
+		err = retirer.hostTrustManager.VerifyHostsAsync(hostIdsForQueue, false, false)
+		if err != nil {
+			return errors.Wrap(err, "FRS encountered an error calling the host trust manager")
+		}
+		defaultLog.Infof("FRS queued %d hosts associated with retired flavors", len(hostIdsForQueue))
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package frs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	supersededFlavorId  = uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3")
+	supersedingFlavorId = uuid.MustParse("e6612219-bbd5-4259-8c7e-991e43729a86")
+)
+
+func TestFlavorRetirerRetiresFlavorAfterGracePeriod(t *testing.T) {
+	flavorStore := mocks.NewMockFlavorStore()
+	err := flavorStore.Supersede(&models.FlavorSupersession{
+		FlavorId:     supersededFlavorId,
+		SupersededBy: supersedingFlavorId,
+		RetireAt:     time.Now().Add(-time.Minute),
+	})
+	assert.NoError(t, err)
+
+	retirer, err := NewFlavorRetirer(FRSConfig{RefreshPeriod: DefaultRefreshPeriod}, flavorStore,
+		mocks.NewFakeFlavorgroupStore(), mocks.NewMockHostStore(), &MockHostTrustManager{})
+	assert.NoError(t, err)
+
+	err = retirer.(*flavorRetirerImpl).retireFlavors()
+	assert.NoError(t, err)
+
+	_, err = flavorStore.Retrieve(supersededFlavorId)
+	assert.Error(t, err)
+	_, err = flavorStore.Retrieve(supersedingFlavorId)
+	assert.NoError(t, err)
+
+	supersessions, err := flavorStore.SearchSupersessions(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, supersessions)
+}
+
+func TestFlavorRetirerKeepsFlavorDuringGracePeriod(t *testing.T) {
+	flavorStore := mocks.NewMockFlavorStore()
+	err := flavorStore.Supersede(&models.FlavorSupersession{
+		FlavorId:     supersededFlavorId,
+		SupersededBy: supersedingFlavorId,
+		RetireAt:     time.Now().Add(DefaultGracePeriod),
+	})
+	assert.NoError(t, err)
+
+	retirer, err := NewFlavorRetirer(FRSConfig{RefreshPeriod: DefaultRefreshPeriod}, flavorStore,
+		mocks.NewFakeFlavorgroupStore(), mocks.NewMockHostStore(), &MockHostTrustManager{})
+	assert.NoError(t, err)
+
+	err = retirer.(*flavorRetirerImpl).retireFlavors()
+	assert.NoError(t, err)
+
+	_, err = flavorStore.Retrieve(supersededFlavorId)
+	assert.NoError(t, err)
+
+	supersessions, err := flavorStore.SearchSupersessions(nil)
+	assert.NoError(t, err)
+	assert.Len(t, supersessions, 1)
+}
+
+// -------------------------------------------------------------------------------------------------
+// M O C K   H O S T   T R U S T   M A N A G E R
+// -------------------------------------------------------------------------------------------------
+type MockHostTrustManager struct{}
+
+func (htm *MockHostTrustManager) VerifyHost(hostId uuid.UUID, fetchHostData bool, preferHashMatch bool) (*models.HVSReport, error) {
+	return nil, errors.New("VerifyHost is not implemented")
+}
+
+func (htm *MockHostTrustManager) ProcessQueue() error {
+	return errors.New("ProcessQueue is not implemented")
+}
+
+func (htm *MockHostTrustManager) VerifyHostsAsync(hostIDs []uuid.UUID, fetchHostData, preferHashMatch bool) error {
+	return nil
+}
@@ -0,0 +1,23 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package frs
+
+import "time"
+
+var (
+	// DefaultGracePeriod by default keeps a superseded flavor active for a day
+	DefaultGracePeriod, _ = time.ParseDuration("24h")
+	// DefaultRefreshPeriod by default check for retired flavors every five minutes
+	DefaultRefreshPeriod, _ = time.ParseDuration("5m")
+)
+
+type FRSConfig struct {
+	// GracePeriod determines how long a superseded flavor stays active along with the flavor superseding it
+	// (defaults to DefaultGracePeriod).
+	GracePeriod time.Duration `yaml:"grace-period" mapstructure:"grace-period"`
+	// RefreshPeriod determines how frequently the FRS checks for superseded flavors whose grace period is over
+	// (defaults to DefaultRefreshPeriod).
+	RefreshPeriod time.Duration `yaml:"refresh-period" mapstructure:"refresh-period"`
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/metrics"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/chnlworkq"
 	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
 	hc "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector"
@@ -263,6 +264,7 @@ func (svc *Service) Retrieve(host hvs.Host) (*types.HostManifest, error) {
 	if err != nil {
 		hostState := utils.DetermineHostState(err)
 		defaultLog.Warnf("hostfetcher/Service:Retrieve() Could not connect to host : %s", hostState.String())
+		metrics.HostConnectionFailures.WithLabelValues(hostState.String()).Inc()
 		hostStatus.HostStatusInformation.HostState = hostState
 		if err := svc.hss.Persist(hostStatus); err != nil {
 			defaultLog.Error("hostfetcher/Service:Retrieve() could not update host status to store")
@@ -332,6 +334,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string, preferHas
 		}
 		hostState := utils.DetermineHostState(err)
 		defaultLog.Warnf("hostfetcher/Service:FetchDataAndRespond() Could not connect to host : %s", hostState.String())
+		metrics.HostConnectionFailures.WithLabelValues(hostState.String()).Inc()
 
 		err = svc.hss.Persist(&hvs.HostStatus{
 			HostID: hId,
@@ -405,13 +408,22 @@ func (svc *Service) GetHostData(connUrl string, pcrList []int) (*types.HostManif
 	defer defaultLog.Trace("hostfetcher/Service:GetHostData() Leaving")
 
 	defaultLog.Debugf("hostfetcher/fetcher:GetHostData()  start for conn url - %s", connUrl)
+	start := time.Now()
+	data, err := svc.getHostManifest(connUrl, pcrList)
+	metrics.ObserveSince(metrics.HostFetchDuration, start, err)
+	return data, err
+}
+
+func (svc *Service) getHostManifest(connUrl string, pcrList []int) (*types.HostManifest, error) {
+	defaultLog.Trace("hostfetcher/Service:getHostManifest() Entering")
+	defer defaultLog.Trace("hostfetcher/Service:getHostManifest() Leaving")
 
 	//get the host data
 	connectionString, _, err := controllers.GenerateConnectionString(connUrl, svc.hcCfg.ServiceUsername,
 		svc.hcCfg.ServicePassword,
 		svc.hcCfg.HCStore)
 	if err != nil {
-		defaultLog.WithError(err).Error("hostfetcher/Service:GetHostData() Could not generate formatted connection string")
+		defaultLog.WithError(err).Error("hostfetcher/Service:getHostManifest() Could not generate formatted connection string")
 		return nil, err
 	}
 
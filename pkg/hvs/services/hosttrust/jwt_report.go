@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hosttrust
+
+import (
+	"strings"
+
+	jwt "github.com/Waterdrips/jwt-go"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/eat"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+)
+
+// GetTrustReportClaims returns the claims of the JWT trust report of a report. The claims carry the same attributes
+// as the SAML report, the token is valid as long as the report.
+func GetTrustReportClaims(report *models.HVSReport) *eat.TrustReportClaims {
+	defaultLog.Trace("hosttrust/jwt_report:GetTrustReportClaims() Entering")
+	defer defaultLog.Trace("hosttrust/jwt_report:GetTrustReportClaims() Leaving")
+
+	t := &report.TrustReport
+	claims := &eat.TrustReportClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        report.ID.String(),
+			Subject:   t.HostManifest.HostInfo.HardwareUUID,
+			NotBefore: report.CreatedAt.Unix(),
+			ExpiresAt: report.Expiration.Unix(),
+		},
+		Trusted:               t.IsTrusted(),
+		FlavorParts:           make(map[string]bool),
+		AssetTags:             make(map[string]string),
+		HardwareFeatures:      make(map[string]string),
+		HostInfo:              getHostInfoMap(t.HostManifest.HostInfo),
+		AIKCertificate:        t.HostManifest.AIKCertificate,
+		BindingKeyCertificate: t.HostManifest.BindingKeyCertificate,
+	}
+
+	// the flavor parts that were not verified are left out, rather than reported as NA
+	for _, flavorType := range common.GetFlavorTypes() {
+		marker := flavorType.String()
+		if len(t.GetResultsForMarker(marker)) > 0 {
+			claims.FlavorParts[marker] = t.IsTrustedForMarker(marker)
+		}
+	}
+	for field, value := range getHardwareFeaturesMap(t.HostManifest.HostInfo.HardwareFeatures) {
+		claims.HardwareFeatures[strings.TrimPrefix(field, "FEATURE_")] = value
+	}
+	for field, value := range getTags(t) {
+		claims.AssetTags[strings.TrimPrefix(field, "TAG_")] = value
+	}
+	if t.HostManifest.HostInfo.HardwareFeatures.TPM.Meta.TPMVersion != "" {
+		claims.HostInfo["TPMVersion"] = t.HostManifest.HostInfo.HardwareFeatures.TPM.Meta.TPMVersion
+	}
+	return claims
+}
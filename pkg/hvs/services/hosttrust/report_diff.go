@@ -0,0 +1,370 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hosttrust
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+var diffPcrBanks = []types.SHAAlgorithm{types.SHA1, types.SHA256, types.SHA384, types.SHA512}
+
+// ruleState is the aggregated result of all the rule results of a report that share the same ruleKey
+type ruleState struct {
+	change  hvs.RuleChange
+	trusted bool
+	faults  []hvs.Fault
+}
+
+// DiffTrustReports compares two trust reports of the same host and returns the rules that changed their trusted
+// state, the PCR values and event log entries that changed per bank and index and the host info fields that changed.
+// 'from' is the older report and 'to' the newer one.
+func DiffTrustReports(from, to *hvs.TrustReport) (*hvs.TrustReportDiff, error) {
+	defaultLog.Trace("hosttrust/report_diff:DiffTrustReports() Entering")
+	defer defaultLog.Trace("hosttrust/report_diff:DiffTrustReports() Leaving")
+
+	if from == nil || to == nil {
+		return nil, errors.New("hosttrust/report_diff:DiffTrustReports() Both trust reports must be provided")
+	}
+
+	eventLogChanges, err := diffEventLogs(&from.HostManifest.PcrManifest.PcrEventLogMap, &to.HostManifest.PcrManifest.PcrEventLogMap)
+	if err != nil {
+		return nil, errors.Wrap(err, "hosttrust/report_diff:DiffTrustReports() Error while comparing event logs")
+	}
+
+	hostInfoChanges, err := diffHostInfo(from.HostManifest.HostInfo, to.HostManifest.HostInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "hosttrust/report_diff:DiffTrustReports() Error while comparing host info")
+	}
+
+	return &hvs.TrustReportDiff{
+		FromTrusted:     from.Trusted,
+		ToTrusted:       to.Trusted,
+		RuleChanges:     diffRuleResults(from.Results, to.Results),
+		PcrChanges:      diffPcrs(&from.HostManifest.PcrManifest, &to.HostManifest.PcrManifest),
+		EventLogChanges: eventLogChanges,
+		HostInfoChanges: hostInfoChanges,
+	}, nil
+}
+
+func diffRuleResults(fromResults, toResults []hvs.RuleResult) []hvs.RuleChange {
+	fromStates := getRuleStates(fromResults)
+	toStates := getRuleStates(toResults)
+
+	var keys []string
+	for key := range fromStates {
+		keys = append(keys, key)
+	}
+	for key := range toStates {
+		if _, ok := fromStates[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	ruleChanges := []hvs.RuleChange{}
+	for _, key := range keys {
+		fromState, inFrom := fromStates[key]
+		toState, inTo := toStates[key]
+		if inFrom && inTo && fromState.trusted == toState.trusted {
+			continue
+		}
+
+		var change hvs.RuleChange
+		if inTo {
+			change = toState.change
+			toTrusted := toState.trusted
+			change.ToTrusted = &toTrusted
+			change.Faults = toState.faults
+		} else {
+			change = fromState.change
+		}
+		if inFrom {
+			fromTrusted := fromState.trusted
+			change.FromTrusted = &fromTrusted
+		}
+		ruleChanges = append(ruleChanges, change)
+	}
+	return ruleChanges
+}
+
+// getRuleStates groups the rule results by rule name, flavor, markers and PCR so that the results of both
+// reports can be matched with each other
+func getRuleStates(results []hvs.RuleResult) map[string]*ruleState {
+	states := make(map[string]*ruleState)
+	for _, result := range results {
+		change := hvs.RuleChange{
+			RuleName: result.Rule.Name,
+			Markers:  result.Rule.Markers,
+			FlavorId: result.FlavorId,
+		}
+		if change.FlavorId == nil {
+			change.FlavorId = result.Rule.FlavorID
+		}
+
+		var pcr *types.Pcr
+		if result.Rule.ExpectedPcr != nil {
+			pcr = &result.Rule.ExpectedPcr.Pcr
+		} else if result.Rule.PCR != nil {
+			pcr = result.Rule.PCR
+		} else if result.Rule.ExpectedPcrEventLogEntry != nil {
+			pcr = &result.Rule.ExpectedPcrEventLogEntry.Pcr
+		}
+		if pcr != nil {
+			pcrBank := types.SHAAlgorithm(pcr.Bank)
+			pcrIndex := types.PcrIndex(pcr.Index)
+			change.PcrBank = &pcrBank
+			change.PcrIndex = &pcrIndex
+		}
+
+		key := getRuleKey(&change)
+		if state, ok := states[key]; ok {
+			state.trusted = state.trusted && result.Trusted
+			state.faults = append(state.faults, result.Faults...)
+			continue
+		}
+		states[key] = &ruleState{
+			change:  change,
+			trusted: result.Trusted,
+			faults:  append([]hvs.Fault{}, result.Faults...),
+		}
+	}
+	return states
+}
+
+func getRuleKey(change *hvs.RuleChange) string {
+	markers := make([]string, 0, len(change.Markers))
+	for _, marker := range change.Markers {
+		markers = append(markers, marker.String())
+	}
+	flavorId := uuid.Nil
+	if change.FlavorId != nil {
+		flavorId = *change.FlavorId
+	}
+	pcr := ""
+	if change.PcrBank != nil && change.PcrIndex != nil {
+		pcr = fmt.Sprintf("%s/%d", *change.PcrBank, *change.PcrIndex)
+	}
+	return strings.Join([]string{change.RuleName, flavorId.String(), strings.Join(markers, ","), pcr}, "|")
+}
+
+func diffPcrs(fromManifest, toManifest *types.PcrManifest) []hvs.PcrChange {
+	pcrChanges := []hvs.PcrChange{}
+	for _, pcrBank := range diffPcrBanks {
+		fromPcrs := getPcrValues(fromManifest, pcrBank)
+		toPcrs := getPcrValues(toManifest, pcrBank)
+
+		pcrIndices := make(map[int]bool)
+		for pcrIndex := range fromPcrs {
+			pcrIndices[pcrIndex] = true
+		}
+		for pcrIndex := range toPcrs {
+			pcrIndices[pcrIndex] = true
+		}
+
+		for _, pcrIndex := range sortPcrIndices(pcrIndices) {
+			if fromPcrs[pcrIndex] == toPcrs[pcrIndex] {
+				continue
+			}
+			pcrChanges = append(pcrChanges, hvs.PcrChange{
+				PcrBank:   pcrBank,
+				PcrIndex:  types.PcrIndex(pcrIndex),
+				FromValue: fromPcrs[pcrIndex],
+				ToValue:   toPcrs[pcrIndex],
+			})
+		}
+	}
+	return pcrChanges
+}
+
+func getPcrValues(pcrManifest *types.PcrManifest, pcrBank types.SHAAlgorithm) map[int]string {
+	var pcrs []types.HostManifestPcrs
+	switch pcrBank {
+	case types.SHA1:
+		pcrs = pcrManifest.Sha1Pcrs
+	case types.SHA256:
+		pcrs = pcrManifest.Sha256Pcrs
+	case types.SHA384:
+		pcrs = pcrManifest.Sha384Pcrs
+	case types.SHA512:
+		pcrs = pcrManifest.Sha512Pcrs
+	}
+
+	pcrValues := make(map[int]string, len(pcrs))
+	for _, pcr := range pcrs {
+		pcrValues[int(pcr.Index)] = strings.ToLower(pcr.Value)
+	}
+	return pcrValues
+}
+
+func diffEventLogs(fromEventLogMap, toEventLogMap *types.PcrEventLogMap) ([]hvs.EventLogChange, error) {
+	eventLogChanges := []hvs.EventLogChange{}
+	for _, pcrBank := range diffPcrBanks {
+		fromEventLogs := getEventLogs(fromEventLogMap, pcrBank)
+		toEventLogs := getEventLogs(toEventLogMap, pcrBank)
+
+		pcrIndices := make(map[int]bool)
+		for pcrIndex := range fromEventLogs {
+			pcrIndices[pcrIndex] = true
+		}
+		for pcrIndex := range toEventLogs {
+			pcrIndices[pcrIndex] = true
+		}
+
+		for _, pcrIndex := range sortPcrIndices(pcrIndices) {
+			fromEventLog := getEventLogOrEmpty(fromEventLogs, pcrBank, pcrIndex)
+			toEventLog := getEventLogOrEmpty(toEventLogs, pcrBank, pcrIndex)
+
+			added, _, err := toEventLog.Subtract(fromEventLog)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error while subtracting event logs of %s pcr %d", pcrBank, pcrIndex)
+			}
+			removed, _, err := fromEventLog.Subtract(toEventLog)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error while subtracting event logs of %s pcr %d", pcrBank, pcrIndex)
+			}
+
+			if len(added.TpmEvent) == 0 && len(removed.TpmEvent) == 0 {
+				continue
+			}
+			eventLogChanges = append(eventLogChanges, hvs.EventLogChange{
+				PcrBank:  string(pcrBank),
+				PcrIndex: pcrIndex,
+				Added:    added.TpmEvent,
+				Removed:  removed.TpmEvent,
+			})
+		}
+	}
+	return eventLogChanges, nil
+}
+
+func getEventLogs(eventLogMap *types.PcrEventLogMap, pcrBank types.SHAAlgorithm) map[int]types.TpmEventLog {
+	var eventLogs []types.TpmEventLog
+	switch pcrBank {
+	case types.SHA1:
+		eventLogs = eventLogMap.Sha1EventLogs
+	case types.SHA256:
+		eventLogs = eventLogMap.Sha256EventLogs
+	case types.SHA384:
+		eventLogs = eventLogMap.Sha384EventLogs
+	case types.SHA512:
+		eventLogs = eventLogMap.Sha512EventLogs
+	}
+
+	eventLogsByIndex := make(map[int]types.TpmEventLog, len(eventLogs))
+	for _, eventLog := range eventLogs {
+		eventLogsByIndex[eventLog.Pcr.Index] = eventLog
+	}
+	return eventLogsByIndex
+}
+
+func getEventLogOrEmpty(eventLogs map[int]types.TpmEventLog, pcrBank types.SHAAlgorithm, pcrIndex int) *types.TpmEventLog {
+	if eventLog, ok := eventLogs[pcrIndex]; ok {
+		return &eventLog
+	}
+	return &types.TpmEventLog{
+		Pcr: types.Pcr{
+			Index: pcrIndex,
+			Bank:  string(pcrBank),
+		},
+	}
+}
+
+// sortPcrIndices returns the PCR indices of the set in ascending order
+func sortPcrIndices(pcrIndices map[int]bool) []int {
+	var sortedIndices []int
+	for pcrIndex := range pcrIndices {
+		sortedIndices = append(sortedIndices, pcrIndex)
+	}
+	sort.Ints(sortedIndices)
+	return sortedIndices
+}
+
+func diffHostInfo(fromHostInfo, toHostInfo taModel.HostInfo) ([]hvs.HostInfoChange, error) {
+	fromFields, err := flattenHostInfo(fromHostInfo)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := flattenHostInfo(toHostInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for field := range fromFields {
+		fields = append(fields, field)
+	}
+	for field := range toFields {
+		if _, ok := fromFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	hostInfoChanges := []hvs.HostInfoChange{}
+	for _, field := range fields {
+		if fromFields[field] == toFields[field] {
+			continue
+		}
+		hostInfoChanges = append(hostInfoChanges, hvs.HostInfoChange{
+			Field: field,
+			From:  fromFields[field],
+			To:    toFields[field],
+		})
+	}
+	return hostInfoChanges, nil
+}
+
+// flattenHostInfo returns the host info fields keyed by their json path
+func flattenHostInfo(hostInfo taModel.HostInfo) (map[string]string, error) {
+	hostInfoBytes, err := json.Marshal(hostInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while marshalling host info")
+	}
+	var hostInfoFields map[string]interface{}
+	if err = json.Unmarshal(hostInfoBytes, &hostInfoFields); err != nil {
+		return nil, errors.Wrap(err, "Error while unmarshalling host info")
+	}
+
+	flattenedFields := make(map[string]string)
+	if err = flattenJsonFields("", hostInfoFields, flattenedFields); err != nil {
+		return nil, err
+	}
+	return flattenedFields, nil
+}
+
+func flattenJsonFields(prefix string, fields map[string]interface{}, flattenedFields map[string]string) error {
+	for name, value := range fields {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		switch fieldValue := value.(type) {
+		case map[string]interface{}:
+			if err := flattenJsonFields(name, fieldValue, flattenedFields); err != nil {
+				return err
+			}
+		case string:
+			flattenedFields[name] = fieldValue
+		case nil:
+			flattenedFields[name] = ""
+		default:
+			valueBytes, err := json.Marshal(fieldValue)
+			if err != nil {
+				return errors.Wrapf(err, "Error while marshalling host info field %s", name)
+			}
+			flattenedFields[name] = string(valueBytes)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hosttrust
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	cf "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTrustReport(t *testing.T) *hvs.TrustReport {
+	var hostManifest types.HostManifest
+	data, err := ioutil.ReadFile("../../../lib/verifier/test_data/intel20/host_manifest.json")
+	assert.NoError(t, err)
+
+	err = json.Unmarshal(data, &hostManifest)
+	assert.NoError(t, err)
+
+	flavorId := uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3")
+	return &hvs.TrustReport{
+		PolicyName: "Intel Host Trust Policy",
+		Trusted:    true,
+		Results: []hvs.RuleResult{
+			{
+				Rule: hvs.RuleInfo{
+					Name:    constants.RulePcrEventLogIntegrity,
+					Markers: []cf.FlavorPart{cf.FlavorPartPlatform},
+					ExpectedPcr: &types.FlavorPcrs{
+						Pcr:         types.Pcr{Index: 0, Bank: string(types.SHA256)},
+						Measurement: hostManifest.PcrManifest.Sha256Pcrs[0].Value,
+					},
+				},
+				FlavorId: &flavorId,
+				Trusted:  true,
+			},
+			{
+				Rule: hvs.RuleInfo{
+					Name:    constants.RuleAikCertificateTrusted,
+					Markers: []cf.FlavorPart{cf.FlavorPartPlatform},
+				},
+				FlavorId: &flavorId,
+				Trusted:  true,
+			},
+		},
+		HostManifest: hostManifest,
+	}
+}
+
+func TestDiffTrustReportsNoChanges(t *testing.T) {
+	from := loadTrustReport(t)
+	to := loadTrustReport(t)
+
+	diff, err := DiffTrustReports(from, to)
+	assert.NoError(t, err)
+	assert.True(t, diff.FromTrusted)
+	assert.True(t, diff.ToTrusted)
+	assert.Empty(t, diff.RuleChanges)
+	assert.Empty(t, diff.PcrChanges)
+	assert.Empty(t, diff.EventLogChanges)
+	assert.Empty(t, diff.HostInfoChanges)
+}
+
+func TestDiffTrustReports(t *testing.T) {
+	from := loadTrustReport(t)
+	to := loadTrustReport(t)
+
+	to.Trusted = false
+	to.Results[0].Trusted = false
+	to.Results[0].Faults = []hvs.Fault{{Name: "PcrEventLogContainsUnexpectedEntries"}}
+	to.HostManifest.PcrManifest.Sha256Pcrs[0].Value = "0000000000000000000000000000000000000000000000000000000000000000"
+	removedEvent := to.HostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs[0].TpmEvent[0]
+	to.HostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs[0].TpmEvent = to.HostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs[0].TpmEvent[1:]
+	addedEvent := types.EventLog{TypeID: "0x80000008", TypeName: "EV_EFI_PLATFORM_FIRMWARE_BLOB", Measurement: "ab"}
+	to.HostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs[0].TpmEvent = append(to.HostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs[0].TpmEvent, addedEvent)
+	to.HostManifest.HostInfo.BiosVersion = "SE5C620.86B.00.01.0016.020120190930"
+
+	diff, err := DiffTrustReports(from, to)
+	assert.NoError(t, err)
+	assert.True(t, diff.FromTrusted)
+	assert.False(t, diff.ToTrusted)
+
+	assert.Len(t, diff.RuleChanges, 1)
+	assert.Equal(t, from.Results[0].Rule.Name, diff.RuleChanges[0].RuleName)
+	assert.True(t, *diff.RuleChanges[0].FromTrusted)
+	assert.False(t, *diff.RuleChanges[0].ToTrusted)
+	assert.Len(t, diff.RuleChanges[0].Faults, 1)
+
+	assert.Len(t, diff.PcrChanges, 1)
+	assert.Equal(t, types.SHA256, diff.PcrChanges[0].PcrBank)
+	assert.Equal(t, from.HostManifest.PcrManifest.Sha256Pcrs[0].Index, diff.PcrChanges[0].PcrIndex)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", diff.PcrChanges[0].ToValue)
+
+	assert.Len(t, diff.EventLogChanges, 1)
+	assert.Equal(t, string(types.SHA256), diff.EventLogChanges[0].PcrBank)
+	assert.Equal(t, []types.EventLog{addedEvent}, diff.EventLogChanges[0].Added)
+	assert.Len(t, diff.EventLogChanges[0].Removed, 1)
+	assert.Equal(t, removedEvent.Measurement, diff.EventLogChanges[0].Removed[0].Measurement)
+
+	assert.Equal(t, []hvs.HostInfoChange{{
+		Field: "bios_version",
+		From:  from.HostManifest.HostInfo.BiosVersion,
+		To:    "SE5C620.86B.00.01.0016.020120190930",
+	}}, diff.HostInfoChanges)
+}
+
+func TestDiffTrustReportsMissingRule(t *testing.T) {
+	from := loadTrustReport(t)
+	to := loadTrustReport(t)
+	to.Results = to.Results[1:]
+
+	diff, err := DiffTrustReports(from, to)
+	assert.NoError(t, err)
+	assert.Len(t, diff.RuleChanges, 1)
+	assert.NotNil(t, diff.RuleChanges[0].FromTrusted)
+	assert.Nil(t, diff.RuleChanges[0].ToTrusted)
+}
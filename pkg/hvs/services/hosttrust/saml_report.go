@@ -9,8 +9,10 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	faultsConst "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/metrics"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/constants"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/saml"
@@ -31,6 +33,7 @@ func (srg *SamlReportGenerator) GenerateSamlReport(report *hvs.TrustReport) saml
 	defaultLog.Trace("hosttrust/saml_report:generateSamlReport() Entering")
 	defer defaultLog.Trace("hosttrust/saml_report:generateSamlReport() Leaving")
 
+	start := time.Now()
 	libSaml, err := saml.NewLegacySAML(*srg.tagIssuer)
 	if err != nil {
 		log.WithError(err).Errorf("hosttrust/saml_report:generateSamlReport() Failed to instantiate SAML library")
@@ -40,6 +43,7 @@ func (srg *SamlReportGenerator) GenerateSamlReport(report *hvs.TrustReport) saml
 	if err != nil {
 		log.WithError(err).Errorf("hosttrust/saml_report:generateSamlReport() Failed to generate SAML assertions")
 	}
+	metrics.ObserveSince(metrics.SamlSigningDuration, start, err)
 	return assertion
 }
 
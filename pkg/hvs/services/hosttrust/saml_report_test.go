@@ -125,9 +125,9 @@ func createVerifierCertificates(
 	assetTagCACertificates.AppendCertsFromPEM(assetTagPemBytes)
 
 	return &verifier.VerifierCertificates{
-		PrivacyCACertificates:    privacyCACertificates,
-		FlavorSigningCertificate: flavorSigningCertificate,
-		AssetTagCACertificates:   assetTagCACertificates,
-		FlavorCACertificates:     flavorCACertificates,
+		PrivacyCACertificates:     privacyCACertificates,
+		FlavorSigningCertificates: []x509.Certificate{*flavorSigningCertificate},
+		AssetTagCACertificates:    assetTagCACertificates,
+		FlavorCACertificates:      flavorCACertificates,
 	}
 }
@@ -6,10 +6,13 @@
 package hosttrust
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/metrics"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
@@ -35,6 +38,7 @@ type Verifier struct {
 	SkipFlavorSignatureVerification bool
 	hostQuoteReportCache            map[uuid.UUID]*models.QuoteReportCache
 	HostTrustCache                  *lru.Cache
+	TrustEventPublisher             domain.TrustEventPublisher
 }
 
 func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
@@ -48,6 +52,7 @@ func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
 		SamlIssuer:                      cfg.SamlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.SkipFlavorSignatureVerification,
 		HostTrustCache:                  cfg.HostTrustCache,
+		TrustEventPublisher:             cfg.TrustEventPublisher,
 		hostQuoteReportCache:            make(map[uuid.UUID]*models.QuoteReportCache),
 	}
 }
@@ -78,6 +83,16 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 	defaultLog.Trace("hosttrust/verifier:Verify() Entering")
 	defer defaultLog.Trace("hosttrust/verifier:Verify() Leaving")
 
+	start := time.Now()
+	report, err := v.verify(hostId, hostData, newData, preferHashMatch)
+	metrics.ObserveSince(metrics.FlavorVerifyDuration, start, err)
+	return report, err
+}
+
+func (v *Verifier) verify(hostId uuid.UUID, hostData *types.HostManifest, newData bool, preferHashMatch bool) (*models.HVSReport, error) {
+	defaultLog.Trace("hosttrust/verifier:verify() Entering")
+	defer defaultLog.Trace("hosttrust/verifier:verify() Leaving")
+
 	defaultLog.Debugf("hosttrust/verifier:Verify() host - %s", hostId.String())
 
 	if hostData == nil {
@@ -100,6 +115,7 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 				// retrieve the stored report
 				log.Debugf("hosttrust/verifier:Verify() Quote values matches cached value for host %s - skipping flavor verification", hostId.String())
 				if report, err := v.refreshTrustReport(hostId, cachedQuote); err == nil {
+					metrics.TrustCacheLookup(metrics.CacheQuote, true)
 					return report, err
 				} else {
 					// log warning message here - continue as normal and create a report from newly fetched data
@@ -107,6 +123,7 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 				}
 			}
 		}
+		metrics.TrustCacheLookup(metrics.CacheQuote, false)
 	}
 	// TODO : remove this when we remove the intermediate collection
 	flvGroupIds, err := v.HostStore.SearchFlavorgroups(hostId)
@@ -156,7 +173,9 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 		}
 
 		fgTrustReport := fgTrustCache.trustReport
-		if !fgTrustReqs.MeetsFlavorGroupReqs(fgTrustCache, v.FlavorVerifier.GetVerifierCerts()) {
+		meetsFlavorGroupReqs := fgTrustReqs.MeetsFlavorGroupReqs(fgTrustCache, v.FlavorVerifier.GetVerifierCerts())
+		metrics.TrustCacheLookup(metrics.CacheFlavorgroup, meetsFlavorGroupReqs)
+		if !meetsFlavorGroupReqs {
 			log.Debug("hosttrust/verifier:Verify() Trust cache doesn't meet flavorgroup requirements")
 			finalReportValid = false
 			fgTrustReport, err = v.CreateFlavorGroupReport(hostId, *fgTrustReqs, hostData, fgTrustCache)
@@ -174,7 +193,8 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 	log.Debugf("hosttrust/verifier:Verify() Final results in report: %d", len(finalTrustReport.Results))
 	if len(finalTrustReport.Results) > 0 && (!finalReportValid || newData) {
 		log.Debugf("hosttrust/verifier:Verify() Generating new SAML for host: %s", hostId)
-		samlReportGen := NewSamlReportGenerator(&v.SamlIssuer)
+		samlIssuer := v.getSamlIssuer(getReportPolicy(flvGroups))
+		samlReportGen := NewSamlReportGenerator(&samlIssuer)
 		samlReport := samlReportGen.GenerateSamlReport(&finalTrustReport)
 		finalTrustReport.Trusted = finalTrustReport.IsTrusted()
 		log.Debugf("hosttrust/verifier:Verify() Saving new report for host: %s", hostId)
@@ -223,7 +243,25 @@ func (v *Verifier) validateCachedFlavors(hostId uuid.UUID,
 	}
 	var collectiveReport hvs.TrustReport
 	var trustCachesToDelete []uuid.UUID
+
+	supersessions, err := v.getCachedFlavorSupersessions(cachedFlavors)
+	if err != nil {
+		return hostTrustCache{}, errors.Wrap(err, "hosttrust/verifier:validateCachedFlavors() Error retrieving flavor supersessions")
+	}
+	now := time.Now()
 	for _, cachedFlavor := range cachedFlavors {
+		// A superseded flavor does not satisfy the trust cache, so that the flavor part is verified again with
+		// the flavor lineage: the flavor superseding it and, during its grace period, the superseded flavor.
+		// The cache entry is kept until the superseded flavor retires.
+		if supersession, ok := supersessions[cachedFlavor.Flavor.Meta.ID]; ok {
+			defaultLog.Debugf("hosttrust/verifier:validateCachedFlavors() Cached flavor %s is superseded by flavor %s",
+				cachedFlavor.Flavor.Meta.ID, supersession.SupersededBy)
+			if supersession.IsRetired(now) {
+				trustCachesToDelete = append(trustCachesToDelete, cachedFlavor.Flavor.Meta.ID)
+			}
+			continue
+		}
+
 		//TODO: change the signature verification depending on decision on signed flavors
 		report, err := v.FlavorVerifier.Verify(hostData, &cachedFlavor, v.SkipFlavorSignatureVerification)
 		if err != nil {
@@ -247,16 +285,75 @@ func (v *Verifier) validateCachedFlavors(hostId uuid.UUID,
 	return htc, nil
 }
 
+// getCachedFlavorSupersessions returns the supersessions of the cached flavors that are superseded, mapped by flavor ID
+func (v *Verifier) getCachedFlavorSupersessions(cachedFlavors []hvs.SignedFlavor) (map[uuid.UUID]models.FlavorSupersession, error) {
+	defaultLog.Trace("hosttrust/verifier:getCachedFlavorSupersessions() Entering")
+	defer defaultLog.Trace("hosttrust/verifier:getCachedFlavorSupersessions() Leaving")
+
+	flavorIds := make([]uuid.UUID, 0, len(cachedFlavors))
+	for _, cachedFlavor := range cachedFlavors {
+		flavorIds = append(flavorIds, cachedFlavor.Flavor.Meta.ID)
+	}
+	supersessions, err := v.FlavorStore.SearchSupersessions(&models.FlavorSupersessionFilterCriteria{FlavorIds: flavorIds})
+	if err != nil {
+		return nil, err
+	}
+
+	supersessionMap := make(map[uuid.UUID]models.FlavorSupersession, len(supersessions))
+	for _, supersession := range supersessions {
+		supersessionMap[supersession.FlavorId] = supersession
+	}
+	return supersessionMap, nil
+}
+
 func (v *Verifier) refreshTrustReport(hostID uuid.UUID, cache *models.QuoteReportCache) (*models.HVSReport, error) {
 	defaultLog.Trace("hosttrust/verifier:refreshTrustReport() Entering")
 	defer defaultLog.Trace("hosttrust/verifier:refreshTrustReport() Leaving")
 	log.Debugf("hosttrust/verifier:refreshTrustReport() Generating SAML for host: %s using existing trust report", hostID)
 
-	samlReportGen := NewSamlReportGenerator(&v.SamlIssuer)
+	flvGroupIds, err := v.HostStore.SearchFlavorgroups(hostID)
+	if err != nil {
+		return nil, errors.Wrap(err, "hosttrust/verifier:refreshTrustReport() Error while retrieving host flavorgroups")
+	}
+	flvGroups, err := v.FlavorGroupStore.Search(&models.FlavorGroupFilterCriteria{Ids: flvGroupIds})
+	if err != nil {
+		return nil, errors.Wrap(err, "hosttrust/verifier:refreshTrustReport() Error while retrieving flavorgroups")
+	}
+	samlIssuer := v.getSamlIssuer(getReportPolicy(flvGroups))
+	samlReportGen := NewSamlReportGenerator(&samlIssuer)
 	samlReport := samlReportGen.GenerateSamlReport(cache.TrustReport)
 	return v.storeTrustReport(hostID, cache.TrustReport, &samlReport), nil
 }
 
+// getReportPolicy returns the shortest report validity and refresh interval of the report policies of the flavorgroups
+func getReportPolicy(flavorGroups []hvs.FlavorGroup) hvs.ReportPolicy {
+	var reportPolicy hvs.ReportPolicy
+	for _, fg := range flavorGroups {
+		if fg.ReportPolicy == nil {
+			continue
+		}
+		if fg.ReportPolicy.ValiditySeconds > 0 &&
+			(reportPolicy.ValiditySeconds == 0 || fg.ReportPolicy.ValiditySeconds < reportPolicy.ValiditySeconds) {
+			reportPolicy.ValiditySeconds = fg.ReportPolicy.ValiditySeconds
+		}
+		if fg.ReportPolicy.RefreshIntervalSeconds > 0 &&
+			(reportPolicy.RefreshIntervalSeconds == 0 || fg.ReportPolicy.RefreshIntervalSeconds < reportPolicy.RefreshIntervalSeconds) {
+			reportPolicy.RefreshIntervalSeconds = fg.ReportPolicy.RefreshIntervalSeconds
+		}
+	}
+	return reportPolicy
+}
+
+// getSamlIssuer returns the SAML issuer configuration with the report validity of the report policy, the configured
+// SAML validity is used when the policy does not set the validity
+func (v *Verifier) getSamlIssuer(reportPolicy hvs.ReportPolicy) saml.IssuerConfiguration {
+	samlIssuer := v.SamlIssuer
+	if reportPolicy.ValiditySeconds > 0 {
+		samlIssuer.ValiditySeconds = reportPolicy.ValiditySeconds
+	}
+	return samlIssuer
+}
+
 func (v *Verifier) storeTrustReport(hostID uuid.UUID, trustReport *hvs.TrustReport, samlReport *saml.SamlAssertion) *models.HVSReport {
 	defaultLog.Trace("hosttrust/verifier:storeTrustReport() Entering")
 	defer defaultLog.Trace("hosttrust/verifier:storeTrustReport() Leaving")
@@ -269,9 +366,55 @@ func (v *Verifier) storeTrustReport(hostID uuid.UUID, trustReport *hvs.TrustRepo
 		Expiration:  samlReport.ExpiryTime,
 		Saml:        samlReport.Assertion,
 	}
+
+	// the previous report is replaced by the update, look up its trust status first
+	var previousReport *models.HVSReport
+	if v.TrustEventPublisher != nil {
+		previousReports, err := v.ReportStore.Search(&models.ReportFilterCriteria{HostID: hostID, LatestPerHost: true, PageCriteria: models.PageCriteria{Limit: 1}})
+		if err != nil {
+			log.WithError(err).Warnf("hosttrust/verifier:storeTrustReport() Failed to retrieve previous report for host %s", hostID)
+		} else if len(previousReports) > 0 {
+			previousReport = &previousReports[0]
+		}
+	}
+
 	report, err := v.ReportStore.Update(&hvsReport)
 	if err != nil {
 		log.WithError(err).Errorf("hosttrust/verifier:storeTrustReport() Failed to store Report")
+		return report
+	}
+	if previousReport != nil && report != nil && previousReport.TrustReport.Trusted != report.TrustReport.Trusted {
+		v.TrustEventPublisher.Publish(newTrustStateChangeEvent(previousReport, report))
 	}
 	return report
 }
+
+func newTrustStateChangeEvent(previousReport, report *models.HVSReport) *hvs.TrustStateChangeEvent {
+	hostInfo := report.TrustReport.HostManifest.HostInfo
+	event := &hvs.TrustStateChangeEvent{
+		ID:              uuid.New(),
+		HostID:          report.HostID,
+		HostName:        hostInfo.HostName,
+		HardwareUUID:    hostInfo.HardwareUUID,
+		ReportID:        report.ID,
+		PreviousTrusted: previousReport.TrustReport.Trusted,
+		Trusted:         report.TrustReport.Trusted,
+		Timestamp:       report.CreatedAt,
+	}
+	for _, flavorPart := range common.GetFlavorTypes() {
+		if len(report.TrustReport.GetResultsForMarker(flavorPart.String())) > 0 &&
+			!report.TrustReport.IsTrustedForMarker(flavorPart.String()) {
+			event.UntrustedFlavorParts = append(event.UntrustedFlavorParts, flavorPart)
+		}
+	}
+	faultNames := make(map[string]bool)
+	for _, result := range report.TrustReport.Results {
+		for _, fault := range result.Faults {
+			if !faultNames[fault.Name] {
+				faultNames[fault.Name] = true
+				event.FaultNames = append(event.FaultNames, fault.Name)
+			}
+		}
+	}
+	return event
+}
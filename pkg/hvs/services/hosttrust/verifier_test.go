@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hosttrust
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/saml"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCachedFlavorsSkipsSupersededFlavors(t *testing.T) {
+	supersededFlavorId := uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3")
+	supersedingFlavorId := uuid.MustParse("e6612219-bbd5-4259-8c7e-991e43729a86")
+
+	for _, retireAt := range []time.Time{time.Now().Add(time.Hour), time.Now().Add(-time.Hour)} {
+		flavorStore := mocks.NewMockFlavorStore()
+		err := flavorStore.Supersede(&models.FlavorSupersession{
+			FlavorId:     supersededFlavorId,
+			SupersededBy: supersedingFlavorId,
+			RetireAt:     retireAt,
+		})
+		assert.NoError(t, err)
+
+		cachedFlavor, err := flavorStore.Retrieve(supersededFlavorId)
+		assert.NoError(t, err)
+
+		// the superseded flavor is not verified, so the flavor verifier is not needed
+		v := &Verifier{
+			FlavorStore: flavorStore,
+			HostStore:   mocks.NewMockHostStore(),
+		}
+		htc, err := v.validateCachedFlavors(uuid.New(), &types.HostManifest{}, []hvs.SignedFlavor{*cachedFlavor})
+		assert.NoError(t, err)
+		assert.True(t, htc.isTrustCacheEmpty())
+		assert.Empty(t, htc.trustReport.Results)
+	}
+}
+
+func TestGetSamlIssuerUsesShortestReportPolicy(t *testing.T) {
+	v := &Verifier{SamlIssuer: saml.IssuerConfiguration{IssuerName: "AttestationService", ValiditySeconds: 86400}}
+
+	// the configured validity applies to the hosts of flavorgroups without a report policy
+	reportPolicy := getReportPolicy([]hvs.FlavorGroup{{Name: "automatic"}})
+	assert.Equal(t, hvs.ReportPolicy{}, reportPolicy)
+	assert.Equal(t, 86400, v.getSamlIssuer(reportPolicy).ValiditySeconds)
+
+	reportPolicy = getReportPolicy([]hvs.FlavorGroup{
+		{Name: "automatic"},
+		{Name: "lab", ReportPolicy: &hvs.ReportPolicy{ValiditySeconds: 172800, RefreshIntervalSeconds: 86400}},
+		{Name: "production", ReportPolicy: &hvs.ReportPolicy{RefreshIntervalSeconds: 900}},
+	})
+	assert.Equal(t, hvs.ReportPolicy{ValiditySeconds: 172800, RefreshIntervalSeconds: 900}, reportPolicy)
+	samlIssuer := v.getSamlIssuer(reportPolicy)
+	assert.Equal(t, 172800, samlIssuer.ValiditySeconds)
+	assert.Equal(t, "AttestationService", samlIssuer.IssuerName)
+	// the verifier configuration is left unchanged
+	assert.Equal(t, 86400, v.SamlIssuer.ValiditySeconds)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/metrics"
 	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
 
 	"github.com/pkg/errors"
@@ -97,17 +98,30 @@ func (refresher *hostReportRefresherImpl) Stop() error {
 // - On subsequent calls, the window wll be from the last time this function was called,
 //   to the next 'refresh period'.
 //
-// The intent of this logic is to avoid adding duplicate hosts to the
-// HostTrustManage queue.
+// The reports of the hosts linked to flavorgroups with a report policy are due at the end
+// of the shortest refresh interval of the policies when it is before their expiration.
+// The windows overlap, so that the reports created after a pass and due before the end of
+// its window are found on the next pass. The hosts already in the HostTrustManager queue
+// are not queued again.
 func (refresher *hostReportRefresherImpl) refreshReports() error {
+	refreshedHosts, err := refresher.queueExpiredReports()
+	metrics.HRRSRefreshRuns.WithLabelValues(metrics.Result(err)).Inc()
+	metrics.HRRSRefreshedHosts.Add(float64(refreshedHosts))
+	return err
+}
+
+// queueExpiredReports queues the hosts with reports expiring in the refresh period for verification and returns the
+// number of hosts queued
+func (refresher *hostReportRefresherImpl) queueExpiredReports() (int, error) {
 
-	toTime := time.Now().UTC().Add(refresher.cfg.RefreshPeriod)
+	now := time.Now().UTC()
+	toTime := now.Add(refresher.cfg.RefreshPeriod)
 	defaultLog.Debugf("HRRS is refreshing hosts that have expired reports between %s and %s", refresher.fromTime, toTime)
 
 	hostIDs, err := refresher.reportStore.FindHostIdsFromExpiredReports(refresher.fromTime, toTime)
 
 	if err != nil {
-		return errors.Wrap(err, "An error occurred while HRRS searched for host ids")
+		return 0, errors.Wrap(err, "An error occurred while HRRS searched for host ids")
 	}
 
 	defaultLog.Debugf("HRRS found %d hosts to refresh", len(hostIDs))
@@ -115,12 +129,12 @@ func (refresher *hostReportRefresherImpl) refreshReports() error {
 	if len(hostIDs) > 0 {
 		err = refresher.hostTrustManager.VerifyHostsAsync(hostIDs, true, true)
 		if err != nil {
-			return errors.Wrap(err, "HRRS encountered an error calling the host trust manager")
+			return 0, errors.Wrap(err, "HRRS encountered an error calling the host trust manager")
 		}
 	}
 
 	defaultLog.Infof("HRRS queued %d hosts from reports that were expiring between %s and %s", len(hostIDs), refresher.fromTime, toTime)
-	refresher.fromTime = toTime
+	refresher.fromTime = now
 
-	return nil
+	return len(hostIDs), nil
 }
@@ -100,6 +100,54 @@ func TestHostReportRefresher(t *testing.T) {
 	}
 }
 
+func TestHostReportRefresherRefreshInterval(t *testing.T) {
+
+	cfg := HRRSConfig{
+		RefreshPeriod: twoSeconds,
+	}
+
+	reportStore := mocks.NewEmptyMockReportStore().(*mocks.MockReportStore)
+
+	// Create two reports that expire in 24 hours, the first host is linked to a flavorgroup
+	// with a report policy refreshing its report every three seconds.
+	// Expect that only the report of the first host is refreshed.
+	created := time.Now()
+	hostUUIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, hostUUID := range hostUUIDs {
+		_, _ = reportStore.Create(&models.HVSReport{
+			ID:         uuid.New(),
+			HostID:     hostUUID,
+			CreatedAt:  created,
+			Expiration: created.Add(twentyFourHours),
+			TrustReport: hvs.TrustReport{
+				Trusted: true,
+			},
+		})
+	}
+	reportStore.RefreshIntervals = map[uuid.UUID]time.Duration{hostUUIDs[0]: 3 * time.Second}
+
+	hostTrustManager := MockHostTrustManager{
+		reportStore: reportStore,
+	}
+
+	refresher, err := NewHostReportRefresher(cfg, reportStore, hostTrustManager)
+	assert.NoError(t, err)
+	err = refresher.Run()
+	assert.NoError(t, err)
+
+	time.Sleep(tenSeconds)
+
+	err = refresher.Stop()
+	assert.NoError(t, err)
+
+	for i, hostId := range hostUUIDs {
+		reports, err := reportStore.Search(&models.ReportFilterCriteria{HostID: hostId})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(reports))
+		assert.Equal(t, i == 0, reports[0].CreatedAt.After(created))
+	}
+}
+
 //-------------------------------------------------------------------------------------------------
 // M O C K   H O S T   T R U S T   M A N A G E R
 //-------------------------------------------------------------------------------------------------
@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package jps
+
+import (
+	"context"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	"github.com/pkg/errors"
+)
+
+// JobPurger runs in the background and periodically deletes the asynchronous jobs
+// that finished before the maximum age, such as the bulk host registrations.
+type JobPurger interface {
+	Run() error
+	Stop() error
+}
+
+func NewJobPurger(cfg JPSConfig, jobStore domain.JobStore) (JobPurger, error) {
+
+	return &jobPurgerImpl{
+		jobStore: jobStore,
+		cfg:      cfg,
+	}, nil
+}
+
+var (
+	defaultLog = commLog.GetDefaultLogger()
+)
+
+type jobPurgerImpl struct {
+	jobStore domain.JobStore
+	cfg      JPSConfig
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func (purger *jobPurgerImpl) Run() error {
+
+	defaultLog.Infof("JPS is starting with refresh period '%s'", purger.cfg.RefreshPeriod)
+
+	if purger.cfg.RefreshPeriod == 0 {
+		defaultLog.Info("The JPS refresh period is zero.  JPS will now exit")
+		return nil
+	}
+	if purger.cfg.MaxAge == 0 {
+		defaultLog.Info("The JPS keeps all the jobs.  JPS will now exit")
+		return nil
+	}
+
+	purger.ctx, purger.cancel = context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			err := purger.purgeJobs()
+			if err != nil {
+				// log any errors, but do not stop trying to purge jobs
+				defaultLog.Errorf("JPS encountered an error while purging jobs...\n%+v\n", err)
+			}
+
+			select {
+			case <-time.After(purger.cfg.RefreshPeriod):
+				// continue with the loop and purge jobs again
+			case <-purger.ctx.Done():
+				defaultLog.Info("The JPS has been stopped and will now exit")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (purger *jobPurgerImpl) Stop() error {
+	if purger.cancel != nil {
+		purger.cancel()
+	} else {
+		defaultLog.Debug("The JPS is not running")
+	}
+
+	return nil
+}
+
+// Deletes the finished jobs last updated before the maximum age of the configuration.
+func (purger *jobPurgerImpl) purgeJobs() error {
+
+	purged, err := purger.jobStore.Purge(time.Now().UTC().Add(-purger.cfg.MaxAge))
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while JPS purged the jobs")
+	}
+
+	defaultLog.Infof("JPS purged %d jobs", purged)
+	return nil
+}
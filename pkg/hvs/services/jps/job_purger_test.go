@@ -0,0 +1,47 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package jps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobPurgerKeepsUnfinishedAndRecentJobs(t *testing.T) {
+	store := mocks.NewMockJobStore()
+	var jobs []*models.Job
+	for _, state := range []models.JobState{models.JobStateCompleted, models.JobStateError, models.JobStatePending} {
+		job, err := store.Create(&models.Job{Action: "host-bulk-create", State: state})
+		assert.NoError(t, err)
+		jobs = append(jobs, job)
+	}
+
+	purger, err := NewJobPurger(JPSConfig{MaxAge: time.Hour, RefreshPeriod: DefaultRefreshPeriod}, store)
+	assert.NoError(t, err)
+	assert.NoError(t, purger.(*jobPurgerImpl).purgeJobs())
+	for _, job := range jobs {
+		_, err := store.Retrieve(job.Id)
+		assert.NoError(t, err)
+	}
+
+	// once they are old enough, the finished jobs are purged while the pending job is kept
+	purger, err = NewJobPurger(JPSConfig{MaxAge: time.Nanosecond, RefreshPeriod: DefaultRefreshPeriod}, store)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	assert.NoError(t, purger.(*jobPurgerImpl).purgeJobs())
+	for _, job := range jobs {
+		_, err := store.Retrieve(job.Id)
+		if job.State.Finished() {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
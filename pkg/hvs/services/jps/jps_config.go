@@ -0,0 +1,22 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package jps
+
+import "time"
+
+var (
+	// DefaultMaxAge by default keeps the finished jobs for seven days
+	DefaultMaxAge, _ = time.ParseDuration("168h")
+	// DefaultRefreshPeriod by default purges the jobs every hour
+	DefaultRefreshPeriod, _ = time.ParseDuration("1h")
+)
+
+type JPSConfig struct {
+	// MaxAge determines how long the finished jobs are kept once they were last updated, 0 keeps all the jobs
+	// (defaults to DefaultMaxAge).
+	MaxAge time.Duration `yaml:"max-age" mapstructure:"max-age"`
+	// RefreshPeriod determines how frequently the JPS purges the jobs (defaults to DefaultRefreshPeriod).
+	RefreshPeriod time.Duration `yaml:"refresh-period" mapstructure:"refresh-period"`
+}
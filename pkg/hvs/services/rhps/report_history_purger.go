@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package rhps
+
+import (
+	"context"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	"github.com/pkg/errors"
+)
+
+// ReportHistoryPurger runs in the background and periodically deletes the reports
+// of the report history that are beyond the number of reports kept per host or
+// older than the maximum age.  The latest report of each host is always kept.
+type ReportHistoryPurger interface {
+	Run() error
+	Stop() error
+}
+
+func NewReportHistoryPurger(cfg RHPSConfig, reportHistoryStore domain.ReportHistoryStore) (ReportHistoryPurger, error) {
+
+	return &reportHistoryPurgerImpl{
+		reportHistoryStore: reportHistoryStore,
+		cfg:                cfg,
+	}, nil
+}
+
+var (
+	defaultLog = commLog.GetDefaultLogger()
+)
+
+type reportHistoryPurgerImpl struct {
+	reportHistoryStore domain.ReportHistoryStore
+	cfg                RHPSConfig
+	ctx                context.Context
+	cancel             context.CancelFunc
+}
+
+func (purger *reportHistoryPurgerImpl) Run() error {
+
+	defaultLog.Infof("RHPS is starting with refresh period '%s'", purger.cfg.RefreshPeriod)
+
+	if purger.cfg.RefreshPeriod == 0 {
+		defaultLog.Info("The RHPS refresh period is zero.  RHPS will now exit")
+		return nil
+	}
+	if purger.cfg.MaxReports == 0 && purger.cfg.MaxAge == 0 {
+		defaultLog.Info("The RHPS keeps all the reports.  RHPS will now exit")
+		return nil
+	}
+
+	purger.ctx, purger.cancel = context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			err := purger.purgeReports()
+			if err != nil {
+				// log any errors, but do not stop trying to purge reports
+				defaultLog.Errorf("RHPS encountered an error while purging reports...\n%+v\n", err)
+			}
+
+			select {
+			case <-time.After(purger.cfg.RefreshPeriod):
+				// continue with the loop and purge reports again
+			case <-purger.ctx.Done():
+				defaultLog.Info("The RHPS has been stopped and will now exit")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (purger *reportHistoryPurgerImpl) Stop() error {
+	if purger.cancel != nil {
+		purger.cancel()
+	} else {
+		defaultLog.Debug("The RHPS is not running")
+	}
+
+	return nil
+}
+
+// Deletes the reports of the report history that are beyond the limits of the configuration.
+func (purger *reportHistoryPurgerImpl) purgeReports() error {
+
+	var createdBefore time.Time
+	if purger.cfg.MaxAge > 0 {
+		createdBefore = time.Now().UTC().Add(-purger.cfg.MaxAge)
+	}
+
+	purged, err := purger.reportHistoryStore.Purge(purger.cfg.MaxReports, createdBefore)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while RHPS purged the report history")
+	}
+
+	defaultLog.Infof("RHPS purged %d reports from the report history", purged)
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package rhps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	hostId      = uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	otherHostId = uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+)
+
+// newReportHistoryStore returns a report history holding a report per day for the last days of the host and a single
+// report created days ago for the other host
+func newReportHistoryStore(days int) *mocks.MockReportHistoryStore {
+	store := mocks.NewMockReportHistoryStore()
+	for day := 0; day < days; day++ {
+		store.Add(models.HVSReport{ID: uuid.New(), HostID: hostId, CreatedAt: time.Now().AddDate(0, 0, -day)})
+	}
+	store.Add(models.HVSReport{ID: uuid.New(), HostID: otherHostId, CreatedAt: time.Now().AddDate(0, 0, -days)})
+	return store
+}
+
+func searchReports(t *testing.T, store *mocks.MockReportHistoryStore, hostId uuid.UUID) []models.HVSReport {
+	reports, err := store.Search(&models.ReportHistoryFilterCriteria{HostID: hostId})
+	assert.NoError(t, err)
+	return reports
+}
+
+func TestReportHistoryPurgerKeepsMaxReports(t *testing.T) {
+	store := newReportHistoryStore(10)
+
+	purger, err := NewReportHistoryPurger(RHPSConfig{MaxReports: 3, RefreshPeriod: DefaultRefreshPeriod}, store)
+	assert.NoError(t, err)
+	err = purger.(*reportHistoryPurgerImpl).purgeReports()
+	assert.NoError(t, err)
+
+	reports := searchReports(t, store, hostId)
+	assert.Len(t, reports, 3)
+	assert.True(t, reports[2].CreatedAt.After(time.Now().AddDate(0, 0, -3)))
+	assert.Len(t, searchReports(t, store, otherHostId), 1)
+}
+
+func TestReportHistoryPurgerKeepsMaxAge(t *testing.T) {
+	store := newReportHistoryStore(10)
+
+	purger, err := NewReportHistoryPurger(RHPSConfig{MaxAge: 4*24*time.Hour - time.Hour, RefreshPeriod: DefaultRefreshPeriod}, store)
+	assert.NoError(t, err)
+	err = purger.(*reportHistoryPurgerImpl).purgeReports()
+	assert.NoError(t, err)
+
+	assert.Len(t, searchReports(t, store, hostId), 4)
+	// the latest report of a host is kept regardless of its age
+	assert.Len(t, searchReports(t, store, otherHostId), 1)
+}
+
+func TestReportHistoryPurgerAppliesBothLimits(t *testing.T) {
+	store := newReportHistoryStore(10)
+
+	purger, err := NewReportHistoryPurger(RHPSConfig{MaxReports: 6, MaxAge: 2*24*time.Hour - time.Hour,
+		RefreshPeriod: DefaultRefreshPeriod}, store)
+	assert.NoError(t, err)
+	err = purger.(*reportHistoryPurgerImpl).purgeReports()
+	assert.NoError(t, err)
+
+	assert.Len(t, searchReports(t, store, hostId), 2)
+}
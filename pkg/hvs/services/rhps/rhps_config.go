@@ -0,0 +1,27 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rhps
+
+import "time"
+
+var (
+	// DefaultMaxReports by default keeps the last hundred reports of each host
+	DefaultMaxReports = 100
+	// DefaultMaxAge by default keeps the reports of the last thirty days
+	DefaultMaxAge, _ = time.ParseDuration("720h")
+	// DefaultRefreshPeriod by default purges the report history every hour
+	DefaultRefreshPeriod, _ = time.ParseDuration("1h")
+)
+
+type RHPSConfig struct {
+	// MaxReports determines how many reports of each host are kept in the report history, 0 keeps all the reports
+	// (defaults to DefaultMaxReports).
+	MaxReports int `yaml:"max-reports" mapstructure:"max-reports"`
+	// MaxAge determines how long the reports are kept in the report history, 0 keeps the reports regardless of their
+	// age (defaults to DefaultMaxAge).
+	MaxAge time.Duration `yaml:"max-age" mapstructure:"max-age"`
+	// RefreshPeriod determines how frequently the RHPS purges the report history (defaults to DefaultRefreshPeriod).
+	RefreshPeriod time.Duration `yaml:"refresh-period" mapstructure:"refresh-period"`
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package tcrs
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	asset_tag "github.com/intel-secl/intel-secl/v4/pkg/lib/asset-tag"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// TagCertificateRenewer runs in the background and periodically renews the tag
+// certificates that expire soon, issuing a tag certificate with the same tag
+// attributes.  When configured to, the renewed tag certificate is deployed to the
+// host and the ASSET_TAG flavor of the host is created.  Each step of a renewal is
+// recorded in the audit log.
+type TagCertificateRenewer interface {
+	Run() error
+	Stop() error
+}
+
+func NewTagCertificateRenewer(cfg TCRSConfig, tagCertificateStore domain.TagCertificateStore,
+	tagCertificateProvisioner domain.TagCertificateProvisioner, auditLogWriter domain.AuditLogWriter) (TagCertificateRenewer, error) {
+
+	// a renewed tag certificate would expire within the renewal period itself and be renewed again endlessly
+	tagCertValidity := time.Duration(constants.DefaultTagCertValiditySeconds) * time.Second
+	if cfg.RenewBefore < 0 || cfg.RenewBefore >= tagCertValidity {
+		return nil, errors.Errorf("The TCRS renewal period '%s' must be positive and shorter than the tag certificate validity '%s'",
+			cfg.RenewBefore, tagCertValidity)
+	}
+
+	return &tagCertificateRenewerImpl{
+		tagCertificateStore:       tagCertificateStore,
+		tagCertificateProvisioner: tagCertificateProvisioner,
+		auditLogWriter:            auditLogWriter,
+		cfg:                       cfg,
+	}, nil
+}
+
+var (
+	defaultLog = commLog.GetDefaultLogger()
+)
+
+type tagCertificateRenewerImpl struct {
+	tagCertificateStore       domain.TagCertificateStore
+	tagCertificateProvisioner domain.TagCertificateProvisioner
+	auditLogWriter            domain.AuditLogWriter
+	cfg                       TCRSConfig
+	ctx                       context.Context
+	cancel                    context.CancelFunc
+}
+
+func (renewer *tagCertificateRenewerImpl) Run() error {
+
+	defaultLog.Infof("TCRS is starting with refresh period '%s'", renewer.cfg.RefreshPeriod)
+
+	if renewer.cfg.RefreshPeriod == 0 {
+		defaultLog.Info("The TCRS refresh period is zero.  TCRS will now exit")
+		return nil
+	}
+	if renewer.tagCertificateProvisioner == nil {
+		defaultLog.Warn("The TCRS cannot issue tag certificates without the Tag CA.  TCRS will now exit")
+		return nil
+	}
+
+	renewer.ctx, renewer.cancel = context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			err := renewer.renewTagCertificates()
+			if err != nil {
+				// log any errors, but do not stop trying to renew tag certificates
+				defaultLog.Errorf("TCRS encountered an error while renewing tag certificates...\n%+v\n", err)
+			}
+
+			select {
+			case <-time.After(renewer.cfg.RefreshPeriod):
+				// continue with the loop and renew tag certificates again
+			case <-renewer.ctx.Done():
+				defaultLog.Info("The TCRS has been stopped and will now exit")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (renewer *tagCertificateRenewerImpl) Stop() error {
+	if renewer.cancel != nil {
+		renewer.cancel()
+	} else {
+		defaultLog.Debug("The TCRS is not running")
+	}
+
+	return nil
+}
+
+// Renews the valid tag certificates expiring within the renewal period.  Only the
+// latest tag certificate of a host is renewed, and only when the host does not have
+// a tag certificate valid beyond the renewal period yet.
+func (renewer *tagCertificateRenewerImpl) renewTagCertificates() error {
+
+	now := time.Now()
+	renewBy := now.Add(renewer.cfg.RenewBefore)
+	expiringTagCertificates, err := renewer.tagCertificateStore.Search(&models.TagCertificateFilterCriteria{
+		ValidOn:       now,
+		ExpiresBefore: renewBy,
+	})
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while TCRS searched for expiring tag certificates")
+	}
+
+	latestTagCertificates := make(map[uuid.UUID]*hvs.TagCertificate)
+	for _, tagCertificate := range expiringTagCertificates {
+		latest, ok := latestTagCertificates[tagCertificate.HardwareUUID]
+		if !ok || tagCertificate.NotAfter.After(latest.NotAfter) {
+			latestTagCertificates[tagCertificate.HardwareUUID] = tagCertificate
+		}
+	}
+
+	defaultLog.Debugf("TCRS found %d hosts with expiring tag certificates", len(latestTagCertificates))
+
+	renewed := 0
+	for hardwareUUID, tagCertificate := range latestTagCertificates {
+		renewedTagCertificates, err := renewer.tagCertificateStore.Search(&models.TagCertificateFilterCriteria{
+			HardwareUUID: hardwareUUID,
+			ValidAfter:   renewBy,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "An error occurred while TCRS searched for the tag certificates of host %s", hardwareUUID)
+		}
+		if len(renewedTagCertificates) > 0 {
+			continue
+		}
+
+		// log any errors, but continue renewing the tag certificates of the other hosts
+		if err = renewer.renewTagCertificate(tagCertificate); err != nil {
+			defaultLog.WithError(err).Errorf("TCRS failed to renew tag certificate %s of host %s", tagCertificate.ID, hardwareUUID)
+			continue
+		}
+		renewed++
+	}
+
+	if renewed > 0 {
+		defaultLog.Infof("TCRS renewed %d tag certificates", renewed)
+	}
+	return nil
+}
+
+// Issues a tag certificate with the tag attributes of the expiring tag certificate,
+// and deploys it to the host when configured to.
+func (renewer *tagCertificateRenewerImpl) renewTagCertificate(tagCertificate *hvs.TagCertificate) error {
+
+	renewal := models.TagCertificateRenewal{
+		TagCertificateID: tagCertificate.ID,
+		HardwareUUID:     tagCertificate.HardwareUUID,
+	}
+
+	renewedTagCertificate, err := renewer.issueTagCertificate(tagCertificate)
+	if err != nil {
+		renewal.Error = err.Error()
+		renewer.audit(models.TagCertificateRenewalRenew, &renewal)
+		return err
+	}
+	renewal.RenewedBy = renewedTagCertificate.ID
+	renewal.NotAfter = renewedTagCertificate.NotAfter
+	renewer.audit(models.TagCertificateRenewalRenew, &renewal)
+	defaultLog.Infof("TCRS renewed tag certificate %s of host %s with tag certificate %s", tagCertificate.ID,
+		tagCertificate.HardwareUUID, renewedTagCertificate.ID)
+
+	if !renewer.cfg.Deploy {
+		return nil
+	}
+
+	signedFlavor, _, err := renewer.tagCertificateProvisioner.DeployTagCertificate(renewedTagCertificate)
+	if err != nil {
+		renewal.Error = err.Error()
+		renewer.audit(models.TagCertificateRenewalDeploy, &renewal)
+		return errors.Wrapf(err, "Failed to deploy tag certificate %s", renewedTagCertificate.ID)
+	}
+	renewal.FlavorID = signedFlavor.Flavor.Meta.ID
+	renewer.audit(models.TagCertificateRenewalDeploy, &renewal)
+	defaultLog.Infof("TCRS deployed tag certificate %s to host %s with flavor %s", renewedTagCertificate.ID,
+		tagCertificate.HardwareUUID, renewal.FlavorID)
+	return nil
+}
+
+func (renewer *tagCertificateRenewerImpl) issueTagCertificate(tagCertificate *hvs.TagCertificate) (*hvs.TagCertificate, error) {
+	x509Certificate, err := x509.ParseCertificate(tagCertificate.Certificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse tag certificate")
+	}
+	tagAttributes, err := asset_tag.GetTagAttributes(x509Certificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get the tag attributes of the tag certificate")
+	}
+
+	renewedTagCertificate, err := renewer.tagCertificateProvisioner.CreateTagCertificate(models.TagCertificateCreateCriteria{
+		HardwareUUID:     tagCertificate.HardwareUUID,
+		SelectionContent: tagAttributes,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to issue tag certificate")
+	}
+	return renewedTagCertificate, nil
+}
+
+func (renewer *tagCertificateRenewerImpl) audit(action string, renewal *models.TagCertificateRenewal) {
+	if renewer.auditLogWriter == nil {
+		return
+	}
+	auditEntry, err := renewer.auditLogWriter.CreateEntry(action, renewal)
+	if err != nil {
+		defaultLog.WithError(err).Error("TCRS failed to create the audit log entry of a tag certificate renewal")
+		return
+	}
+	renewer.auditLogWriter.Log(auditEntry)
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package tcrs
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	asset_tag "github.com/intel-secl/intel-secl/v4/pkg/lib/asset-tag"
+	commErr "github.com/intel-secl/intel-secl/v4/pkg/lib/common/err"
+	fm "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	hardwareUUID      = uuid.MustParse("80ecce40-04b8-e811-906e-00163566263e")
+	otherHardwareUUID = uuid.MustParse("7a569dad-2d82-49e4-9156-069b0065b262")
+	tagAttributes     = []asset_tag.TagKvAttribute{{Key: "Location", Value: "SantaClara"}}
+)
+
+func newTagCertificateRenewer(t *testing.T, cfg TCRSConfig, store *MockTagCertificateStore) (*tagCertificateRenewerImpl, *MockTagCertificateProvisioner, *MockAuditLogWriter) {
+	provisioner := &MockTagCertificateProvisioner{t: t, store: store}
+	auditLogWriter := &MockAuditLogWriter{}
+	renewer, err := NewTagCertificateRenewer(cfg, store, provisioner, auditLogWriter)
+	assert.NoError(t, err)
+	return renewer.(*tagCertificateRenewerImpl), provisioner, auditLogWriter
+}
+
+func TestTagCertificateRenewerRenewsExpiringTagCertificate(t *testing.T) {
+	store := &MockTagCertificateStore{}
+	expiring := store.add(newTagCertificate(t, hardwareUUID, time.Now().AddDate(0, 0, 10)))
+	store.add(newTagCertificate(t, otherHardwareUUID, time.Now().AddDate(0, 6, 0)))
+
+	renewer, provisioner, auditLogWriter := newTagCertificateRenewer(t, TCRSConfig{RenewBefore: DefaultRenewBefore,
+		RefreshPeriod: DefaultRefreshPeriod}, store)
+	err := renewer.renewTagCertificates()
+	assert.NoError(t, err)
+
+	assert.Len(t, provisioner.created, 1)
+	assert.Equal(t, hardwareUUID, provisioner.created[0].HardwareUUID)
+	assert.Equal(t, tagAttributes, provisioner.created[0].SelectionContent)
+	assert.Empty(t, provisioner.deployed)
+
+	assert.Len(t, auditLogWriter.entries, 1)
+	assert.Equal(t, models.TagCertificateRenewalRenew, auditLogWriter.entries[0].action)
+	assert.Equal(t, expiring.ID, auditLogWriter.entries[0].renewal.TagCertificateID)
+	assert.NotEqual(t, uuid.Nil, auditLogWriter.entries[0].renewal.RenewedBy)
+	assert.Empty(t, auditLogWriter.entries[0].renewal.Error)
+
+	// the tag certificate is renewed only once
+	err = renewer.renewTagCertificates()
+	assert.NoError(t, err)
+	assert.Len(t, provisioner.created, 1)
+}
+
+func TestTagCertificateRenewerRejectsRenewalPeriodBeyondValidity(t *testing.T) {
+	store := &MockTagCertificateStore{}
+	provisioner := &MockTagCertificateProvisioner{t: t, store: store}
+
+	// the renewed tag certificates would be renewed again on every refresh
+	for _, renewBefore := range []time.Duration{365 * 24 * time.Hour, 400 * 24 * time.Hour, -time.Hour} {
+		_, err := NewTagCertificateRenewer(TCRSConfig{RenewBefore: renewBefore, RefreshPeriod: DefaultRefreshPeriod},
+			store, provisioner, &MockAuditLogWriter{})
+		assert.Error(t, err)
+	}
+
+	_, err := NewTagCertificateRenewer(TCRSConfig{RenewBefore: 364 * 24 * time.Hour, RefreshPeriod: DefaultRefreshPeriod},
+		store, provisioner, &MockAuditLogWriter{})
+	assert.NoError(t, err)
+}
+
+func TestTagCertificateRenewerDeploysRenewedTagCertificate(t *testing.T) {
+	store := &MockTagCertificateStore{}
+	store.add(newTagCertificate(t, hardwareUUID, time.Now().AddDate(0, 0, 10)))
+
+	renewer, provisioner, auditLogWriter := newTagCertificateRenewer(t, TCRSConfig{RenewBefore: DefaultRenewBefore,
+		Deploy: true, RefreshPeriod: DefaultRefreshPeriod}, store)
+	err := renewer.renewTagCertificates()
+	assert.NoError(t, err)
+
+	assert.Len(t, provisioner.deployed, 1)
+	assert.Equal(t, provisioner.deployed[0].ID, auditLogWriter.entries[0].renewal.RenewedBy)
+	assert.Len(t, auditLogWriter.entries, 2)
+	assert.Equal(t, models.TagCertificateRenewalDeploy, auditLogWriter.entries[1].action)
+	assert.NotEqual(t, uuid.Nil, auditLogWriter.entries[1].renewal.FlavorID)
+}
+
+func TestTagCertificateRenewerRecordsDeployFailure(t *testing.T) {
+	store := &MockTagCertificateStore{}
+	store.add(newTagCertificate(t, hardwareUUID, time.Now().AddDate(0, 0, 10)))
+
+	renewer, provisioner, auditLogWriter := newTagCertificateRenewer(t, TCRSConfig{RenewBefore: DefaultRenewBefore,
+		Deploy: true, RefreshPeriod: DefaultRefreshPeriod}, store)
+	provisioner.deployErr = errors.New("Tag Certificate Deploy failure: Target Host lookup failed")
+	err := renewer.renewTagCertificates()
+	assert.NoError(t, err)
+
+	assert.Len(t, auditLogWriter.entries, 2)
+	assert.Equal(t, models.TagCertificateRenewalDeploy, auditLogWriter.entries[1].action)
+	assert.Equal(t, provisioner.deployErr.Error(), auditLogWriter.entries[1].renewal.Error)
+	assert.Equal(t, uuid.Nil, auditLogWriter.entries[1].renewal.FlavorID)
+}
+
+func TestTagCertificateRenewerSkipsRenewedAndExpiredTagCertificates(t *testing.T) {
+	store := &MockTagCertificateStore{}
+	// renewed through the API already
+	store.add(newTagCertificate(t, hardwareUUID, time.Now().AddDate(0, 0, 10)))
+	store.add(newTagCertificate(t, hardwareUUID, time.Now().AddDate(1, 0, 0)))
+	// expired already
+	store.add(newTagCertificate(t, otherHardwareUUID, time.Now().AddDate(0, 0, -1)))
+
+	renewer, provisioner, auditLogWriter := newTagCertificateRenewer(t, TCRSConfig{RenewBefore: DefaultRenewBefore,
+		RefreshPeriod: DefaultRefreshPeriod}, store)
+	err := renewer.renewTagCertificates()
+	assert.NoError(t, err)
+
+	assert.Empty(t, provisioner.created)
+	assert.Empty(t, auditLogWriter.entries)
+}
+
+var (
+	tagCAKey  *rsa.PrivateKey
+	tagCACert *x509.Certificate
+)
+
+// newTagCertificate returns a tag certificate of the host issued by a test tag CA, valid until notAfter
+func newTagCertificate(t *testing.T, hardwareUUID uuid.UUID, notAfter time.Time) *hvs.TagCertificate {
+	if tagCACert == nil {
+		var err error
+		tagCAKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "HVS Tag Certificate"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().AddDate(5, 0, 0),
+			KeyUsage:              x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		caDer, err := x509.CreateCertificate(rand.Reader, template, template, &tagCAKey.PublicKey, tagCAKey)
+		assert.NoError(t, err)
+		tagCACert, err = x509.ParseCertificate(caDer)
+		assert.NoError(t, err)
+	}
+
+	tagCertificate, err := asset_tag.NewAssetTag().CreateAssetTag(asset_tag.TagCertConfig{
+		SubjectUUID:       hardwareUUID.String(),
+		PrivateKey:        tagCAKey,
+		TagCACert:         tagCACert,
+		TagAttributes:     tagAttributes,
+		ValidityInSeconds: 1,
+	})
+	assert.NoError(t, err)
+	return &hvs.TagCertificate{
+		ID:           uuid.New(),
+		Certificate:  tagCertificate,
+		Subject:      hardwareUUID.String(),
+		Issuer:       tagCACert.Issuer.String(),
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+		HardwareUUID: hardwareUUID,
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// M O C K   T A G   C E R T I F I C A T E   S T O R E
+// -------------------------------------------------------------------------------------------------
+type MockTagCertificateStore struct {
+	tagCertificates []*hvs.TagCertificate
+}
+
+func (store *MockTagCertificateStore) add(tagCertificate *hvs.TagCertificate) *hvs.TagCertificate {
+	store.tagCertificates = append(store.tagCertificates, tagCertificate)
+	return tagCertificate
+}
+
+func (store *MockTagCertificateStore) Create(tagCertificate *hvs.TagCertificate) (*hvs.TagCertificate, error) {
+	tagCertificate.ID = uuid.New()
+	return store.add(tagCertificate), nil
+}
+
+func (store *MockTagCertificateStore) Retrieve(id uuid.UUID) (*hvs.TagCertificate, error) {
+	for _, tagCertificate := range store.tagCertificates {
+		if tagCertificate.ID == id {
+			return tagCertificate, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+func (store *MockTagCertificateStore) Delete(id uuid.UUID) error {
+	return errors.New("Delete is not implemented")
+}
+
+// Search only supports the criteria used by the TCRS
+func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilterCriteria) ([]*hvs.TagCertificate, error) {
+	var tagCertificates []*hvs.TagCertificate
+	for _, tagCertificate := range store.tagCertificates {
+		if (criteria.HardwareUUID != uuid.Nil && tagCertificate.HardwareUUID != criteria.HardwareUUID) ||
+			(!criteria.ValidOn.IsZero() && (criteria.ValidOn.Before(tagCertificate.NotBefore) || criteria.ValidOn.After(tagCertificate.NotAfter))) ||
+			(!criteria.ValidAfter.IsZero() && criteria.ValidAfter.After(tagCertificate.NotAfter)) ||
+			(!criteria.ExpiresBefore.IsZero() && !tagCertificate.NotAfter.Before(criteria.ExpiresBefore)) {
+			continue
+		}
+		tagCertificates = append(tagCertificates, tagCertificate)
+	}
+	return tagCertificates, nil
+}
+
+func (store *MockTagCertificateStore) Count(criteria *models.TagCertificateFilterCriteria) (int, error) {
+	tagCertificates, err := store.Search(criteria)
+	return len(tagCertificates), err
+}
+
+// -------------------------------------------------------------------------------------------------
+// M O C K   T A G   C E R T I F I C A T E   P R O V I S I O N E R
+// -------------------------------------------------------------------------------------------------
+type MockTagCertificateProvisioner struct {
+	t         *testing.T
+	store     *MockTagCertificateStore
+	created   []models.TagCertificateCreateCriteria
+	deployed  []*hvs.TagCertificate
+	deployErr error
+}
+
+func (provisioner *MockTagCertificateProvisioner) CreateTagCertificate(criteria models.TagCertificateCreateCriteria) (*hvs.TagCertificate, error) {
+	provisioner.created = append(provisioner.created, criteria)
+	tagCertificate := newTagCertificate(provisioner.t, criteria.HardwareUUID, time.Now().AddDate(1, 0, 0))
+	return provisioner.store.Create(tagCertificate)
+}
+
+func (provisioner *MockTagCertificateProvisioner) DeployTagCertificate(tagCertificate *hvs.TagCertificate) (*hvs.SignedFlavor, int, error) {
+	if provisioner.deployErr != nil {
+		return nil, http.StatusBadRequest, provisioner.deployErr
+	}
+	provisioner.deployed = append(provisioner.deployed, tagCertificate)
+	return &hvs.SignedFlavor{Flavor: hvs.Flavor{Meta: fm.Meta{ID: uuid.New()}}}, http.StatusOK, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// M O C K   A U D I T   L O G   W R I T E R
+// -------------------------------------------------------------------------------------------------
+type auditedRenewal struct {
+	action  string
+	renewal models.TagCertificateRenewal
+}
+
+type MockAuditLogWriter struct {
+	entries []auditedRenewal
+}
+
+func (writer *MockAuditLogWriter) CreateEntry(action string, values ...interface{}) (*models.AuditLogEntry, error) {
+	writer.entries = append(writer.entries, auditedRenewal{action: action, renewal: *values[0].(*models.TagCertificateRenewal)})
+	return &models.AuditLogEntry{Action: action}, nil
+}
+
+func (writer *MockAuditLogWriter) Log(*models.AuditLogEntry) {}
+
+func (writer *MockAuditLogWriter) Stop() {}
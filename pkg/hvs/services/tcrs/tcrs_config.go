@@ -0,0 +1,27 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tcrs
+
+import "time"
+
+var (
+	// DefaultRenewBefore by default renews the tag certificates thirty days before they expire
+	DefaultRenewBefore, _ = time.ParseDuration("720h")
+	// DefaultDeploy by default only issues the renewed tag certificates, they are deployed through the API
+	DefaultDeploy = false
+	// DefaultRefreshPeriod by default checks for expiring tag certificates every hour
+	DefaultRefreshPeriod, _ = time.ParseDuration("1h")
+)
+
+type TCRSConfig struct {
+	// RenewBefore determines how long before its expiry a tag certificate is renewed (defaults to DefaultRenewBefore).
+	RenewBefore time.Duration `yaml:"renew-before" mapstructure:"renew-before"`
+	// Deploy determines whether the renewed tag certificates are deployed to the hosts, creating their ASSET_TAG
+	// flavors (defaults to DefaultDeploy).
+	Deploy bool `yaml:"deploy" mapstructure:"deploy"`
+	// RefreshPeriod determines how frequently the TCRS checks for expiring tag certificates
+	// (defaults to DefaultRefreshPeriod).
+	RefreshPeriod time.Duration `yaml:"refresh-period" mapstructure:"refresh-period"`
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package trustevent
+
+import (
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+)
+
+type natsTarget struct {
+	conn         *nats.Conn
+	subject      string
+	flushTimeout time.Duration
+}
+
+// newNatsTarget connects to the NATS servers with the same TLS and credential settings used for the trust agent
+// connections. The connection is retried in the background so that HVS can start while NATS is unavailable.
+func newNatsTarget(natsServers []string, subject string, flushTimeout time.Duration) (*natsTarget, error) {
+	tlsConfig := tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    loadRootCAs(),
+	}
+
+	conn, err := nats.Connect(strings.Join(natsServers, ","),
+		nats.Name(constants.ServiceName+" trust events"),
+		nats.Secure(&tlsConfig),
+		nats.UserCredentials(constants.NatsCredentials),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			defaultLog.Infof("trustevent/nats:newNatsTarget() NATS: Client disconnected: %v", err)
+		}),
+		nats.ReconnectHandler(func(_ *nats.Conn) {
+			defaultLog.Info("trustevent/nats:newNatsTarget() NATS: Client reconnected")
+		}),
+		nats.ClosedHandler(func(_ *nats.Conn) {
+			defaultLog.Info("trustevent/nats:newNatsTarget() NATS: Client closed")
+		}))
+	if err != nil {
+		return nil, errors.Wrap(err, "trustevent/nats:newNatsTarget() Failed to create nats connection")
+	}
+
+	return &natsTarget{
+		conn:         conn,
+		subject:      subject,
+		flushTimeout: flushTimeout,
+	}, nil
+}
+
+func (nt *natsTarget) name() string {
+	return "nats subject " + nt.subject
+}
+
+func (nt *natsTarget) deliver(payload []byte) error {
+	if !nt.conn.IsConnected() {
+		return errors.New("not connected to NATS")
+	}
+	if err := nt.conn.Publish(nt.subject, payload); err != nil {
+		return errors.Wrap(err, "Failed to publish to NATS")
+	}
+	// make sure the server received the message before reporting success
+	if err := nt.conn.FlushTimeout(nt.flushTimeout); err != nil {
+		return errors.Wrap(err, "Failed to flush NATS connection")
+	}
+	return nil
+}
+
+func (nt *natsTarget) close() {
+	nt.conn.Close()
+}
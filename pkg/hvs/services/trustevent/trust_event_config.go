@@ -0,0 +1,39 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package trustevent
+
+import "time"
+
+var (
+	// DefaultMaxRetries is the number of times a failed delivery is retried before the event is dropped
+	DefaultMaxRetries = 5
+	// DefaultRetryBackoff is the wait before the first retry, it is doubled for every subsequent retry
+	DefaultRetryBackoff, _ = time.ParseDuration("2s")
+	// DefaultRequestTimeout bounds a single webhook request or NATS flush
+	DefaultRequestTimeout, _ = time.ParseDuration("10s")
+	// DefaultBufferSize is the number of events that can be queued per delivery target
+	DefaultBufferSize = 1000
+)
+
+type TrustEventConfig struct {
+	// Webhooks receive the events as HTTP POST requests
+	Webhooks []WebhookConfig `yaml:"webhooks" mapstructure:"webhooks"`
+	// NatsSubject, when set, is the subject the events are published to on the NATS servers configured for HVS
+	NatsSubject string `yaml:"nats-subject" mapstructure:"nats-subject"`
+	// MaxRetries determines how many times a failed delivery is retried (defaults to DefaultMaxRetries)
+	MaxRetries int `yaml:"max-retries" mapstructure:"max-retries"`
+	// RetryBackoff is the initial wait between retries (defaults to DefaultRetryBackoff)
+	RetryBackoff time.Duration `yaml:"retry-backoff" mapstructure:"retry-backoff"`
+	// RequestTimeout bounds each delivery attempt (defaults to DefaultRequestTimeout)
+	RequestTimeout time.Duration `yaml:"request-timeout" mapstructure:"request-timeout"`
+	// BufferSize is the number of events queued per target before new events are dropped (defaults to DefaultBufferSize)
+	BufferSize int `yaml:"buffer-size" mapstructure:"buffer-size"`
+}
+
+type WebhookConfig struct {
+	URL string `yaml:"url" mapstructure:"url"`
+	// Secret is the key used to compute the HMAC-SHA256 signature of the request body
+	Secret string `yaml:"secret" mapstructure:"secret"`
+}
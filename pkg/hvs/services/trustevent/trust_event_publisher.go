@@ -0,0 +1,242 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package trustevent
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	cos "github.com/intel-secl/intel-secl/v4/pkg/lib/common/os"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+// eventTarget delivers a serialized event to a single destination
+type eventTarget interface {
+	name() string
+	deliver(payload []byte) error
+	close()
+}
+
+// permanentError is returned by targets when retrying the delivery can not succeed
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+type targetWorker struct {
+	target eventTarget
+	queue  chan []byte
+}
+
+// trustEventPublisher fans out trust state change events to the configured webhooks and NATS subject. Every target
+// has its own queue and go routine so that a slow or unreachable target does not hold back the others or the
+// host trust verifiers.
+type trustEventPublisher struct {
+	cfg      TrustEventConfig
+	workers  []*targetWorker
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewTrustEventPublisher creates the delivery targets described by the configuration and starts their delivery
+// routines. natsServers are the servers HVS uses to reach the trust agents, they are only used when a NATS subject
+// is configured.
+func NewTrustEventPublisher(cfg TrustEventConfig, natsServers []string) (domain.TrustEventPublisher, error) {
+	defaultLog.Trace("trustevent/trust_event_publisher:NewTrustEventPublisher() Entering")
+	defer defaultLog.Trace("trustevent/trust_event_publisher:NewTrustEventPublisher() Leaving")
+
+	cfg = applyDefaults(cfg)
+
+	var targets []eventTarget
+	if len(cfg.Webhooks) > 0 {
+		client := &http.Client{
+			Timeout: cfg.RequestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion: tls.VersionTLS12,
+					RootCAs:    loadRootCAs(),
+				},
+			},
+		}
+		for _, webhook := range cfg.Webhooks {
+			target, err := newWebhookTarget(webhook, client)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, target)
+		}
+	}
+	if cfg.NatsSubject != "" {
+		if len(natsServers) == 0 {
+			return nil, errors.New("trustevent/trust_event_publisher:NewTrustEventPublisher() A NATS subject is configured but no NATS servers are configured")
+		}
+		target, err := newNatsTarget(natsServers, cfg.NatsSubject, cfg.RequestTimeout)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	return newTrustEventPublisher(cfg, targets), nil
+}
+
+func newTrustEventPublisher(cfg TrustEventConfig, targets []eventTarget) *trustEventPublisher {
+	p := &trustEventPublisher{
+		cfg:      cfg,
+		stopChan: make(chan struct{}),
+	}
+	for _, target := range targets {
+		worker := &targetWorker{
+			target: target,
+			queue:  make(chan []byte, cfg.BufferSize),
+		}
+		p.workers = append(p.workers, worker)
+		p.wg.Add(1)
+		go p.run(worker)
+	}
+	return p
+}
+
+func applyDefaults(cfg TrustEventConfig) TrustEventConfig {
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = DefaultRequestTimeout
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultBufferSize
+	}
+	return cfg
+}
+
+// Publish queues the event for delivery to all the targets. It never blocks, when the queue of a target is full
+// the event is dropped for that target.
+func (p *trustEventPublisher) Publish(event *hvs.TrustStateChangeEvent) {
+	defaultLog.Trace("trustevent/trust_event_publisher:Publish() Entering")
+	defer defaultLog.Trace("trustevent/trust_event_publisher:Publish() Leaving")
+
+	if event == nil || len(p.workers) == 0 {
+		return
+	}
+	select {
+	case <-p.stopChan:
+		defaultLog.Warnf("trustevent/trust_event_publisher:Publish() Publisher is stopped, dropping event for host %s", event.HostID)
+		return
+	default:
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("trustevent/trust_event_publisher:Publish() Failed to marshal event for host %s", event.HostID)
+		return
+	}
+	for _, worker := range p.workers {
+		select {
+		case worker.queue <- payload:
+		default:
+			defaultLog.Warnf("trustevent/trust_event_publisher:Publish() Event queue of %s is full, dropping event for host %s",
+				worker.target.name(), event.HostID)
+		}
+	}
+}
+
+// Stop ends the delivery routines, events still queued are not delivered
+func (p *trustEventPublisher) Stop() {
+	defaultLog.Trace("trustevent/trust_event_publisher:Stop() Entering")
+	defer defaultLog.Trace("trustevent/trust_event_publisher:Stop() Leaving")
+
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+		p.wg.Wait()
+		for _, worker := range p.workers {
+			if len(worker.queue) > 0 {
+				defaultLog.Warnf("trustevent/trust_event_publisher:Stop() %d events were not delivered to %s",
+					len(worker.queue), worker.target.name())
+			}
+			worker.target.close()
+		}
+	})
+}
+
+func (p *trustEventPublisher) run(worker *targetWorker) {
+	defer p.wg.Done()
+	for {
+		select {
+		case payload := <-worker.queue:
+			p.deliver(worker.target, payload)
+		case <-p.stopChan:
+			return
+		}
+	}
+}
+
+// deliver sends the payload to the target, retrying with an exponential backoff until it succeeds, the retries are
+// exhausted or the publisher is stopped
+func (p *trustEventPublisher) deliver(target eventTarget, payload []byte) {
+	backoff := p.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := target.deliver(payload)
+		if err == nil {
+			defaultLog.Debugf("trustevent/trust_event_publisher:deliver() Event delivered to %s", target.name())
+			return
+		}
+		if _, ok := err.(*permanentError); ok {
+			defaultLog.WithError(err).Errorf("trustevent/trust_event_publisher:deliver() Event rejected by %s, dropping event", target.name())
+			return
+		}
+		if attempt >= p.cfg.MaxRetries {
+			defaultLog.WithError(err).Errorf("trustevent/trust_event_publisher:deliver() Failed to deliver event to %s after %d attempts, dropping event",
+				target.name(), attempt+1)
+			return
+		}
+		defaultLog.WithError(err).Warnf("trustevent/trust_event_publisher:deliver() Failed to deliver event to %s, retrying in %s",
+			target.name(), backoff)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-p.stopChan:
+			timer.Stop()
+			return
+		}
+		backoff *= 2
+	}
+}
+
+// loadRootCAs returns the system certificate pool extended with the certificates from the HVS trusted CA directory
+func loadRootCAs() *x509.CertPool {
+	rootCAs, _ := x509.SystemCertPool()
+	if rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+	certs, err := cos.GetDirFileContents(constants.TrustedCaCertsDir, "*.pem")
+	if err != nil {
+		defaultLog.WithError(err).Warnf("trustevent/trust_event_publisher:loadRootCAs() Failed to read certificates from %s", constants.TrustedCaCertsDir)
+	}
+	for _, cert := range certs {
+		if ok := rootCAs.AppendCertsFromPEM(cert); !ok {
+			defaultLog.Debug("trustevent/trust_event_publisher:loadRootCAs() Could not append certificate to root CAs")
+		}
+	}
+	return rootCAs
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package trustevent
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "webhook-secret"
+
+type receivedEvent struct {
+	event     hvs.TrustStateChangeEvent
+	signature string
+	body      []byte
+}
+
+// newTestWebhook starts a webhook that fails the first failures requests with the given status
+func newTestWebhook(t *testing.T, failures int32, failureStatus int) (*httptest.Server, chan receivedEvent, *int32) {
+	received := make(chan receivedEvent, 10)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(failureStatus)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var event hvs.TrustStateChangeEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		received <- receivedEvent{event: event, signature: r.Header.Get(SignatureHeader), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return server, received, &calls
+}
+
+func newTestPublisher(t *testing.T, url string) *trustEventPublisher {
+	cfg := applyDefaults(TrustEventConfig{
+		MaxRetries:   2,
+		RetryBackoff: 10 * time.Millisecond,
+	})
+	target, err := newWebhookTarget(WebhookConfig{URL: url, Secret: testSecret}, &http.Client{Timeout: time.Second})
+	assert.NoError(t, err)
+	return newTrustEventPublisher(cfg, []eventTarget{target})
+}
+
+func newTestEvent() *hvs.TrustStateChangeEvent {
+	return &hvs.TrustStateChangeEvent{
+		ID:              uuid.New(),
+		HostID:          uuid.New(),
+		HostName:        "host-1",
+		ReportID:        uuid.New(),
+		PreviousTrusted: true,
+		Trusted:         false,
+		Timestamp:       time.Now().UTC(),
+	}
+}
+
+func TestPublishSignedWebhook(t *testing.T) {
+	server, received, _ := newTestWebhook(t, 0, 0)
+	defer server.Close()
+	p := newTestPublisher(t, server.URL)
+	defer p.Stop()
+
+	event := newTestEvent()
+	p.Publish(event)
+
+	select {
+	case r := <-received:
+		assert.Equal(t, event.HostID, r.event.HostID)
+		assert.False(t, r.event.Trusted)
+		assert.True(t, r.event.PreviousTrusted)
+		assert.Equal(t, signaturePrefix+Sign(r.body, []byte(testSecret)), r.signature)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+}
+
+func TestPublishRetriesFailedDelivery(t *testing.T) {
+	server, received, calls := newTestWebhook(t, 2, http.StatusServiceUnavailable)
+	defer server.Close()
+	p := newTestPublisher(t, server.URL)
+	defer p.Stop()
+
+	p.Publish(newTestEvent())
+
+	select {
+	case <-received:
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered after retries")
+	}
+}
+
+func TestPublishDoesNotRetryRejectedEvent(t *testing.T) {
+	server, received, calls := newTestWebhook(t, 1, http.StatusBadRequest)
+	defer server.Close()
+	p := newTestPublisher(t, server.URL)
+
+	p.Publish(newTestEvent())
+	time.Sleep(200 * time.Millisecond)
+	p.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.Equal(t, 0, len(received))
+}
+
+func TestNewTrustEventPublisherValidation(t *testing.T) {
+	_, err := NewTrustEventPublisher(TrustEventConfig{Webhooks: []WebhookConfig{{URL: "ftp://hook.example.com", Secret: testSecret}}}, nil)
+	assert.Error(t, err)
+
+	_, err = NewTrustEventPublisher(TrustEventConfig{Webhooks: []WebhookConfig{{URL: "https://hook.example.com"}}}, nil)
+	assert.Error(t, err)
+
+	_, err = NewTrustEventPublisher(TrustEventConfig{NatsSubject: "trust.events"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package trustevent
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/common/constants"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the request body computed with the webhook secret,
+	// prefixed with "sha256="
+	SignatureHeader = "X-HVS-Signature"
+	signaturePrefix = "sha256="
+)
+
+type webhookTarget struct {
+	url         string
+	redactedUrl string
+	secret      []byte
+	client      *http.Client
+}
+
+func newWebhookTarget(cfg WebhookConfig, client *http.Client) (*webhookTarget, error) {
+	webhookUrl, err := url.Parse(cfg.URL)
+	if err != nil || webhookUrl.Host == "" || (webhookUrl.Scheme != "https" && webhookUrl.Scheme != "http") {
+		return nil, errors.Errorf("trustevent/webhook:newWebhookTarget() Invalid webhook URL %q", cfg.URL)
+	}
+	if cfg.Secret == "" {
+		return nil, errors.Errorf("trustevent/webhook:newWebhookTarget() Secret is not set for webhook %s", webhookUrl.Redacted())
+	}
+	return &webhookTarget{
+		url:         cfg.URL,
+		redactedUrl: webhookUrl.Redacted(),
+		secret:      []byte(cfg.Secret),
+		client:      client,
+	}, nil
+}
+
+func (wt *webhookTarget) name() string {
+	return "webhook " + wt.redactedUrl
+}
+
+func (wt *webhookTarget) deliver(payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, wt.url, bytes.NewReader(payload))
+	if err != nil {
+		return &permanentError{err: errors.Wrap(err, "Failed to create webhook request")}
+	}
+	req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+	req.Header.Set(SignatureHeader, signaturePrefix+Sign(payload, wt.secret))
+
+	resp, err := wt.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to send webhook request")
+	}
+	defer func() {
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		derr := resp.Body.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing response body")
+		}
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	statusErr := fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	// client errors other than throttling will not go away by sending the same request again
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode != http.StatusRequestTimeout {
+		return &permanentError{err: statusErr}
+	}
+	return statusErr
+}
+
+func (wt *webhookTarget) close() {}
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload, receivers can use it to verify the SignatureHeader
+func Sign(payload, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
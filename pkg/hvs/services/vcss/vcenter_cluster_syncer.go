@@ -43,9 +43,9 @@ func NewVCenterClusterSyncer(cfg config.VCSSConfig, hcConfig domain.HostControll
 
 	flavorStore := postgres.NewFlavorStore(dataStore)
 	flavorGroupStore := postgres.NewFlavorGroupStore(dataStore)
-	hostCredentialStore := postgres.NewHostCredentialStore(dataStore, hcConfig.DataEncryptionKey)
+	hostCredentialStore := postgres.NewHostCredentialStore(dataStore, hcConfig.DataEncryptionKeys)
 
-	ecStore := postgres.NewESXiCLusterStore(dataStore, hcConfig.DataEncryptionKey)
+	ecStore := postgres.NewESXiCLusterStore(dataStore, hcConfig.DataEncryptionKeys)
 
 	hostController := controllers.NewHostController(hostStore, hostStatusStore,
 		flavorStore, flavorGroupStore, hostCredentialStore,
@@ -51,11 +51,11 @@ func (a *App) setup(args []string) error {
 			return errors.Wrap(err, "Failed to read answer file")
 		}
 	}
-	runner, err := a.setupTaskRunner()
+	cmd := args[1]
+	runner, err := a.setupTaskRunner(cmd)
 	if err != nil {
 		return err
 	}
-	cmd := args[1]
 	// print help and return if applicable
 	if len(args) > 2 && args[2] == "--help" {
 		if cmd == "all" {
@@ -108,8 +108,8 @@ func (a *App) setup(args []string) error {
 	return cos.ChownDirForUser(constants.ServiceUserName, a.configDir())
 }
 
-// a helper function for setting up the task runner
-func (a *App) setupTaskRunner() (*setup.Runner, error) {
+// a helper function for setting up the task runner, cmd is the setup task called
+func (a *App) setupTaskRunner(cmd string) (*setup.Runner, error) {
 
 	loadAlias()
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
@@ -153,6 +153,16 @@ func (a *App) setupTaskRunner() (*setup.Runner, error) {
 	runner.AddTask("create-dek", "", &tasks.CreateDek{
 		DekStore: &a.Config.Dek,
 	})
+	// rotate-dek is not part of 'setup all', which runs create-dek first and is run on reinstalls, it is added only
+	// when it is called explicitly
+	if cmd == "rotate-dek" {
+		runner.AddTask("rotate-dek", "", &tasks.RotateDek{
+			DBConfig:      dbConf,
+			Config:        a.Config,
+			ConfigFile:    constants.DefaultConfigFilePath,
+			ConsoleWriter: a.consoleWriter(),
+		})
+	}
 	runner.AddTask("download-ca-cert", "", &setup.DownloadCMSCert{
 		CaCertDirPath: constants.TrustedRootCACertsDir,
 		ConsoleWriter: a.consoleWriter(),
@@ -203,6 +213,19 @@ func (a *App) setupTaskRunner() (*setup.Runner, error) {
 		DBConf:    dbConf,
 		Directory: constants.DefaultFlavorTemplatesDirectory,
 	})
+	// resign-flavors is not part of 'setup all', it fails while some flavors are not signed by a trusted flavor
+	// signing certificate and is only needed after the flavor signing certificate is renewed
+	if cmd == "resign-flavors" {
+		runner.AddTask("resign-flavors", "", &tasks.ResignFlavors{
+			DBConfig:                dbConf,
+			SigningKeyFile:          viper.GetString("flavor-signing-key-file"),
+			SigningCertFile:         viper.GetString("flavor-signing-cert-file"),
+			PreviousSigningCertsDir: constants.PreviousFlavorSigningCertsDir,
+			RootCACertsDir:          constants.TrustedRootCACertsDir,
+			BatchSize:               viper.GetInt("resign-flavors-batch-size"),
+			ConsoleWriter:           a.consoleWriter(),
+		})
+	}
 
 	if strings.TrimSpace(viper.GetString("nats-servers")) != "" || len(a.Config.NATS.Servers) != 0 {
 		runner.AddTask("download-credential", "", &setup.DownloadCredential{
@@ -240,7 +263,7 @@ func (a *App) downloadCertTask(certType string) setup.Task {
 		updateSAMLConfig.ValiditySeconds = viper.GetInt("saml-validity-seconds")
 		updateSAMLConfig.Issuer = viper.GetString("saml-issuer-name")
 	}
-	return &setup.DownloadCert{
+	downloadCert := &setup.DownloadCert{
 		KeyFile:      viper.GetString(certType + "-key-file"),
 		CertFile:     viper.GetString(certType + "-cert-file"),
 		KeyAlgorithm: constants.DefaultKeyAlgorithm,
@@ -254,6 +277,13 @@ func (a *App) downloadCertTask(certType string) setup.Task {
 		CmsBaseURL:    viper.GetString("cms-base-url"),
 		BearerToken:   viper.GetString("bearer-token"),
 	}
+	if certType == "flavor-signing" {
+		return &tasks.DownloadFlavorSigningCert{
+			DownloadCert:     downloadCert,
+			PreviousCertsDir: constants.PreviousFlavorSigningCertsDir,
+		}
+	}
+	return downloadCert
 }
 
 func (a *App) selfSignTask(name string) setup.Task {
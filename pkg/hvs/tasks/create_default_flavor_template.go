@@ -32,6 +32,7 @@ type CreateDefaultFlavorTemplate struct {
 
 var defaultFlavorTemplateNames = []string{
 	"default-linux-tpm20-tboot",
+	"default-linux-tpm20-tboot-sha384",
 	"default-linux-tpm20-suefi",
 	"default-linux-tpm20-cbnt",
 	"default-uefi",
@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tasks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/setup"
+	"github.com/pkg/errors"
+)
+
+// DownloadFlavorSigningCert downloads the flavor signing certificate from CMS. The flavor signing certificate it
+// replaces is archived in PreviousCertsDir, so that the flavors it signed remain trusted until they are re-signed
+// by the resign-flavors task.
+type DownloadFlavorSigningCert struct {
+	*setup.DownloadCert
+	PreviousCertsDir string
+}
+
+func (t *DownloadFlavorSigningCert) Run() error {
+	certPem, err := ioutil.ReadFile(t.CertFile)
+	if err == nil {
+		if err = os.MkdirAll(t.PreviousCertsDir, 0755); err != nil {
+			return errors.Wrap(err, "Failed to create the directory of the previous flavor signing certificates")
+		}
+		if err = crypt.SavePemCertWithShortSha1FileName(certPem, t.PreviousCertsDir); err != nil {
+			return errors.Wrap(err, "Failed to archive the flavor signing certificate")
+		}
+		if t.ConsoleWriter != nil {
+			fmt.Fprintln(t.ConsoleWriter, "Archived the flavor signing certificate in", t.PreviousCertsDir)
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Wrap(err, "Failed to read the flavor signing certificate")
+	}
+	return t.DownloadCert.Run()
+}
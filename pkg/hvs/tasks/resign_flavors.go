@@ -0,0 +1,275 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tasks
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
+	commConfig "github.com/intel-secl/intel-secl/v4/pkg/lib/common/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/setup"
+	flavormodel "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const DefaultResignFlavorsBatchSize = 100
+
+// ResignFlavors re-signs the flavors stored in the database with the current flavor signing key, after the flavor
+// signing certificate was renewed. Only the flavors whose signature verifies with the current or one of the previous
+// flavor signing certificates are re-signed, the flavors are processed in batches of BatchSize flavors. Once all the
+// flavors are signed with the current key, the previous flavor signing certificates are removed so that they are no
+// longer trusted.
+type ResignFlavors struct {
+	DBConfig commConfig.DBConfig
+	// SigningKeyFile and SigningCertFile are the current flavor signing key and its certificate chain
+	SigningKeyFile  string
+	SigningCertFile string
+	// PreviousSigningCertsDir holds the flavor signing certificates replaced by the renewal
+	PreviousSigningCertsDir string
+	// RootCACertsDir holds the root CAs the flavor signing certificates are issued by
+	RootCACertsDir string
+	BatchSize      int
+	ConsoleWriter  io.Writer
+	FlavorStore    domain.FlavorStore
+
+	commandName string
+}
+
+var resignFlavorsEnvHelp = map[string]string{
+	"RESIGN_FLAVORS_BATCH_SIZE": "Number of flavors re-signed per batch, defaults to 100",
+}
+
+// resignStatus counts the flavors processed by the task
+type resignStatus struct {
+	total     int
+	processed int
+	resigned  int
+	current   int
+	failed    []string
+}
+
+func (t *ResignFlavors) Run() error {
+	key, signingCerts, caCerts, intermediateCerts, err := t.loadSigningCertificates()
+	if err != nil {
+		return err
+	}
+
+	status, err := t.processFlavors(func(sf *hvs.SignedFlavor, status *resignStatus) error {
+		if sf.Verify(&key.PublicKey) == nil {
+			status.current++
+			return nil
+		}
+		if !isSignedByTrustedCertificate(sf, signingCerts, caCerts, intermediateCerts) {
+			return errors.New("the flavor signature is not trusted")
+		}
+		resigned, err := flavormodel.NewSignedFlavor(&sf.Flavor, key)
+		if err != nil {
+			return err
+		}
+		if err = t.FlavorStore.UpdateSignature(sf.Flavor.Meta.ID, resigned.Signature); err != nil {
+			return err
+		}
+		status.resigned++
+		return nil
+	}, true)
+	if err != nil {
+		return err
+	}
+	if len(status.failed) != 0 {
+		for _, failure := range status.failed {
+			fmt.Fprintln(t.ConsoleWriter, failure)
+		}
+		return errors.Errorf("%d flavor(s) could not be re-signed", len(status.failed))
+	}
+	return t.removePreviousSigningCertificates()
+}
+
+// Validate checks that all the flavors are signed with the current flavor signing key
+func (t *ResignFlavors) Validate() error {
+	key, _, _, _, err := t.loadSigningCertificates()
+	if err != nil {
+		return err
+	}
+
+	status, err := t.processFlavors(func(sf *hvs.SignedFlavor, status *resignStatus) error {
+		if err := sf.Verify(&key.PublicKey); err != nil {
+			return err
+		}
+		status.current++
+		return nil
+	}, false)
+	if err != nil {
+		return err
+	}
+	if len(status.failed) != 0 {
+		return errors.Errorf("%s: %d flavor(s) are not signed with the current flavor signing key", t.commandName, len(status.failed))
+	}
+	return nil
+}
+
+func (t *ResignFlavors) PrintHelp(w io.Writer) {
+	setup.PrintEnvHelp(w, DbEnvHelpPrompt, "", DbEnvHelp)
+	fmt.Fprintln(w, "")
+	setup.PrintEnvHelp(w, "Following environment variables are optional for "+t.commandName+":", "", resignFlavorsEnvHelp)
+	fmt.Fprintln(w, "")
+}
+
+func (t *ResignFlavors) SetName(n, e string) {
+	t.commandName = n
+}
+
+// processFlavors applies the function to all the flavors, in batches of BatchSize flavors ordered by ID
+func (t *ResignFlavors) processFlavors(apply func(*hvs.SignedFlavor, *resignStatus) error, printProgress bool) (*resignStatus, error) {
+	if t.FlavorStore == nil {
+		dataStore, err := postgres.NewDataStore(postgres.NewDatabaseConfig(constants.DBTypePostgres, &t.DBConfig))
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to connect database")
+		}
+		t.FlavorStore = postgres.NewFlavorStore(dataStore)
+	}
+	batchSize := t.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultResignFlavorsBatchSize
+	}
+
+	var err error
+	status := resignStatus{}
+	status.total, err = t.FlavorStore.Count(&models.FlavorVerificationFC{})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to count the flavors")
+	}
+	if printProgress {
+		fmt.Fprintf(t.ConsoleWriter, "Re-signing %d flavor(s) in batches of %d\n", status.total, batchSize)
+	}
+
+	for offset := 0; offset < status.total; offset += batchSize {
+		signedFlavors, err := t.FlavorStore.Search(&models.FlavorVerificationFC{
+			FlavorFC: models.FlavorFilterCriteria{
+				PageCriteria: models.PageCriteria{
+					Limit:  batchSize,
+					Offset: offset,
+					SortBy: models.SortById,
+				},
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to retrieve the flavors %d to %d", offset+1, offset+batchSize)
+		}
+		if len(signedFlavors) == 0 {
+			break
+		}
+		for i := range signedFlavors {
+			status.processed++
+			if err := apply(&signedFlavors[i], &status); err != nil {
+				status.failed = append(status.failed, fmt.Sprintf("Flavor %s: %s", signedFlavors[i].Flavor.Meta.ID, err.Error()))
+			}
+		}
+		if printProgress {
+			fmt.Fprintf(t.ConsoleWriter, "Processed %d/%d flavor(s): %d re-signed, %d already signed with the current key, %d failed\n",
+				status.processed, status.total, status.resigned, status.current, len(status.failed))
+		}
+	}
+	return &status, nil
+}
+
+// removePreviousSigningCertificates removes the archived flavor signing certificates and their intermediate CAs, which
+// are not needed anymore once all the flavors are signed with the current flavor signing key
+func (t *ResignFlavors) removePreviousSigningCertificates() error {
+	if _, err := os.Stat(t.PreviousSigningCertsDir); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.RemoveAll(t.PreviousSigningCertsDir); err != nil {
+		return errors.Wrap(err, "Failed to remove the previous flavor signing certificates")
+	}
+	fmt.Fprintln(t.ConsoleWriter, "Removed the previous flavor signing certificates from", t.PreviousSigningCertsDir)
+	return nil
+}
+
+// loadSigningCertificates loads the current flavor signing key, the current and previous flavor signing certificates,
+// the CAs they are issued by and the archived intermediate CAs of the previous flavor signing certificates
+func (t *ResignFlavors) loadSigningCertificates() (*rsa.PrivateKey, []x509.Certificate, *x509.CertPool, *x509.CertPool, error) {
+	privateKey, err := crypt.GetPrivateKeyFromPKCS8File(t.SigningKeyFile)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "Failed to load the flavor signing key")
+	}
+	key, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, nil, nil, errors.New("The flavor signing key is not a RSA key")
+	}
+
+	currentCerts, err := crypt.GetSubjectCertsMapFromPemFile(t.SigningCertFile)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "Failed to load the flavor signing certificate")
+	}
+	if len(currentCerts) == 0 {
+		return nil, nil, nil, nil, errors.New("The flavor signing certificate file does not contain a certificate")
+	}
+	currentPublicKey, ok := currentCerts[0].PublicKey.(*rsa.PublicKey)
+	if !ok || currentPublicKey.N.Cmp(key.PublicKey.N) != 0 || currentPublicKey.E != key.PublicKey.E {
+		return nil, nil, nil, nil, errors.New("The flavor signing key does not match the flavor signing certificate")
+	}
+
+	var previousCerts []x509.Certificate
+	if _, err := os.Stat(t.PreviousSigningCertsDir); err == nil {
+		previousCerts, err = crypt.GetCertsFromDir(t.PreviousSigningCertsDir)
+		if err != nil {
+			return nil, nil, nil, nil, errors.Wrap(err, "Failed to load the previous flavor signing certificates")
+		}
+	}
+
+	caCerts, err := crypt.GetCertsFromDir(t.RootCACertsDir)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "Failed to load the root CA certificates")
+	}
+	caPool := crypt.GetCertPool(caCerts)
+
+	signingCerts := []x509.Certificate{currentCerts[0]}
+	for i := range currentCerts[1:] {
+		caPool.AddCert(&currentCerts[i+1])
+	}
+	// the archived intermediate CAs only chain the previous flavor signing certificates to the root CAs
+	intermediatePool := x509.NewCertPool()
+	for i := range previousCerts {
+		if previousCerts[i].IsCA {
+			intermediatePool.AddCert(&previousCerts[i])
+		} else {
+			signingCerts = append(signingCerts, previousCerts[i])
+		}
+	}
+	return key, signingCerts, caPool, intermediatePool, nil
+}
+
+// isSignedByTrustedCertificate checks that the flavor signature verifies with one of the flavor signing certificates
+// issued by the CAs. The previous flavor signing certificates may have expired, the chain is verified at the end of
+// their validity so that the flavors signed while they were valid can be re-signed.
+func isSignedByTrustedCertificate(sf *hvs.SignedFlavor, signingCerts []x509.Certificate, caCerts, intermediateCerts *x509.CertPool) bool {
+	for i := range signingCerts {
+		opts := x509.VerifyOptions{
+			Roots:         caCerts,
+			Intermediates: intermediateCerts,
+			CurrentTime:   signingCerts[i].NotAfter,
+		}
+		if _, err := signingCerts[i].Verify(opts); err != nil {
+			continue
+		}
+		publicKey, ok := signingCerts[i].PublicKey.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		if sf.Verify(publicKey) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tasks
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	flavormodel "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+// resignFlavorsTestSetup holds the CA, the previous and current flavor signing keys of a flavor signing
+// certificate renewal, with the task configured to use them
+type resignFlavorsTestSetup struct {
+	task        *ResignFlavors
+	flavorStore *mocks.MockFlavorStore
+	previousKey *rsa.PrivateKey
+	currentKey  *rsa.PrivateKey
+	caKey       *rsa.PrivateKey
+	caCert      *x509.Certificate
+}
+
+func newResignFlavorsTestSetup(t *testing.T) *resignFlavorsTestSetup {
+	dir, err := ioutil.TempDir("", "resign-flavors")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	rootCACertsDir := filepath.Join(dir, "root")
+	previousCertsDir := filepath.Join(dir, "flavor-signing-previous")
+	assert.NoError(t, os.Mkdir(rootCACertsDir, 0755))
+	assert.NoError(t, os.Mkdir(previousCertsDir, 0755))
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	caTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CMS Signing CA"},
+		NotBefore:             time.Now().AddDate(-2, 0, 0),
+		NotAfter:              time.Now().AddDate(5, 0, 0),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDer)
+	assert.NoError(t, err)
+	assert.NoError(t, crypt.SavePemCert(caDer, filepath.Join(rootCACertsDir, "ca.pem")))
+
+	setup := &resignFlavorsTestSetup{
+		flavorStore: &mocks.MockFlavorStore{},
+		caKey:       caKey,
+		caCert:      caCert,
+	}
+
+	// the previous flavor signing certificate has expired when it is renewed
+	var previousDer []byte
+	setup.previousKey, previousDer = setup.newSigningCertificate(t, time.Now().AddDate(-1, 0, 0), time.Now().AddDate(0, 0, -1))
+	assert.NoError(t, crypt.SavePemCert(previousDer, filepath.Join(previousCertsDir, "previous.pem")))
+
+	var currentDer []byte
+	setup.currentKey, currentDer = setup.newSigningCertificate(t, time.Now().AddDate(0, 0, -1), time.Now().AddDate(1, 0, 0))
+	certFile := filepath.Join(dir, "flavor-signing.pem")
+	assert.NoError(t, crypt.SavePemCertChain(certFile, currentDer))
+	keyDer, err := x509.MarshalPKCS8PrivateKey(setup.currentKey)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(dir, "flavor-signing.key")
+	assert.NoError(t, crypt.SavePrivateKeyAsPKCS8(keyDer, keyFile))
+
+	setup.task = &ResignFlavors{
+		SigningKeyFile:          keyFile,
+		SigningCertFile:         certFile,
+		PreviousSigningCertsDir: previousCertsDir,
+		RootCACertsDir:          rootCACertsDir,
+		BatchSize:               2,
+		ConsoleWriter:           &bytes.Buffer{},
+		FlavorStore:             setup.flavorStore,
+	}
+	return setup
+}
+
+func (s *resignFlavorsTestSetup) newSigningCertificate(t *testing.T, notBefore, notAfter time.Time) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(notBefore.UnixNano()),
+		Subject:      pkix.Name{CommonName: "HVS Flavor Signing Certificate"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, s.caCert, &key.PublicKey, s.caKey)
+	assert.NoError(t, err)
+	return key, der
+}
+
+func (s *resignFlavorsTestSetup) createFlavor(t *testing.T, key *rsa.PrivateKey) uuid.UUID {
+	flavor := hvs.Flavor{
+		Meta: flavormodel.Meta{
+			ID:          uuid.New(),
+			Description: map[string]interface{}{flavormodel.Label: "flavor-" + uuid.New().String()},
+		},
+	}
+	signedFlavor, err := flavormodel.NewSignedFlavor(&flavor, key)
+	assert.NoError(t, err)
+	_, err = s.flavorStore.Create(signedFlavor)
+	assert.NoError(t, err)
+	return flavor.Meta.ID
+}
+
+func TestResignFlavors(t *testing.T) {
+	s := newResignFlavorsTestSetup(t)
+	var previousFlavorIds []uuid.UUID
+	for i := 0; i < 3; i++ {
+		previousFlavorIds = append(previousFlavorIds, s.createFlavor(t, s.previousKey))
+	}
+	currentFlavorId := s.createFlavor(t, s.currentKey)
+	currentFlavor, err := s.flavorStore.Retrieve(currentFlavorId)
+	assert.NoError(t, err)
+
+	assert.Error(t, s.task.Validate())
+	assert.NoError(t, s.task.Run())
+	assert.NoError(t, s.task.Validate())
+
+	for _, id := range previousFlavorIds {
+		sf, err := s.flavorStore.Retrieve(id)
+		assert.NoError(t, err)
+		assert.NoError(t, sf.Verify(&s.currentKey.PublicKey))
+	}
+	// the flavors already signed with the current key are not updated
+	sf, err := s.flavorStore.Retrieve(currentFlavorId)
+	assert.NoError(t, err)
+	assert.Equal(t, currentFlavor.Signature, sf.Signature)
+	assert.Contains(t, s.task.ConsoleWriter.(*bytes.Buffer).String(), "Processed 4/4 flavor(s): 3 re-signed, 1 already signed with the current key, 0 failed")
+
+	// the previous flavor signing certificates are removed once all the flavors are re-signed
+	_, err = os.Stat(s.task.PreviousSigningCertsDir)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, s.task.Run())
+}
+
+func TestResignFlavorsUntrustedSignature(t *testing.T) {
+	s := newResignFlavorsTestSetup(t)
+	previousFlavorId := s.createFlavor(t, s.previousKey)
+
+	// a flavor signed by a key none of the flavor signing certificates is issued for is not re-signed
+	unknownKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	untrustedFlavorId := s.createFlavor(t, unknownKey)
+	untrustedFlavor, err := s.flavorStore.Retrieve(untrustedFlavorId)
+	assert.NoError(t, err)
+
+	assert.Error(t, s.task.Run())
+	assert.Error(t, s.task.Validate())
+
+	sf, err := s.flavorStore.Retrieve(previousFlavorId)
+	assert.NoError(t, err)
+	assert.NoError(t, sf.Verify(&s.currentKey.PublicKey))
+	sf, err = s.flavorStore.Retrieve(untrustedFlavorId)
+	assert.NoError(t, err)
+	assert.Equal(t, untrustedFlavor.Signature, sf.Signature)
+	assert.Contains(t, s.task.ConsoleWriter.(*bytes.Buffer).String(), untrustedFlavorId.String())
+
+	// the previous flavor signing certificates are kept until all the flavors are re-signed
+	_, err = os.Stat(s.task.PreviousSigningCertsDir)
+	assert.NoError(t, err)
+}
+
+func TestResignFlavorsKeyMismatch(t *testing.T) {
+	s := newResignFlavorsTestSetup(t)
+	s.createFlavor(t, s.previousKey)
+
+	// the signing key must be the key of the flavor signing certificate
+	keyDer, err := x509.MarshalPKCS8PrivateKey(s.previousKey)
+	assert.NoError(t, err)
+	assert.NoError(t, crypt.SavePrivateKeyAsPKCS8(keyDer, s.task.SigningKeyFile))
+
+	assert.Error(t, s.task.Run())
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tasks
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/dek"
+	commConfig "github.com/intel-secl/intel-secl/v4/pkg/lib/common/config"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/setup"
+	"github.com/pkg/errors"
+)
+
+// RotateDek replaces the data encryption key with a new key and re-encrypts the host credentials and the ESXi cluster
+// connection strings with it. The configuration file is updated with the new key before the credentials are
+// re-encrypted, the previous key is kept in the configuration until no credential is encrypted with it.
+type RotateDek struct {
+	DBConfig      commConfig.DBConfig
+	Config        *config.Configuration
+	ConfigFile    string
+	ConsoleWriter io.Writer
+	Store         domain.DataEncryptionKeyStore
+
+	commandName string
+}
+
+func (t *RotateDek) Run() error {
+	if t.Config == nil {
+		return errors.New("Configuration can not be nil")
+	}
+	keys, err := dek.NewDataEncryptionKeys(t.Config)
+	if err != nil {
+		return errors.Wrap(err, "Failed to load the data encryption key, it must be created with create-dek")
+	}
+	store, err := t.store()
+	if err != nil {
+		return err
+	}
+
+	rotation, err := dek.NewRotator(t.Config, t.ConfigFile, keys, store).Rotate()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(t.ConsoleWriter, "Data encryption key rotated to key %s: %d host credential(s) and %d ESXi cluster(s) re-encrypted\n",
+		rotation.KeyId, rotation.HostCredentials, rotation.ESXiClusters)
+	return nil
+}
+
+// Validate checks that the credentials are all encrypted with the current data encryption key and that no previous
+// key is left in the configuration by an interrupted rotation
+func (t *RotateDek) Validate() error {
+	if t.Config == nil {
+		return errors.New("Configuration can not be nil")
+	}
+	keys, err := dek.NewDataEncryptionKeys(t.Config)
+	if err != nil {
+		return err
+	}
+	if len(keys.Previous()) != 0 {
+		return errors.Errorf("%s: the rotation of the data encryption key is not completed", t.commandName)
+	}
+	store, err := t.store()
+	if err != nil {
+		return err
+	}
+	keyIds, err := store.RetrieveKeyIds()
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve the data encryption keys in use")
+	}
+	currentKeyId, _ := keys.Current()
+	for _, keyId := range keyIds {
+		if keyId != currentKeyId {
+			return errors.Errorf("%s: credentials are not encrypted with the current data encryption key", t.commandName)
+		}
+	}
+	return nil
+}
+
+func (t *RotateDek) PrintHelp(w io.Writer) {
+	fmt.Fprintln(w, "Rotates the data encryption key and re-encrypts the stored credentials with the new key")
+	fmt.Fprintln(w, "The task is skipped when the credentials are encrypted with the current key, run it with --force to rotate the key")
+	fmt.Fprintln(w, "The HVS must be stopped while the task is run, the data encryption key of a running HVS is rotated with the")
+	fmt.Fprintln(w, "POST /data-encryption-keys/rotate API")
+	setup.PrintEnvHelp(w, DbEnvHelpPrompt, "", DbEnvHelp)
+	fmt.Fprintln(w, "")
+}
+
+func (t *RotateDek) SetName(n, e string) {
+	t.commandName = n
+}
+
+func (t *RotateDek) store() (domain.DataEncryptionKeyStore, error) {
+	if t.Store == nil {
+		// the database is migrated for the key id columns, in case the task is run before the upgraded service is started
+		dataStore, err := postgres.InitDatabase(&t.DBConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to connect database")
+		}
+		t.Store = postgres.NewDataEncryptionKeyStore(dataStore)
+	}
+	return t.Store, nil
+}
@@ -9,7 +9,11 @@ import (
 	"fmt"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/frs"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/jps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/rhps"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/services/tcrs"
 	commConfig "github.com/intel-secl/intel-secl/v4/pkg/lib/common/config"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/setup"
 	"github.com/pkg/errors"
@@ -40,9 +44,20 @@ var envHelp = map[string]string{
 	"AAS_BASE_URL":                           "AAS Base URL",
 	"HRRS_REFRESH_PERIOD":                    "Host report refresh service period",
 	"VCSS_REFRESH_PERIOD":                    "VCenter refresh service period",
+	"FRS_GRACE_PERIOD":                       "Period a superseded flavor stays active along with the flavor superseding it",
+	"FRS_REFRESH_PERIOD":                     "Flavor retirement service period",
+	"RHPS_MAX_REPORTS":                       "Number of reports of each host kept in the report history, 0 keeps all the reports",
+	"RHPS_MAX_AGE":                           "Period the reports are kept in the report history, 0 keeps the reports regardless of their age",
+	"RHPS_REFRESH_PERIOD":                    "Report history purge service period",
+	"JPS_MAX_AGE":                            "Period the finished jobs are kept, 0 keeps all the jobs",
+	"JPS_REFRESH_PERIOD":                     "Job purge service period",
+	"TCRS_RENEW_BEFORE":                      "Period before its expiry a tag certificate is renewed, shorter than the tag certificate validity (365 days)",
+	"TCRS_DEPLOY":                            "Deploys the renewed tag certificates to the hosts when set to true",
+	"TCRS_REFRESH_PERIOD":                    "Tag certificate renewal service period",
 	"FVS_NUMBER_OF_VERIFIERS":                "NUmber of Flavor verification verifier threads",
 	"FVS_NUMBER_OF_DATA_FETCHERS":            "Number of Flavor verification data fetcher threads",
 	"FVS_SKIP_FLAVOR_SIGNATURE_VERIFICATION": "Skips flavor signature verification when set to true",
+	"FVS_ENABLE_SIMULATED_HOSTS":             "Allows the simulated hosts, for testing only, when set to true",
 	"HOST_TRUST_CACHE_THRESHOLD":             "Maximum number of entries to be cached in the Trust/Flavor caches",
 	"SERVER_PORT":                            "The Port on which Server Listens to",
 	"SERVER_READ_TIMEOUT":                    "Request Read Timeout Duration in Seconds",
@@ -51,6 +66,11 @@ var envHelp = map[string]string{
 	"SERVER_IDLE_TIMEOUT":                    "Request Idle Timeout in Seconds",
 	"SERVER_MAX_HEADER_BYTES":                "Max Length Of Request Header in Bytes",
 	"NAT_SERVERS":                            "List of NATs servers to establish connection with outbound TAs",
+	"TRUST_EVENTS_NATS_SUBJECT":              "NATS subject to publish host trust state change events to",
+	"TRUST_EVENTS_MAX_RETRIES":               "Number of times a failed trust state change event delivery is retried",
+	"TRUST_EVENTS_RETRY_BACKOFF":             "Initial wait between trust state change event delivery retries",
+	"REPORT_TOKEN_KEY_FILE":                  "PKCS8 key file signing the JWT trust reports, the SAML key signs them when not set",
+	"REPORT_TOKEN_CERT_FILE":                 "Certificate file of the key signing the JWT trust reports",
 }
 
 func (uc UpdateServiceConfig) Run() error {
@@ -84,11 +104,38 @@ func (uc UpdateServiceConfig) Run() error {
 	(*uc.AppConfig).VCSS = config.VCSSConfig{
 		RefreshPeriod: viper.GetDuration(constants.VcssRefreshPeriod),
 	}
+	(*uc.AppConfig).FRS = frs.FRSConfig{
+		GracePeriod:   viper.GetDuration(constants.FrsGracePeriod),
+		RefreshPeriod: viper.GetDuration(constants.FrsRefreshPeriod),
+	}
+	(*uc.AppConfig).RHPS = rhps.RHPSConfig{
+		MaxReports:    viper.GetInt(constants.RhpsMaxReports),
+		MaxAge:        viper.GetDuration(constants.RhpsMaxAge),
+		RefreshPeriod: viper.GetDuration(constants.RhpsRefreshPeriod),
+	}
+	(*uc.AppConfig).JPS = jps.JPSConfig{
+		MaxAge:        viper.GetDuration(constants.JpsMaxAge),
+		RefreshPeriod: viper.GetDuration(constants.JpsRefreshPeriod),
+	}
+	(*uc.AppConfig).TCRS = tcrs.TCRSConfig{
+		RenewBefore:   viper.GetDuration(constants.TcrsRenewBefore),
+		Deploy:        viper.GetBool(constants.TcrsDeploy),
+		RefreshPeriod: viper.GetDuration(constants.TcrsRefreshPeriod),
+	}
+	// webhooks are only configured in the configuration file, keep them
+	(*uc.AppConfig).TrustEvents.NatsSubject = viper.GetString(constants.TrustEventsNatsSubject)
+	(*uc.AppConfig).TrustEvents.MaxRetries = viper.GetInt(constants.TrustEventsMaxRetries)
+	(*uc.AppConfig).TrustEvents.RetryBackoff = viper.GetDuration(constants.TrustEventsRetryBackoff)
+	(*uc.AppConfig).ReportToken = config.ReportTokenConfig{
+		KeyFile:  viper.GetString(constants.ReportTokenKeyFile),
+		CertFile: viper.GetString(constants.ReportTokenCertFile),
+	}
 	(*uc.AppConfig).FVS = config.FVSConfig{
 		NumberOfVerifiers:               viper.GetInt(constants.FvsNumberOfVerifiers),
 		NumberOfDataFetchers:            viper.GetInt(constants.FvsNumberOfDataFetchers),
 		SkipFlavorSignatureVerification: viper.GetBool(constants.FvsSkipFlavorSignatureVerification),
 		HostTrustCacheThreshold:         viper.GetInt(constants.FvsHostTrustCacheThreshold),
+		EnableSimulatedHosts:            viper.GetBool(constants.FvsEnableSimulatedHosts),
 	}
 
 	if uc.NatServers != "" {
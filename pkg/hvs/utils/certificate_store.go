@@ -6,8 +6,11 @@ package utils
 
 import (
 	"crypto"
+	"crypto/x509"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/verifier"
+	"github.com/pkg/errors"
 )
 
 func LoadCertificates(certificatePaths *models.CertificatesPathStore) *models.CertificatesStore {
@@ -82,3 +85,64 @@ func loadKey(keyFile string) crypto.PrivateKey {
 	}
 	return key
 }
+
+// NewFlavorVerifier creates a flavor verifier that trusts the privacy CA, tag CA, flavor signing and root CA
+// certificates loaded in the certificate store. The previous flavor signing certificates are trusted along with the
+// current one, so that the flavors signed before the flavor signing certificate was renewed remain trusted until they
+// are re-signed. Their archived intermediate CAs are used to build their chains but are not trusted as roots.
+func NewFlavorVerifier(certStore *models.CertificatesStore, previousSigningCerts []x509.Certificate) (verifier.Verifier, error) {
+	defaultLog.Trace("utils/certificate_store:NewFlavorVerifier() Entering")
+	defer defaultLog.Trace("utils/certificate_store:NewFlavorVerifier() Leaving")
+
+	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
+	tagCAs := (*certStore)[models.CaCertTypesTagCa.String()]
+	privacyCAs := (*certStore)[models.CaCertTypesPrivacyCa.String()]
+	signingCerts := (*certStore)[models.CertTypesFlavorSigning.String()]
+	if rootCAs == nil || tagCAs == nil || privacyCAs == nil || signingCerts == nil || len(signingCerts.Certificates) == 0 {
+		return nil, errors.New("utils/certificate_store:NewFlavorVerifier() Certificates required by the flavor verifier are not loaded")
+	}
+
+	rootCApool := crypt.GetCertPool(rootCAs.Certificates)
+	for i := range signingCerts.Certificates[1:] {
+		rootCApool.AddCert(&signingCerts.Certificates[i+1]) //Add intermediate CA
+	}
+
+	// the archived intermediate CAs only chain the previous flavor signing certificates to the root CAs
+	flavorSigningCerts := []x509.Certificate{signingCerts.Certificates[0]}
+	intermediateCApool := x509.NewCertPool()
+	for i := range previousSigningCerts {
+		if previousSigningCerts[i].IsCA {
+			intermediateCApool.AddCert(&previousSigningCerts[i])
+		} else {
+			flavorSigningCerts = append(flavorSigningCerts, previousSigningCerts[i])
+		}
+	}
+
+	verifierCerts := verifier.VerifierCertificates{
+		PrivacyCACertificates:            crypt.GetCertPool(privacyCAs.Certificates),
+		AssetTagCACertificates:           crypt.GetCertPool(tagCAs.Certificates),
+		FlavorSigningCertificates:        flavorSigningCerts,
+		FlavorCACertificates:             rootCApool,
+		FlavorIntermediateCACertificates: intermediateCApool,
+	}
+	return verifier.NewVerifier(verifierCerts)
+}
+
+// LoadPreviousFlavorSigningCertificates returns the certificates archived in the directory of the previous flavor
+// signing certificates, with their intermediate CAs
+func LoadPreviousFlavorSigningCertificates(dir string) []x509.Certificate {
+	defaultLog.Trace("utils/certificate_store:LoadPreviousFlavorSigningCertificates() Entering")
+	defer defaultLog.Trace("utils/certificate_store:LoadPreviousFlavorSigningCertificates() Leaving")
+
+	certs, err := crypt.GetCertsFromDir(dir)
+	if err != nil {
+		defaultLog.WithError(err).Warnf("utils/certificate_store:LoadPreviousFlavorSigningCertificates() Error while reading certificates from %s", dir)
+		return nil
+	}
+	for _, cert := range certs {
+		if !cert.IsCA {
+			defaultLog.Debugf("utils/certificate_store:LoadPreviousFlavorSigningCertificates() Previous flavor signing certificate CN - %s, expires %s", cert.Subject.CommonName, cert.NotAfter)
+		}
+	}
+	return certs
+}
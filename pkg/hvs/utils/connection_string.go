@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/constants"
 	hcUtil "github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/util"
 	"github.com/pkg/errors"
 )
@@ -28,7 +29,9 @@ func GetConnectionStringWithoutCredentials(cs string) string {
 	return strings.Join(csParts, ";")
 }
 
-func ValidateConnectionString(cs string) error {
+// ValidateConnectionString validates the connection string of a host, the connection strings of the simulated hosts are
+// rejected unless simulatedHostsEnabled is set
+func ValidateConnectionString(cs string, simulatedHostsEnabled bool) error {
 	defaultLog.Trace("util/connection_string:ValidateConnectionString() Entering")
 	defer defaultLog.Trace("util/connection_string:ValidateConnectionString() Leaving")
 
@@ -36,6 +39,9 @@ func ValidateConnectionString(cs string) error {
 	if err != nil {
 		return errors.Wrap(err, "Invalid URL in connection string")
 	}
+	if vc.Vendor == constants.VendorSimulator && !simulatedHostsEnabled {
+		return errors.New("Simulated hosts are not enabled")
+	}
 
 	// TODO: Validate host and port in url
 	// need to change the type to URL*
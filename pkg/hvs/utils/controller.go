@@ -7,8 +7,11 @@ package utils
 
 import (
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	"github.com/pkg/errors"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -43,3 +46,49 @@ func ParseDateQueryParam(dt string) (time.Time, error) {
 	}
 	return pTime, nil
 }
+
+// ParsePageCriteria parses the limit, offset, sortBy and orderBy query params of a collection search. The limit is
+// left at zero when it is not provided, sortBy must be one of the given sort fields.
+func ParsePageCriteria(params url.Values, sortFields map[string]bool) (*models.PageCriteria, error) {
+	defaultLog.Trace("utils/controller:ParsePageCriteria() Entering")
+	defer defaultLog.Trace("utils/controller:ParsePageCriteria() Leaving")
+
+	pageCriteria := models.PageCriteria{}
+
+	limit := strings.TrimSpace(params.Get("limit"))
+	if limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l <= 0 {
+			return nil, errors.New("Limit must be an integer > 0")
+		}
+		pageCriteria.Limit = l
+	}
+
+	offset := strings.TrimSpace(params.Get("offset"))
+	if offset != "" {
+		off, err := strconv.Atoi(offset)
+		if err != nil || off < 0 {
+			return nil, errors.New("Offset must be an integer >= 0")
+		}
+		pageCriteria.Offset = off
+	}
+
+	sortBy := strings.TrimSpace(params.Get("sortBy"))
+	if sortBy != "" {
+		if !sortFields[sortBy] {
+			return nil, errors.New("Invalid sortBy query param value")
+		}
+		pageCriteria.SortBy = sortBy
+	}
+
+	orderBy := strings.TrimSpace(params.Get("orderBy"))
+	if orderBy != "" {
+		orderType, err := models.GetOrderType(orderBy)
+		if err != nil {
+			return nil, errors.New("Invalid orderBy query param value, must be asc/desc")
+		}
+		pageCriteria.OrderBy = orderType
+	}
+
+	return &pageCriteria, nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
+	fm "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// GetHostsAssociatedWithFlavor returns the hosts that need to be re-verified when the flavor is deleted
+func GetHostsAssociatedWithFlavor(hStore domain.HostStore, fgStore domain.FlavorGroupStore, flavor *hvs.SignedFlavor) ([]uuid.UUID, error) {
+	defaultLog.Trace("utils/flavor:GetHostsAssociatedWithFlavor() Entering")
+	defer defaultLog.Trace("utils/flavor:GetHostsAssociatedWithFlavor() Leaving")
+
+	id := flavor.Flavor.Meta.ID
+	flavorGroups, err := fgStore.Search(&models.FlavorGroupFilterCriteria{FlavorId: &id})
+	if err != nil {
+		return nil, errors.Wrapf(err, "utils/flavor:GetHostsAssociatedWithFlavor() Failed to retrieve flavorgroups "+
+			"associated with flavor %v for trust re-verification", id)
+	}
+
+	var hostIdsForQueue []uuid.UUID
+	for _, flavorGroup := range flavorGroups {
+		//Host unique flavors are associated with only host_unique flavorgroup and associated with only one host uniquely
+		if flavorGroup.Name == models.FlavorGroupsHostUnique.String() {
+			hardwareUUID, err := uuid.Parse(flavor.Flavor.Meta.Description[fm.HardwareUUID].(string))
+			if err != nil {
+				return nil, errors.Wrap(err, "utils/flavor:GetHostsAssociatedWithFlavor() Failed to parse hardwareUUID")
+			}
+			hosts, err := hStore.Search(&models.HostFilterCriteria{
+				HostHardwareId: hardwareUUID,
+			}, nil)
+			if err != nil {
+				return nil, errors.Wrapf(err, "utils/flavor:GetHostsAssociatedWithFlavor() Failed to retrieve hosts "+
+					"associated with flavor %v for trust re-verification", id)
+			}
+			if len(hosts) > 0 {
+				hostIdsForQueue = append(hostIdsForQueue, hosts[0].Id)
+				break
+			}
+		}
+		hostIds, err := fgStore.SearchHostsByFlavorGroup(flavorGroup.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "utils/flavor:GetHostsAssociatedWithFlavor() Failed to retrieve hosts "+
+				"associated with flavorgroup %v for trust re-verification", flavorGroup.ID)
+		}
+		hostIdsForQueue = append(hostIdsForQueue, hostIds...)
+	}
+	return hostIdsForQueue, nil
+}
@@ -6,6 +6,7 @@ package utils
 
 import (
 	"encoding/base64"
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
 	"github.com/pkg/errors"
 )
@@ -38,3 +39,44 @@ func DecryptString(cipherText string, key []byte) (string, error) {
 
 	return string(bytes), nil
 }
+
+// EncryptWithCurrentKey encrypts the plain text with the current data encryption key, and returns the cipher text
+// along with the id of the key
+func EncryptWithCurrentKey(plainText string, keys *models.DataEncryptionKeys) (string, string, error) {
+	defaultLog.Trace("utils/host_credential:EncryptWithCurrentKey() Entering")
+	defer defaultLog.Trace("utils/host_credential:EncryptWithCurrentKey() Leaving")
+
+	keyId, key := keys.Current()
+	if key == nil {
+		return "", "", errors.New("Data encryption key is not defined")
+	}
+	cipherText, err := EncryptString(plainText, key)
+	if err != nil {
+		return "", "", err
+	}
+	return cipherText, keyId, nil
+}
+
+// DecryptWithKeyId decrypts the cipher text with the data encryption key identified by the key id. The cipher texts
+// stored before the key ids were recorded have no key id, they are decrypted with the first key that authenticates them.
+func DecryptWithKeyId(cipherText string, keyId string, keys *models.DataEncryptionKeys) (string, error) {
+	defaultLog.Trace("utils/host_credential:DecryptWithKeyId() Entering")
+	defer defaultLog.Trace("utils/host_credential:DecryptWithKeyId() Leaving")
+
+	if keyId != "" {
+		key, ok := keys.Get(keyId)
+		if !ok {
+			return "", errors.Errorf("Data encryption key %s is not defined", keyId)
+		}
+		return DecryptString(cipherText, key)
+	}
+
+	err := errors.New("Data encryption key is not defined")
+	for _, key := range keys.All() {
+		var plainText string
+		if plainText, err = DecryptString(cipherText, key); err == nil {
+			return plainText, nil
+		}
+	}
+	return "", err
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants"
+	"github.com/pkg/errors"
+)
+
+var (
+	// a label key starts and ends with an alphanumeric character and may hold '.', '_', '-' and '/' in between
+	labelKeyRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]{0,61}[A-Za-z0-9])?$`)
+	// a label value is empty or starts and ends with an alphanumeric character and may hold '.', '_' and '-' in between
+	labelValueRegex = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+// ValidateLabels validates the keys and values of host labels or of a label selector
+func ValidateLabels(labels map[string]string) error {
+	defaultLog.Trace("utils/labels:ValidateLabels() Entering")
+	defer defaultLog.Trace("utils/labels:ValidateLabels() Leaving")
+
+	if len(labels) > constants.MaxLabelCount {
+		return errors.Errorf("At most %d labels can be specified", constants.MaxLabelCount)
+	}
+	for key, value := range labels {
+		if !labelKeyRegex.MatchString(key) {
+			return errors.Errorf("Invalid label key %q", key)
+		}
+		if !labelValueRegex.MatchString(value) {
+			return errors.Errorf("Invalid value %q for label %s", value, key)
+		}
+	}
+	return nil
+}
+
+// LabelsMatch returns true when the labels hold every key and value of the selector. An empty selector does not
+// select any labels.
+func LabelsMatch(selector, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseLabelQueryParams parses the label query params of a search, each one formatted as key:value
+func ParseLabelQueryParams(params []string) (map[string]string, error) {
+	defaultLog.Trace("utils/labels:ParseLabelQueryParams() Entering")
+	defer defaultLog.Trace("utils/labels:ParseLabelQueryParams() Leaving")
+
+	if len(params) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string, len(params))
+	for _, param := range params {
+		keyValue := strings.SplitN(strings.TrimSpace(param), ":", 2)
+		if len(keyValue) != 2 {
+			return nil, errors.Errorf("Invalid label %q, must be formatted as key:value", param)
+		}
+		if value, ok := labels[keyValue[0]]; ok && value != keyValue[1] {
+			return nil, errors.Errorf("Label %s cannot have several values", keyValue[0])
+		}
+		labels[keyValue[0]] = keyValue[1]
+	}
+	if err := ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
@@ -48,7 +48,7 @@ func (aTag *atag) CreateAssetTag(tagCertConfig TagCertConfig) ([]byte, error) {
 		}
 		extensions = append(extensions, pkix.Extension{
 			Critical: false,
-			Id:       asn1.ObjectIdentifier{2, 5, 4, 789, 1},
+			Id:       TagAttributeOid,
 			Value:    derEncodedAttr,
 		})
 	}
@@ -91,6 +91,23 @@ func tagCertificateBuilder(certConfig TagCertBuilderConfig) ([]byte, error) {
 	return derBytes, nil
 }
 
+// GetTagAttributes returns the key-value attributes of an asset tag certificate, in the order they were set when
+// the certificate was created
+func GetTagAttributes(tagCertificate *x509.Certificate) ([]TagKvAttribute, error) {
+	var tagAttributes []TagKvAttribute
+	for _, extension := range tagCertificate.Extensions {
+		if !extension.Id.Equal(TagAttributeOid) {
+			continue
+		}
+		var tagAttribute TagKvAttribute
+		if _, err := asn1.Unmarshal(extension.Value, &tagAttribute); err != nil {
+			return nil, errors.Wrap(err, "Failed to unmarshal ASN1 Tag Cert attributes")
+		}
+		tagAttributes = append(tagAttributes, tagAttribute)
+	}
+	return tagAttributes, nil
+}
+
 // DeployAssetTag implements the interface AssetTag to deploy an asset tag certificate on a particular host with custom tag attributes
 func (aTag *atag) DeployAssetTag(connector hc.HostConnector, tagCertDigest, hostHardwareUUID string) error {
 
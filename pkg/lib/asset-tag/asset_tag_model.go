@@ -8,10 +8,14 @@ package asset_tag
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"math/big"
 	"time"
 )
 
+// TagAttributeOid is the object identifier of the extensions holding the key-value attributes of an asset tag certificate
+var TagAttributeOid = asn1.ObjectIdentifier{2, 5, 4, 789, 1}
+
 // TagCertConfig is the input struct for Asset-Tag create interface implementation
 type TagCertConfig struct {
 	SubjectUUID       string
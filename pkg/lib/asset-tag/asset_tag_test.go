@@ -153,10 +153,45 @@ func TestAtag_CreateAssetTag(t *testing.T) {
 
 }
 
+func TestGetTagAttributes(t *testing.T) {
+	privKey, cert, err := createX509CertAndKey()
+	if err != nil {
+		t.Fatalf("Error generating the key and certificate : %v", err)
+	}
+
+	tagAttributes := []TagKvAttribute{{
+		Key:   "Country",
+		Value: "US",
+	}}
+	tagCertificate, err := NewAssetTag().CreateAssetTag(TagCertConfig{
+		SubjectUUID:       "803f6068-06da-e811-906e-00163566263e",
+		PrivateKey:        privKey,
+		TagCACert:         cert,
+		TagAttributes:     tagAttributes,
+		ValidityInSeconds: 1000,
+	})
+	if err != nil {
+		t.Fatalf("Error while creating an asset tag: %v", err)
+	}
+	parsedCert, err := x509.ParseCertificate(tagCertificate)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	attributes, err := GetTagAttributes(parsedCert)
+	assert.NoError(t, err)
+	assert.Equal(t, tagAttributes, attributes)
+
+	// the extensions that are not tag attributes are ignored
+	attributes, err = GetTagAttributes(cert)
+	assert.NoError(t, err)
+	assert.Empty(t, attributes)
+}
+
 func TestAtag_DeployAssetTag(t *testing.T) {
 	newTag := NewAssetTag()
 	var trustedCAcerts []x509.Certificate
-	htcFactory := hc.NewHostConnectorFactory("", trustedCAcerts, nil)
+	htcFactory := hc.NewHostConnectorFactory("", trustedCAcerts, nil, false)
 	connector, err := htcFactory.NewHostConnector("https://ta.ip.com:1443;u=serviceUsername;p=servicePassword")
 	assert.NoError(t, err)
 	dtErr := newTag.DeployAssetTag(connector, "0966d97d182ee8fac40bee16018e762ae46a026f0bb437600e029a755f8745a9a6bb8b3da152ea37ef52f0d855b6622f\n", "803f6068-06da-e811-906e-00163566263e")
@@ -12,4 +12,7 @@ const (
 	HTTPMediaTypeSaml        = "application/samlassertion+xml"
 	HTTPMediaTypePemFile     = "application/x-pem-file"
 	HTTPMediaTypeOctetStream = "application/octet-stream"
+	HTTPMediaTypeNDJson      = "application/x-ndjson"
+	HTTPMediaTypeCsv         = "text/csv"
+	HTTPMediaTypeJwt         = "application/jwt"
 )
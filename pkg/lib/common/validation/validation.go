@@ -44,6 +44,9 @@ var (
 	jwtReg              = regexp.MustCompile("^[A-Za-z0-9-_=]+\\.[A-Za-z0-9-_=]+\\.?[A-Za-z0-9-_.+/=]*")
 )
 
+// simulatorConnectionStringReg matches the connection strings of the simulated hosts, which refer to a host profile
+var simulatorConnectionStringReg = regexp.MustCompile("^simulator\\:file\\:\\/\\/\\/[^;]+(;u=.+;p=.+)?$")
+
 // ValidateEnvList can check if all environment variables in input slice exist
 // If things missing, return a slice contains all missing variables and an error.
 // Otherwise two nil
@@ -257,6 +260,14 @@ func ValidateConnectionString(cs string) error {
 	return errors.New("Invalid connection string")
 }
 
+// ValidateSimulatorConnectionString validates the connection string of a simulated host
+func ValidateSimulatorConnectionString(cs string) error {
+	if simulatorConnectionStringReg.MatchString(cs) {
+		return nil
+	}
+	return errors.New("Invalid simulator connection string")
+}
+
 // ValidateJWT method is used to check if the jwt token format is valid
 func ValidateJWT(token string) error {
 	if jwtReg.MatchString(token) {
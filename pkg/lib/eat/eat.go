@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// eat package issues and verifies the trust reports of hosts as signed JWTs, in the manner of Entity Attestation
+// Tokens. A token carries the same attributes as the SAML report of the host.
+package eat
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"time"
+
+	jwt "github.com/Waterdrips/jwt-go"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	"github.com/pkg/errors"
+)
+
+var log = commLog.GetDefaultLogger()
+
+// gracePeriodForClockSkew backdates the issue time of the tokens, the clock of a relying party might be behind the
+// clock of the issuer
+const gracePeriodForClockSkew = 30 * time.Second
+
+// validSigningMethods are the algorithms the tokens are signed and verified with
+var validSigningMethods = []string{jwt.SigningMethodRS384.Alg(), jwt.SigningMethodES256.Alg(), jwt.SigningMethodES384.Alg()}
+
+// TrustReportClaims are the claims of a trust report token. The subject is the hardware UUID of the host, the ID is
+// the ID of the report and the validity window of the token is the validity window of the report.
+type TrustReportClaims struct {
+	jwt.StandardClaims
+	// Trusted is the overall trust status of the host
+	Trusted bool `json:"trusted"`
+	// FlavorParts holds the trust status of each flavor part verified in the report
+	FlavorParts           map[string]bool   `json:"flavor_parts"`
+	AssetTags             map[string]string `json:"asset_tags,omitempty"`
+	HardwareFeatures      map[string]string `json:"hardware_features,omitempty"`
+	HostInfo              map[string]string `json:"host_info"`
+	AIKCertificate        string            `json:"aik_certificate,omitempty"`
+	BindingKeyCertificate string            `json:"binding_key_certificate,omitempty"`
+}
+
+// IssuerConfiguration holds the key signing the tokens and its certificate. The key is an RSA key or an ECDSA key on
+// the NIST P-256 or P-384 curve.
+type IssuerConfiguration struct {
+	PrivateKey  crypto.PrivateKey
+	Certificate *x509.Certificate
+	IssuerName  string
+}
+
+// Issuer signs trust report tokens
+type Issuer struct {
+	config        IssuerConfiguration
+	signingMethod jwt.SigningMethod
+	keyId         string
+}
+
+// NewIssuer returns an Issuer signing the tokens with the key of the issuer configuration
+func NewIssuer(ic IssuerConfiguration) (*Issuer, error) {
+	log.Trace("eat/eat:NewIssuer() Entering")
+	defer log.Trace("eat/eat:NewIssuer() Leaving")
+
+	if ic.IssuerName == "" {
+		return nil, errors.New("eat/eat:NewIssuer() Invalid IssuerName for IssuerConfiguration")
+	}
+	if ic.Certificate == nil {
+		return nil, errors.New("eat/eat:NewIssuer() No certificate assigned to issuer configuration")
+	}
+
+	var signingMethod jwt.SigningMethod
+	var publicKey crypto.PublicKey
+	switch key := ic.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		signingMethod = jwt.SigningMethodRS384
+		publicKey = key.Public()
+	case *ecdsa.PrivateKey:
+		switch key.Curve.Params().BitSize {
+		case 256:
+			signingMethod = jwt.SigningMethodES256
+		case 384:
+			signingMethod = jwt.SigningMethodES384
+		default:
+			return nil, errors.Errorf("eat/eat:NewIssuer() Unsupported ECDSA curve %s", key.Curve.Params().Name)
+		}
+		publicKey = key.Public()
+	case nil:
+		return nil, errors.New("eat/eat:NewIssuer() No private key assigned to issuer configuration")
+	default:
+		return nil, errors.Errorf("eat/eat:NewIssuer() Unsupported private key type %T", ic.PrivateKey)
+	}
+	if !publicKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(ic.Certificate.PublicKey) {
+		return nil, errors.New("eat/eat:NewIssuer() The private key does not match the certificate")
+	}
+
+	keyId, err := crypt.GetCertHashInHex(ic.Certificate, crypto.SHA1)
+	if err != nil {
+		return nil, errors.Wrap(err, "eat/eat:NewIssuer() Error computing the key ID of the certificate")
+	}
+	return &Issuer{config: ic, signingMethod: signingMethod, keyId: keyId}, nil
+}
+
+// Sign returns the token of the claims. The issuer and the issue time of the claims are set by the Issuer.
+func (i *Issuer) Sign(claims *TrustReportClaims) (string, error) {
+	log.Trace("eat/eat:Sign() Entering")
+	defer log.Trace("eat/eat:Sign() Leaving")
+
+	claims.Issuer = i.config.IssuerName
+	claims.IssuedAt = time.Now().Add(-gracePeriodForClockSkew).Unix()
+
+	token := jwt.NewWithClaims(i.signingMethod, claims)
+	token.Header["kid"] = i.keyId
+	signedToken, err := token.SignedString(i.config.PrivateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "eat/eat:Sign() Error signing the trust report token")
+	}
+	return signedToken, nil
+}
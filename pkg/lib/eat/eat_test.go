@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package eat
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/Waterdrips/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSelfSignedCert(t *testing.T, key crypto.Signer) *x509.Certificate {
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "HVS Report Signing Certificate"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, &template, &template, key.Public(), key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDer)
+	assert.NoError(t, err)
+	return cert
+}
+
+func newClaims() *TrustReportClaims {
+	return &TrustReportClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        "b3ae8d3b-3bd5-4b0a-9ec4-a1bd39ffcb7a",
+			Subject:   "00ecd3ab-9af4-e711-906e-001560a04062",
+			NotBefore: time.Now().Add(-time.Minute).Unix(),
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+		Trusted:          true,
+		FlavorParts:      map[string]bool{"PLATFORM": true, "OS": true},
+		AssetTags:        map[string]string{"Country": "US"},
+		HardwareFeatures: map[string]string{"TPM": "true"},
+		HostInfo:         map[string]string{"HostName": "host1"},
+	}
+}
+
+func TestSignAndVerifyTrustReportToken(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 3072)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+
+	for _, key := range []crypto.Signer{rsaKey, ecKey} {
+		cert := newSelfSignedCert(t, key)
+		issuer, err := NewIssuer(IssuerConfiguration{PrivateKey: key, Certificate: cert, IssuerName: "HVS"})
+		assert.NoError(t, err)
+
+		token, err := issuer.Sign(newClaims())
+		assert.NoError(t, err)
+
+		claims, err := VerifyTrustReportToken(token, []x509.Certificate{*cert}, []x509.Certificate{*cert})
+		assert.NoError(t, err)
+		assert.Equal(t, "HVS", claims.Issuer)
+		assert.Equal(t, "00ecd3ab-9af4-e711-906e-001560a04062", claims.Subject)
+		assert.True(t, claims.Trusted)
+		assert.Equal(t, map[string]bool{"PLATFORM": true, "OS": true}, claims.FlavorParts)
+		assert.Equal(t, "US", claims.AssetTags["Country"])
+	}
+}
+
+func TestVerifyTrustReportTokenFailures(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	cert := newSelfSignedCert(t, key)
+	issuer, err := NewIssuer(IssuerConfiguration{PrivateKey: key, Certificate: cert, IssuerName: "HVS"})
+	assert.NoError(t, err)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherCert := newSelfSignedCert(t, otherKey)
+
+	token, err := issuer.Sign(newClaims())
+	assert.NoError(t, err)
+
+	// the token is not signed by the key of the certificate
+	_, err = VerifyTrustReportToken(token, []x509.Certificate{*otherCert}, []x509.Certificate{*otherCert})
+	assert.Error(t, err)
+
+	// the signing certificate is not trusted
+	_, err = VerifyTrustReportToken(token, []x509.Certificate{*cert}, []x509.Certificate{*otherCert})
+	assert.Error(t, err)
+
+	// the claims of the token are tampered with
+	parts := strings.Split(token, ".")
+	tamperedClaims := newClaims()
+	tamperedClaims.Trusted = false
+	tamperedToken, err := jwt.NewWithClaims(jwt.SigningMethodES256, tamperedClaims).SigningString()
+	assert.NoError(t, err)
+	_, err = VerifyTrustReportToken(strings.Split(tamperedToken, ".")[0]+"."+strings.Split(tamperedToken, ".")[1]+"."+parts[2],
+		[]x509.Certificate{*cert}, []x509.Certificate{*cert})
+	assert.Error(t, err)
+
+	// the report of the token has expired
+	expiredClaims := newClaims()
+	expiredClaims.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	token, err = issuer.Sign(expiredClaims)
+	assert.NoError(t, err)
+	_, err = VerifyTrustReportToken(token, []x509.Certificate{*cert}, []x509.Certificate{*cert})
+	assert.Error(t, err)
+}
+
+func TestNewIssuerKeyMismatch(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	_, err = NewIssuer(IssuerConfiguration{PrivateKey: key, Certificate: newSelfSignedCert(t, otherKey), IssuerName: "HVS"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package eat
+
+import (
+	"crypto"
+	"crypto/x509"
+	"time"
+
+	jwt "github.com/Waterdrips/jwt-go"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/common/crypt"
+	"github.com/pkg/errors"
+)
+
+// VerifyTrustReportToken verifies the certificate chain of the signing certificate, the signature and the validity
+// window of a trust report token and returns its claims. signingCerts is the certificate chain of the key signing the
+// token, the signing certificate first, and caCerts are the trusted root CA certificates.
+func VerifyTrustReportToken(token string, signingCerts []x509.Certificate, caCerts []x509.Certificate) (*TrustReportClaims, error) {
+	log.Trace("eat/verifier:VerifyTrustReportToken() Entering")
+	defer log.Trace("eat/verifier:VerifyTrustReportToken() Leaving")
+
+	if len(signingCerts) == 0 {
+		return nil, errors.New("eat/verifier:VerifyTrustReportToken() No signing certificate provided")
+	}
+	signingCert := signingCerts[0]
+	verifyRootCAOpts := x509.VerifyOptions{
+		Roots:         crypt.GetCertPool(caCerts),
+		Intermediates: crypt.GetCertPool(signingCerts[1:]),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		CurrentTime:   time.Now(),
+	}
+	if _, err := signingCert.Verify(verifyRootCAOpts); err != nil {
+		return nil, errors.Wrap(err, "eat/verifier:VerifyTrustReportToken() Error verifying the certificate chain of the signing certificate")
+	}
+	keyId, err := crypt.GetCertHashInHex(&signingCert, crypto.SHA1)
+	if err != nil {
+		return nil, errors.Wrap(err, "eat/verifier:VerifyTrustReportToken() Error computing the key ID of the signing certificate")
+	}
+
+	var claims TrustReportClaims
+	parser := jwt.Parser{ValidMethods: validSigningMethods}
+	_, err = parser.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		if kid, ok := token.Header["kid"].(string); !ok || kid != keyId {
+			return nil, errors.Errorf("kid (key id) of the token does not match the signing certificate: %v", token.Header["kid"])
+		}
+		return signingCert.PublicKey, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "eat/verifier:VerifyTrustReportToken() Error verifying the trust report token")
+	}
+	// the tokens are always issued with a validity window
+	if claims.ExpiresAt == 0 || claims.NotBefore == 0 {
+		return nil, errors.New("eat/verifier:VerifyTrustReportToken() The trust report token has no validity window")
+	}
+	return &claims, nil
+}
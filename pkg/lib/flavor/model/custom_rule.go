@@ -0,0 +1,12 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+// CustomRule attaches a verification rule from the verifier's custom rule registry to a flavor.
+// The parameters are handed to the rule when the verifier creates it, e.g. {"min_version": "1.2.3"}.
+type CustomRule struct {
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
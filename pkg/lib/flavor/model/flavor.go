@@ -30,6 +30,10 @@ type Flavor struct {
 	// External section is unique to AssetTag Flavor type
 	External *External `json:"external,omitempty"`
 	Software *Software `json:"software,omitempty"`
+	// Ima section holds the allowlist of the files measured by the IMA subsystem of the host
+	Ima *Ima `json:"ima,omitempty"`
+	// CustomRules are additional rules from the custom rule registry that are applied when verifying the flavor
+	CustomRules []CustomRule `json:"custom_rules,omitempty"`
 }
 
 // NewFlavor returns a new instance of Flavor
@@ -47,7 +51,7 @@ func NewFlavor(meta *Meta, bios *Bios, hardware *Hardware, pcrs []types.FlavorPc
 
 // GetFlavorDigest Calculates the SHA384 hash of the Flavor's json data for use when
 // signing/verifying signed flavors.
-func (flavor *Flavor) getFlavorDigest() ([]byte, error) {
+func (flavor *Flavor) GetFlavorDigest() ([]byte, error) {
 	// account for a differences in properties set at runtime
 	tempFlavor := *flavor
 	tempFlavor.Meta.ID = uuid.Nil
@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// imaDigestSizes are the sizes of the digests of the algorithms the IMA subsystem measures the files with
+var imaDigestSizes = map[string]int{
+	"md5":    16,
+	"sha1":   20,
+	"sha256": 32,
+	"sha384": 48,
+	"sha512": 64,
+	"sm3":    32,
+}
+
+// Ima holds the allowlist of the files the Linux IMA subsystem may measure on the host
+type Ima struct {
+	// Allowlist maps the path of the files to the digests they may be measured with. The digests are hex encoded and
+	// prefixed with their algorithm, as in the IMA log (e.g. sha256:...).
+	Allowlist map[string][]string `json:"allowlist"`
+}
+
+// Validate returns an error when the allowlist is empty, lists a file without digests or holds a digest that is not
+// a hex encoded digest of an algorithm supported by the IMA subsystem
+func (ima *Ima) Validate() error {
+	if ima == nil || len(ima.Allowlist) == 0 {
+		return errors.New("The IMA allowlist cannot be empty")
+	}
+	for path, digests := range ima.Allowlist {
+		if path == "" {
+			return errors.New("The IMA allowlist cannot hold an empty file path")
+		}
+		if len(digests) == 0 {
+			return errors.Errorf("The IMA allowlist holds no digest for '%s'", path)
+		}
+		for _, digest := range digests {
+			separator := strings.Index(digest, ":")
+			if separator == -1 {
+				return errors.Errorf("The digest '%s' of '%s' is not prefixed with its algorithm", digest, path)
+			}
+			size, ok := imaDigestSizes[strings.ToLower(digest[:separator])]
+			if !ok {
+				return errors.Errorf("The algorithm of the digest '%s' of '%s' is not supported", digest, path)
+			}
+			if value, err := hex.DecodeString(digest[separator+1:]); err != nil || len(value) != size {
+				return errors.Errorf("The digest '%s' of '%s' is invalid", digest, path)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImaValidate(t *testing.T) {
+	ima := Ima{Allowlist: map[string][]string{
+		"boot_aggregate": {"sha1:" + strings.Repeat("a1", 20), "SHA256:" + strings.Repeat("A1", 32)},
+		"/usr/bin/bash":  {"sha512:" + strings.Repeat("b2", 64)},
+	}}
+	assert.NoError(t, ima.Validate())
+
+	for _, allowlist := range []map[string][]string{
+		nil,
+		{"/usr/bin/bash": nil},
+		{"": {"sha256:" + strings.Repeat("b2", 32)}},
+		{"/usr/bin/bash": {strings.Repeat("b2", 32)}},
+		{"/usr/bin/bash": {"sha3:" + strings.Repeat("b2", 32)}},
+		{"/usr/bin/bash": {"sha256:" + strings.Repeat("b2", 20)}},
+		{"/usr/bin/bash": {"sha256:" + strings.Repeat("zz", 32)}},
+	} {
+		assert.Error(t, (&Ima{Allowlist: allowlist}).Validate())
+	}
+	assert.Error(t, (*Ima)(nil).Validate())
+}
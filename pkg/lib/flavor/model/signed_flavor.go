@@ -35,7 +35,7 @@ func NewSignedFlavor(flavor *Flavor, privateKey *rsa.PrivateKey) (*SignedFlavor,
 		return nil, errors.New("Valid private key must be provided and cannot be nil")
 	}
 
-	flavorDigest, err := flavor.getFlavorDigest()
+	flavorDigest, err := flavor.GetFlavorDigest()
 	if err != nil {
 		return nil, errors.Wrap(err, "An error occurred while creating the signed flavor")
 	}
@@ -66,7 +66,7 @@ func (signedFlavor *SignedFlavor) Verify(publicKey *rsa.PublicKey) error {
 		return errors.Wrap(err, "Could not verify the signed flavor: An error occurred attempting to decode the signed flavor's signature")
 	}
 
-	flavorDigest, err := signedFlavor.Flavor.getFlavorDigest()
+	flavorDigest, err := signedFlavor.Flavor.GetFlavorDigest()
 	if err != nil {
 		return errors.Wrap(err, "Could not verify the signed flavor: An error occurred collecting the flavor digest")
 	}
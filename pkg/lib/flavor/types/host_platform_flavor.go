@@ -145,6 +145,7 @@ func (pf HostPlatformFlavor) getPlatformFlavor() ([]cm.Flavor, error) {
 
 	// Assemble the Platform Flavor
 	platformFlavor := cm.NewFlavor(newMeta, newBios, newHW, allPcrDetails, nil, nil)
+	platformFlavor.CustomRules = pfutil.GetCustomRules(cf.FlavorPartPlatform, pf.FlavorTemplates)
 
 	log.Debugf("flavor/types/host_platform_flavor:getPlatformFlavor()  New PlatformFlavor: %v", platformFlavor)
 
@@ -184,6 +185,7 @@ func (pf HostPlatformFlavor) getOsFlavor() ([]cm.Flavor, error) {
 
 	// Assemble the OS Flavor
 	osFlavor := cm.NewFlavor(newMeta, newBios, nil, allPcrDetails, nil, nil)
+	osFlavor.CustomRules = pfutil.GetCustomRules(cf.FlavorPartOs, pf.FlavorTemplates)
 
 	log.Debugf("flavor/types/host_platform_flavor:getOSFlavor()  New OS Flavor: %v", osFlavor)
 
@@ -226,6 +228,7 @@ func (pf HostPlatformFlavor) getHostUniqueFlavor() ([]cm.Flavor, error) {
 
 	// Assemble the Host Unique Flavor
 	hostUniqueFlavor := cm.NewFlavor(newMeta, newBios, nil, allPcrDetails, nil, nil)
+	hostUniqueFlavor.CustomRules = pfutil.GetCustomRules(cf.FlavorPartHostUnique, pf.FlavorTemplates)
 
 	log.Debugf("flavor/types/host_platform_flavor:getHostUniqueFlavor() New Host unique flavor: %v", hostUniqueFlavor)
 
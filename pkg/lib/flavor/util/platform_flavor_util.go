@@ -7,6 +7,7 @@ package util
 import (
 	"crypto/rsa"
 	"encoding/xml"
+	"reflect"
 	"strings"
 	"time"
 
@@ -222,6 +223,12 @@ func (pfutil PlatformFlavorUtil) GetHardwareSectionDetails(hostManifest *hcTypes
 	if hostManifest.PcrManifest.Sha256Pcrs != nil {
 		tpm.Meta.PCRBanks = append(tpm.Meta.PCRBanks, string(hcTypes.SHA256))
 	}
+	if len(hostManifest.PcrManifest.Sha384Pcrs) > 0 {
+		tpm.Meta.PCRBanks = append(tpm.Meta.PCRBanks, string(hcTypes.SHA384))
+	}
+	if len(hostManifest.PcrManifest.Sha512Pcrs) > 0 {
+		tpm.Meta.PCRBanks = append(tpm.Meta.PCRBanks, string(hcTypes.SHA512))
+	}
 	feature.TPM = tpm
 
 	txt := fm.HardwareFeature{}
@@ -269,7 +276,7 @@ func (pfutil PlatformFlavorUtil) GetPcrDetails(pcrManifest hcTypes.PcrManifest,
 
 	var pcrCollection []hcTypes.FlavorPcrs
 
-	// pull out the logs for the required PCRs from both banks
+	// pull out the logs for the required PCRs from the bank requested by the template rule
 	for pcr, rules := range pcrList {
 		pI := hcTypes.PcrIndex(pcr.Index)
 		var pcrInfo *hcTypes.HostManifestPcrs
@@ -293,6 +300,8 @@ func (pfutil PlatformFlavorUtil) GetPcrDetails(pcrManifest hcTypes.PcrManifest,
 
 				// Convert EventLog to flavor format
 				for _, manifestEventLog := range manifestPcrEventLogs {
+					// the event data is verified against the host manifest only, the flavor holds the event digests
+					manifestEventLog.EventData = nil
 					if len(manifestEventLog.Tags) == 0 {
 						if rules.PcrEquals.IsPcrEquals {
 							eventLogEqualEvents = append(eventLogEqualEvents, manifestEventLog)
@@ -474,6 +483,47 @@ func (pfutil PlatformFlavorUtil) GetPcrRulesMap(flavorPart cf.FlavorPart, flavor
 	return pcrRulesForFlavorPart, nil
 }
 
+// GetCustomRules Helper function to collect the custom rules of the flavor part specified from all the
+// flavor templates. Identical rules defined by more than one template are only added once.
+func (pfutil PlatformFlavorUtil) GetCustomRules(flavorPart cf.FlavorPart, flavorTemplates []hvs.FlavorTemplate) []hvs.CustomRule {
+	log.Trace("flavor/util/platform_flavor_util:GetCustomRules() Entering")
+	defer log.Trace("flavor/util/platform_flavor_util:GetCustomRules() Leaving")
+
+	var customRules []hvs.CustomRule
+	for _, flavorTemplate := range flavorTemplates {
+		if flavorTemplate.FlavorParts == nil {
+			continue
+		}
+		var templateFlavorPart *hvs.FlavorPart
+		switch flavorPart {
+		case cf.FlavorPartPlatform:
+			templateFlavorPart = flavorTemplate.FlavorParts.Platform
+		case cf.FlavorPartOs:
+			templateFlavorPart = flavorTemplate.FlavorParts.OS
+		case cf.FlavorPartHostUnique:
+			templateFlavorPart = flavorTemplate.FlavorParts.HostUnique
+		}
+		if templateFlavorPart == nil {
+			continue
+		}
+
+		for _, customRule := range templateFlavorPart.CustomRules {
+			duplicate := false
+			for _, existingRule := range customRules {
+				if reflect.DeepEqual(existingRule, customRule) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				customRules = append(customRules, customRule)
+			}
+		}
+	}
+
+	return customRules
+}
+
 func getPcrRulesForFlavorPart(flavorPart *hvs.FlavorPart, pcrList map[hvs.PCR]hvs.PcrListRules) (map[hvs.PCR]hvs.PcrListRules, error) {
 	log.Trace("flavor/util/platform_flavor_util:getPcrRulesForFlavorPart() Entering")
 	defer log.Trace("flavor/util/platform_flavor_util:getPcrRulesForFlavorPart() Leaving")
@@ -17,10 +17,11 @@ const (
 	VendorIntel
 	VendorVMware
 	VendorMicrosoft
+	VendorSimulator
 )
 
 func (vendor Vendor) String() string {
-	return [...]string{"UNKNOWN", "INTEL", "VMWARE", "MICROSOFT"}[vendor]
+	return [...]string{"UNKNOWN", "INTEL", "VMWARE", "MICROSOFT", "SIMULATOR"}[vendor]
 }
 
 func (vendor *Vendor) GetVendorFromOSName(osName string) error {
@@ -55,6 +56,8 @@ func (vendor *Vendor) UnmarshalJSON(b []byte) error {
 		*vendor = VendorVMware
 	case "INTEL":
 		*vendor = VendorIntel
+	case "SIMULATOR":
+		*vendor = VendorSimulator
 	default:
 		*vendor = VendorUnknown
 		err = errors.Errorf("Provided vendor is not supported. Vendor : '%s'", jsonValue)
@@ -24,10 +24,13 @@ type HostConnectorFactory struct {
 	aasApiUrl      string
 	trustedCaCerts []x509.Certificate
 	natsServers    []string
+	// simulatorEnabled allows the simulated hosts, whose connector reads and writes the host profile file of the
+	// connection string. It is meant for testing only.
+	simulatorEnabled bool
 }
 
-func NewHostConnectorFactory(aasApiUrl string, trustedCaCerts []x509.Certificate, natsServers []string) *HostConnectorFactory {
-	return &HostConnectorFactory{aasApiUrl, trustedCaCerts, natsServers}
+func NewHostConnectorFactory(aasApiUrl string, trustedCaCerts []x509.Certificate, natsServers []string, simulatorEnabled bool) *HostConnectorFactory {
+	return &HostConnectorFactory{aasApiUrl, trustedCaCerts, natsServers, simulatorEnabled}
 }
 
 func (htcFactory *HostConnectorFactory) NewHostConnector(connectionString string) (HostConnector, error) {
@@ -47,6 +50,13 @@ func (htcFactory *HostConnectorFactory) NewHostConnector(connectionString string
 	case constants.VendorVMware:
 		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is VMWARE")
 		connectorFactory = &VmwareConnectorFactory{}
+	case constants.VendorSimulator:
+		if !htcFactory.simulatorEnabled {
+			secLog.Error("host_connector/host_connector_factory:NewHostConnector() Simulated hosts are not enabled")
+			return nil, errors.New("host_connector_factory:NewHostConnector() Simulated hosts are not enabled")
+		}
+		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is SIMULATOR")
+		connectorFactory = &SimulatorConnectorFactory{}
 	default:
 		return nil, errors.New("host_connector_factory:NewHostConnector() Vendor not supported yet: " + vendorConnector.Vendor.String())
 	}
@@ -65,8 +65,8 @@ func (ic *IntelConnector) GetHostManifestAcceptNonce(nonce string, pcrList []int
 		pcrList = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}
 	}
 
-	//always request sha1/sha256 PCR banks from TA
-	pcrBankList = []string{"SHA1", "SHA256"}
+	//always request all the PCR banks supported by HVS from TA, the quote only includes the banks enabled in the TPM
+	pcrBankList = []string{"SHA1", "SHA256", "SHA384", "SHA512"}
 
 	//check if AIK Certificate is present on host before getting host manifest
 	aikInDER, err := ic.client.GetAIK()
@@ -185,6 +185,9 @@ func (ic *IntelConnector) GetHostManifestAcceptNonce(nonce string, pcrList []int
 	hostManifest.BindingKeyCertificate = bindingKeyCertificateBase64
 	hostManifest.MeasurementXmls = tpmQuoteResponse.TcbMeasurements.TcbMeasurements
 	hostManifest.QuoteDigest = hex.EncodeToString(pcrsDigest) + hostManifest.AssetTagDigest
+	if tpmQuoteResponse.ImaLog != "" {
+		hostManifest.ImaLog = &types.ImaLog{Ascii: tpmQuoteResponse.ImaLog}
+	}
 
 	hostManifestJson, err := json.Marshal(hostManifest)
 	if err != nil {
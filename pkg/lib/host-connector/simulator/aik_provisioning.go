@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/tpm2utils"
+	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+const tpmVersion = "2.0"
+
+// PrivacyCAClient is the part of the HVS client used to certify the AIK, it is implemented by
+// hvsclient.PrivacyCAClient
+type PrivacyCAClient interface {
+	DownloadPrivacyCa() ([]byte, error)
+	GetIdentityProofRequest(identityChallengeRequest *taModel.IdentityChallengePayload) (*taModel.IdentityProofRequest, error)
+	GetIdentityProofResponse(identityChallengeResponse *taModel.IdentityChallengePayload) (*taModel.IdentityProofRequest, error)
+}
+
+// newProvisionedTpm returns the simulated TPM of the host profile, whose AIK must be provisioned
+func newProvisionedTpm(profile *HostProfile) (*simulatedTpm, error) {
+	if profile.Tpm.AikKey == "" || profile.Tpm.AikCertificate == "" {
+		return nil, errors.New("The AIK of the simulated TPM is not provisioned")
+	}
+	aikKey, err := decodeRsaPrivateKey(profile.Tpm.AikKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid AIK")
+	}
+	return &simulatedTpm{profile: profile, aikKey: aikKey}, nil
+}
+
+// ProvisionAik generates the AIK of the simulated TPM and gets it certified by the privacy CA of the HVS, the same way
+// the Trust Agent provisions the AIK of a TPM: the identity request is sent with the EK certificate, the challenge of
+// the privacy CA is activated with the EK and the AIK certificate is decrypted from the response to the challenge. The
+// endorsement key must have been generated and be trusted by the HVS.
+func (profile *HostProfile) ProvisionAik(privacyCAClient PrivacyCAClient) error {
+	log.Trace("simulator/aik_provisioning:ProvisionAik() Entering")
+	defer log.Trace("simulator/aik_provisioning:ProvisionAik() Leaving")
+
+	if profile.Tpm.EkKey == "" || profile.Tpm.EkCertificate == "" {
+		return errors.New("simulator/aik_provisioning:ProvisionAik() The endorsement key of the simulated TPM is not generated")
+	}
+	ekKey, err := decodeRsaPrivateKey(profile.Tpm.EkKey)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Invalid endorsement key")
+	}
+	ekCertificate, err := decodeCertificate(profile.Tpm.EkCertificate)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Invalid endorsement certificate")
+	}
+
+	privacyCaBytes, err := privacyCAClient.DownloadPrivacyCa()
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error downloading the privacy CA certificate")
+	}
+	if block, _ := pem.Decode(privacyCaBytes); block != nil {
+		privacyCaBytes = block.Bytes
+	}
+	privacyCa, err := x509.ParseCertificate(privacyCaBytes)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error parsing the privacy CA certificate")
+	}
+	privacyCaKey, ok := privacyCa.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("simulator/aik_provisioning:ProvisionAik() The privacy CA key is not an RSA key")
+	}
+
+	aikKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error generating AIK")
+	}
+	tpm := simulatedTpm{profile: profile, aikKey: aikKey}
+	identityRequest := taModel.IdentityRequest{
+		TpmVersion: tpmVersion,
+		AikModulus: aikKey.PublicKey.N.Bytes(),
+		AikName:    tpm.getAikName(),
+	}
+	pca, err := privacyca.NewPrivacyCA(identityRequest)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error creating privacy CA")
+	}
+
+	// the privacy CA encrypts a challenge for the EK and the AIK
+	identityChallengeRequest, err := pca.GetIdentityChallengeRequest(ekCertificate.Raw, privacyCaKey, identityRequest)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error creating identity challenge request")
+	}
+	identityProofRequest, err := privacyCAClient.GetIdentityProofRequest(&identityChallengeRequest)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error getting identity challenge")
+	}
+	challenge, err := tpm.decryptIdentityProofRequest(ekKey, identityProofRequest)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error activating identity challenge")
+	}
+
+	// the decrypted challenge proves the AIK is resident in the TPM of the EK, the privacy CA encrypts the AIK
+	// certificate in response
+	identityChallengeResponse, err := pca.GetIdentityChallengeRequest(challenge, privacyCaKey, identityRequest)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error creating identity challenge response")
+	}
+	identityProofResponse, err := privacyCAClient.GetIdentityProofResponse(&identityChallengeResponse)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error submitting identity challenge response")
+	}
+	aikCertificate, err := tpm.decryptIdentityProofRequest(ekKey, identityProofResponse)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error activating AIK certificate")
+	}
+	if _, err = x509.ParseCertificate(aikCertificate); err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Invalid AIK certificate returned by the privacy CA")
+	}
+
+	profile.Tpm.AikKey, err = encodePrivateKey(aikKey)
+	if err != nil {
+		return errors.Wrap(err, "simulator/aik_provisioning:ProvisionAik() Error encoding AIK")
+	}
+	profile.Tpm.AikCertificate = string(pem.EncodeToMemory(&pem.Block{Type: pemTypeCert, Bytes: aikCertificate}))
+	return nil
+}
+
+// decryptIdentityProofRequest activates the credential of the identity proof request and decrypts its symmetric blob
+// with the credential
+func (tpm *simulatedTpm) decryptIdentityProofRequest(ekKey *rsa.PrivateKey, proofRequest *taModel.IdentityProofRequest) ([]byte, error) {
+	if proofRequest == nil {
+		return nil, errors.New("The identity proof request is empty")
+	}
+	key, err := tpm.activateCredential(ekKey, proofRequest.Credential, proofRequest.Secret)
+	if err != nil {
+		return nil, err
+	}
+	return tpm2utils.DecryptSym(proofRequest.SymmetricBlob, key, proofRequest.TpmSymmetricKeyParams.IV, "CBC", consts.TPM_ALG_AES)
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+const (
+	rsaKeyBits      = 2048
+	ekCommonName    = "Simulated TPM Endorsement Key"
+	ekValidityYears = 10
+	pemTypePrivKey  = "PRIVATE KEY"
+	pemTypeCert     = "CERTIFICATE"
+	profileFileMode = 0600
+)
+
+// defaultPcrBanks are the PCR banks enabled in the simulated TPM when the profile does not list them
+var defaultPcrBanks = []types.SHAAlgorithm{types.SHA1, types.SHA256}
+
+// HostProfile declares the state of a simulated host: the host information, the PCR values and event log of its
+// TPM, its asset tag and the keys of its TPM. It is stored as a JSON file which is referred to by the connection
+// string of the host, e.g. 'simulator:file:///var/lib/simulator/host1.json'.
+type HostProfile struct {
+	HostInfo taModel.HostInfo `json:"host_info"`
+	// Pcrs holds the values of the PCRs by PCR bank and PCR index. The PCRs that are not listed are replayed from
+	// the event log and the IMA log, or are zero when there are no events for them
+	Pcrs     map[types.SHAAlgorithm]map[int]string `json:"pcrs,omitempty"`
+	EventLog []types.MeasureLog                    `json:"event_log,omitempty"`
+	// ImaLog is the IMA runtime measurement list of the host in the format of the ascii_runtime_measurements file
+	ImaLog string `json:"ima_log,omitempty"`
+	// AssetTag is the base64 encoded asset tag deployed to the TPM, the host is not tag provisioned when it is empty
+	AssetTag              string     `json:"asset_tag,omitempty"`
+	TcbMeasurements       []string   `json:"tcb_measurements,omitempty"`
+	BindingKeyCertificate string     `json:"binding_key_certificate,omitempty"`
+	Tpm                   TpmProfile `json:"tpm"`
+}
+
+// TpmProfile holds the PCR banks enabled in the simulated TPM and its keys and certificates in PEM format
+type TpmProfile struct {
+	PcrBanks       []types.SHAAlgorithm `json:"pcr_banks,omitempty"`
+	EkKey          string               `json:"ek_key,omitempty"`
+	EkCertificate  string               `json:"ek_certificate,omitempty"`
+	AikKey         string               `json:"aik_key,omitempty"`
+	AikCertificate string               `json:"aik_certificate,omitempty"`
+}
+
+// LoadHostProfile reads the host profile from the JSON file at path
+func LoadHostProfile(path string) (*HostProfile, error) {
+	log.Trace("simulator/host_profile:LoadHostProfile() Entering")
+	defer log.Trace("simulator/host_profile:LoadHostProfile() Leaving")
+
+	profileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "simulator/host_profile:LoadHostProfile() Error reading host profile %s", path)
+	}
+	var profile HostProfile
+	if err = json.Unmarshal(profileBytes, &profile); err != nil {
+		return nil, errors.Wrapf(err, "simulator/host_profile:LoadHostProfile() Error unmarshalling host profile %s", path)
+	}
+	return &profile, nil
+}
+
+// Save writes the host profile as a JSON file at path
+func (profile *HostProfile) Save(path string) error {
+	log.Trace("simulator/host_profile:Save() Entering")
+	defer log.Trace("simulator/host_profile:Save() Leaving")
+
+	profileBytes, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "simulator/host_profile:Save() Error marshalling host profile")
+	}
+	if err = ioutil.WriteFile(path, profileBytes, profileFileMode); err != nil {
+		return errors.Wrapf(err, "simulator/host_profile:Save() Error writing host profile %s", path)
+	}
+	return nil
+}
+
+// GenerateEndorsementKey generates the endorsement key of the simulated TPM and its certificate. The certificate is
+// issued by the endorsement CA when provided, it is self signed otherwise and must be registered in the HVS with
+// POST /tpm-endorsements before the AIK can be provisioned.
+func (profile *HostProfile) GenerateEndorsementKey(caCertificate *x509.Certificate, caKey crypto.PrivateKey) error {
+	log.Trace("simulator/host_profile:GenerateEndorsementKey() Entering")
+	defer log.Trace("simulator/host_profile:GenerateEndorsementKey() Leaving")
+
+	ekKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return errors.Wrap(err, "simulator/host_profile:GenerateEndorsementKey() Error generating endorsement key")
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return errors.Wrap(err, "simulator/host_profile:GenerateEndorsementKey() Error generating serial number")
+	}
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: ekCommonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(ekValidityYears, 0, 0),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+	issuer := &template
+	var signer crypto.PrivateKey = ekKey
+	if caCertificate != nil {
+		issuer = caCertificate
+		signer = caKey
+	}
+	ekCertificate, err := x509.CreateCertificate(rand.Reader, &template, issuer, &ekKey.PublicKey, signer)
+	if err != nil {
+		return errors.Wrap(err, "simulator/host_profile:GenerateEndorsementKey() Error creating endorsement certificate")
+	}
+
+	profile.Tpm.EkKey, err = encodePrivateKey(ekKey)
+	if err != nil {
+		return errors.Wrap(err, "simulator/host_profile:GenerateEndorsementKey() Error encoding endorsement key")
+	}
+	profile.Tpm.EkCertificate = string(pem.EncodeToMemory(&pem.Block{Type: pemTypeCert, Bytes: ekCertificate}))
+	return nil
+}
+
+// getPcrBanks returns the PCR banks enabled in the simulated TPM
+func (profile *HostProfile) getPcrBanks() []types.SHAAlgorithm {
+	if len(profile.Tpm.PcrBanks) == 0 {
+		return defaultPcrBanks
+	}
+	return profile.Tpm.PcrBanks
+}
+
+// getPcrValue returns the value of the PCR of the bank, the value is replayed from the event log, or from the IMA log
+// for the IMA PCR, when the profile does not declare it
+func (profile *HostProfile) getPcrValue(pcrBank types.SHAAlgorithm, pcrIndex int) ([]byte, error) {
+	if value, ok := profile.Pcrs[pcrBank][pcrIndex]; ok {
+		return hex.DecodeString(value)
+	}
+	if pcrIndex == int(types.ImaPcrIndex) && profile.ImaLog != "" {
+		events, err := (&types.ImaLog{Ascii: profile.ImaLog}).GetEvents()
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing the IMA log")
+		}
+		value, err := types.ReplayImaEvents(events, pcrBank, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error replaying the IMA log to PCR %d of bank %s", pcrIndex, pcrBank)
+		}
+		return hex.DecodeString(value)
+	}
+
+	eventLog := types.TpmEventLog{Pcr: types.Pcr{Index: pcrIndex, Bank: string(pcrBank)}}
+	for _, measureLog := range profile.EventLog {
+		if measureLog.Pcr.Index == pcrIndex && strings.EqualFold(measureLog.Pcr.Bank, string(pcrBank)) {
+			eventLog.TpmEvent = append(eventLog.TpmEvent, measureLog.TpmEvents...)
+		}
+	}
+	value, err := eventLog.Replay()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error replaying the event log of PCR %d of bank %s", pcrIndex, pcrBank)
+	}
+	return hex.DecodeString(value)
+}
+
+// getEventLog returns the event log of the profile in the format of the quote responses of the Trust Agent
+func (profile *HostProfile) getEventLog() (string, error) {
+	eventLog := profile.EventLog
+	if eventLog == nil {
+		eventLog = []types.MeasureLog{}
+	}
+	eventLogBytes, err := json.Marshal(eventLog)
+	if err != nil {
+		return "", err
+	}
+	return string(eventLogBytes), nil
+}
+
+func encodePrivateKey(key crypto.PrivateKey) (string, error) {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePrivKey, Bytes: keyBytes})), nil
+}
+
+func decodeRsaPrivateKey(keyPem string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPem))
+	if block == nil {
+		return nil, errors.New("Could not decode the PEM private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not parse the private key")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("The private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+func decodeCertificate(certificatePem string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certificatePem))
+	if block == nil {
+		return nil, errors.New("Could not decode the PEM certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,233 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/util"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/tpm2utils"
+	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPrivacyCAClient certifies the AIKs like the privacy CA endpoints of the HVS
+type testPrivacyCAClient struct {
+	caCertificate *x509.Certificate
+	caKey         *rsa.PrivateKey
+	challenges    map[string]*x509.Certificate
+}
+
+func newTestPrivacyCAClient(t *testing.T) *testPrivacyCAClient {
+	caKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "HVS Privacy Certificate"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caCertificateBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	caCertificate, err := x509.ParseCertificate(caCertificateBytes)
+	assert.NoError(t, err)
+	return &testPrivacyCAClient{caCertificate: caCertificate, caKey: caKey, challenges: map[string]*x509.Certificate{}}
+}
+
+func (client *testPrivacyCAClient) DownloadPrivacyCa() ([]byte, error) {
+	return client.caCertificate.Raw, nil
+}
+
+func (client *testPrivacyCAClient) GetIdentityProofRequest(identityChallengeRequest *taModel.IdentityChallengePayload) (*taModel.IdentityProofRequest, error) {
+	pca, err := privacyca.NewPrivacyCA(identityChallengeRequest.IdentityRequest)
+	if err != nil {
+		return nil, err
+	}
+	ekCertificateBytes, err := pca.GetEkCert(*identityChallengeRequest, client.caKey)
+	if err != nil {
+		return nil, err
+	}
+	ekCertificate, err := x509.ParseCertificate(ekCertificateBytes)
+	if err != nil {
+		return nil, err
+	}
+	challenge := make([]byte, 32)
+	_, _ = rand.Read(challenge)
+	client.challenges[hex.EncodeToString(challenge)] = ekCertificate
+
+	proofRequest, err := pca.ProcessIdentityRequest(identityChallengeRequest.IdentityRequest, ekCertificate.PublicKey, challenge)
+	return &proofRequest, err
+}
+
+func (client *testPrivacyCAClient) GetIdentityProofResponse(identityChallengeResponse *taModel.IdentityChallengePayload) (*taModel.IdentityProofRequest, error) {
+	pca, err := privacyca.NewPrivacyCA(identityChallengeResponse.IdentityRequest)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := pca.GetEkCert(*identityChallengeResponse, client.caKey)
+	if err != nil {
+		return nil, err
+	}
+	ekCertificate, ok := client.challenges[hex.EncodeToString(challenge)]
+	if !ok {
+		return nil, errors.New("Invalid challenge response")
+	}
+
+	aikPublicKey, err := tpm2utils.GetAikPublicKey(identityChallengeResponse.IdentityRequest.AikModulus)
+	if err != nil {
+		return nil, err
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: client.caCertificate.Subject.CommonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	aikCertificate, err := x509.CreateCertificate(rand.Reader, &template, client.caCertificate, aikPublicKey, client.caKey)
+	if err != nil {
+		return nil, err
+	}
+	proofRequest, err := pca.ProcessIdentityRequest(identityChallengeResponse.IdentityRequest, ekCertificate.PublicKey, aikCertificate)
+	return &proofRequest, err
+}
+
+// newTestHostProfile returns a host profile with an event log for PCR 0 of the SHA256 bank and a declared PCR 7
+func newTestHostProfile() *HostProfile {
+	profile := HostProfile{
+		HostInfo: taModel.HostInfo{
+			OSName:       "RedHatEnterprise",
+			HostName:     "simulated-host",
+			HardwareUUID: "00b61da0-5ada-e811-906e-00163566263e",
+		},
+		Pcrs: map[types.SHAAlgorithm]map[int]string{
+			types.SHA256: {7: strings.Repeat("07", 32)},
+		},
+		EventLog: []types.MeasureLog{
+			{
+				Pcr: types.Pcr{Index: 0, Bank: string(types.SHA256)},
+				TpmEvents: []types.EventLog{
+					{TypeName: "EV_S_CRTM_VERSION", Measurement: strings.Repeat("a1", 32)},
+					{TypeName: "EV_POST_CODE", Measurement: strings.Repeat("b2", 32)},
+				},
+			},
+		},
+	}
+	return &profile
+}
+
+func TestProvisionAikAndQuote(t *testing.T) {
+	privacyCAClient := newTestPrivacyCAClient(t)
+	profile := newTestHostProfile()
+	assert.NoError(t, profile.GenerateEndorsementKey(nil, nil))
+	assert.NoError(t, profile.ProvisionAik(privacyCAClient))
+
+	aikCertificate, err := decodeCertificate(profile.Tpm.AikCertificate)
+	assert.NoError(t, err)
+	assert.NoError(t, aikCertificate.CheckSignatureFrom(privacyCAClient.caCertificate))
+
+	profileDir, err := ioutil.TempDir("", "simulator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(profileDir)
+	profilePath := filepath.Join(profileDir, "host.json")
+	assert.NoError(t, profile.Save(profilePath))
+
+	taClient, err := NewTAClient(profilePath)
+	assert.NoError(t, err)
+	nonce, err := util.GenerateNonce(20)
+	assert.NoError(t, err)
+	quoteResponse, err := taClient.GetTPMQuote(nonce, []int{0, 7, 10}, []string{"SHA1", "SHA256", "SHA384"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SHA1", "SHA256"}, quoteResponse.SelectedPcrBanks.SelectedPcrBanks)
+
+	nonceBytes, _ := base64.StdEncoding.DecodeString(nonce)
+	verificationNonce, err := util.GetVerificationNonce(nonceBytes, quoteResponse)
+	assert.NoError(t, err)
+	verificationNonceBytes, _ := base64.StdEncoding.DecodeString(verificationNonce)
+	quote, _ := base64.StdEncoding.DecodeString(quoteResponse.Quote)
+	pcrManifest, _, err := util.VerifyQuoteAndGetPCRManifest(quoteResponse.EventLog, verificationNonceBytes, quote, aikCertificate)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, len(pcrManifest.Sha1Pcrs))
+	assert.Equal(t, 3, len(pcrManifest.Sha256Pcrs))
+	pcr0, err := pcrManifest.GetPcrValue(types.SHA256, types.PCR0)
+	assert.NoError(t, err)
+	eventLog := pcrManifest.PcrEventLogMap.Sha256EventLogs[0]
+	replay, err := eventLog.Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, replay, pcr0.Value)
+	pcr7, err := pcrManifest.GetPcrValue(types.SHA256, types.PCR7)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("07", 32), pcr7.Value)
+
+	// the quote does not verify with another AIK
+	otherProfile := newTestHostProfile()
+	assert.NoError(t, otherProfile.GenerateEndorsementKey(nil, nil))
+	assert.NoError(t, otherProfile.ProvisionAik(privacyCAClient))
+	otherAikCertificate, err := decodeCertificate(otherProfile.Tpm.AikCertificate)
+	assert.NoError(t, err)
+	_, _, err = util.VerifyQuoteAndGetPCRManifest(quoteResponse.EventLog, verificationNonceBytes, quote, otherAikCertificate)
+	assert.Error(t, err)
+}
+
+func TestProvisionAikWithUntrustedEk(t *testing.T) {
+	profile := newTestHostProfile()
+	err := profile.ProvisionAik(newTestPrivacyCAClient(t))
+	assert.Error(t, err)
+
+	// the challenge is encrypted for another EK
+	assert.NoError(t, profile.GenerateEndorsementKey(nil, nil))
+	otherProfile := newTestHostProfile()
+	assert.NoError(t, otherProfile.GenerateEndorsementKey(nil, nil))
+	profile.Tpm.EkKey = otherProfile.Tpm.EkKey
+	err = profile.ProvisionAik(newTestPrivacyCAClient(t))
+	assert.Error(t, err)
+	assert.Empty(t, profile.Tpm.AikCertificate)
+}
+
+func TestTAClientDeployAssetTag(t *testing.T) {
+	profileDir, err := ioutil.TempDir("", "simulator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(profileDir)
+	profilePath := filepath.Join(profileDir, "host.json")
+	profile := newTestHostProfile()
+	assert.NoError(t, profile.Save(profilePath))
+
+	taClient, err := NewTAClient(profilePath)
+	assert.NoError(t, err)
+	_, err = taClient.GetAIK()
+	assert.Error(t, err)
+	_, err = taClient.GetTPMQuote("AAAA", []int{0}, []string{"SHA256"})
+	assert.Error(t, err)
+
+	tag := base64.StdEncoding.EncodeToString(make([]byte, 48))
+	assert.Error(t, taClient.DeployAssetTag("7a569dad-2d82-49e4-9156-069b0065b262", tag))
+	assert.NoError(t, taClient.DeployAssetTag(strings.ToUpper(profile.HostInfo.HardwareUUID), tag))
+
+	profile, err = LoadHostProfile(profilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, tag, profile.AssetTag)
+	hostInfo, err := taClient.GetHostInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, profile.HostInfo.HostName, hostInfo.HostName)
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	client "github.com/intel-secl/intel-secl/v4/pkg/clients/ta"
+	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+var log = commLog.GetDefaultLogger()
+
+// profileLock serializes the updates of the host profiles by the simulated Trust Agents
+var profileLock sync.Mutex
+
+// NewTAClient returns a Trust Agent client that answers the requests of the HVS from the host profile at profilePath.
+// The profile is read on every request, so that it can be changed while the host is registered in the HVS.
+func NewTAClient(profilePath string) (client.TAClient, error) {
+	if profilePath == "" {
+		return nil, errors.New("simulator/ta_client:NewTAClient() The host profile path cannot be empty")
+	}
+	return &taClient{profilePath: profilePath}, nil
+}
+
+type taClient struct {
+	profilePath string
+}
+
+func (tc *taClient) loadProfile() (*HostProfile, error) {
+	profileLock.Lock()
+	defer profileLock.Unlock()
+	return LoadHostProfile(tc.profilePath)
+}
+
+func (tc *taClient) GetHostInfo() (taModel.HostInfo, error) {
+	log.Trace("simulator/ta_client:GetHostInfo() Entering")
+	defer log.Trace("simulator/ta_client:GetHostInfo() Leaving")
+
+	profile, err := tc.loadProfile()
+	if err != nil {
+		return taModel.HostInfo{}, errors.Wrap(err, "simulator/ta_client:GetHostInfo() Error loading host profile")
+	}
+	return profile.HostInfo, nil
+}
+
+func (tc *taClient) GetTPMQuote(nonce string, pcrList []int, pcrBankList []string) (taModel.TpmQuoteResponse, error) {
+	log.Trace("simulator/ta_client:GetTPMQuote() Entering")
+	defer log.Trace("simulator/ta_client:GetTPMQuote() Leaving")
+
+	var quoteResponse taModel.TpmQuoteResponse
+	profile, err := tc.loadProfile()
+	if err != nil {
+		return quoteResponse, errors.Wrap(err, "simulator/ta_client:GetTPMQuote() Error loading host profile")
+	}
+	tpm, err := newProvisionedTpm(profile)
+	if err != nil {
+		return quoteResponse, errors.Wrap(err, "simulator/ta_client:GetTPMQuote() Error loading the simulated TPM")
+	}
+
+	nonceBytes, err := base64.StdEncoding.DecodeString(nonce)
+	if err != nil {
+		return quoteResponse, errors.Wrap(err, "simulator/ta_client:GetTPMQuote() Error decoding nonce")
+	}
+	// the Trust Agent extends the nonce with the asset tag deployed to the TPM
+	quoteResponse.IsTagProvisioned = profile.AssetTag != ""
+	quoteResponse.AssetTag = profile.AssetTag
+	verificationNonce, err := util.GetVerificationNonce(nonceBytes, quoteResponse)
+	if err != nil {
+		return quoteResponse, errors.Wrap(err, "simulator/ta_client:GetTPMQuote() Error extending nonce")
+	}
+	verificationNonceBytes, err := base64.StdEncoding.DecodeString(verificationNonce)
+	if err != nil {
+		return quoteResponse, errors.Wrap(err, "simulator/ta_client:GetTPMQuote() Error decoding verification nonce")
+	}
+
+	quote, err := tpm.getQuote(verificationNonceBytes, pcrList, pcrBankList)
+	if err != nil {
+		return quoteResponse, errors.Wrap(err, "simulator/ta_client:GetTPMQuote() Error creating TPM quote")
+	}
+	quoteResponse.EventLog, err = profile.getEventLog()
+	if err != nil {
+		return quoteResponse, errors.Wrap(err, "simulator/ta_client:GetTPMQuote() Error marshalling event log")
+	}
+
+	quoteResponse.TimeStamp = time.Now().UnixNano() / int64(time.Millisecond)
+	quoteResponse.Quote = base64.StdEncoding.EncodeToString(quote)
+	quoteResponse.Aik = base64.StdEncoding.EncodeToString([]byte(profile.Tpm.AikCertificate))
+	quoteResponse.TcbMeasurements.TcbMeasurements = profile.TcbMeasurements
+	quoteResponse.ImaLog = profile.ImaLog
+	for _, pcrBank := range profile.getPcrBanks() {
+		if containsPcrBank(pcrBankList, pcrBank) {
+			quoteResponse.SelectedPcrBanks.SelectedPcrBanks = append(quoteResponse.SelectedPcrBanks.SelectedPcrBanks, string(pcrBank))
+		}
+	}
+	return quoteResponse, nil
+}
+
+func (tc *taClient) GetAIK() ([]byte, error) {
+	log.Trace("simulator/ta_client:GetAIK() Entering")
+	defer log.Trace("simulator/ta_client:GetAIK() Leaving")
+
+	profile, err := tc.loadProfile()
+	if err != nil {
+		return nil, errors.Wrap(err, "simulator/ta_client:GetAIK() Error loading host profile")
+	}
+	block, _ := pem.Decode([]byte(profile.Tpm.AikCertificate))
+	if block == nil {
+		return nil, errors.New("simulator/ta_client:GetAIK() The AIK of the simulated TPM is not provisioned")
+	}
+	return block.Bytes, nil
+}
+
+func (tc *taClient) GetBindingKeyCertificate() ([]byte, error) {
+	log.Trace("simulator/ta_client:GetBindingKeyCertificate() Entering")
+	defer log.Trace("simulator/ta_client:GetBindingKeyCertificate() Leaving")
+
+	profile, err := tc.loadProfile()
+	if err != nil {
+		return nil, errors.Wrap(err, "simulator/ta_client:GetBindingKeyCertificate() Error loading host profile")
+	}
+	return []byte(profile.BindingKeyCertificate), nil
+}
+
+func (tc *taClient) DeployAssetTag(hardwareUUID, tag string) error {
+	log.Trace("simulator/ta_client:DeployAssetTag() Entering")
+	defer log.Trace("simulator/ta_client:DeployAssetTag() Leaving")
+
+	profileLock.Lock()
+	defer profileLock.Unlock()
+
+	profile, err := LoadHostProfile(tc.profilePath)
+	if err != nil {
+		return errors.Wrap(err, "simulator/ta_client:DeployAssetTag() Error loading host profile")
+	}
+	if !strings.EqualFold(profile.HostInfo.HardwareUUID, hardwareUUID) {
+		return errors.Errorf("simulator/ta_client:DeployAssetTag() The hardware UUID %s does not match the hardware UUID of the host", hardwareUUID)
+	}
+	if _, err = base64.StdEncoding.DecodeString(tag); err != nil {
+		return errors.Wrap(err, "simulator/ta_client:DeployAssetTag() The asset tag is not base64 encoded")
+	}
+	profile.AssetTag = tag
+	return profile.Save(tc.profilePath)
+}
+
+func (tc *taClient) DeploySoftwareManifest(manifest taModel.Manifest) error {
+	log.Trace("simulator/ta_client:DeploySoftwareManifest() Entering")
+	defer log.Trace("simulator/ta_client:DeploySoftwareManifest() Leaving")
+
+	// the measurements of the software manifests are declared in the host profile
+	log.Debugf("simulator/ta_client:DeploySoftwareManifest() Ignoring manifest %s deployed to simulated host", manifest.Label)
+	return nil
+}
+
+func (tc *taClient) GetMeasurementFromManifest(manifest taModel.Manifest) (taModel.Measurement, error) {
+	return taModel.Measurement{}, errors.New("simulator/ta_client:GetMeasurementFromManifest() Operation not supported by the simulated host")
+}
+
+func (tc *taClient) GetBaseURL() *url.URL {
+	return &url.URL{Scheme: "file", Path: tc.profilePath}
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	consts "github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/constants"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/tpm2utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	tpmStAttestQuote = 0x8018
+	tpmAlgRsa        = 0x0001
+	tpmAlgNull       = 0x0010
+	pcrSelectSize    = 3
+	maxPcrIndex      = 23
+
+	// the attributes of a restricted signing key created by the TPM: fixedTPM, fixedParent, sensitiveDataOrigin,
+	// userWithAuth, restricted and sign
+	aikObjectAttributes = 0x00050072
+)
+
+// tpmBankAlgorithms maps the PCR banks to their TPM algorithm ids
+var tpmBankAlgorithms = map[types.SHAAlgorithm]uint16{
+	types.SHA1:   consts.TPM_ALG_ID_SHA1,
+	types.SHA256: consts.TPM_ALG_ID_SHA256,
+	types.SHA384: consts.TPM_ALG_ID_SHA384,
+	types.SHA512: consts.TPM_ALG_ID_SHA512,
+}
+
+// simulatedTpm signs the quotes of the PCRs of a host profile with its AIK and activates the credentials of the
+// privacy CA with its EK
+type simulatedTpm struct {
+	profile *HostProfile
+	aikKey  *rsa.PrivateKey
+}
+
+// getAikName returns the TPM name of the AIK, the SHA256 digest of its TPMT_PUBLIC area prefixed by the algorithm id
+func (tpm *simulatedTpm) getAikName() []byte {
+	modulus := tpm.aikKey.PublicKey.N.Bytes()
+
+	tpmtPublic := new(bytes.Buffer)
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(tpmAlgRsa))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(consts.TPM_ALG_ID_SHA256))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint32(aikObjectAttributes))
+	// empty authPolicy
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(0))
+	// TPMS_RSA_PARMS: no symmetric algorithm, RSASSA with SHA256, key size and default exponent
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(tpmAlgNull))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(consts.TPM_ALG_RSASSA))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(consts.TPM_ALG_ID_SHA256))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(tpm.aikKey.PublicKey.N.BitLen()))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint32(0))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(len(modulus)))
+	tpmtPublic.Write(modulus)
+
+	digest := sha256.Sum256(tpmtPublic.Bytes())
+	name := make([]byte, 2, 2+len(digest))
+	binary.BigEndian.PutUint16(name, consts.TPM_ALG_ID_SHA256)
+	return append(name, digest[:]...)
+}
+
+// getQuote returns the quote of the PCRs of the banks enabled in the TPM in the format of the Trust Agent: the size of
+// the TPMS_ATTEST structure, the TPMS_ATTEST structure, its TPMT_SIGNATURE and the values of the quoted PCRs
+func (tpm *simulatedTpm) getQuote(nonce []byte, pcrList []int, pcrBankList []string) ([]byte, error) {
+	pcrSelections := new(bytes.Buffer)
+	var pcrValues []byte
+	bankCount := 0
+	for _, pcrBank := range tpm.profile.getPcrBanks() {
+		if !containsPcrBank(pcrBankList, pcrBank) {
+			continue
+		}
+		algorithm, ok := tpmBankAlgorithms[pcrBank]
+		if !ok {
+			return nil, errors.Errorf("Unsupported PCR bank %s", pcrBank)
+		}
+
+		pcrSelect := make([]byte, pcrSelectSize)
+		for _, pcrIndex := range pcrList {
+			if pcrIndex < 0 || pcrIndex > maxPcrIndex {
+				return nil, errors.Errorf("Invalid PCR index %d", pcrIndex)
+			}
+			pcrSelect[pcrIndex/8] |= 1 << uint(pcrIndex%8)
+		}
+		// the PCR values are ordered by PCR index in the quote
+		for pcrIndex := 0; pcrIndex <= maxPcrIndex; pcrIndex++ {
+			if pcrSelect[pcrIndex/8]&(1<<uint(pcrIndex%8)) == 0 {
+				continue
+			}
+			pcrValue, err := tpm.profile.getPcrValue(pcrBank, pcrIndex)
+			if err != nil {
+				return nil, err
+			}
+			pcrValues = append(pcrValues, pcrValue...)
+		}
+
+		_ = binary.Write(pcrSelections, binary.BigEndian, algorithm)
+		pcrSelections.WriteByte(pcrSelectSize)
+		pcrSelections.Write(pcrSelect)
+		bankCount++
+	}
+	if bankCount == 0 {
+		return nil, errors.New("None of the requested PCR banks is enabled in the TPM")
+	}
+	pcrDigest := sha256.Sum256(pcrValues)
+
+	clockInfo := make([]byte, 17)
+	_, err := rand.Read(clockInfo[:8])
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating the TPM clock")
+	}
+	// the clock is safe
+	clockInfo[16] = 1
+
+	aikName := tpm.getAikName()
+	attest := new(bytes.Buffer)
+	attest.Write(consts.Tpm2CertifiedKeyMagic[:])
+	_ = binary.Write(attest, binary.BigEndian, uint16(tpmStAttestQuote))
+	_ = binary.Write(attest, binary.BigEndian, uint16(len(aikName)))
+	attest.Write(aikName)
+	_ = binary.Write(attest, binary.BigEndian, uint16(len(nonce)))
+	attest.Write(nonce)
+	attest.Write(clockInfo)
+	// firmware version
+	_ = binary.Write(attest, binary.BigEndian, uint64(0))
+	_ = binary.Write(attest, binary.BigEndian, uint32(bankCount))
+	attest.Write(pcrSelections.Bytes())
+	_ = binary.Write(attest, binary.BigEndian, uint16(len(pcrDigest)))
+	attest.Write(pcrDigest[:])
+
+	attestDigest := sha256.Sum256(attest.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, tpm.aikKey, crypto.SHA256, attestDigest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "Error signing the quote with the AIK")
+	}
+
+	quote := new(bytes.Buffer)
+	_ = binary.Write(quote, binary.BigEndian, uint16(attest.Len()))
+	quote.Write(attest.Bytes())
+	_ = binary.Write(quote, binary.BigEndian, uint16(consts.TPM_ALG_RSASSA))
+	_ = binary.Write(quote, binary.BigEndian, uint16(consts.TPM_ALG_ID_SHA256))
+	_ = binary.Write(quote, binary.BigEndian, uint16(len(signature)))
+	quote.Write(signature)
+	quote.Write(pcrValues)
+	return quote.Bytes(), nil
+}
+
+// activateCredential recovers the credential the privacy CA encrypted for the AIK with MakeCredential, in the same way
+// the TPM2_ActivateCredential command does
+func (tpm *simulatedTpm) activateCredential(ekKey *rsa.PrivateKey, credentialBlob, secret []byte) ([]byte, error) {
+	encryptedSeed, err := readSizedBuffer(bytes.NewBuffer(secret))
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid credential secret")
+	}
+	label := append([]byte(consts.IDENTITY), 0)
+	seed, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, ekKey, encryptedSeed, label)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decrypting the credential secret with the EK")
+	}
+
+	// the credential blob holds its size, the integrity HMAC and the encrypted credential
+	blobBytes, err := readSizedBuffer(bytes.NewBuffer(credentialBlob))
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid credential blob")
+	}
+	blob := bytes.NewBuffer(blobBytes)
+	integrity, err := readSizedBuffer(blob)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid credential blob integrity")
+	}
+	encryptedCredential := blob.Bytes()
+
+	aikName := tpm.getAikName()
+	hmacKey, err := tpm2utils.KDFa(crypto.SHA256, seed, consts.INTEGRITY, nil, nil, sha256.Size*8)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error deriving the credential HMAC key")
+	}
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write(encryptedCredential)
+	mac.Write(aikName)
+	if !hmac.Equal(mac.Sum(nil), integrity) {
+		return nil, errors.New("The integrity of the credential blob could not be verified for the AIK")
+	}
+
+	symKey, err := tpm2utils.KDFa(crypto.SHA256, seed, consts.STORAGE, aikName, nil, consts.SymmetricKeyBits128)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error deriving the credential symmetric key")
+	}
+	iv := make([]byte, len(symKey))
+	credential, err := tpm2utils.DecryptSym(encryptedCredential, symKey, iv, "CBF", consts.TPM_ALG_AES)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decrypting the credential")
+	}
+	return readSizedBuffer(bytes.NewBuffer(credential))
+}
+
+// readSizedBuffer reads a buffer prefixed by its size
+func readSizedBuffer(buf *bytes.Buffer) ([]byte, error) {
+	var size uint16
+	if err := binary.Read(buf, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if int(size) > buf.Len() {
+		return nil, errors.Errorf("Buffer size %d exceeds the %d remaining bytes", size, buf.Len())
+	}
+	return buf.Next(int(size)), nil
+}
+
+func containsPcrBank(pcrBankList []string, pcrBank types.SHAAlgorithm) bool {
+	for _, bank := range pcrBankList {
+		if types.SHAAlgorithm(bank) == pcrBank {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/x509"
+	"net/url"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+// SimulatorConnectorFactory creates the connectors of the simulated hosts, whose TPM is simulated from the host
+// profile referred to by the connection string (e.g. simulator:file:///var/lib/simulator/host1.json). The quotes of
+// the simulated TPM are verified the same way as the quotes of the Trust Agent.
+type SimulatorConnectorFactory struct {
+}
+
+func (scf *SimulatorConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate) (HostConnector, error) {
+
+	log.Trace("simulator_host_connector_factory:GetHostConnector() Entering")
+	defer log.Trace("simulator_host_connector_factory:GetHostConnector() Leaving")
+
+	profileURL, err := url.Parse(vendorConnector.Url)
+	if err != nil || profileURL.Scheme != "file" {
+		return nil, errors.New("simulator_host_connector_factory:GetHostConnector() error retrieving host profile path")
+	}
+
+	taClient, err := simulator.NewTAClient(profileURL.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "simulator_host_connector_factory:GetHostConnector() Could not create simulated Trust Agent client")
+	}
+	return &IntelConnector{taClient}, nil
+}
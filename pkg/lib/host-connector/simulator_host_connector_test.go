@@ -0,0 +1,154 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSimulatedHostProfile writes a host profile with the sample host info and event log, and an AIK certified by
+// a test privacy CA
+func newSimulatedHostProfile(t *testing.T, profilePath string) *simulator.HostProfile {
+	var profile simulator.HostProfile
+	hostInfoJson, err := ioutil.ReadFile("./test/sample_platform_info.json")
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(hostInfoJson, &profile.HostInfo))
+	eventLogJson, err := ioutil.ReadFile("./test/sample_measure_log.json")
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(eventLogJson, &profile.EventLog))
+
+	aikKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "HVS Privacy Certificate"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	aikCertificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &aikKey.PublicKey, aikKey)
+	assert.NoError(t, err)
+	aikKeyBytes, err := x509.MarshalPKCS8PrivateKey(aikKey)
+	assert.NoError(t, err)
+	profile.Tpm.AikKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: aikKeyBytes}))
+	profile.Tpm.AikCertificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: aikCertificate}))
+
+	assert.NoError(t, profile.Save(profilePath))
+	return &profile
+}
+
+func TestSimulatorHostConnector(t *testing.T) {
+	profileDir, err := ioutil.TempDir("", "simulator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(profileDir)
+	profilePath := filepath.Join(profileDir, "host.json")
+	profile := newSimulatedHostProfile(t, profilePath)
+
+	// the simulated hosts are rejected unless they are enabled
+	_, err = NewHostConnectorFactory("", nil, nil, false).NewHostConnector("simulator:file://" + profilePath)
+	assert.Error(t, err)
+
+	htcFactory := NewHostConnectorFactory("", nil, nil, true)
+	hostConnector, err := htcFactory.NewHostConnector("simulator:file://" + profilePath + ";u=admin;p=password")
+	assert.NoError(t, err)
+
+	// the tag is extended to the nonce of the quotes once deployed
+	tag := base64.StdEncoding.EncodeToString(make([]byte, 48))
+	assert.NoError(t, hostConnector.DeployAssetTag(profile.HostInfo.HardwareUUID, tag))
+
+	hostManifest, err := hostConnector.GetHostManifest(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, profile.HostInfo.HardwareUUID, hostManifest.HostInfo.HardwareUUID)
+	assert.Equal(t, tag, hostManifest.AssetTagDigest)
+	assert.Equal(t, 24, len(hostManifest.PcrManifest.Sha1Pcrs))
+	assert.Equal(t, 24, len(hostManifest.PcrManifest.Sha256Pcrs))
+
+	// the PCRs replay their event log
+	for _, eventLog := range hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs {
+		replay, err := eventLog.Replay()
+		assert.NoError(t, err)
+		pcr, err := hostManifest.PcrManifest.GetPcrValue(types.SHA256, types.PcrIndex(eventLog.Pcr.Index))
+		assert.NoError(t, err)
+		assert.Equal(t, replay, pcr.Value)
+	}
+
+	// the profile of a simulated host must be referred to by an absolute file path
+	_, err = htcFactory.NewHostConnector("simulator:https://ta.ip.com:1443")
+	assert.Error(t, err)
+}
+
+// newImaLogLine returns the ascii_runtime_measurements line of an ima-ng measurement of the file
+func newImaLogLine(t *testing.T, fileDigest, filePath string) string {
+	digest, err := hex.DecodeString(strings.SplitN(fileDigest, ":", 2)[1])
+	assert.NoError(t, err)
+	var templateData []byte
+	for _, field := range [][]byte{
+		append([]byte(strings.SplitN(fileDigest, ":", 2)[0]+":\x00"), digest...),
+		append([]byte(filePath), 0),
+	} {
+		length := make([]byte, 4)
+		binary.LittleEndian.PutUint32(length, uint32(len(field)))
+		templateData = append(append(templateData, length...), field...)
+	}
+	return fmt.Sprintf("10 %x ima-ng %s %s\n", sha1.Sum(templateData), fileDigest, filePath)
+}
+
+func TestSimulatorHostConnectorImaLog(t *testing.T) {
+	profileDir, err := ioutil.TempDir("", "simulator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(profileDir)
+	profilePath := filepath.Join(profileDir, "host.json")
+	profile := newSimulatedHostProfile(t, profilePath)
+	profile.ImaLog = newImaLogLine(t, "sha256:"+strings.Repeat("a1", 32), types.ImaBootAggregate) +
+		newImaLogLine(t, "sha256:"+strings.Repeat("b2", 32), "/usr/bin/bash")
+	assert.NoError(t, profile.Save(profilePath))
+
+	hostConnector, err := NewHostConnectorFactory("", nil, nil, true).NewHostConnector("simulator:file://" + profilePath)
+	assert.NoError(t, err)
+	hostManifest, err := hostConnector.GetHostManifest(nil)
+	assert.NoError(t, err)
+
+	// the IMA log of the quote is carried to the host manifest and replays the IMA PCR
+	assert.NotNil(t, hostManifest.ImaLog)
+	events, err := hostManifest.ImaLog.GetEvents()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, "/usr/bin/bash", events[1].FilePath)
+	for _, pcrBank := range []types.SHAAlgorithm{types.SHA1, types.SHA256} {
+		replay, err := types.ReplayImaEvents(events, pcrBank, false)
+		assert.NoError(t, err)
+		pcr, err := hostManifest.PcrManifest.GetPcrValue(pcrBank, types.ImaPcrIndex)
+		assert.NoError(t, err)
+		assert.Equal(t, replay, pcr.Value)
+	}
+
+	// the host manifest has no IMA log when IMA is not enabled on the host
+	profile.ImaLog = ""
+	assert.NoError(t, profile.Save(profilePath))
+	hostManifest, err = hostConnector.GetHostManifest(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, hostManifest.ImaLog)
+}
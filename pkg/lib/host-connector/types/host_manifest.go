@@ -19,6 +19,8 @@ type HostManifest struct {
 	BindingKeyCertificate string           `json:"binding_key_certificate,omitempty"`
 	MeasurementXmls       []string         `json:"measurement_xmls,omitempty"`
 	QuoteDigest           string           `json:"quote_digest,omitempty"`
+	// ImaLog is the IMA runtime measurement list of the host, whose measurements are extended to PCR 10
+	ImaLog *ImaLog `json:"ima_log,omitempty"`
 }
 
 func (hostManifest *HostManifest) GetAIKCertificate() (*x509.Certificate, error) {
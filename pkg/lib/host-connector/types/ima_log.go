@@ -0,0 +1,273 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ImaPcrIndex is the PCR the IMA subsystem extends the template hashes of its measurements to
+	ImaPcrIndex = PCR10
+	// ImaBootAggregate is the path of the first IMA measurement, which holds the digest of the boot PCRs
+	ImaBootAggregate = "boot_aggregate"
+
+	imaTemplateNg  = "ima-ng"
+	imaTemplateSig = "ima-sig"
+)
+
+// ImaLog is the IMA runtime measurement list of a host, as read from either the ascii_runtime_measurements or
+// the binary_runtime_measurements file of securityfs. Only the measurements of the ima-ng and ima-sig templates,
+// the defaults of the kernel, are supported.
+type ImaLog struct {
+	Ascii  string `json:"ascii,omitempty"`
+	Binary []byte `json:"binary,omitempty"`
+}
+
+// ImaEvent is a measurement of the IMA runtime measurement list
+type ImaEvent struct {
+	Pcr int `json:"pcr"`
+	// TemplateHash is the hex encoded SHA1 hash of the template data, all zeros for a measurement violation
+	TemplateHash string `json:"template_hash"`
+	TemplateName string `json:"template_name"`
+	// FileDigest is the hex encoded digest of the file prefixed with its algorithm, e.g. sha256:...
+	FileDigest string `json:"file_digest"`
+	FilePath   string `json:"file_path"`
+
+	templateData []byte
+}
+
+// IsEmpty returns true when the host did not report an IMA log
+func (imaLog *ImaLog) IsEmpty() bool {
+	return imaLog == nil || (imaLog.Ascii == "" && len(imaLog.Binary) == 0)
+}
+
+// GetEvents parses the IMA log and verifies that the template hash of each measurement matches its template data
+func (imaLog *ImaLog) GetEvents() ([]ImaEvent, error) {
+	var events []ImaEvent
+	var err error
+	if len(imaLog.Binary) != 0 {
+		events, err = parseBinaryImaLog(imaLog.Binary)
+	} else {
+		events, err = parseAsciiImaLog(imaLog.Ascii)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, event := range events {
+		if event.IsViolation() {
+			continue
+		}
+		templateHash := sha1.Sum(event.templateData)
+		if hex.EncodeToString(templateHash[:]) != strings.ToLower(event.TemplateHash) {
+			return nil, errors.Errorf("The template hash of IMA measurement %d of '%s' does not match its template data", i, event.FilePath)
+		}
+	}
+	return events, nil
+}
+
+// IsViolation returns true for the measurements the kernel records when a file is measured while opened for
+// write (ToMToU) or already opened for write when measured (open writers)
+func (event *ImaEvent) IsViolation() bool {
+	templateHash, err := hex.DecodeString(event.TemplateHash)
+	return err == nil && bytes.Equal(templateHash, make([]byte, sha1.Size))
+}
+
+// ReplayImaEvents returns the value the IMA measurements extend the PCR of the bank to. The SHA1 bank is extended
+// with the template hashes. The kernels extend the other banks with the hashes of the template data computed with the
+// algorithm of the bank (5.8 and later) or, when padTemplateHash is true, with the SHA1 template hashes padded with
+// zeros (before 5.8). The measurement violations are extended as all ones.
+func ReplayImaEvents(events []ImaEvent, pcrBank SHAAlgorithm, padTemplateHash bool) (string, error) {
+	cumulativeHash, err := getCumulativeHash(pcrBank)
+	if err != nil {
+		return "", err
+	}
+
+	for i, event := range events {
+		var eventHash []byte
+		if event.IsViolation() {
+			eventHash = bytes.Repeat([]byte{0xff}, len(cumulativeHash))
+		} else if pcrBank == SHA1 || padTemplateHash {
+			templateHash, err := hex.DecodeString(event.TemplateHash)
+			if err != nil || len(templateHash) != sha1.Size {
+				return "", errors.Errorf("Invalid template hash '%s' of IMA measurement %d", event.TemplateHash, i)
+			}
+			eventHash = make([]byte, len(cumulativeHash))
+			copy(eventHash, templateHash)
+		} else {
+			hash := getHash(pcrBank)
+			hash.Write(event.templateData)
+			eventHash = hash.Sum(nil)
+		}
+
+		hash := getHash(pcrBank)
+		hash.Write(cumulativeHash)
+		hash.Write(eventHash)
+		cumulativeHash = hash.Sum(nil)
+	}
+
+	return hex.EncodeToString(cumulativeHash), nil
+}
+
+// parseAsciiImaLog parses the lines '<pcr> <template hash> <template name> <file digest> <file path> [<signature>]'
+// of the ascii_runtime_measurements file
+func parseAsciiImaLog(asciiLog string) ([]ImaEvent, error) {
+	var events []ImaEvent
+	scanner := bufio.NewScanner(strings.NewReader(asciiLog))
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		// the file path may hold spaces
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), " ", 5)
+		if len(fields) != 5 {
+			return nil, errors.Errorf("Invalid IMA measurement at line %d", line)
+		}
+		pcr, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid PCR of the IMA measurement at line %d", line)
+		}
+
+		event := ImaEvent{
+			Pcr:          pcr,
+			TemplateHash: fields[1],
+			TemplateName: fields[2],
+			FileDigest:   fields[3],
+			FilePath:     fields[4],
+		}
+		var signature []byte
+		switch event.TemplateName {
+		case imaTemplateNg:
+		case imaTemplateSig:
+			// the signature, when the file has one, is hex encoded after the file path
+			if separator := strings.LastIndex(event.FilePath, " "); separator != -1 {
+				if signature, err = hex.DecodeString(event.FilePath[separator+1:]); err == nil {
+					event.FilePath = event.FilePath[:separator]
+				} else {
+					signature = nil
+				}
+			}
+		default:
+			return nil, errors.Errorf("Unsupported template '%s' of the IMA measurement at line %d", event.TemplateName, line)
+		}
+
+		if event.templateData, err = newImaTemplateData(event.TemplateName, event.FileDigest, event.FilePath, signature); err != nil {
+			return nil, errors.Wrapf(err, "Invalid IMA measurement at line %d", line)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "Failed to read the IMA log")
+	}
+	return events, nil
+}
+
+// parseBinaryImaLog parses the binary_runtime_measurements file, where each measurement holds the PCR, the template
+// hash, the template name and the template data, the variable length values being prefixed with their length
+func parseBinaryImaLog(binaryLog []byte) ([]ImaEvent, error) {
+	var events []ImaEvent
+	reader := bytes.NewReader(binaryLog)
+	for i := 0; reader.Len() > 0; i++ {
+		var header struct {
+			Pcr          uint32
+			TemplateHash [sha1.Size]byte
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+			return nil, errors.Wrapf(err, "Failed to read the header of IMA measurement %d", i)
+		}
+		templateName, err := readImaField(reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read the template name of IMA measurement %d", i)
+		}
+		templateData, err := readImaField(reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read the template data of IMA measurement %d", i)
+		}
+
+		event := ImaEvent{
+			Pcr:          int(header.Pcr),
+			TemplateHash: hex.EncodeToString(header.TemplateHash[:]),
+			TemplateName: string(templateName),
+			templateData: templateData,
+		}
+		if event.TemplateName != imaTemplateNg && event.TemplateName != imaTemplateSig {
+			return nil, errors.Errorf("Unsupported template '%s' of IMA measurement %d", event.TemplateName, i)
+		}
+
+		// the d-ng field holds '<algorithm>:\0<digest>' and the n-ng field the NUL terminated path
+		fieldReader := bytes.NewReader(templateData)
+		digestField, err := readImaField(fieldReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read the file digest of IMA measurement %d", i)
+		}
+		pathField, err := readImaField(fieldReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read the file path of IMA measurement %d", i)
+		}
+		separator := bytes.Index(digestField, []byte(":\x00"))
+		if separator == -1 {
+			return nil, errors.Errorf("Invalid file digest of IMA measurement %d", i)
+		}
+		event.FileDigest = string(digestField[:separator+1]) + hex.EncodeToString(digestField[separator+2:])
+		event.FilePath = string(bytes.TrimSuffix(pathField, []byte{0}))
+
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// newImaTemplateData returns the template data of an ima-ng or ima-sig measurement, which is the concatenation of
+// the d-ng, n-ng and, for ima-sig, sig fields prefixed with their length
+func newImaTemplateData(templateName, fileDigest, filePath string, signature []byte) ([]byte, error) {
+	separator := strings.Index(fileDigest, ":")
+	if separator == -1 {
+		return nil, errors.Errorf("Invalid file digest '%s'", fileDigest)
+	}
+	digest, err := hex.DecodeString(fileDigest[separator+1:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid file digest '%s'", fileDigest)
+	}
+
+	fields := [][]byte{
+		append([]byte(fileDigest[:separator+1]+"\x00"), digest...),
+		append([]byte(filePath), 0),
+	}
+	if templateName == imaTemplateSig {
+		fields = append(fields, signature)
+	}
+
+	templateData := bytes.Buffer{}
+	for _, field := range fields {
+		_ = binary.Write(&templateData, binary.LittleEndian, uint32(len(field)))
+		templateData.Write(field)
+	}
+	return templateData.Bytes(), nil
+}
+
+// readImaField reads a value prefixed with its length
+func readImaField(reader *bytes.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return nil, err
+	}
+	if int64(length) > int64(reader.Len()) {
+		return nil, errors.Errorf("The length %d exceeds the %d remaining bytes", length, reader.Len())
+	}
+	field := make([]byte, length)
+	if _, err := reader.Read(field); err != nil && length != 0 {
+		return nil, err
+	}
+	return field, nil
+}
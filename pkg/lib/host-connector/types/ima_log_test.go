@@ -0,0 +1,143 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package types
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testImaMeasurement struct {
+	templateName string
+	fileDigest   string
+	filePath     string
+	signature    []byte
+	violation    bool
+}
+
+var testImaMeasurements = []testImaMeasurement{
+	{templateName: imaTemplateNg, fileDigest: "sha256:" + strings.Repeat("a1", 32), filePath: ImaBootAggregate},
+	{templateName: imaTemplateSig, fileDigest: "sha256:" + strings.Repeat("b2", 32), filePath: "/usr/bin/bash", signature: []byte{0x03, 0x02, 0x04}},
+	{templateName: imaTemplateSig, fileDigest: "sha256:" + strings.Repeat("c3", 32), filePath: "/opt/my app/run"},
+	{templateName: imaTemplateNg, fileDigest: "sha1:" + strings.Repeat("00", 20), filePath: "/var/log/messages", violation: true},
+}
+
+// newTestImaLog returns the ascii and binary IMA logs of the measurements
+func newTestImaLog(t *testing.T, measurements []testImaMeasurement) (string, []byte) {
+	asciiLog := strings.Builder{}
+	binaryLog := bytes.Buffer{}
+	for _, measurement := range measurements {
+		templateData, err := newImaTemplateData(measurement.templateName, measurement.fileDigest, measurement.filePath, measurement.signature)
+		assert.NoError(t, err)
+		templateHash := sha1.Sum(templateData)
+		if measurement.violation {
+			templateHash = [sha1.Size]byte{}
+		}
+
+		line := fmt.Sprintf("10 %x %s %s %s", templateHash, measurement.templateName, measurement.fileDigest, measurement.filePath)
+		if len(measurement.signature) != 0 {
+			line += " " + hex.EncodeToString(measurement.signature)
+		}
+		asciiLog.WriteString(line + "\n")
+
+		_ = binary.Write(&binaryLog, binary.LittleEndian, uint32(ImaPcrIndex))
+		binaryLog.Write(templateHash[:])
+		_ = binary.Write(&binaryLog, binary.LittleEndian, uint32(len(measurement.templateName)))
+		binaryLog.WriteString(measurement.templateName)
+		_ = binary.Write(&binaryLog, binary.LittleEndian, uint32(len(templateData)))
+		binaryLog.Write(templateData)
+	}
+	return asciiLog.String(), binaryLog.Bytes()
+}
+
+func TestImaLogAsciiAndBinaryEvents(t *testing.T) {
+	asciiLog, binaryLog := newTestImaLog(t, testImaMeasurements)
+
+	asciiEvents, err := (&ImaLog{Ascii: asciiLog}).GetEvents()
+	assert.NoError(t, err)
+	binaryEvents, err := (&ImaLog{Binary: binaryLog}).GetEvents()
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(testImaMeasurements), len(asciiEvents))
+	assert.Equal(t, asciiEvents, binaryEvents)
+	for i, measurement := range testImaMeasurements {
+		assert.Equal(t, int(ImaPcrIndex), asciiEvents[i].Pcr)
+		assert.Equal(t, measurement.templateName, asciiEvents[i].TemplateName)
+		assert.Equal(t, measurement.fileDigest, asciiEvents[i].FileDigest)
+		assert.Equal(t, measurement.filePath, asciiEvents[i].FilePath)
+		assert.Equal(t, measurement.violation, asciiEvents[i].IsViolation())
+	}
+}
+
+func TestImaLogTemplateHashMismatch(t *testing.T) {
+	asciiLog, _ := newTestImaLog(t, testImaMeasurements)
+	// the file digest of /usr/bin/bash is changed without updating the template hash
+	asciiLog = strings.Replace(asciiLog, strings.Repeat("b2", 32), strings.Repeat("b3", 32), 1)
+
+	_, err := (&ImaLog{Ascii: asciiLog}).GetEvents()
+	assert.Error(t, err)
+}
+
+func TestImaLogInvalid(t *testing.T) {
+	invalidLogs := []ImaLog{
+		{Ascii: "10 0000 ima-ng sha256:00"},
+		{Ascii: "pcr 0000 ima-ng sha256:00 /usr/bin/bash"},
+		{Ascii: "10 0000 ima d41d8cd98f00b204e9800998ecf8427e /usr/bin/bash"},
+		{Ascii: "10 0000 ima-ng sha256:xyz /usr/bin/bash"},
+		{Binary: []byte{10, 0, 0, 0}},
+	}
+	for _, imaLog := range invalidLogs {
+		_, err := imaLog.GetEvents()
+		assert.Error(t, err, imaLog)
+	}
+
+	// a truncated binary log
+	_, binaryLog := newTestImaLog(t, testImaMeasurements)
+	_, err := (&ImaLog{Binary: binaryLog[:len(binaryLog)-1]}).GetEvents()
+	assert.Error(t, err)
+
+	assert.True(t, (*ImaLog)(nil).IsEmpty())
+	assert.True(t, (&ImaLog{}).IsEmpty())
+}
+
+func TestReplayImaEvents(t *testing.T) {
+	asciiLog, _ := newTestImaLog(t, testImaMeasurements)
+	events, err := (&ImaLog{Ascii: asciiLog}).GetEvents()
+	assert.NoError(t, err)
+
+	// replay the SHA256 bank as the kernels from 5.8 do
+	expected := make([]byte, sha256.Size)
+	for _, event := range events {
+		eventHash := sha256.Sum256(event.templateData)
+		if event.IsViolation() {
+			copy(eventHash[:], bytes.Repeat([]byte{0xff}, sha256.Size))
+		}
+		cumulativeHash := sha256.Sum256(append(expected, eventHash[:]...))
+		expected = cumulativeHash[:]
+	}
+	replayValue, err := ReplayImaEvents(events, SHA256, false)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(expected), replayValue)
+
+	// the SHA1 bank and the padded SHA256 bank are extended with the template hashes
+	sha1Value, err := ReplayImaEvents(events, SHA1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*sha1.Size, len(sha1Value))
+	paddedValue, err := ReplayImaEvents(events, SHA256, true)
+	assert.NoError(t, err)
+	assert.NotEqual(t, replayValue, paddedValue)
+
+	_, err = ReplayImaEvents(events, "SHA3", false)
+	assert.Error(t, err)
+}
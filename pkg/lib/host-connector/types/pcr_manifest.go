@@ -41,6 +41,10 @@ type EventLog struct {
 	TypeName    string   `json:"type_name"` //oneof-required
 	Tags        []string `json:"tags,omitempty"`
 	Measurement string   `json:"measurement"` //required
+	// EventData is the raw data of the event, it is only reported for the events whose content is verified
+	// (e.g. the UEFI_VARIABLE_DATA of the EV_EFI_VARIABLE_DRIVER_CONFIG events) and is not copied to the flavors.
+	// It is read from the "event_data" field of the Trust Agent measure log, the VMware hosts do not report it.
+	EventData []byte `json:"event_data,omitempty"`
 }
 
 type eventLogKeyAttr struct {
@@ -76,10 +80,14 @@ type EventLogEqual struct {
 type PcrEventLogMap struct {
 	Sha1EventLogs   []TpmEventLog `json:"SHA1"`
 	Sha256EventLogs []TpmEventLog `json:"SHA256"`
+	Sha384EventLogs []TpmEventLog `json:"SHA384,omitempty"`
+	Sha512EventLogs []TpmEventLog `json:"SHA512,omitempty"`
 }
 type PcrManifest struct {
 	Sha1Pcrs       []HostManifestPcrs `json:"sha1pcrs"`
 	Sha256Pcrs     []HostManifestPcrs `json:"sha2pcrs"`
+	Sha384Pcrs     []HostManifestPcrs `json:"sha384pcrs,omitempty"`
+	Sha512Pcrs     []HostManifestPcrs `json:"sha512pcrs,omitempty"`
 	PcrEventLogMap PcrEventLogMap     `json:"pcr_event_log_map"`
 }
 
@@ -194,7 +202,7 @@ func GetPcrIndexFromString(stringValue string) (PcrIndex, error) {
 
 // Finds the Pcr in a PcrManifest provided the pcrBank and index.  Returns
 // null if not found.  Returns an error if the pcrBank is not supported
-// by intel-secl (currently supports SHA1 and SHA256).
+// by intel-secl (currently supports SHA1, SHA256, SHA384 and SHA512).
 func (pcrManifest *PcrManifest) GetPcrValue(pcrBank SHAAlgorithm, pcrIndex PcrIndex) (*HostManifestPcrs, error) {
 	// TODO: Is this the right data model for the PcrManifest?  Two things...
 	// - Flavor API returns a map[bank]map[pcrindex]
@@ -216,6 +224,20 @@ func (pcrManifest *PcrManifest) GetPcrValue(pcrBank SHAAlgorithm, pcrIndex PcrIn
 				break
 			}
 		}
+	case SHA384:
+		for _, pcr := range pcrManifest.Sha384Pcrs {
+			if pcr.Index == pcrIndex {
+				pcrValue = &pcr
+				break
+			}
+		}
+	case SHA512:
+		for _, pcr := range pcrManifest.Sha512Pcrs {
+			if pcr.Index == pcrIndex {
+				pcrValue = &pcr
+				break
+			}
+		}
 	default:
 		return nil, errors.Errorf("Unsupported sha algorithm %s", pcrBank)
 	}
@@ -223,15 +245,15 @@ func (pcrManifest *PcrManifest) GetPcrValue(pcrBank SHAAlgorithm, pcrIndex PcrIn
 	return pcrValue, nil
 }
 
-// IsEmpty returns true if both the Sha1Pcrs and Sha256Pcrs
-// are empty.
+// IsEmpty returns true if the PCRs of all the banks are empty.
 func (pcrManifest *PcrManifest) IsEmpty() bool {
-	return len(pcrManifest.Sha1Pcrs) == 0 && len(pcrManifest.Sha256Pcrs) == 0
+	return len(pcrManifest.Sha1Pcrs) == 0 && len(pcrManifest.Sha256Pcrs) == 0 &&
+		len(pcrManifest.Sha384Pcrs) == 0 && len(pcrManifest.Sha512Pcrs) == 0
 }
 
 // Finds the EventLogEntry in a PcrEventLogMap provided the pcrBank and index.  Returns
 // null if not found.  Returns an error if the pcrBank is not supported
-// by intel-secl (currently supports SHA1 and SHA256).
+// by intel-secl (currently supports SHA1, SHA256, SHA384 and SHA512).
 func (pcrEventLogMap *PcrEventLogMap) GetEventLogNew(pcrBank string, pcrIndex int) ([]EventLog, int, string, error) {
 	var eventLog []EventLog
 	var pIndex int
@@ -256,6 +278,24 @@ func (pcrEventLogMap *PcrEventLogMap) GetEventLogNew(pcrBank string, pcrIndex in
 				break
 			}
 		}
+	case SHA384:
+		for _, entry := range pcrEventLogMap.Sha384EventLogs {
+			if entry.Pcr.Index == pcrIndex {
+				eventLog = entry.TpmEvent
+				pIndex = entry.Pcr.Index
+				bank = entry.Pcr.Bank
+				break
+			}
+		}
+	case SHA512:
+		for _, entry := range pcrEventLogMap.Sha512EventLogs {
+			if entry.Pcr.Index == pcrIndex {
+				eventLog = entry.TpmEvent
+				pIndex = entry.Pcr.Index
+				bank = entry.Pcr.Bank
+				break
+			}
+		}
 	default:
 		return nil, 0, "", errors.Errorf("Unsupported sha algorithm %s", pcrBank)
 	}
@@ -352,8 +392,9 @@ func (eventLogEntry *TpmEventLog) Replay() (string, error) {
 	}
 
 	// use the first EV_NO_ACTION/"StartupLocality" event to send the cumualtive hash
-	if eventLogEntry.Pcr.Index == 0 && eventLogEntry.TpmEvent[0].TypeName == StartupLocalityEvent &&
-		eventLogEntry.TpmEvent[0].Tags[0] == StartupLocalityTag {
+	if eventLogEntry.Pcr.Index == 0 && len(eventLogEntry.TpmEvent) > 0 &&
+		eventLogEntry.TpmEvent[0].TypeName == StartupLocalityEvent &&
+		len(eventLogEntry.TpmEvent[0].Tags) > 0 && eventLogEntry.TpmEvent[0].Tags[0] == StartupLocalityTag {
 		cumulativeHash[len(cumulativeHash)-1] = 0x3
 	}
 
@@ -396,6 +437,18 @@ func (pcrManifest *PcrManifest) GetEventLogCriteria(pcrBank SHAAlgorithm, pcrInd
 				return eventLogEntry.TpmEvent, nil
 			}
 		}
+	case "SHA384":
+		for _, eventLogEntry := range pcrManifest.PcrEventLogMap.Sha384EventLogs {
+			if eventLogEntry.Pcr.Index == pI {
+				return eventLogEntry.TpmEvent, nil
+			}
+		}
+	case "SHA512":
+		for _, eventLogEntry := range pcrManifest.PcrEventLogMap.Sha512EventLogs {
+			if eventLogEntry.Pcr.Index == pI {
+				return eventLogEntry.TpmEvent, nil
+			}
+		}
 	default:
 		return nil, fmt.Errorf("Unsupported sha algorithm %s", pcrBank)
 	}
@@ -414,6 +467,12 @@ func (pcrManifest *PcrManifest) GetPcrBanks() []SHAAlgorithm {
 	if len(pcrManifest.Sha256Pcrs) > 0 {
 		bankList = append(bankList, SHA256)
 	}
+	if len(pcrManifest.Sha384Pcrs) > 0 {
+		bankList = append(bankList, SHA384)
+	}
+	if len(pcrManifest.Sha512Pcrs) > 0 {
+		bankList = append(bankList, SHA512)
+	}
 
 	return bankList
 }
@@ -0,0 +1,60 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTpmEventLogReplay(t *testing.T) {
+	eventLog := TpmEventLog{
+		Pcr: Pcr{Index: 0, Bank: "SHA256"},
+		TpmEvent: []EventLog{
+			{
+				TypeName:    StartupLocalityEvent,
+				Tags:        []string{StartupLocalityTag},
+				Measurement: "0000000000000000000000000000000000000000000000000000000000000000",
+			},
+			{
+				TypeName:    "EV_S_CRTM_VERSION",
+				Measurement: "d5a4e7b0d4b3c1e26dd7c2b59e2b1a7c03f2ee8e0b2f0b3b9c6f1f2f0a7d9e11",
+			},
+			{
+				TypeName:    "EV_POST_CODE",
+				Measurement: "96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+			},
+		},
+	}
+
+	cumulativeHash, err := eventLog.Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, "e9d8c854b5a0fd4cd894d3ae76e829b3b52300ce3e7606fb4110256af7135212", cumulativeHash)
+}
+
+func TestTpmEventLogReplayEmpty(t *testing.T) {
+	// an empty event log replays to the initial value of the PCR
+	eventLog := TpmEventLog{Pcr: Pcr{Index: 0, Bank: "SHA256"}}
+	cumulativeHash, err := eventLog.Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", cumulativeHash)
+
+	// a startup locality event without tags does not set the locality
+	eventLog.TpmEvent = []EventLog{{TypeName: StartupLocalityEvent}}
+	cumulativeHash, err = eventLog.Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", cumulativeHash)
+}
+
+func TestTpmEventLogReplayInvalidMeasurement(t *testing.T) {
+	eventLog := TpmEventLog{
+		Pcr:      Pcr{Index: 17, Bank: "SHA256"},
+		TpmEvent: []EventLog{{TypeName: "EV_POST_CODE", Measurement: "not-hex"}},
+	}
+	_, err := eventLog.Replay()
+	assert.Error(t, err)
+}
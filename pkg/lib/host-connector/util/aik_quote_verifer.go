@@ -6,11 +6,8 @@ package util
 
 import (
 	"bytes"
-	"crypto"
 	"crypto/rand"
-	"crypto/rsa"
 	"crypto/sha1"
-	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/binary"
@@ -22,6 +19,7 @@ import (
 
 	commLog "github.com/intel-secl/intel-secl/v4/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/tpm2utils"
 	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
 	"github.com/pkg/errors"
 )
@@ -44,6 +42,8 @@ const (
 	PCR_VALUE_UNTAINT         = "[^0-9a-fA-F]"
 	SHA1                      = "SHA1"
 	SHA256                    = "SHA256"
+	SHA384                    = "SHA384"
+	SHA512                    = "SHA512"
 	EVENT_LOG_DIGEST_SHA1     = "com.intel.mtwilson.core.common.model.MeasurementSha1"
 	EVENT_LOG_DIGEST_SHA256   = "com.intel.mtwilson.core.common.model.MeasurementSha256"
 	EVENT_NAME                = "OpenSource.EventName"
@@ -52,6 +52,14 @@ const (
 var PCR_NUMBER_PATTERN = regexp.MustCompile("[0-9]|[0-1][0-9]|2[0-3]")
 var PCR_VALUE_PATTERN = regexp.MustCompile("[0-9a-fA-F]+")
 
+// pcrBankNames maps the TPM algorithm ids of the supported PCR banks to their names in the PCR manifest
+var pcrBankNames = map[uint16]string{
+	TPM_API_ALG_ID_SHA1:   SHA1,
+	TPM_API_ALG_ID_SHA256: SHA256,
+	TPM_API_ALG_ID_SHA384: SHA384,
+	TPM_API_ALG_ID_SHA512: SHA512,
+}
+
 type pcrSelection struct {
 	size        int
 	hashAlg     uint16
@@ -135,48 +143,36 @@ func VerifyQuoteAndGetPCRManifest(decodedEventLog string, verificationNonce []by
 
 	tpmtSigIndex := 2 + quoteInfoLen
 	tpmtSig := tpmQuoteInBytes[tpmtSigIndex:]
-	var pos uint16 = 0
-	/* sigAlg -indicates the signature algorithm TPMI_SIG_ALG_SCHEME
-	 * for now, it is TPM_ALG_RSASSA with value 0x0014
-	 */
-	tpmtSignatureAlg := binary.BigEndian.Uint16(tpmtSig[0:2])
-	secLog.Debugf("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() TPM signature Algorithm: %v", tpmtSignatureAlg)
-	/* hashAlg used by the signature algorithm indicated above
-	 * TPM_ALG_HASH
-	 * for TPM_ALG_RSASSA, the default hash algorithm is TPM_ALG_SHA256 with value 0x000b
+	/* sigAlg indicates the signature scheme TPMI_SIG_ALG_SCHEME, TPM_ALG_RSASSA, TPM_ALG_RSAPSS or TPM_ALG_ECDSA
+	 * depending on the AIK, followed by the hash algorithm used by the scheme and the signature
 	 */
-	pos += 2
-	tpmtSignatureHashAlg := binary.BigEndian.Uint16(tpmtSig[pos : pos+2])
-	secLog.Debugf("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() TPM signature Hash Algorithm: %v", tpmtSignatureHashAlg)
-
-	pos += 2
-	tpmtSignatureSize := binary.BigEndian.Uint16(tpmtSig[pos : pos+2])
-
-	pos += 2
-	tpmtSignature := tpmtSig[pos : pos+tpmtSignatureSize]
-	secLog.Debugf("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() TPMT signature : %v", tpmtSignature)
+	tpmtSignature, tpmtSignatureLen, err := tpm2utils.ParseTpmtSignature(tpmtSig)
+	if err != nil {
+		return types.PcrManifest{}, nil, errors.Wrap(err, "util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() "+
+			"Error parsing quote signature")
+	}
+	secLog.Debugf("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() TPM signature Algorithm: %v, "+
+		"Hash Algorithm: %v", tpmtSignature.SigAlg, tpmtSignature.HashAlg)
 
-	hash := sha256.New()
-	_, err := hash.Write(quoteInfo)
+	err = tpmtSignature.Verify(aikCertificate.PublicKey, quoteInfo)
 	if err != nil {
-		return types.PcrManifest{}, nil, errors.Wrap(err, "Error writing quote information")
+		return types.PcrManifest{}, nil, errors.Wrap(err, "util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() "+
+			"Error verifying pcrs digest")
 	}
-	pcrsDigest := hash.Sum(nil)
-	secLog.Debugf("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() Quote signature : %v", pcrsDigest)
-	err = rsa.VerifyPKCS1v15(aikCertificate.PublicKey.(*rsa.PublicKey), crypto.SHA256, pcrsDigest, tpmtSignature)
+	// the PCR digest in the quote is computed with the hash algorithm of the signature scheme
+	signatureHash, err := tpmtSignature.Hash()
 	if err != nil {
 		return types.PcrManifest{}, nil, errors.Wrap(err, "util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() "+
 			"Error verifying pcrs digest")
 	}
 
-	pos += tpmtSignatureSize
+	pos := uint16(tpmtSignatureLen)
 	pcrLen := uint16(len(tpmQuoteInBytes)) - (pos + tpmtSigIndex)
 	if pcrLen <= 0 {
 		return types.PcrManifest{}, nil, errors.New("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() " +
 			"AIK Quote verification failed, No PCR values included in quote")
 	}
 	pcrs := tpmtSig[pos : pos+pcrLen]
-	pcrConcatLen := SHA256_SIZE * 24 * 3
 	pcrPos := 0
 	count := 0
 	var pcrConcat []byte
@@ -201,16 +197,18 @@ func VerifyQuoteAndGetPCRManifest(decodedEventLog string, verificationNonce []by
 			pcrSelected := pcrSelection[j].pcrSelected
 			selected := pcrSelected[pcr/8] & (1 << (uint16(pcr) % 8))
 			if selected > 0 {
-				if (pcrPos + pcrSize) < pcrConcatLen {
-					pcrConcat = append(pcrConcat, pcrs[pcrPos:pcrPos+pcrSize]...)
+				if pcrPos+pcrSize > len(pcrs) {
+					return types.PcrManifest{}, nil, errors.New("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() " +
+						"AIK Quote verification failed, the quote does not contain all the selected PCR values")
 				}
-				if hashAlg == TPM_API_ALG_ID_SHA1 {
-					buffer.WriteString(fmt.Sprintf("%2d ", pcr))
-				} else if hashAlg == TPM_API_ALG_ID_SHA256 {
-					buffer.WriteString(fmt.Sprintf("%2d_SHA256 ", pcr))
-				}
-				//Ignore the pcr banks other than SHA1 and SHA256
-				if hashAlg == TPM_API_ALG_ID_SHA1 || hashAlg == TPM_API_ALG_ID_SHA256 {
+				pcrConcat = append(pcrConcat, pcrs[pcrPos:pcrPos+pcrSize]...)
+				//Ignore the pcr banks other than SHA1, SHA256, SHA384 and SHA512
+				if bankName, ok := pcrBankNames[hashAlg]; ok {
+					if hashAlg == TPM_API_ALG_ID_SHA1 {
+						buffer.WriteString(fmt.Sprintf("%2d ", pcr))
+					} else {
+						buffer.WriteString(fmt.Sprintf("%2d_%s ", pcr, bankName))
+					}
 					for i := 0; i < pcrSize; i++ {
 						buffer.WriteString(fmt.Sprintf("%02x", pcrs[pcrPos+i]))
 					}
@@ -221,12 +219,12 @@ func VerifyQuoteAndGetPCRManifest(decodedEventLog string, verificationNonce []by
 			}
 		}
 	}
-	hash = sha256.New()
+	hash := signatureHash.New()
 	_, err = hash.Write(pcrConcat)
 	if err != nil {
 		return types.PcrManifest{}, nil, errors.Wrap(err, "Error writing pcr hash")
 	}
-	pcrsDigest = hash.Sum(nil)
+	pcrsDigest := hash.Sum(nil)
 
 	if !bytes.EqualFold(pcrsDigest, tpm2bDigest) {
 		log.Error("util/aik_quote_verifier:VerifyQuoteAndGetPCRManifest() AIK Quote verification failed, Digest " +
@@ -321,18 +319,20 @@ func createPCRManifest(pcrList []string, eventLog string) (types.PcrManifest, er
 					return pcrManifest, err
 				}
 
-				if strings.EqualFold(pcrBank, "SHA256") {
-					pcrManifest.Sha256Pcrs = append(pcrManifest.Sha256Pcrs, types.HostManifestPcrs{
-						Index:   pcrIndex,
-						Value:   pcrValue,
-						PcrBank: shaAlgorithm,
-					})
-				} else if strings.EqualFold(pcrBank, "SHA1") {
-					pcrManifest.Sha1Pcrs = append(pcrManifest.Sha1Pcrs, types.HostManifestPcrs{
-						Index:   pcrIndex,
-						Value:   pcrValue,
-						PcrBank: shaAlgorithm,
-					})
+				manifestPcr := types.HostManifestPcrs{
+					Index:   pcrIndex,
+					Value:   pcrValue,
+					PcrBank: shaAlgorithm,
+				}
+				switch shaAlgorithm {
+				case types.SHA1:
+					pcrManifest.Sha1Pcrs = append(pcrManifest.Sha1Pcrs, manifestPcr)
+				case types.SHA256:
+					pcrManifest.Sha256Pcrs = append(pcrManifest.Sha256Pcrs, manifestPcr)
+				case types.SHA384:
+					pcrManifest.Sha384Pcrs = append(pcrManifest.Sha384Pcrs, manifestPcr)
+				case types.SHA512:
+					pcrManifest.Sha512Pcrs = append(pcrManifest.Sha512Pcrs, manifestPcr)
 				}
 			} else {
 				log.Warn("util/aik_quote_verifier:createPCRManifest() Result PCR invalid")
@@ -368,48 +368,36 @@ func addPcrEntry(module types.MeasureLog, eventLogMap *types.PcrEventLogMap) {
 
 	log.Trace("util/aik_quote_verifier:addPcrEntry() Entering")
 	defer log.Trace("util/aik_quote_verifier:addPcrEntry() Leaving")
-	pcrFound := false
-	index := 0
+
+	var bankEventLogs *[]types.TpmEventLog
 	switch module.Pcr.Bank {
 	case SHA1:
-		for _, entry := range eventLogMap.Sha1EventLogs {
-			if entry.Pcr.Index == module.Pcr.Index {
-				pcrFound = true
-				break
-			}
-			index++
-		}
-
-		if !pcrFound {
-			eventLogMap.Sha1EventLogs = append(eventLogMap.Sha1EventLogs, types.TpmEventLog{Pcr: types.Pcr{Index: module.Pcr.Index, Bank: SHA1}, TpmEvent: module.TpmEvents})
-		} else {
-			for _, events := range module.TpmEvents {
-				eventLog := types.EventLog{Measurement: events.Measurement,
-					Tags: events.Tags, TypeID: events.TypeID, TypeName: events.TypeName}
-
-				eventLogMap.Sha1EventLogs[index].TpmEvent = append(eventLogMap.Sha1EventLogs[index].TpmEvent, eventLog)
-			}
-		}
-
+		bankEventLogs = &eventLogMap.Sha1EventLogs
 	case SHA256:
-		for _, entry := range eventLogMap.Sha256EventLogs {
-			if entry.Pcr.Index == module.Pcr.Index {
-				pcrFound = true
-				break
-			}
-			index++
-		}
+		bankEventLogs = &eventLogMap.Sha256EventLogs
+	case SHA384:
+		bankEventLogs = &eventLogMap.Sha384EventLogs
+	case SHA512:
+		bankEventLogs = &eventLogMap.Sha512EventLogs
+	default:
+		log.Warnf("util/aik_quote_verifier:addPcrEntry() Ignoring event log entries of unsupported PCR bank %s", module.Pcr.Bank)
+		return
+	}
 
-		if !pcrFound {
-			eventLogMap.Sha256EventLogs = append(eventLogMap.Sha256EventLogs, types.TpmEventLog{Pcr: types.Pcr{Index: module.Pcr.Index, Bank: SHA256}, TpmEvent: module.TpmEvents})
-		} else {
-			for _, events := range module.TpmEvents {
-				eventLog := types.EventLog{Measurement: events.Measurement,
-					Tags: events.Tags, TypeID: events.TypeID, TypeName: events.TypeName}
-				eventLogMap.Sha256EventLogs[index].TpmEvent = append(eventLogMap.Sha256EventLogs[index].TpmEvent, eventLog)
-			}
+	pcrFound := false
+	index := 0
+	for _, entry := range *bankEventLogs {
+		if entry.Pcr.Index == module.Pcr.Index {
+			pcrFound = true
+			break
 		}
+		index++
+	}
 
+	if !pcrFound {
+		*bankEventLogs = append(*bankEventLogs, types.TpmEventLog{Pcr: types.Pcr{Index: module.Pcr.Index, Bank: module.Pcr.Bank}, TpmEvent: module.TpmEvents})
+	} else {
+		(*bankEventLogs)[index].TpmEvent = append((*bankEventLogs)[index].TpmEvent, module.TpmEvents...)
 	}
 	log.Debugf("util/aik_quote_verifier:addPcrEntry() Successfully added PCR log entries")
 }
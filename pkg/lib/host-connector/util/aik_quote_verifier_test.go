@@ -6,8 +6,15 @@
 package util
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/pem"
 	"encoding/xml"
 	"io/ioutil"
@@ -92,3 +99,98 @@ func TestGetVerificationNonceAssetTagProvisioned(t *testing.T) {
 	_, err = GetVerificationNonce(nonceInBytes, tpmQuoteResponse)
 	assert.NoError(t, err)
 }
+
+func TestCreatePCRManifestSHA384(t *testing.T) {
+	sha384Value := "518923b0f955d08da077c96aaba522b9decede61c599cea6c41889cfbea4ae4d50529d96fe4d1afdafb65e7f95bf23c4"
+	pcrList := []string{
+		" 0 3ea1e5b1b7d2a5a0e5e6ce0b4b2e4e7b5f2c4a1d",
+		" 0_SHA384 " + sha384Value,
+		"",
+	}
+	eventLog := `[{"pcr":{"index":0,"bank":"SHA384"},"tpm_events":[{"type_id":"0x8","type_name":"EV_S_CRTM_VERSION","measurement":"` + sha384Value + `"}]},` +
+		`{"pcr":{"index":0,"bank":"SHA384"},"tpm_events":[{"type_id":"0x1","type_name":"EV_POST_CODE","measurement":"` + sha384Value + `"}]}]`
+
+	pcrManifest, err := createPCRManifest(pcrList, eventLog)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pcrManifest.Sha1Pcrs))
+	assert.Equal(t, 1, len(pcrManifest.Sha384Pcrs))
+
+	pcr, err := pcrManifest.GetPcrValue("SHA384", 0)
+	assert.NoError(t, err)
+	assert.NotNil(t, pcr)
+	assert.Equal(t, sha384Value, pcr.Value)
+
+	events, err := pcrManifest.GetEventLogCriteria("SHA384", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+}
+
+func TestCreatePCRManifestEventDataOfSamePcr(t *testing.T) {
+	sha256Value := "3ea1e5b1b7d2a5a0e5e6ce0b4b2e4e7b5f2c4a1d3ea1e5b1b7d2a5a0e5e6ce0b"
+	pcrList := []string{
+		" 7_SHA256 " + sha256Value,
+		"",
+	}
+	// the event logs of PCR 7 are reported in two batches, the event data of both must be kept
+	eventLog := `[{"pcr":{"index":7,"bank":"SHA256"},"tpm_events":[{"type_id":"0x80000001","type_name":"EV_EFI_VARIABLE_DRIVER_CONFIG","measurement":"` + sha256Value + `","event_data":"U2VjdXJlQm9vdA=="}]},` +
+		`{"pcr":{"index":7,"bank":"SHA256"},"tpm_events":[{"type_id":"0x80000001","type_name":"EV_EFI_VARIABLE_DRIVER_CONFIG","measurement":"` + sha256Value + `","tags":["PK"],"event_data":"UEs="}]}]`
+
+	pcrManifest, err := createPCRManifest(pcrList, eventLog)
+	assert.NoError(t, err)
+
+	events, err := pcrManifest.GetEventLogCriteria("SHA256", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, []byte("SecureBoot"), events[0].EventData)
+	assert.Equal(t, []byte("PK"), events[1].EventData)
+	assert.Equal(t, []string{"PK"}, events[1].Tags)
+}
+
+// buildEcdsaQuote builds a quote of the SHA384 PCR 0 signed with an ECC AIK using the ECDSA scheme and SHA384
+func buildEcdsaQuote(t *testing.T, aikKey *ecdsa.PrivateKey, nonce []byte, pcr0 []byte) []byte {
+	pcrDigest := sha512.Sum384(pcr0)
+
+	quoteInfo := new(bytes.Buffer)
+	for _, data := range []interface{}{[]byte{0xff, 0x54, 0x43, 0x47, 0x80, 0x18}, // magic and TPM_ST_ATTEST_QUOTE
+		uint16(0),                 // qualifiedSigner
+		uint16(len(nonce)), nonce, // extraData
+		make([]byte, 17), make([]byte, 8), // clockInfo and firmwareVersion
+		uint32(1), uint16(TPM_API_ALG_ID_SHA384), uint8(3), []byte{0x01, 0x00, 0x00}, // PCR selection
+		uint16(len(pcrDigest)), pcrDigest[:]} {
+		assert.NoError(t, binary.Write(quoteInfo, binary.BigEndian, data))
+	}
+
+	quoteInfoDigest := sha512.Sum384(quoteInfo.Bytes())
+	r, s, err := ecdsa.Sign(rand.Reader, aikKey, quoteInfoDigest[:])
+	assert.NoError(t, err)
+
+	quote := new(bytes.Buffer)
+	for _, data := range []interface{}{uint16(quoteInfo.Len()), quoteInfo.Bytes(),
+		uint16(0x0018), uint16(TPM_API_ALG_ID_SHA384), uint16(len(r.Bytes())), r.Bytes(), uint16(len(s.Bytes())), s.Bytes(),
+		pcr0} {
+		assert.NoError(t, binary.Write(quote, binary.BigEndian, data))
+	}
+	return quote.Bytes()
+}
+
+func TestVerifyQuoteAndGetPCRManifestEcdsa(t *testing.T) {
+	aikKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	aikCertificate := &x509.Certificate{PublicKey: &aikKey.PublicKey}
+
+	nonce := []byte("0123456789abcdef0123")
+	pcr0 := bytes.Repeat([]byte{0xab}, SHA384_SIZE)
+	quote := buildEcdsaQuote(t, aikKey, nonce, pcr0)
+
+	pcrManifest, _, err := VerifyQuoteAndGetPCRManifest("[]", nonce, quote, aikCertificate)
+	assert.NoError(t, err)
+	pcr, err := pcrManifest.GetPcrValue("SHA384", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(pcr0), pcr.Value)
+
+	// the quote is not signed by another AIK
+	otherKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	_, _, err = VerifyQuoteAndGetPCRManifest("[]", nonce, quote, &x509.Certificate{PublicKey: &otherKey.PublicKey})
+	assert.Error(t, err)
+}
@@ -23,9 +23,12 @@ func GetConnectorDetails(connectionString string) (types.VendorConnector, error)
 	var vendorURL string
 	var vendorName string
 
-	// use a regex to eliminate all invalid connection strings
+	// use a regex to eliminate all invalid connection strings, the simulated hosts are only rejected by the
+	// HostConnectorFactory when they are not enabled
 	if err := validation.ValidateConnectionString(connectionString); err != nil {
-		return types.VendorConnector{}, err
+		if validation.ValidateSimulatorConnectionString(connectionString) != nil {
+			return types.VendorConnector{}, err
+		}
 	}
 
 	vendor := GetVendorPrefix(connectionString)
@@ -70,6 +73,8 @@ func GetVendorPrefix(connectionString string) constants.Vendor {
 		return constants.VendorVMware
 	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorMicrosoft.String()+":")) {
 		return constants.VendorMicrosoft
+	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorSimulator.String()+":")) {
+		return constants.VendorSimulator
 	}
 	return constants.VendorUnknown
 }
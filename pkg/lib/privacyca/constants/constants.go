@@ -17,8 +17,13 @@ const (
 	IDENTITY                    = "IDENTITY"
 	STORAGE                     = "STORAGE"
 	INTEGRITY                   = "INTEGRITY"
+	TPM_ALG_ID_SHA1             = 0x0004
 	TPM_ALG_ID_SHA256           = 0x000B
 	TPM_ALG_ID_SHA384           = 0x000C
+	TPM_ALG_ID_SHA512           = 0x000D
+	TPM_ALG_RSASSA              = 0x0014
+	TPM_ALG_RSAPSS              = 0x0016
+	TPM_ALG_ECDSA               = 0x0018
 	HOST_KEYS_CERT_VALIDITY     = 10
 	Tpm2NameDigestPrefixPadding = "22000b"
 	Tpm2NameDigestSuffixPadding = "00000000000000000000000000000000000000000000000000000000000000000000"
@@ -9,8 +9,12 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/aes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/base64"
@@ -267,3 +271,94 @@ func TestGetPublicKeyFromModulus(t *testing.T) {
 	_, err = certifyKey20.GetPublicKeyFromModulus()
 	assert.NoError(t, err)
 }
+
+func TestProcessMakeCredentialWithEccEk(t *testing.T) {
+	ekKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	credential, err := crypt.GetRandomBytes(16)
+	assert.NoError(t, err)
+	tpm2Credential, err := tpm2utils.MakeCredential(&ekKey.PublicKey, consts.TPM2AlgorithmSymmetricAES, consts.SymmetricKeyBits128, crypto.SHA256, credential, aikName)
+	assert.NoError(t, err)
+
+	// recover the seed as the TPM does with the EK private key and the ephemeral point held by the secret
+	secret := bytes.NewBuffer(tpm2Credential.Secret)
+	readTpm2b := func() []byte {
+		var size uint16
+		assert.NoError(t, binary.Read(secret, binary.BigEndian, &size))
+		return secret.Next(int(size))
+	}
+	secretSize := binary.BigEndian.Uint16(secret.Next(2))
+	assert.Equal(t, int(secretSize), secret.Len())
+	ephemeralX, ephemeralY := readTpm2b(), readTpm2b()
+	z, _ := elliptic.P256().ScalarMult(new(big.Int).SetBytes(ephemeralX), new(big.Int).SetBytes(ephemeralY), ekKey.D.Bytes())
+	pad := func(b []byte) []byte { return append(make([]byte, 32-len(b)), b...) }
+	seed, err := tpm2utils.KDFe(crypto.SHA256, pad(z.Bytes()), consts.IDENTITY, ephemeralX, pad(ekKey.X.Bytes()), 256)
+	assert.NoError(t, err)
+
+	// decrypt the credential with the symmetric key derived from the seed
+	symKey, err := tpm2utils.KDFa(crypto.SHA256, seed, consts.STORAGE, aikName, nil, consts.SymmetricKeyBits128)
+	assert.NoError(t, err)
+	credentialBlob := tpm2Credential.CredentialBlob
+	integritySize := binary.BigEndian.Uint16(credentialBlob[2:4])
+	encryptedCredential := credentialBlob[4+integritySize:]
+	decryptedCredential, err := tpm2utils.DecryptSym(encryptedCredential, symKey, make([]byte, aes.BlockSize), "CBF", consts.TPM_ALG_AES)
+	assert.NoError(t, err)
+	assert.Equal(t, credential, decryptedCredential[2:])
+}
+
+func TestTpmtSignatureVerify(t *testing.T) {
+	message := []byte("TPMS_ATTEST")
+
+	eccKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	digest := sha512.Sum384(message)
+	r, s, err := ecdsa.Sign(rand.Reader, eccKey, digest[:])
+	assert.NoError(t, err)
+	eccSignature := new(bytes.Buffer)
+	for _, data := range []interface{}{uint16(consts.TPM_ALG_ECDSA), uint16(consts.TPM_ALG_ID_SHA384),
+		uint16(len(r.Bytes())), r.Bytes(), uint16(len(s.Bytes())), s.Bytes()} {
+		assert.NoError(t, binary.Write(eccSignature, binary.BigEndian, data))
+	}
+
+	tpmtSignature, size, err := tpm2utils.ParseTpmtSignature(append(eccSignature.Bytes(), 0xff))
+	assert.NoError(t, err)
+	assert.Equal(t, eccSignature.Len(), size)
+	assert.NoError(t, tpmtSignature.Verify(&eccKey.PublicKey, message))
+	assert.Error(t, tpmtSignature.Verify(&eccKey.PublicKey, []byte("tampered")))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaDigest := sha256.Sum256(message)
+	pssSignature, err := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, rsaDigest[:], nil)
+	assert.NoError(t, err)
+	rsaSignature := new(bytes.Buffer)
+	for _, data := range []interface{}{uint16(consts.TPM_ALG_RSAPSS), uint16(consts.TPM_ALG_ID_SHA256),
+		uint16(len(pssSignature)), pssSignature} {
+		assert.NoError(t, binary.Write(rsaSignature, binary.BigEndian, data))
+	}
+
+	tpmtSignature, _, err = tpm2utils.ParseTpmtSignature(rsaSignature.Bytes())
+	assert.NoError(t, err)
+	assert.NoError(t, tpmtSignature.Verify(&rsaKey.PublicKey, message))
+	// the ECDSA scheme cannot be verified with an RSA key
+	assert.Error(t, tpmtSignature.Verify(&eccKey.PublicKey, message))
+
+	_, _, err = tpm2utils.ParseTpmtSignature(rsaSignature.Bytes()[:10])
+	assert.Error(t, err)
+}
+
+func TestGetAikPublicKey(t *testing.T) {
+	rsaAik, err := tpm2utils.GetAikPublicKey(aikModulus)
+	assert.NoError(t, err)
+	assert.IsType(t, &rsa.PublicKey{}, rsaAik)
+
+	eccKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	eccAik, err := tpm2utils.GetAikPublicKey(elliptic.Marshal(elliptic.P256(), eccKey.X, eccKey.Y))
+	assert.NoError(t, err)
+	assert.Equal(t, &eccKey.PublicKey, eccAik)
+
+	_, err = tpm2utils.GetAikPublicKey(nil)
+	assert.Error(t, err)
+}
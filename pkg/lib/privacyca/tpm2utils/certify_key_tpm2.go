@@ -8,6 +8,7 @@ package tpm2utils
 import (
 	"bytes"
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -49,6 +50,19 @@ func (certifyKey20 *CertifyKey20) IsCertifiedKeySignatureValid(aikCert *x509.Cer
 		return false, errors.New("tpm2utils/certify_key_tpm2:IsCertifiedKeySignatureValid() Error while getting hash algorithm from tpm certificate")
 	}
 
+	if aikEcdsaPubKey, ok := aikCert.PublicKey.(*ecdsa.PublicKey); ok {
+		// the certify signature of an ECC AIK is a TPMT_SIGNATURE using the ECDSA scheme
+		tpmtSignature, _, err := ParseTpmtSignature(tpmCertifyKeySignatureBytes)
+		if err != nil {
+			return false, errors.Wrap(err, "tpm2utils/certify_key_tpm2:IsCertifiedKeySignatureValid() Error parsing certifyKeySignatureBlob")
+		}
+		err = tpmtSignature.Verify(aikEcdsaPubKey, tpmCertifyKeyBytes)
+		if err != nil {
+			return false, errors.Wrap(err, "tpm2utils/certify_key_tpm2:IsCertifiedKeySignatureValid() Error during signature verification.")
+		}
+		return true, nil
+	}
+
 	if len(tpmCertifyKeySignatureBytes) > 256 {
 		defaultLog.Debug("tpm2utils/certify_key_tpm2:IsCertifiedKeySignatureValid() Length of certifyKeySignatureBlob is larger then 256, TPM 2.0. Will only parse out the required 256 bytes:")
 		signedSignatureBytes = make([]byte, 256)
@@ -57,7 +71,10 @@ func (certifyKey20 *CertifyKey20) IsCertifiedKeySignatureValid(aikCert *x509.Cer
 		return false, errors.New("tpm2utils/certify_key_tpm2:IsCertifiedKeySignatureValid() Length of certifyKeySignatureBlob is 256 or less, TPM 1.2")
 	}
 
-	aikRsaPubKey := aikCert.PublicKey.(*rsa.PublicKey)
+	aikRsaPubKey, ok := aikCert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return false, errors.Errorf("tpm2utils/certify_key_tpm2:IsCertifiedKeySignatureValid() Unsupported AIK public key type %T", aikCert.PublicKey)
+	}
 	if hashAlg != constants.TPM_ALG_ID_SHA256 {
 		return false, errors.Errorf("tpm2utils/certify_key_tpm2:IsCertifiedKeySignatureValid() Unsupported hash algorithm, hash alg ID: %d", hashAlg)
 	}
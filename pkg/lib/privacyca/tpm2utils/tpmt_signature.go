@@ -0,0 +1,165 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package tpm2utils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/privacyca/constants"
+	"github.com/pkg/errors"
+)
+
+// TpmtSignature corresponds to the TPMT_SIGNATURE structure produced by TPM2_Quote and TPM2_Certify. Signature is
+// set for the RSASSA and RSAPSS schemes, R and S are set for the ECDSA scheme.
+type TpmtSignature struct {
+	SigAlg    uint16
+	HashAlg   uint16
+	Signature []byte
+	R         *big.Int
+	S         *big.Int
+}
+
+// tpmHashAlgorithms maps the TPM algorithm ids of the hash algorithms accepted in signatures to their crypto.Hash
+var tpmHashAlgorithms = map[uint16]crypto.Hash{
+	constants.TPM_ALG_ID_SHA256: crypto.SHA256,
+	constants.TPM_ALG_ID_SHA384: crypto.SHA384,
+	constants.TPM_ALG_ID_SHA512: crypto.SHA512,
+}
+
+// ParseTpmtSignature parses the TPMT_SIGNATURE at the start of tpmtSignatureBytes and returns it with the number
+// of bytes it spans
+func ParseTpmtSignature(tpmtSignatureBytes []byte) (*TpmtSignature, int, error) {
+	defaultLog.Trace("tpm2utils/tpmt_signature:ParseTpmtSignature() Entering")
+	defer defaultLog.Trace("tpm2utils/tpmt_signature:ParseTpmtSignature() Leaving")
+
+	buf := bytes.NewBuffer(tpmtSignatureBytes)
+	var tpmtSignature TpmtSignature
+	if err := binary.Read(buf, binary.BigEndian, &tpmtSignature.SigAlg); err != nil {
+		return nil, 0, errors.Wrap(err, "tpm2utils/tpmt_signature:ParseTpmtSignature() Error reading signature algorithm")
+	}
+	if err := binary.Read(buf, binary.BigEndian, &tpmtSignature.HashAlg); err != nil {
+		return nil, 0, errors.Wrap(err, "tpm2utils/tpmt_signature:ParseTpmtSignature() Error reading signature hash algorithm")
+	}
+
+	switch tpmtSignature.SigAlg {
+	case constants.TPM_ALG_RSASSA, constants.TPM_ALG_RSAPSS:
+		// TPMS_SIGNATURE_RSA holds a TPM2B_PUBLIC_KEY_RSA
+		signature, err := readTpm2b(buf)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "tpm2utils/tpmt_signature:ParseTpmtSignature() Error reading RSA signature")
+		}
+		tpmtSignature.Signature = signature
+	case constants.TPM_ALG_ECDSA:
+		// TPMS_SIGNATURE_ECC holds the R and S TPM2B_ECC_PARAMETERs
+		r, err := readTpm2b(buf)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "tpm2utils/tpmt_signature:ParseTpmtSignature() Error reading ECDSA signature R")
+		}
+		s, err := readTpm2b(buf)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "tpm2utils/tpmt_signature:ParseTpmtSignature() Error reading ECDSA signature S")
+		}
+		tpmtSignature.R = new(big.Int).SetBytes(r)
+		tpmtSignature.S = new(big.Int).SetBytes(s)
+	default:
+		return nil, 0, errors.Errorf("tpm2utils/tpmt_signature:ParseTpmtSignature() Unsupported signature algorithm, alg ID: %d", tpmtSignature.SigAlg)
+	}
+
+	return &tpmtSignature, len(tpmtSignatureBytes) - buf.Len(), nil
+}
+
+// readTpm2b reads a TPM2B structure, a buffer prefixed by its size
+func readTpm2b(buf *bytes.Buffer) ([]byte, error) {
+	var size uint16
+	if err := binary.Read(buf, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if int(size) > buf.Len() {
+		return nil, errors.Errorf("size %d exceeds the %d remaining bytes", size, buf.Len())
+	}
+	return buf.Next(int(size)), nil
+}
+
+// Hash returns the hash algorithm of the signature
+func (tpmtSignature *TpmtSignature) Hash() (crypto.Hash, error) {
+	hash, ok := tpmHashAlgorithms[tpmtSignature.HashAlg]
+	if !ok {
+		return 0, errors.Errorf("tpm2utils/tpmt_signature:Hash() Unsupported signature hash algorithm, hash alg ID: %d", tpmtSignature.HashAlg)
+	}
+	return hash, nil
+}
+
+// Verify verifies the signature of message with the public key, using the signature scheme and hash algorithm of the
+// signature
+func (tpmtSignature *TpmtSignature) Verify(publicKey crypto.PublicKey, message []byte) error {
+	defaultLog.Trace("tpm2utils/tpmt_signature:Verify() Entering")
+	defer defaultLog.Trace("tpm2utils/tpmt_signature:Verify() Leaving")
+
+	hash, err := tpmtSignature.Hash()
+	if err != nil {
+		return err
+	}
+	h := hash.New()
+	if _, err = h.Write(message); err != nil {
+		return errors.Wrap(err, "tpm2utils/tpmt_signature:Verify() Error writing message")
+	}
+	digest := h.Sum(nil)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		switch tpmtSignature.SigAlg {
+		case constants.TPM_ALG_RSASSA:
+			err = rsa.VerifyPKCS1v15(key, hash, digest, tpmtSignature.Signature)
+		case constants.TPM_ALG_RSAPSS:
+			err = rsa.VerifyPSS(key, hash, digest, tpmtSignature.Signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		default:
+			return errors.Errorf("tpm2utils/tpmt_signature:Verify() Signature algorithm %d cannot be verified with an RSA key", tpmtSignature.SigAlg)
+		}
+		if err != nil {
+			return errors.Wrap(err, "tpm2utils/tpmt_signature:Verify() RSA signature verification failed")
+		}
+	case *ecdsa.PublicKey:
+		if tpmtSignature.SigAlg != constants.TPM_ALG_ECDSA {
+			return errors.Errorf("tpm2utils/tpmt_signature:Verify() Signature algorithm %d cannot be verified with an ECC key", tpmtSignature.SigAlg)
+		}
+		if !ecdsa.Verify(key, digest, tpmtSignature.R, tpmtSignature.S) {
+			return errors.New("tpm2utils/tpmt_signature:Verify() ECDSA signature verification failed")
+		}
+	default:
+		return errors.Errorf("tpm2utils/tpmt_signature:Verify() Unsupported public key type %T", publicKey)
+	}
+	return nil
+}
+
+// GetAikPublicKey returns the AIK public key sent in an identity request. The request holds the modulus of an RSA AIK,
+// or the uncompressed point of an ECC AIK on the NIST P-256 or P-384 curve.
+func GetAikPublicKey(aikPublic []byte) (crypto.PublicKey, error) {
+	defaultLog.Trace("tpm2utils/tpmt_signature:GetAikPublicKey() Entering")
+	defer defaultLog.Trace("tpm2utils/tpmt_signature:GetAikPublicKey() Leaving")
+
+	if len(aikPublic) == 0 {
+		return nil, errors.New("tpm2utils/tpmt_signature:GetAikPublicKey() AIK public key is empty")
+	}
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		coordinateSize := (curve.Params().BitSize + 7) / 8
+		if len(aikPublic) != 1+2*coordinateSize || aikPublic[0] != 0x04 {
+			continue
+		}
+		x, y := elliptic.Unmarshal(curve, aikPublic)
+		if x == nil {
+			return nil, errors.New("tpm2utils/tpmt_signature:GetAikPublicKey() AIK public point is not on its curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	// the TPM uses the default RSA exponent
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(aikPublic), E: 65537}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"crypto"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/ecdsa"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/rsa"
@@ -26,7 +27,7 @@ import (
 
 func isSupportedAsymAlgorithm(pubKey crypto.PublicKey) bool {
 	switch pubKey.(type) {
-	case *rsa.PublicKey:
+	case *rsa.PublicKey, *ecdsa.PublicKey:
 		return true
 	default:
 		return false
@@ -114,6 +115,24 @@ func MakeCredential(ekPubKey crypto.PublicKey, symmetricAlgorithm string, symKey
 			}
 		}
 		break
+	case *ecdsa.PublicKey:
+		{
+			// the seed is shared with the TPM through an ephemeral ECDH key and the encrypted secret is the
+			// ephemeral public point, a TPMS_ECC_POINT
+			secretData, encryptedSecret, err := makeEccSecret(ekPubKey.(*ecdsa.PublicKey), nameAlgorithm, consts.IDENTITY)
+			if err != nil {
+				return types.Tpm2Credential{}, err
+			}
+			seed = secretData
+			err = binary.Write(encryptedSecretByteBuffer, binary.BigEndian, uint16(len(encryptedSecret)))
+			if err != nil {
+				return types.Tpm2Credential{}, errors.Wrapf(err, "privacyca/tpm2utils/utils:MakeCredential() Failed to write secret size")
+			}
+			err = binary.Write(encryptedSecretByteBuffer, binary.BigEndian, encryptedSecret)
+			if err != nil {
+				return types.Tpm2Credential{}, errors.Wrapf(err, "privacyca/tpm2utils/utils:MakeCredential() Failed to write secret")
+			}
+		}
 	default:
 		return types.Tpm2Credential{}, errors.New("privacyca/tpm2utils/utils:MakeCredential() Key Algorithm is not currently supported")
 	}
@@ -197,6 +216,86 @@ func MakeCredential(ekPubKey crypto.PublicKey, symmetricAlgorithm string, symKey
 	return tpm2Credential, nil
 }
 
+// makeEccSecret generates an ephemeral key on the curve of the EK and derives the seed from the ECDH shared secret
+// with KDFe as described in the TPM 2.0 specification. It returns the seed and the marshalled ephemeral public point
+func makeEccSecret(ekPubKey *ecdsa.PublicKey, nameAlgorithm crypto.Hash, label string) ([]byte, []byte, error) {
+	defaultLog.Trace("privacyca/tpm2utils/utils:makeEccSecret() Entering")
+	defer defaultLog.Trace("privacyca/tpm2utils/utils:makeEccSecret() Leaving")
+
+	curve := ekPubKey.Curve
+	if !curve.IsOnCurve(ekPubKey.X, ekPubKey.Y) {
+		return nil, nil, errors.New("privacyca/tpm2utils/utils:makeEccSecret() Ek PubKey is not on its curve")
+	}
+	ephemeralKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "privacyca/tpm2utils/utils:makeEccSecret() Unable to generate ephemeral key")
+	}
+	z, _ := curve.ScalarMult(ekPubKey.X, ekPubKey.Y, ephemeralKey.D.Bytes())
+
+	coordinateSize := (curve.Params().BitSize + 7) / 8
+	ephemeralX := padLeft(ephemeralKey.X.Bytes(), coordinateSize)
+	ephemeralY := padLeft(ephemeralKey.Y.Bytes(), coordinateSize)
+	seed, err := KDFe(nameAlgorithm, padLeft(z.Bytes(), coordinateSize), label, ephemeralX,
+		padLeft(ekPubKey.X.Bytes(), coordinateSize), nameAlgorithm.Size()*8)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	point := new(bytes.Buffer)
+	for _, coordinate := range [][]byte{ephemeralX, ephemeralY} {
+		err = binary.Write(point, binary.BigEndian, uint16(len(coordinate)))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "privacyca/tpm2utils/utils:makeEccSecret() Failed to write coordinate size")
+		}
+		err = binary.Write(point, binary.BigEndian, coordinate)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "privacyca/tpm2utils/utils:makeEccSecret() Failed to write coordinate")
+		}
+	}
+	return seed, point.Bytes(), nil
+}
+
+func padLeft(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
+
+// KDFe is the key derivation function of the TPM 2.0 specification used with ECDH shared secrets
+func KDFe(hashAlg crypto.Hash, z []byte, label string, partyUInfo, partyVInfo []byte, sizeInBits int) ([]byte, error) {
+	defaultLog.Trace("privacyca/tpm2utils/utils:KDFe() Entering")
+	defer defaultLog.Trace("privacyca/tpm2utils/utils:KDFe() Leaving")
+
+	if !isSupportedHashAlgorithm(hashAlg) {
+		return nil, errors.Errorf("privacyca/tpm2utils/utils:KDFe() Algorithm: %s, is not a supported hashing algorithm", crypt.GetHashingAlgorithmName(hashAlg))
+	}
+
+	symBytesLen := (sizeInBits + 7) / 8
+	var outBuf []byte
+	for counter := uint32(1); len(outBuf) < symBytesLen; counter++ {
+		h := hashAlg.New()
+		b := new(bytes.Buffer)
+		for _, data := range []interface{}{counter, z, []byte(label), byte(0x00), partyUInfo, partyVInfo} {
+			err := binary.Write(b, binary.BigEndian, data)
+			if err != nil {
+				return nil, errors.Wrap(err, "privacyca/tpm2utils/utils:KDFe() Failed to write bytes")
+			}
+		}
+		_, err := h.Write(b.Bytes())
+		if err != nil {
+			return nil, errors.Wrap(err, "privacyca/tpm2utils/utils:KDFe() Failed to write bytes")
+		}
+		outBuf = append(outBuf, h.Sum(nil)...)
+	}
+	outBuf = outBuf[:symBytesLen]
+
+	if (sizeInBits % 8) != 0 {
+		outBuf[0] &= byte((1 << uint(sizeInBits%8)) - 1)
+	}
+	return outBuf, nil
+}
+
 func KDFa(hashAlg crypto.Hash, key []byte, label string, contextU, contextV []byte, sizeInBits int) ([]byte, error) {
 	defaultLog.Trace("privacyca/tpm2utils/utils:KDFa() Entering")
 	defer defaultLog.Trace("privacyca/tpm2utils/utils:KDFa() Leaving")
@@ -123,6 +123,16 @@ func (factory *ruleFactory) GetVerificationRules() ([]rules.Rule, string, error)
 		}
 	}
 
+	// add the rules from the custom rule registry that are attached to the flavor
+	for _, customRule := range factory.signedFlavor.Flavor.CustomRules {
+		requiredRules = append(requiredRules, rules.NewCustomRule(customRule, flavorPart))
+	}
+
+	// verify the IMA log against the allowlist of the flavor
+	if factory.signedFlavor.Flavor.Ima != nil {
+		requiredRules = append(requiredRules, rules.NewImaMeasurementLogTrusted(factory.signedFlavor.Flavor.Meta.ID, factory.signedFlavor.Flavor.Ima, flavorPart))
+	}
+
 	// if skip flavor signing verification is enabled, add the FlavorTrusted.
 	if !factory.skipSignedFlavorVerification {
 		var flavorPart common.FlavorPart
@@ -132,8 +142,9 @@ func (factory *ruleFactory) GetVerificationRules() ([]rules.Rule, string, error)
 		}
 
 		flavorTrusted, err := rules.NewFlavorTrusted(factory.signedFlavor,
-			factory.verifierCertificates.FlavorSigningCertificate,
+			factory.verifierCertificates.FlavorSigningCertificates,
 			factory.verifierCertificates.FlavorCACertificates,
+			factory.verifierCertificates.FlavorIntermediateCACertificates,
 			flavorPart)
 
 		if err != nil {
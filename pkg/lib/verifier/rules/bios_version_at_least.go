@@ -0,0 +1,98 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Custom rule that requires the BIOS version of the host to be at least 'min_version'.
+//
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+)
+
+const minVersionParameter = "min_version"
+
+// NewBiosVersionAtLeast creates the BiosVersionAtLeast custom rule, the 'min_version' parameter is required
+func NewBiosVersionAtLeast(parameters map[string]interface{}, marker common.FlavorPart) (Rule, error) {
+	minVersion, err := getStringParameter(parameters, minVersionParameter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &biosVersionAtLeast{
+		minVersion: minVersion,
+		marker:     marker,
+	}, nil
+}
+
+type biosVersionAtLeast struct {
+	minVersion string
+	marker     common.FlavorPart
+}
+
+func (rule *biosVersionAtLeast) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleBiosVersionAtLeast
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.ExpectedValue = &rule.minVersion
+	result.Rule.Parameters = map[string]interface{}{minVersionParameter: rule.minVersion}
+
+	biosVersion := hostManifest.HostInfo.BiosVersion
+	if compareVersions(biosVersion, rule.minVersion) < 0 {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:          constants.FaultBiosVersionBelowMinimum,
+			Description:   fmt.Sprintf("Host BIOS version '%s' is lower than the minimum version '%s'", biosVersion, rule.minVersion),
+			ExpectedValue: &rule.minVersion,
+			ActualValue:   &biosVersion,
+		})
+	}
+
+	return &result, nil
+}
+
+// compareVersions compares two version strings segment by segment, where segments are separated by any character
+// other than a letter or digit. Numeric segments are compared as numbers and other segments as strings. Only the
+// segments present in 'minVersion' are compared, so "1.2.3" is not lower than "1.2". Returns a negative number when
+// 'version' is lower than 'minVersion', zero when equal and a positive number otherwise.
+func compareVersions(version, minVersion string) int {
+	isSeparator := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}
+	versionSegments := strings.FieldsFunc(version, isSeparator)
+	minVersionSegments := strings.FieldsFunc(minVersion, isSeparator)
+
+	for i, minSegment := range minVersionSegments {
+		if i >= len(versionSegments) {
+			return -1
+		}
+		segment := versionSegments[i]
+
+		number, numberErr := strconv.ParseUint(segment, 10, 64)
+		minNumber, minNumberErr := strconv.ParseUint(minSegment, 10, 64)
+		if numberErr == nil && minNumberErr == nil {
+			if number != minNumber {
+				if number < minNumber {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+
+		if comparison := strings.Compare(strings.ToUpper(segment), strings.ToUpper(minSegment)); comparison != 0 {
+			return comparison
+		}
+	}
+	return 0
+}
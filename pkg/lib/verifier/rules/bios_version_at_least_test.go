@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiosVersionAtLeastNoFault(t *testing.T) {
+	rule, err := NewBiosVersionAtLeast(map[string]interface{}{minVersionParameter: "SE5C620.86B.00.01.0014"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.BiosVersion = "SE5C620.86B.00.01.0015.110720180833"
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RuleBiosVersionAtLeast, result.Rule.Name)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.True(t, result.Trusted)
+}
+
+func TestBiosVersionAtLeastFault(t *testing.T) {
+	rule, err := NewBiosVersionAtLeast(map[string]interface{}{minVersionParameter: "SE5C620.86B.00.01.0015"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.BiosVersion = "SE5C620.86B.00.01.0009.101920170742"
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultBiosVersionBelowMinimum, result.Faults[0].Name)
+	assert.Equal(t, "SE5C620.86B.00.01.0009.101920170742", *result.Faults[0].ActualValue)
+}
+
+func TestBiosVersionAtLeastMissingParameter(t *testing.T) {
+	_, err := NewBiosVersionAtLeast(map[string]interface{}{}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("4.1.8", "4.1.8"))
+	assert.True(t, compareVersions("4.1.10", "4.1.8") > 0)
+	assert.True(t, compareVersions("4.1", "4.1.8") < 0)
+	assert.Equal(t, 0, compareVersions("4.1.8.1", "4.1.8"))
+	assert.True(t, compareVersions("2.0b", "2.0a") > 0)
+	assert.True(t, compareVersions("", "1") < 0)
+}
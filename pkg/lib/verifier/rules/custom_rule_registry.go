@@ -0,0 +1,211 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Registry of the custom rules that can be attached to flavors and flavor templates
+// by name, in addition to the rules created by the vendor rule builders.
+//
+
+import (
+	"fmt"
+	"sync"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// CustomRuleBuilder creates a Rule from the parameters of a custom rule attached to a flavor.
+// The marker is the flavor part of the flavor that the rule is attached to.
+type CustomRuleBuilder func(parameters map[string]interface{}, marker common.FlavorPart) (Rule, error)
+
+var (
+	customRuleBuilders = make(map[string]CustomRuleBuilder)
+	customRuleLock     sync.RWMutex
+)
+
+func init() {
+	builtinCustomRules := map[string]CustomRuleBuilder{
+		constants.CustomRuleBiosVersionAtLeast:      NewBiosVersionAtLeast,
+		constants.CustomRuleHardwareFeaturesEnabled: NewHardwareFeaturesEnabled,
+		constants.CustomRuleHostInfoDenylist:        NewHostInfoDenylist,
+		constants.CustomRuleSecureBootPolicy:        NewSecureBootPolicy,
+	}
+	for name, builder := range builtinCustomRules {
+		if err := RegisterCustomRule(name, builder); err != nil {
+			log.WithError(err).Errorf("Error registering custom rule %s", name)
+		}
+	}
+}
+
+// RegisterCustomRule adds a custom rule to the registry so that flavors and flavor templates can reference it by name.
+// An error is returned if a rule is already registered with the same name.
+func RegisterCustomRule(name string, builder CustomRuleBuilder) error {
+	if name == "" {
+		return errors.New("The custom rule name cannot be empty")
+	}
+	if builder == nil {
+		return errors.Errorf("The builder of custom rule '%s' cannot be nil", name)
+	}
+
+	customRuleLock.Lock()
+	defer customRuleLock.Unlock()
+	if _, ok := customRuleBuilders[name]; ok {
+		return errors.Errorf("A custom rule is already registered with name '%s'", name)
+	}
+	customRuleBuilders[name] = builder
+	return nil
+}
+
+// ValidateCustomRule checks that the custom rule is registered and that the rule can be created from its parameters
+func ValidateCustomRule(customRule model.CustomRule) error {
+	customRuleLock.RLock()
+	builder, ok := customRuleBuilders[customRule.Name]
+	customRuleLock.RUnlock()
+
+	if !ok {
+		return errors.Errorf("Custom rule '%s' is not registered", customRule.Name)
+	}
+	if _, err := builder(customRule.Parameters, common.FlavorPartPlatform); err != nil {
+		return errors.Wrapf(err, "Invalid parameters for custom rule '%s'", customRule.Name)
+	}
+	return nil
+}
+
+// NewCustomRule creates the rule for a custom rule attached to a flavor. Flavors can reach the verifier from
+// other sources than the flavor templates (e.g. imported flavors), so a rule that is not registered or that
+// has invalid parameters does not fail the verification but results in a rule that always raises a fault.
+func NewCustomRule(customRule model.CustomRule, marker common.FlavorPart) Rule {
+	customRuleLock.RLock()
+	builder, ok := customRuleBuilders[customRule.Name]
+	customRuleLock.RUnlock()
+
+	if !ok {
+		return &customRuleFault{
+			customRule: customRule,
+			marker:     marker,
+			fault: hvs.Fault{
+				Name:        constants.FaultCustomRuleNotRegistered,
+				Description: fmt.Sprintf("Custom rule '%s' is not registered in the verifier", customRule.Name),
+			},
+		}
+	}
+
+	rule, err := builder(customRule.Parameters, marker)
+	if err != nil {
+		return &customRuleFault{
+			customRule: customRule,
+			marker:     marker,
+			fault: hvs.Fault{
+				Name:        constants.FaultCustomRuleInvalid,
+				Description: fmt.Sprintf("Custom rule '%s' could not be created: %s", customRule.Name, err.Error()),
+			},
+		}
+	}
+	return rule
+}
+
+// customRuleFault is used in place of a custom rule that could not be created
+type customRuleFault struct {
+	customRule model.CustomRule
+	marker     common.FlavorPart
+	fault      hvs.Fault
+}
+
+func (rule *customRuleFault) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{}
+	result.Trusted = false
+	result.Rule.Name = constants.RulePrefix + rule.customRule.Name
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.Parameters = rule.customRule.Parameters
+	result.Faults = append(result.Faults, rule.fault)
+	return &result, nil
+}
+
+// getStringParameter returns the value of a mandatory string parameter of a custom rule
+func getStringParameter(parameters map[string]interface{}, name string) (string, error) {
+	value, ok := parameters[name]
+	if !ok {
+		return "", errors.Errorf("The parameter '%s' is required", name)
+	}
+	stringValue, ok := value.(string)
+	if !ok || stringValue == "" {
+		return "", errors.Errorf("The parameter '%s' must be a non empty string", name)
+	}
+	return stringValue, nil
+}
+
+// getStringListParameter returns the value of a mandatory string list parameter of a custom rule
+func getStringListParameter(parameters map[string]interface{}, name string) ([]string, error) {
+	value, ok := parameters[name]
+	if !ok {
+		return nil, errors.Errorf("The parameter '%s' is required", name)
+	}
+
+	var values []string
+	switch listValue := value.(type) {
+	case []string:
+		values = listValue
+	case []interface{}:
+		for _, item := range listValue {
+			stringItem, ok := item.(string)
+			if !ok {
+				return nil, errors.Errorf("The parameter '%s' must be a list of strings", name)
+			}
+			values = append(values, stringItem)
+		}
+	default:
+		return nil, errors.Errorf("The parameter '%s' must be a list of strings", name)
+	}
+
+	if len(values) == 0 {
+		return nil, errors.Errorf("The parameter '%s' cannot be empty", name)
+	}
+	return values, nil
+}
+
+// getBoolParameter returns the value of a mandatory boolean parameter of a custom rule
+func getBoolParameter(parameters map[string]interface{}, name string) (bool, error) {
+	value, ok := parameters[name]
+	if !ok {
+		return false, errors.Errorf("The parameter '%s' is required", name)
+	}
+	boolValue, ok := value.(bool)
+	if !ok {
+		return false, errors.Errorf("The parameter '%s' must be a boolean", name)
+	}
+	return boolValue, nil
+}
+
+// getIntParameter returns the value of a mandatory non negative integer parameter of a custom rule. The numbers of
+// the parameters unmarshalled from json are float64, they must hold an integer value.
+func getIntParameter(parameters map[string]interface{}, name string) (int, error) {
+	value, ok := parameters[name]
+	if !ok {
+		return 0, errors.Errorf("The parameter '%s' is required", name)
+	}
+
+	var intValue int
+	switch numberValue := value.(type) {
+	case int:
+		intValue = numberValue
+	case float64:
+		intValue = int(numberValue)
+		if float64(intValue) != numberValue {
+			return 0, errors.Errorf("The parameter '%s' must be an integer", name)
+		}
+	default:
+		return 0, errors.Errorf("The parameter '%s' must be an integer", name)
+	}
+
+	if intValue < 0 {
+		return 0, errors.Errorf("The parameter '%s' cannot be negative", name)
+	}
+	return intValue, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCustomRule struct {
+	marker common.FlavorPart
+}
+
+func (rule *testCustomRule) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{Trusted: true}
+	result.Rule.Name = constants.RulePrefix + "TestCustomRule"
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	return &result, nil
+}
+
+func TestRegisterCustomRule(t *testing.T) {
+	builder := func(parameters map[string]interface{}, marker common.FlavorPart) (Rule, error) {
+		return &testCustomRule{marker: marker}, nil
+	}
+
+	err := RegisterCustomRule("TestCustomRule", builder)
+	assert.NoError(t, err)
+
+	// a rule cannot be registered twice, including the built-in ones
+	err = RegisterCustomRule("TestCustomRule", builder)
+	assert.Error(t, err)
+	err = RegisterCustomRule(constants.CustomRuleBiosVersionAtLeast, builder)
+	assert.Error(t, err)
+
+	err = RegisterCustomRule("", builder)
+	assert.Error(t, err)
+	err = RegisterCustomRule("NilCustomRule", nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, ValidateCustomRule(model.CustomRule{Name: "TestCustomRule"}))
+
+	rule := NewCustomRule(model.CustomRule{Name: "TestCustomRule"}, common.FlavorPartOs)
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.True(t, result.Trusted)
+	assert.Equal(t, []common.FlavorPart{common.FlavorPartOs}, result.Rule.Markers)
+}
+
+func TestCustomRuleNotRegistered(t *testing.T) {
+	customRule := model.CustomRule{Name: "NotRegistered"}
+	assert.Error(t, ValidateCustomRule(customRule))
+
+	rule := NewCustomRule(customRule, common.FlavorPartPlatform)
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.False(t, result.Trusted)
+	assert.Equal(t, constants.RulePrefix+"NotRegistered", result.Rule.Name)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultCustomRuleNotRegistered, result.Faults[0].Name)
+}
+
+func TestCustomRuleInvalidParameters(t *testing.T) {
+	customRule := model.CustomRule{
+		Name:       constants.CustomRuleBiosVersionAtLeast,
+		Parameters: map[string]interface{}{minVersionParameter: 12},
+	}
+	assert.Error(t, ValidateCustomRule(customRule))
+
+	rule := NewCustomRule(customRule, common.FlavorPartPlatform)
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.False(t, result.Trusted)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultCustomRuleInvalid, result.Faults[0].Name)
+}
@@ -13,30 +13,37 @@ import (
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"time"
 )
 
-func NewFlavorTrusted(signedFlavor *hvs.SignedFlavor, flavorSigningCertificate *x509.Certificate, flavorCaCertificates *x509.CertPool, marker common.FlavorPart) (Rule, error) {
+// NewFlavorTrusted creates the rule verifying the flavor signature. The first of the flavorSigningCertificates is the
+// current flavor signing certificate, the others are the previous ones. flavorIntermediateCertificates holds the
+// intermediate CAs of the previous flavor signing certificates, it may be nil.
+func NewFlavorTrusted(signedFlavor *hvs.SignedFlavor, flavorSigningCertificates []x509.Certificate, flavorCaCertificates *x509.CertPool, flavorIntermediateCertificates *x509.CertPool, marker common.FlavorPart) (Rule, error) {
 
 	return &flavorTrusted{
-		signedFlavor:             signedFlavor,
-		flavorId:                 signedFlavor.Flavor.Meta.ID,
-		flavorSigningCertificate: flavorSigningCertificate,
-		flavorCaCertificates:     flavorCaCertificates,
-		marker:                   marker,
+		signedFlavor:                   signedFlavor,
+		flavorId:                       signedFlavor.Flavor.Meta.ID,
+		flavorSigningCertificates:      flavorSigningCertificates,
+		flavorCaCertificates:           flavorCaCertificates,
+		flavorIntermediateCertificates: flavorIntermediateCertificates,
+		marker:                         marker,
 	}, nil
 }
 
 type flavorTrusted struct {
-	signedFlavor             *hvs.SignedFlavor
-	flavorId                 uuid.UUID
-	flavorSigningCertificate *x509.Certificate
-	flavorCaCertificates     *x509.CertPool
-	marker                   common.FlavorPart
+	signedFlavor                   *hvs.SignedFlavor
+	flavorId                       uuid.UUID
+	flavorSigningCertificates      []x509.Certificate
+	flavorCaCertificates           *x509.CertPool
+	flavorIntermediateCertificates *x509.CertPool
+	marker                         common.FlavorPart
 }
 
 // - If the flavor does not have a signature create a FaultFlavorSignatureMissing
-// - If the flavor's signature does not verify with the signing certificate and CAs, create a
-//   FaultFlavorSignatureNotTrusted
+// - If none of the signing certificates validate against the CAs, create FaultFlavorSignatureVerificationFailed
+// - If the flavor's signature does not verify with any of the signing certificates that validate against
+//   the CAs, create a FaultFlavorSignatureNotTrusted
 // - If any errors occur during verification, create FaultFlavorSignatureVerificationFailed
 func (rule *flavorTrusted) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
 
@@ -52,45 +59,64 @@ func (rule *flavorTrusted) Apply(hostManifest *types.HostManifest) (*hvs.RuleRes
 		}
 
 		result.Faults = append(result.Faults, fault)
-	} else if rule.flavorSigningCertificate == nil {
-		log.Error("FlavorSignatureVerificationFailed fault: The flavor signing certificate was not provided")
+	} else if len(rule.flavorSigningCertificates) == 0 {
+		log.Error("FlavorSignatureVerificationFailed fault: The flavor signing certificates were not provided")
 		result.Faults = append(result.Faults, newFlavorSignatureVerificationFailed(rule.flavorId))
 	} else if rule.flavorCaCertificates == nil {
 		log.Error("FlavorSignatureVerificationFailed fault: The flavor signing CA certificates were not provided")
 		result.Faults = append(result.Faults, newFlavorSignatureVerificationFailed(rule.flavorId))
 	} else {
 
-		// verify the cert and ca...
-		opts := x509.VerifyOptions{
-			Roots: rule.flavorCaCertificates,
-		}
+		// the flavor is trusted when its signature verifies with any of the signing certificates
+		// that validate against the CAs (i.e. the current and the previous flavor signing certificates)
+		trustedCertificates := 0
+		for i := range rule.flavorSigningCertificates {
+			signingCertificate := &rule.flavorSigningCertificates[i]
 
-		_, err := rule.flavorSigningCertificate.Verify(opts)
-		if err != nil {
-			log.Error("FlavorSignatureVerificationFailed fault: The flavor signing certificate did not validate against the CAs")
-			result.Faults = append(result.Faults, newFlavorSignatureVerificationFailed(rule.flavorId))
-			return &result, nil
+			opts := x509.VerifyOptions{
+				Roots:         rule.flavorCaCertificates,
+				Intermediates: rule.flavorIntermediateCertificates,
+			}
+			// the previous flavor signing certificates may have expired, their chain is verified at the end of
+			// their validity so that the flavors signed while they were valid remain trusted until re-signed
+			if i > 0 && time.Now().After(signingCertificate.NotAfter) {
+				opts.CurrentTime = signingCertificate.NotAfter
+			}
+
+			_, err := signingCertificate.Verify(opts)
+			if err != nil {
+				log.WithError(err).Debugf("The flavor signing certificate '%s' did not validate against the CAs", signingCertificate.Subject.CommonName)
+				continue
+			}
+
+			// get the public key for verifying the signed flavor
+			publicKey, ok := signingCertificate.PublicKey.(*rsa.PublicKey)
+			if !ok {
+				log.Debugf("The flavor signing certificate '%s' does not have a RSA public key", signingCertificate.Subject.CommonName)
+				continue
+			}
+			trustedCertificates++
+
+			err = rule.signedFlavor.Verify(publicKey)
+			if err == nil {
+				return &result, nil
+			}
+			log.WithError(err).Debugf("The flavor signature did not verify with the flavor signing certificate '%s'", signingCertificate.Subject.CommonName)
 		}
 
-		// get the public key for verifying the signed flavor
-		var ok bool
-		var publicKey *rsa.PublicKey
-		if publicKey, ok = rule.flavorSigningCertificate.PublicKey.(*rsa.PublicKey); !ok {
-			log.Error("FlavorSignatureVerificationFailed fault: Could not get the flavor signing certificate's public key")
+		if trustedCertificates == 0 {
+			log.Error("FlavorSignatureVerificationFailed fault: None of the flavor signing certificates validated against the CAs")
 			result.Faults = append(result.Faults, newFlavorSignatureVerificationFailed(rule.flavorId))
 			return &result, nil
 		}
 
-		err = rule.signedFlavor.Verify(publicKey)
-		if err != nil {
-			log.WithError(err).Error("FlavorSignatureVerificationFailed fault: Flavor Signature verification failed")
-			fault := hvs.Fault{
-				Name:        constants.FaultFlavorSignatureNotTrusted,
-				Description: fmt.Sprintf("Signature is not trusted for flavor with id %s", rule.flavorId),
-			}
-
-			result.Faults = append(result.Faults, fault)
+		log.Error("FlavorSignatureVerificationFailed fault: Flavor Signature verification failed")
+		fault := hvs.Fault{
+			Name:        constants.FaultFlavorSignatureNotTrusted,
+			Description: fmt.Sprintf("Signature is not trusted for flavor with id %s", rule.flavorId),
 		}
+
+		result.Faults = append(result.Faults, fault)
 	}
 
 	return &result, nil
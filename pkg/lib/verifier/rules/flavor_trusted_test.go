@@ -5,16 +5,22 @@
 package rules
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"math/big"
 	"testing"
+	"time"
 )
 
 func TestFlavorTrustedNoFault(t *testing.T) {
@@ -34,7 +40,7 @@ func TestFlavorTrustedNoFault(t *testing.T) {
 	assert.NoError(t, err)
 
 	// create the rule
-	rule, err := NewFlavorTrusted(signedFlavor, flavorSigningCertificate, flavorCaCertificates, common.FlavorPartPlatform)
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*flavorSigningCertificate}, flavorCaCertificates, nil, common.FlavorPartPlatform)
 	assert.NoError(t, err)
 
 	// apply the rule, the hostManifest has no impact on FlavorTrusted rule
@@ -65,7 +71,7 @@ func TestFlavorTrustedNoFaultFromJSON(t *testing.T) {
 	assert.NoError(t, err)
 
 	// create the rule
-	rule, err := NewFlavorTrusted(signedFlavor, flavorSigningCertificate, flavorCaCertificates, common.FlavorPartPlatform)
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*flavorSigningCertificate}, flavorCaCertificates, nil, common.FlavorPartPlatform)
 	assert.NoError(t, err)
 
 	// apply the rule, the hostManifest has no impact on FlavorTrusted rule
@@ -95,7 +101,7 @@ func TestFlavorTrustedFlavorSignatureMissingFault(t *testing.T) {
 	signedFlavor.Signature = ""
 
 	// create the rule
-	rule, err := NewFlavorTrusted(signedFlavor, flavorSigningCertificate, flavorCaCertificates, common.FlavorPartPlatform)
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*flavorSigningCertificate}, flavorCaCertificates, nil, common.FlavorPartPlatform)
 	assert.NoError(t, err)
 
 	// apply the rule, the hostManifest has no impact on FlavorTrusted rule
@@ -110,7 +116,7 @@ func TestFlavorTrustedFlavorSignatureMissingFault(t *testing.T) {
 func TestFlavorTrustedMissingFlavorSigningCertificate(t *testing.T) {
 
 	// create all of the certs, private keys, CAs etc. to test the rule
-	// the FlavorSigningCertificate will not be used in this test
+	// the FlavorSigningCertificates will not be used in this test
 	_, flavorCaCertificates, privateKey, err := createCryptoResources()
 	assert.NoError(t, err)
 
@@ -124,9 +130,9 @@ func TestFlavorTrustedMissingFlavorSigningCertificate(t *testing.T) {
 	signedFlavor, err := model.NewSignedFlavor(&flavor, privateKey)
 	assert.NoError(t, err)
 
-	// create the rule without the flavorSigningCertificate to invoke
+	// create the rule without the flavorSigningCertificates to invoke
 	// FaultFlavorSignatureVerificationFailed
-	rule, err := NewFlavorTrusted(signedFlavor, nil, flavorCaCertificates, common.FlavorPartPlatform)
+	rule, err := NewFlavorTrusted(signedFlavor, nil, flavorCaCertificates, nil, common.FlavorPartPlatform)
 	assert.NoError(t, err)
 
 	// apply the rule, the hostManifest has no impact on FlavorTrusted rule
@@ -157,7 +163,7 @@ func TestFlavorTrustedMissingCACertificates(t *testing.T) {
 
 	// create the rule without the CA certs to invoke
 	// FaultFlavorSignatureVerificationFailed
-	rule, err := NewFlavorTrusted(signedFlavor, flavorSigningCertificate, nil, common.FlavorPartPlatform)
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*flavorSigningCertificate}, nil, nil, common.FlavorPartPlatform)
 	assert.NoError(t, err)
 
 	// apply the rule, the hostManifest has no impact on FlavorTrusted rule
@@ -195,7 +201,7 @@ func TestFlavorTrustedInvalidRootCA(t *testing.T) {
 
 	// create the rule without the CA certs to invoke the
 	// FaultFlavorSignatureVerificationFailed
-	rule, err := NewFlavorTrusted(signedFlavor, flavorSigningCertificate, invalidCaCertificates, common.FlavorPartPlatform)
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*flavorSigningCertificate}, invalidCaCertificates, nil, common.FlavorPartPlatform)
 	assert.NoError(t, err)
 
 	// apply the rule, the hostManifest has no impact on FlavorTrusted rule
@@ -227,7 +233,7 @@ func TestFlavorTrustedForceSignatureVerificationToFail(t *testing.T) {
 	signedFlavor.Signature = "invalidsignature"
 
 	// create the rule
-	rule, err := NewFlavorTrusted(signedFlavor, flavorSigningCertificate, flavorCaCertificates, common.FlavorPartPlatform)
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*flavorSigningCertificate}, flavorCaCertificates, nil, common.FlavorPartPlatform)
 	assert.NoError(t, err)
 
 	// apply the rule, the hostManifest has no impact on FlavorTrusted rule
@@ -239,6 +245,199 @@ func TestFlavorTrustedForceSignatureVerificationToFail(t *testing.T) {
 	t.Logf("Fault description: %s", result.Faults[0].Description)
 }
 
+func TestFlavorTrustedPreviousFlavorSigningCertificate(t *testing.T) {
+
+	caCertificate, flavorCaCertificates, caPrivateKey, err := createCACryptoResources()
+	assert.NoError(t, err)
+
+	// the flavor was signed before the flavor signing certificate was renewed
+	previousSigningCertificate, previousPrivateKey, err := newFlavorSigningCertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+	currentSigningCertificate, _, err := newFlavorSigningCertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+
+	flavor := hvs.Flavor{
+		Meta: model.Meta{
+			ID: testUuid,
+		},
+	}
+
+	signedFlavor, err := model.NewSignedFlavor(&flavor, previousPrivateKey)
+	assert.NoError(t, err)
+
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*currentSigningCertificate, *previousSigningCertificate}, flavorCaCertificates, nil, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestFlavorTrustedUnknownFlavorSigningKey(t *testing.T) {
+
+	caCertificate, flavorCaCertificates, caPrivateKey, err := createCACryptoResources()
+	assert.NoError(t, err)
+
+	currentSigningCertificate, _, err := newFlavorSigningCertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+	previousSigningCertificate, _, err := newFlavorSigningCertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+
+	// the flavor is signed by a key none of the flavor signing certificates is issued for
+	_, unknownPrivateKey, err := newFlavorSigningCertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+
+	flavor := hvs.Flavor{
+		Meta: model.Meta{
+			ID: testUuid,
+		},
+	}
+
+	signedFlavor, err := model.NewSignedFlavor(&flavor, unknownPrivateKey)
+	assert.NoError(t, err)
+
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*currentSigningCertificate, *previousSigningCertificate}, flavorCaCertificates, nil, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultFlavorSignatureNotTrusted, result.Faults[0].Name)
+}
+
+func TestFlavorTrustedUntrustedPreviousFlavorSigningCertificate(t *testing.T) {
+
+	caCertificate, flavorCaCertificates, caPrivateKey, err := createCACryptoResources()
+	assert.NoError(t, err)
+	currentSigningCertificate, _, err := newFlavorSigningCertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+
+	// the previous flavor signing certificate is issued by a CA that is not trusted
+	otherCaCertificate, _, otherCaPrivateKey, err := createCACryptoResources()
+	assert.NoError(t, err)
+	previousSigningCertificate, previousPrivateKey, err := newFlavorSigningCertificate(otherCaCertificate, otherCaPrivateKey)
+	assert.NoError(t, err)
+
+	flavor := hvs.Flavor{
+		Meta: model.Meta{
+			ID: testUuid,
+		},
+	}
+
+	signedFlavor, err := model.NewSignedFlavor(&flavor, previousPrivateKey)
+	assert.NoError(t, err)
+
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*currentSigningCertificate, *previousSigningCertificate}, flavorCaCertificates, nil, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultFlavorSignatureNotTrusted, result.Faults[0].Name)
+}
+
+func TestFlavorTrustedExpiredPreviousFlavorSigningCertificate(t *testing.T) {
+
+	caCertificate, flavorCaCertificates, caPrivateKey, err := createCACryptoResources()
+	assert.NoError(t, err)
+	currentSigningCertificate, _, err := newFlavorSigningCertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+
+	// the previous flavor signing certificate has expired and was issued by an intermediate CA that is archived
+	// along with it
+	intermediateCaCertificate, intermediateCaPrivateKey, err := newIntermediateCACertificate(caCertificate, caPrivateKey)
+	assert.NoError(t, err)
+	previousSigningCertificate, previousPrivateKey, err := newFlavorSigningCertificate(intermediateCaCertificate, intermediateCaPrivateKey)
+	assert.NoError(t, err)
+	previousSigningCertificate, err = expireCertificate(previousSigningCertificate, intermediateCaCertificate, intermediateCaPrivateKey)
+	assert.NoError(t, err)
+	flavorIntermediateCertificates := x509.NewCertPool()
+	flavorIntermediateCertificates.AddCert(intermediateCaCertificate)
+
+	flavor := hvs.Flavor{
+		Meta: model.Meta{
+			ID: testUuid,
+		},
+	}
+
+	signedFlavor, err := model.NewSignedFlavor(&flavor, previousPrivateKey)
+	assert.NoError(t, err)
+
+	rule, err := NewFlavorTrusted(signedFlavor, []x509.Certificate{*currentSigningCertificate, *previousSigningCertificate}, flavorCaCertificates, flavorIntermediateCertificates, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 0, len(result.Faults))
+
+	// the intermediate CA is not trusted as a root
+	_, err = previousSigningCertificate.Verify(x509.VerifyOptions{
+		Roots:       flavorIntermediateCertificates,
+		CurrentTime: previousSigningCertificate.NotAfter,
+	})
+	assert.NoError(t, err)
+	rule, err = NewFlavorTrusted(signedFlavor, []x509.Certificate{*currentSigningCertificate, *previousSigningCertificate}, x509.NewCertPool(), flavorIntermediateCertificates, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err = rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultFlavorSignatureVerificationFailed, result.Faults[0].Name)
+}
+
+func createCACryptoResources() (*x509.Certificate, *x509.CertPool, *rsa.PrivateKey, error) {
+
+	caPemBytes, caPrivateKey, err := newCACertificate()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	block, _ := pem.Decode(caPemBytes)
+	if block == nil {
+		return nil, nil, nil, errors.New("Could not decode the CA certificate")
+	}
+	caCertificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	flavorCaCertificates := x509.NewCertPool()
+	flavorCaCertificates.AddCert(caCertificate)
+	return caCertificate, flavorCaCertificates, caPrivateKey, nil
+}
+
+// newFlavorSigningCertificate creates a flavor signing key and its certificate issued by the CA
+func newFlavorSigningCertificate(caCertificate *x509.Certificate, caPrivateKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, error) {
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := newCertificateTemplate()
+	if err != nil {
+		return nil, nil, err
+	}
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.Subject = pkix.Name{CommonName: "HVS Flavor Signing Certificate"}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCertificate, &privateKey.PublicKey, caPrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificate, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certificate, privateKey, nil
+}
+
 func createCryptoResources() (*x509.Certificate, *x509.CertPool, *rsa.PrivateKey, error) {
 
 	// create a CA certpool...
@@ -271,3 +470,46 @@ func createCryptoResources() (*x509.Certificate, *x509.CertPool, *rsa.PrivateKey
 
 	return flavorSigningCertificate, flavorCaCertificates, privateKey, nil
 }
+
+// newIntermediateCACertificate creates an intermediate CA key and its certificate issued by the CA
+func newIntermediateCACertificate(caCertificate *x509.Certificate, caPrivateKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, error) {
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := newCertificateTemplate()
+	if err != nil {
+		return nil, nil, err
+	}
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.Subject = pkix.Name{CommonName: "HVS Flavor Signing Intermediate CA"}
+	template.IsCA = true
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCertificate, &privateKey.PublicKey, caPrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificate, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certificate, privateKey, nil
+}
+
+// expireCertificate re-issues a certificate with a validity that ended the day before
+func expireCertificate(certificate *x509.Certificate, caCertificate *x509.Certificate, caPrivateKey *rsa.PrivateKey) (*x509.Certificate, error) {
+
+	template := *certificate
+	template.NotBefore = time.Now().AddDate(-1, 0, 0)
+	template.NotAfter = time.Now().AddDate(0, 0, -1)
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, caCertificate, certificate.PublicKey, caPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certBytes)
+}
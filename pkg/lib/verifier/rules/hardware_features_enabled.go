@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Custom rule that requires the hardware 'features' of the host (TXT, TPM, CBNT, UEFI, PFR, BMC) to be enabled.
+//
+
+import (
+	"fmt"
+	"strings"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+const featuresParameter = "features"
+
+// hardwareFeatureEnabled returns the enabled state of each hardware feature supported by the rule
+var hardwareFeatureEnabled = map[string]func(features *taModel.HardwareFeatures) bool{
+	"TXT":  func(features *taModel.HardwareFeatures) bool { return features.TXT.Enabled },
+	"TPM":  func(features *taModel.HardwareFeatures) bool { return features.TPM.Enabled },
+	"CBNT": func(features *taModel.HardwareFeatures) bool { return features.CBNT.Enabled },
+	"UEFI": func(features *taModel.HardwareFeatures) bool { return features.UEFI.Enabled },
+	"PFR":  func(features *taModel.HardwareFeatures) bool { return features.PFR.Enabled },
+	"BMC":  func(features *taModel.HardwareFeatures) bool { return features.BMC.Enabled },
+}
+
+// NewHardwareFeaturesEnabled creates the HardwareFeaturesEnabled custom rule, the 'features' parameter is required
+func NewHardwareFeaturesEnabled(parameters map[string]interface{}, marker common.FlavorPart) (Rule, error) {
+	features, err := getStringListParameter(parameters, featuresParameter)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredFeatures := make([]string, 0, len(features))
+	for _, feature := range features {
+		if _, ok := hardwareFeatureEnabled[strings.ToUpper(feature)]; !ok {
+			return nil, errors.Errorf("Unknown hardware feature '%s'", feature)
+		}
+		requiredFeatures = append(requiredFeatures, strings.ToUpper(feature))
+	}
+
+	return &hardwareFeaturesEnabled{
+		features: requiredFeatures,
+		marker:   marker,
+	}, nil
+}
+
+type hardwareFeaturesEnabled struct {
+	features []string
+	marker   common.FlavorPart
+}
+
+func (rule *hardwareFeaturesEnabled) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleHardwareFeaturesEnabled
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.Parameters = map[string]interface{}{featuresParameter: rule.features}
+
+	for _, feature := range rule.features {
+		if !hardwareFeatureEnabled[feature](&hostManifest.HostInfo.HardwareFeatures) {
+			result.Faults = append(result.Faults, hvs.Fault{
+				Name:        constants.FaultHardwareFeatureNotEnabled,
+				Description: fmt.Sprintf("Hardware feature %s is not enabled on the host", feature),
+			})
+		}
+	}
+
+	return &result, nil
+}
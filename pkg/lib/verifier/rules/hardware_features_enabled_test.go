@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardwareFeaturesEnabledNoFault(t *testing.T) {
+	rule, err := NewHardwareFeaturesEnabled(map[string]interface{}{featuresParameter: []interface{}{"tpm", "TXT"}}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.HardwareFeatures.TPM.Enabled = true
+	hostManifest.HostInfo.HardwareFeatures.TXT.Enabled = true
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RuleHardwareFeaturesEnabled, result.Rule.Name)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.True(t, result.Trusted)
+}
+
+func TestHardwareFeaturesEnabledFault(t *testing.T) {
+	rule, err := NewHardwareFeaturesEnabled(map[string]interface{}{featuresParameter: []string{"TPM", "TXT", "CBNT"}}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.HardwareFeatures.TPM.Enabled = true
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Faults))
+	assert.Equal(t, constants.FaultHardwareFeatureNotEnabled, result.Faults[0].Name)
+}
+
+func TestHardwareFeaturesEnabledUnknownFeature(t *testing.T) {
+	_, err := NewHardwareFeaturesEnabled(map[string]interface{}{featuresParameter: []string{"SGX"}}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+
+	_, err = NewHardwareFeaturesEnabled(map[string]interface{}{featuresParameter: "TPM"}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Custom rule that raises a fault when a host info 'field' of the host has one of the 'values' in the denylist,
+// e.g. a TPM version or BIOS version known to be vulnerable.
+//
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	fieldParameter  = "field"
+	valuesParameter = "values"
+)
+
+// NewHostInfoDenylist creates the HostInfoDenylist custom rule. The 'field' parameter is the json path of the host
+// info field separated by dots (e.g. "hardware_features.TPM.meta.tpm_version") and 'values' are the denied values.
+func NewHostInfoDenylist(parameters map[string]interface{}, marker common.FlavorPart) (Rule, error) {
+	field, err := getStringParameter(parameters, fieldParameter)
+	if err != nil {
+		return nil, err
+	}
+	values, err := getStringListParameter(parameters, valuesParameter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hostInfoDenylist{
+		field:  field,
+		values: values,
+		marker: marker,
+	}, nil
+}
+
+type hostInfoDenylist struct {
+	field  string
+	values []string
+	marker common.FlavorPart
+}
+
+func (rule *hostInfoDenylist) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleHostInfoDenylist
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.Parameters = map[string]interface{}{fieldParameter: rule.field, valuesParameter: rule.values}
+
+	value, found, err := getHostInfoField(hostManifest, rule.field)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in getting the host info field in HostInfoDenylist rule")
+	}
+
+	if !found {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultHostInfoFieldMissing,
+			Description: fmt.Sprintf("Host info does not include the field '%s'", rule.field),
+		})
+		return &result, nil
+	}
+
+	for _, deniedValue := range rule.values {
+		if value == deniedValue {
+			result.Faults = append(result.Faults, hvs.Fault{
+				Name:        constants.FaultHostInfoValueDenied,
+				Description: fmt.Sprintf("Host info field '%s' has the denied value '%s'", rule.field, value),
+				ActualValue: &value,
+			})
+			break
+		}
+	}
+
+	return &result, nil
+}
+
+// getHostInfoField returns the string representation of the host info field at the given json path
+func getHostInfoField(hostManifest *types.HostManifest, field string) (string, bool, error) {
+	hostInfoBytes, err := json.Marshal(hostManifest.HostInfo)
+	if err != nil {
+		return "", false, errors.Wrap(err, "Error marshalling host info")
+	}
+
+	var value interface{}
+	if err = json.Unmarshal(hostInfoBytes, &value); err != nil {
+		return "", false, errors.Wrap(err, "Error unmarshalling host info")
+	}
+
+	for _, name := range strings.Split(field, ".") {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false, nil
+		}
+		if value, ok = fields[name]; !ok {
+			return "", false, nil
+		}
+	}
+
+	switch fieldValue := value.(type) {
+	case string:
+		return fieldValue, true, nil
+	case map[string]interface{}, []interface{}, nil:
+		return "", false, nil
+	default:
+		return fmt.Sprintf("%v", fieldValue), true, nil
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostInfoDenylistNoFault(t *testing.T) {
+	rule, err := NewHostInfoDenylist(map[string]interface{}{
+		fieldParameter:  "hardware_features.TPM.meta.tpm_version",
+		valuesParameter: []interface{}{"1.2"},
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.HardwareFeatures.TPM.Meta.TPMVersion = "2.0"
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RuleHostInfoDenylist, result.Rule.Name)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.True(t, result.Trusted)
+}
+
+func TestHostInfoDenylistFault(t *testing.T) {
+	rule, err := NewHostInfoDenylist(map[string]interface{}{
+		fieldParameter:  "bios_version",
+		valuesParameter: []string{"SE5C620.86B.00.01.0009.101920170742", "SE5C620.86B.00.01.0010.010920180151"},
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.BiosVersion = "SE5C620.86B.00.01.0010.010920180151"
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoValueDenied, result.Faults[0].Name)
+}
+
+func TestHostInfoDenylistMissingField(t *testing.T) {
+	rule, err := NewHostInfoDenylist(map[string]interface{}{
+		fieldParameter:  "hardware_features.TPM.meta.firmware_version",
+		valuesParameter: []string{"7.2.0.1"},
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoFieldMissing, result.Faults[0].Name)
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	flavormodel "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	ta "github.com/intel-secl/intel-secl/v4/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// imaPcrBanks are the banks of PCR 10 the IMA log is replayed against, in order of preference
+var imaPcrBanks = []types.SHAAlgorithm{types.SHA256, types.SHA1}
+
+// NewImaMeasurementLogTrusted creates a rule that verifies the IMA log of the host manifest replays to PCR 10 and
+// only holds measurements of the files of the allowlist of the flavor. Like the custom rules, an invalid allowlist
+// does not fail the verification but results in a rule that always raises an ImaAllowlistInvalid fault.
+func NewImaMeasurementLogTrusted(flavorID uuid.UUID, ima *flavormodel.Ima, marker common.FlavorPart) Rule {
+	if err := ima.Validate(); err != nil {
+		return &imaMeasurementLogTrusted{
+			flavorID:     flavorID,
+			marker:       marker,
+			allowlistErr: err,
+		}
+	}
+
+	// the digests are compared case insensitively
+	allowlist := make(map[string][]string, len(ima.Allowlist))
+	for path, digests := range ima.Allowlist {
+		for _, digest := range digests {
+			allowlist[path] = append(allowlist[path], strings.ToLower(digest))
+		}
+	}
+
+	rule := imaMeasurementLogTrusted{
+		flavorID:  flavorID,
+		allowlist: allowlist,
+		marker:    marker,
+	}
+	return &rule
+}
+
+type imaMeasurementLogTrusted struct {
+	flavorID     uuid.UUID
+	allowlist    map[string][]string
+	marker       common.FlavorPart
+	allowlistErr error
+}
+
+//   - If the allowlist of the flavor is invalid, create an ImaAllowlistInvalid fault.
+//   - If the IMA log is not present in the host manifest, create an ImaMeasurementLogMissing fault.
+//   - If the IMA log cannot be parsed or the template hash of a measurement does not match its template data, create
+//     an ImaMeasurementLogInvalid fault.
+//   - If the PcrManifest is not present, create a PcrManifestMissing fault. If PCR 10 is present in neither the
+//     SHA256 nor the SHA1 bank, create a PcrValueMissing fault.
+//   - If the measurements of the IMA log extended to PCR 10 do not replay to the PCR 10 value, create a
+//     PcrEventLogInvalid fault.
+//   - Otherwise, compare the measurements extended to PCR 10 (except the boot aggregate) with the allowlist: create
+//     an ImaMeasurementLogContainsUnexpectedEntries fault for the files that are not in the allowlist and an
+//     ImaMeasurementLogValueMismatchEntries fault for the files measured with a digest that is not allowed.
+func (rule *imaMeasurementLogTrusted) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleImaMeasurementLogTrusted
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.FlavorID = &rule.flavorID
+
+	if rule.allowlistErr != nil {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultImaAllowlistInvalid,
+			Description: fmt.Sprintf("The IMA allowlist of the flavor is invalid: %s", rule.allowlistErr.Error()),
+		})
+		return &result, nil
+	}
+
+	if hostManifest.ImaLog.IsEmpty() {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultImaMeasurementLogMissing,
+			Description: "Host report does not include an IMA log",
+		})
+		return &result, nil
+	}
+
+	events, err := hostManifest.ImaLog.GetEvents()
+	if err != nil {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultImaMeasurementLogInvalid,
+			Description: fmt.Sprintf("Host IMA log is invalid: %s", err.Error()),
+		})
+		return &result, nil
+	}
+
+	// the measurements of the other PCRs are not verified by the replay
+	var pcrEvents []types.ImaEvent
+	for _, event := range events {
+		if event.Pcr == int(types.ImaPcrIndex) {
+			pcrEvents = append(pcrEvents, event)
+		}
+	}
+
+	fault, err := verifyImaLogIntegrity(&hostManifest.PcrManifest, pcrEvents)
+	if err != nil {
+		return nil, err
+	}
+	if fault != nil {
+		result.Faults = append(result.Faults, *fault)
+		return &result, nil
+	}
+
+	result.Faults = append(result.Faults, rule.createMeasurementFaults(pcrEvents)...)
+	return &result, nil
+}
+
+// verifyImaLogIntegrity replays the IMA measurements against PCR 10 and returns a fault when they do not replay to
+// its value
+func verifyImaLogIntegrity(pcrManifest *types.PcrManifest, events []types.ImaEvent) (*hvs.Fault, error) {
+	if pcrManifest.IsEmpty() {
+		fault := newPcrManifestMissingFault()
+		return &fault, nil
+	}
+
+	var actualPcr *types.HostManifestPcrs
+	var bank types.SHAAlgorithm
+	for _, bank = range imaPcrBanks {
+		var err error
+		actualPcr, err = pcrManifest.GetPcrValue(bank, types.ImaPcrIndex)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error in getting actual Pcr in Ima Measurement Log Trusted rule")
+		}
+		if actualPcr != nil {
+			break
+		}
+	}
+	if actualPcr == nil {
+		fault := newPcrValueMissingFault(imaPcrBanks[0], types.ImaPcrIndex)
+		return &fault, nil
+	}
+
+	calculatedValue, err := types.ReplayImaEvents(events, bank, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in calculating replay in Ima Measurement Log Trusted rule")
+	}
+	if strings.EqualFold(calculatedValue, actualPcr.Value) {
+		return nil, nil
+	}
+	if bank != types.SHA1 {
+		// the kernels before 5.8 extend the banks other than SHA1 with the padded SHA1 template hashes
+		paddedValue, err := types.ReplayImaEvents(events, bank, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error in calculating replay in Ima Measurement Log Trusted rule")
+		}
+		if strings.EqualFold(paddedValue, actualPcr.Value) {
+			return nil, nil
+		}
+	}
+
+	pcrIndex := types.ImaPcrIndex
+	return &hvs.Fault{
+		Name:            constants.FaultPcrEventLogInvalid,
+		Description:     fmt.Sprintf("PCR %d IMA log is invalid,mismatches between calculated IMA log value %s and actual pcr value %s", pcrIndex, calculatedValue, actualPcr.Value),
+		PcrIndex:        &pcrIndex,
+		PcrBank:         &bank,
+		CalculatedValue: &calculatedValue,
+		ActualPcrValue:  &actualPcr.Value,
+	}, nil
+}
+
+// createMeasurementFaults rolls up the measurements of files that are not in the allowlist and the measurements
+// of files whose digest is not in the allowlist into a fault each
+func (rule *imaMeasurementLogTrusted) createMeasurementFaults(events []types.ImaEvent) []hvs.Fault {
+	var faults []hvs.Fault
+	var unexpectedMeasurements []ta.FlavorMeasurement
+	var mismatchMeasurements []ta.FlavorMeasurement
+
+	for _, event := range events {
+		// the boot aggregate is the digest of the boot PCRs verified by the platform flavor
+		if event.FilePath == types.ImaBootAggregate {
+			continue
+		}
+		measurement := ta.FlavorMeasurement{
+			Type:  ta.MeasurementTypeFile,
+			Value: event.FileDigest,
+			Path:  event.FilePath,
+		}
+		digests, ok := rule.allowlist[event.FilePath]
+		if !ok {
+			unexpectedMeasurements = append(unexpectedMeasurements, measurement)
+			continue
+		}
+		allowed := false
+		for _, digest := range digests {
+			if digest == strings.ToLower(event.FileDigest) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			mismatchMeasurements = append(mismatchMeasurements, measurement)
+		}
+	}
+
+	if len(unexpectedMeasurements) > 0 {
+		faults = append(faults, hvs.Fault{
+			Name:                   constants.FaultImaMeasurementLogContainsUnexpectedEntries,
+			Description:            fmt.Sprintf("IMA log contains %d measurements of files not in the allowlist of flavor %s.", len(unexpectedMeasurements), rule.flavorID),
+			FlavorId:               &rule.flavorID,
+			UnexpectedMeasurements: unexpectedMeasurements,
+		})
+	}
+	if len(mismatchMeasurements) > 0 {
+		faults = append(faults, hvs.Fault{
+			Name:                 constants.FaultImaMeasurementLogValueMismatchEntries,
+			Description:          fmt.Sprintf("IMA log contains %d measurements of files with a digest not in the allowlist of flavor %s.", len(mismatchMeasurements), rule.flavorID),
+			FlavorId:             &rule.flavorID,
+			MismatchMeasurements: mismatchMeasurements,
+		})
+	}
+	return faults
+}
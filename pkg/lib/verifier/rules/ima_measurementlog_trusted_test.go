@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	flavormodel "github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testImaFlavorID = uuid.MustParse("6f7a1e0c-2b3d-4c5e-9f8a-7b6c5d4e3f21")
+	testImaDigests  = map[string]string{
+		types.ImaBootAggregate: "sha256:" + strings.Repeat("a1", 32),
+		"/usr/bin/bash":        "sha256:" + strings.Repeat("b2", 32),
+		"/usr/lib/libc.so.6":   "sha256:" + strings.Repeat("c3", 32),
+	}
+	testImaAllowlist = flavormodel.Ima{
+		Allowlist: map[string][]string{
+			"/usr/bin/bash":      {"sha256:" + strings.Repeat("B2", 32)},
+			"/usr/lib/libc.so.6": {"sha256:" + strings.Repeat("00", 32), "sha256:" + strings.Repeat("c3", 32)},
+		},
+	}
+)
+
+// newImaHostManifest returns a host manifest with the ascii ima-ng log of the files and the SHA1 PCR 10 value the log
+// replays to
+func newImaHostManifest(t *testing.T, paths ...string) *types.HostManifest {
+	imaLog := strings.Builder{}
+	pcr10 := make([]byte, sha1.Size)
+	for _, path := range paths {
+		digest := testImaDigests[path]
+		if digest == "" {
+			digest = "sha256:" + strings.Repeat("ff", 32)
+		}
+		digestBytes, err := hex.DecodeString(strings.TrimPrefix(digest, "sha256:"))
+		assert.NoError(t, err)
+
+		// the template data holds the d-ng and n-ng fields prefixed with their length
+		templateData := bytes.Buffer{}
+		for _, field := range [][]byte{append([]byte("sha256:\x00"), digestBytes...), append([]byte(path), 0)} {
+			_ = binary.Write(&templateData, binary.LittleEndian, uint32(len(field)))
+			templateData.Write(field)
+		}
+		templateHash := sha1.Sum(templateData.Bytes())
+		imaLog.WriteString(fmt.Sprintf("10 %x ima-ng %s %s\n", templateHash, digest, path))
+
+		cumulativeHash := sha1.Sum(append(pcr10, templateHash[:]...))
+		pcr10 = cumulativeHash[:]
+	}
+
+	hostManifest := types.HostManifest{ImaLog: &types.ImaLog{Ascii: imaLog.String()}}
+	hostManifest.PcrManifest.Sha1Pcrs = []types.HostManifestPcrs{{Index: types.PCR10, Value: hex.EncodeToString(pcr10), PcrBank: types.SHA1}}
+	return &hostManifest
+}
+
+func TestImaMeasurementLogTrustedNoFault(t *testing.T) {
+	hostManifest := newImaHostManifest(t, types.ImaBootAggregate, "/usr/bin/bash", "/usr/lib/libc.so.6", "/usr/bin/bash")
+
+	rule := NewImaMeasurementLogTrusted(testImaFlavorID, &testImaAllowlist, common.FlavorPartOs)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RuleImaMeasurementLogTrusted, result.Rule.Name)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.True(t, result.Trusted)
+}
+
+func TestImaMeasurementLogTrustedUnexpectedAndMismatchEntries(t *testing.T) {
+	testImaDigests["/usr/lib/libc.so.6"] = "sha256:" + strings.Repeat("d4", 32)
+	defer func() { testImaDigests["/usr/lib/libc.so.6"] = "sha256:" + strings.Repeat("c3", 32) }()
+	hostManifest := newImaHostManifest(t, types.ImaBootAggregate, "/usr/bin/bash", "/usr/lib/libc.so.6", "/tmp/malware")
+
+	rule := NewImaMeasurementLogTrusted(testImaFlavorID, &testImaAllowlist, common.FlavorPartOs)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Faults))
+	assert.Equal(t, constants.FaultImaMeasurementLogContainsUnexpectedEntries, result.Faults[0].Name)
+	assert.Equal(t, 1, len(result.Faults[0].UnexpectedMeasurements))
+	assert.Equal(t, "/tmp/malware", result.Faults[0].UnexpectedMeasurements[0].Path)
+	assert.Equal(t, constants.FaultImaMeasurementLogValueMismatchEntries, result.Faults[1].Name)
+	assert.Equal(t, 1, len(result.Faults[1].MismatchMeasurements))
+	assert.Equal(t, "/usr/lib/libc.so.6", result.Faults[1].MismatchMeasurements[0].Path)
+	assert.Equal(t, testImaDigests["/usr/lib/libc.so.6"], result.Faults[1].MismatchMeasurements[0].Value)
+}
+
+func TestImaMeasurementLogTrustedPcrMismatch(t *testing.T) {
+	hostManifest := newImaHostManifest(t, types.ImaBootAggregate, "/usr/bin/bash")
+	// a measurement removed from the log
+	otherHostManifest := newImaHostManifest(t, types.ImaBootAggregate)
+	hostManifest.ImaLog = otherHostManifest.ImaLog
+
+	rule := NewImaMeasurementLogTrusted(testImaFlavorID, &testImaAllowlist, common.FlavorPartOs)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrEventLogInvalid, result.Faults[0].Name)
+	assert.Equal(t, types.PCR10, *result.Faults[0].PcrIndex)
+
+	// no PCR 10 in the host manifest
+	hostManifest.PcrManifest.Sha1Pcrs[0].Index = types.PCR11
+	result, err = rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrValueMissing, result.Faults[0].Name)
+}
+
+func TestImaMeasurementLogTrustedMissingAndInvalidLog(t *testing.T) {
+	rule := NewImaMeasurementLogTrusted(testImaFlavorID, &testImaAllowlist, common.FlavorPartOs)
+
+	hostManifest := newImaHostManifest(t, types.ImaBootAggregate, "/usr/bin/bash")
+	hostManifest.ImaLog = nil
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultImaMeasurementLogMissing, result.Faults[0].Name)
+
+	// the file digest does not match the template hash
+	hostManifest = newImaHostManifest(t, types.ImaBootAggregate, "/tmp/malware")
+	hostManifest.ImaLog.Ascii = strings.Replace(hostManifest.ImaLog.Ascii, strings.Repeat("ff", 32), strings.Repeat("b2", 32), 1)
+	result, err = rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultImaMeasurementLogInvalid, result.Faults[0].Name)
+
+	// an invalid allowlist does not fail the verification but raises a fault
+	for _, ima := range []flavormodel.Ima{
+		{},
+		{Allowlist: map[string][]string{"/usr/bin/bash": {}}},
+		{Allowlist: map[string][]string{"/usr/bin/bash": {strings.Repeat("b2", 32)}}},
+		{Allowlist: map[string][]string{"/usr/bin/bash": {"sha256:" + strings.Repeat("b2", 20)}}},
+	} {
+		result, err = NewImaMeasurementLogTrusted(testImaFlavorID, &ima, common.FlavorPartOs).Apply(newImaHostManifest(t, types.ImaBootAggregate))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(result.Faults))
+		assert.Equal(t, constants.FaultImaAllowlistInvalid, result.Faults[0].Name)
+	}
+}
@@ -101,7 +101,7 @@ func TestPcrEventLogEqualsExcludingUnsupportedSHAFault(t *testing.T) {
 	flavorEventsLog := types.TpmEventLog{
 		Pcr: types.Pcr{
 			Index: 0,
-			Bank:  "SHA512",
+			Bank:  "SM3_256",
 		},
 		TpmEvent: []types.EventLog{
 			{
@@ -80,7 +80,7 @@ func TestPcrEventLogIncludesUnsupportedSHAFault(t *testing.T) {
 	flavorEventsLog := types.TpmEventLog{
 		Pcr: types.Pcr{
 			Index: 0,
-			Bank:  "SHA512",
+			Bank:  "SM3_256",
 		},
 		TpmEvent: []types.EventLog{
 			{
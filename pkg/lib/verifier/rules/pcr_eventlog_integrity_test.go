@@ -44,6 +44,40 @@ func TestPcrEventLogIntegrityNoFault(t *testing.T) {
 	t.Logf("Integrity rule verified")
 }
 
+func TestPcrEventLogIntegritySHA384NoFault(t *testing.T) {
+	sha384EventLogEntry := types.TpmEventLog{
+		Pcr: types.Pcr{
+			Index: 0,
+			Bank:  "SHA384",
+		},
+		TpmEvent: testExpectedPcrEventLogEntry.TpmEvent,
+	}
+	expectedCumulativeHash, err := sha384EventLogEntry.Replay()
+	assert.NoError(t, err)
+
+	expectedPcrLog := types.FlavorPcrs{
+		Pcr: types.Pcr{
+			Index: 0,
+			Bank:  "SHA384",
+		},
+		Measurement: expectedCumulativeHash,
+	}
+
+	hostManifest := types.HostManifest{}
+	hostManifest.PcrManifest.PcrEventLogMap.Sha384EventLogs = append(hostManifest.PcrManifest.PcrEventLogMap.Sha384EventLogs, sha384EventLogEntry)
+	hostManifest.PcrManifest.Sha384Pcrs = append(hostManifest.PcrManifest.Sha384Pcrs, types.HostManifestPcrs{
+		Index:   0,
+		PcrBank: types.SHA384,
+		Value:   expectedCumulativeHash,
+	})
+
+	rule, err := NewPcrEventLogIntegrity(&expectedPcrLog, common.FlavorPartPlatform)
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
 func TestPcrEventLogIntegrityFault(t *testing.T) {
 
 	_, err := NewPcrEventLogIntegrity(nil, common.FlavorPartPlatform)
@@ -85,7 +119,7 @@ func TestPcrEventLogIntegrityUnsupportedSHAFault(t *testing.T) {
 	expectedPcrLog := types.FlavorPcrs{
 		Pcr: types.Pcr{
 			Index: 0,
-			Bank:  "SHA512",
+			Bank:  "SM3_256",
 		},
 		Measurement: expectedCumulativeHash,
 	}
@@ -117,6 +117,39 @@ func TestPcrMatchesConstantMismatchFault(t *testing.T) {
 	t.Logf("Fault description: %s", result.Faults[0].Description)
 }
 
+func TestPcrMatchesConstantMismatchFaultSHA384(t *testing.T) {
+	expectedPcr := types.FlavorPcrs{
+		Pcr: types.Pcr{
+			Index: 0,
+			Bank:  "SHA384",
+		},
+		Measurement: PCR_VALID_256,
+	}
+
+	// host manifest with 'invalid' value for pcr0 in the SHA384 bank
+	hostManifest := types.HostManifest{
+		PcrManifest: types.PcrManifest{
+			Sha384Pcrs: []types.HostManifestPcrs{
+				{
+					Index:   0,
+					Value:   PCR_INVALID_256,
+					PcrBank: types.SHA384,
+				},
+			},
+		},
+	}
+
+	rule, err := NewPcrMatchesConstant(&expectedPcr, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, len(result.Faults), 1)
+	assert.Equal(t, result.Faults[0].Name, constants.FaultPcrValueMismatchSHA384)
+	t.Logf("Fault description: %s", result.Faults[0].Description)
+}
+
 func TestPcrMatchesConstantMissingFault(t *testing.T) {
 	// empty manifest will result in 'missing' fault
 	hostManifest := types.HostManifest{
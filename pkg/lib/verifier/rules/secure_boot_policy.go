@@ -0,0 +1,343 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Custom rule that verifies the content of the UEFI Secure Boot variables (SecureBoot, PK, KEK, db and dbx)
+// measured in the EV_EFI_VARIABLE_DRIVER_CONFIG events of the PCR 7 event log. The event data of these events
+// is trusted once it matches the event digest and the event log replays to the PCR 7 value of the host manifest.
+//
+// The rule requires the Trust Agent to report the data of these events in the "event_data" field of its measure
+// log. The hosts that do not report it (the VMware hosts and the Trust Agents that only report the event digests)
+// fail the rule with SecureBootVariableMissing faults, so the rule is not attached by the default flavor templates
+// and is only applied to the flavors and flavor templates that reference it explicitly.
+//
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha1"
+	"crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v4/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	secureBootEnabledParameter = "secure_boot_enabled"
+	pkCertificatesParameter    = "pk_certificates"
+	kekCertificatesParameter   = "kek_certificates"
+	dbCertificatesParameter    = "db_certificates"
+	dbxMinEntriesParameter     = "dbx_min_entries"
+	dbxHashesParameter         = "dbx_hashes"
+
+	efiVariableDriverConfigEvent = "EV_EFI_VARIABLE_DRIVER_CONFIG"
+)
+
+// secureBootPcrBanks are the banks the PCR 7 event log is read from, in order of preference
+var secureBootPcrBanks = []struct {
+	bank types.SHAAlgorithm
+	hash crypto.Hash
+}{
+	{types.SHA256, crypto.SHA256},
+	{types.SHA384, crypto.SHA384},
+	{types.SHA512, crypto.SHA512},
+	{types.SHA1, crypto.SHA1},
+}
+
+// certificateParameters maps the certificate parameters to the signature database variable they apply to
+var certificateParameters = []struct {
+	parameter string
+	variable  string
+}{
+	{pkCertificatesParameter, uefiVariablePK},
+	{kekCertificatesParameter, uefiVariableKEK},
+	{dbCertificatesParameter, uefiVariableDb},
+}
+
+// NewSecureBootPolicy creates the SecureBootPolicy custom rule. All the parameters are optional, but at least one
+// of them is required:
+//   - secure_boot_enabled: when true, the SecureBoot variable must be set
+//   - pk_certificates, kek_certificates, db_certificates: the hex encoded SHA256 fingerprints of the X.509
+//     certificates that must be present in PK, KEK and db
+//   - dbx_min_entries: the minimum number of entries of dbx. The dbx updates are cumulative, so a dbx revision
+//     (e.g. the revocations of BootHole) is asserted by the number of entries of this revision.
+//   - dbx_hashes: the hex encoded SHA256 hashes that must be revoked by dbx
+func NewSecureBootPolicy(parameters map[string]interface{}, marker common.FlavorPart) (Rule, error) {
+	rule := secureBootPolicy{
+		certificates: make(map[string][]string),
+		marker:       marker,
+	}
+
+	var err error
+	if _, ok := parameters[secureBootEnabledParameter]; ok {
+		if rule.secureBootEnabled, err = getBoolParameter(parameters, secureBootEnabledParameter); err != nil {
+			return nil, err
+		}
+	}
+	for _, certificateParameter := range certificateParameters {
+		if _, ok := parameters[certificateParameter.parameter]; !ok {
+			continue
+		}
+		fingerprints, err := getSha256ListParameter(parameters, certificateParameter.parameter)
+		if err != nil {
+			return nil, err
+		}
+		rule.certificates[certificateParameter.variable] = fingerprints
+	}
+	if _, ok := parameters[dbxMinEntriesParameter]; ok {
+		if rule.dbxMinEntries, err = getIntParameter(parameters, dbxMinEntriesParameter); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := parameters[dbxHashesParameter]; ok {
+		if rule.dbxHashes, err = getSha256ListParameter(parameters, dbxHashesParameter); err != nil {
+			return nil, err
+		}
+	}
+
+	if !rule.secureBootEnabled && len(rule.certificates) == 0 && rule.dbxMinEntries == 0 && len(rule.dbxHashes) == 0 {
+		return nil, errors.New("The secure boot policy does not assert anything")
+	}
+	return &rule, nil
+}
+
+type secureBootPolicy struct {
+	secureBootEnabled bool
+	// certificates holds the fingerprints of the certificates required in each signature database variable
+	certificates  map[string][]string
+	dbxMinEntries int
+	dbxHashes     []string
+	marker        common.FlavorPart
+}
+
+//   - If the PcrManifest is not present in the host manifest, raise PcrManifestMissing fault.
+//   - If the PCR 7 event log is not present in the host manifest, raise PcrEventLogMissing fault.
+//   - If the PCR 7 event log does not replay to the PCR 7 value or an event data does not match its digest, raise
+//     SecureBootEventLogInvalid fault.
+//   - Otherwise, raise a fault for each variable missing from the event log or assertion of the policy that fails.
+func (rule *secureBootPolicy) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleSecureBootPolicy
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.Parameters = rule.parameters()
+
+	if hostManifest.PcrManifest.IsEmpty() {
+		result.Faults = append(result.Faults, newPcrManifestMissingFault())
+		return &result, nil
+	}
+
+	variables, fault := getSecureBootVariables(&hostManifest.PcrManifest)
+	if fault != nil {
+		result.Faults = append(result.Faults, *fault)
+		return &result, nil
+	}
+
+	if rule.secureBootEnabled {
+		if variable, fault := getSecureBootVariable(variables, uefiVariableSecureBoot); fault != nil {
+			result.Faults = append(result.Faults, *fault)
+		} else if !bytes.Equal(variable.data, []byte{1}) {
+			result.Faults = append(result.Faults, hvs.Fault{
+				Name:        constants.FaultSecureBootNotEnabled,
+				Description: "Secure Boot is not enabled on the host",
+			})
+		}
+	}
+
+	for _, certificateParameter := range certificateParameters {
+		fingerprints, ok := rule.certificates[certificateParameter.variable]
+		if !ok {
+			continue
+		}
+		signatures, fault := getSignatureDatabase(variables, certificateParameter.variable)
+		if fault != nil {
+			result.Faults = append(result.Faults, *fault)
+			continue
+		}
+		present := make(map[string]bool)
+		for _, signature := range signatures {
+			if signature.signatureType == efiCertX509Guid {
+				fingerprint := sha256.Sum256(signature.data)
+				present[hex.EncodeToString(fingerprint[:])] = true
+			}
+		}
+		for _, fingerprint := range fingerprints {
+			if !present[fingerprint] {
+				result.Faults = append(result.Faults, hvs.Fault{
+					Name:        constants.FaultSecureBootCertificateMissing,
+					Description: fmt.Sprintf("Certificate with SHA256 fingerprint %s is not present in %s", fingerprint, certificateParameter.variable),
+				})
+			}
+		}
+	}
+
+	if rule.dbxMinEntries > 0 || len(rule.dbxHashes) > 0 {
+		signatures, fault := getSignatureDatabase(variables, uefiVariableDbx)
+		if fault != nil {
+			result.Faults = append(result.Faults, *fault)
+			return &result, nil
+		}
+		if len(signatures) < rule.dbxMinEntries {
+			result.Faults = append(result.Faults, hvs.Fault{
+				Name:        constants.FaultSecureBootDbxOutdated,
+				Description: fmt.Sprintf("dbx contains %d entries, at least %d entries are required", len(signatures), rule.dbxMinEntries),
+			})
+		}
+		revoked := make(map[string]bool)
+		for _, signature := range signatures {
+			if signature.signatureType == efiCertSha256Guid {
+				revoked[hex.EncodeToString(signature.data)] = true
+			}
+		}
+		for _, hash := range rule.dbxHashes {
+			if !revoked[hash] {
+				result.Faults = append(result.Faults, hvs.Fault{
+					Name:        constants.FaultSecureBootDbxHashMissing,
+					Description: fmt.Sprintf("SHA256 hash %s is not revoked in dbx", hash),
+				})
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+// parameters returns the parameters of the rule, as reported in the trust report
+func (rule *secureBootPolicy) parameters() map[string]interface{} {
+	parameters := make(map[string]interface{})
+	if rule.secureBootEnabled {
+		parameters[secureBootEnabledParameter] = true
+	}
+	for _, certificateParameter := range certificateParameters {
+		if fingerprints, ok := rule.certificates[certificateParameter.variable]; ok {
+			parameters[certificateParameter.parameter] = fingerprints
+		}
+	}
+	if rule.dbxMinEntries > 0 {
+		parameters[dbxMinEntriesParameter] = rule.dbxMinEntries
+	}
+	if len(rule.dbxHashes) > 0 {
+		parameters[dbxHashesParameter] = rule.dbxHashes
+	}
+	return parameters
+}
+
+// getSecureBootVariables returns the Secure Boot variables measured in the PCR 7 event log, after checking that the
+// event log replays to the PCR 7 value and that the data of the variables matches the event digests
+func getSecureBootVariables(pcrManifest *types.PcrManifest) (map[string]*uefiVariable, *hvs.Fault) {
+	for _, pcrBank := range secureBootPcrBanks {
+		bank := pcrBank.bank
+		events, err := pcrManifest.GetEventLogCriteria(bank, types.PCR7)
+		if err != nil || len(events) == 0 {
+			continue
+		}
+
+		eventLog := types.TpmEventLog{Pcr: types.Pcr{Index: int(types.PCR7), Bank: string(bank)}, TpmEvent: events}
+		replayValue, err := eventLog.Replay()
+		if err != nil {
+			return nil, newSecureBootEventLogInvalidFault(fmt.Sprintf("The PCR 7 event log of %s could not be replayed: %s", bank, err.Error()))
+		}
+		pcr, _ := pcrManifest.GetPcrValue(bank, types.PCR7)
+		if pcr == nil {
+			fault := newPcrValueMissingFault(bank, types.PCR7)
+			return nil, &fault
+		}
+		if !strings.EqualFold(pcr.Value, replayValue) {
+			return nil, newSecureBootEventLogInvalidFault(fmt.Sprintf("The PCR 7 event log of %s does not replay to the PCR value", bank))
+		}
+
+		variables := make(map[string]*uefiVariable)
+		for i, event := range events {
+			if event.TypeName != efiVariableDriverConfigEvent || len(event.EventData) == 0 {
+				continue
+			}
+			hash := pcrBank.hash.New()
+			hash.Write(event.EventData)
+			if !strings.EqualFold(hex.EncodeToString(hash.Sum(nil)), event.Measurement) {
+				return nil, newSecureBootEventLogInvalidFault(fmt.Sprintf("The data of PCR 7 event %d does not match its digest", i))
+			}
+			variable, err := parseUefiVariable(event.EventData)
+			if err != nil {
+				return nil, &hvs.Fault{
+					Name:        constants.FaultSecureBootVariableInvalid,
+					Description: fmt.Sprintf("The UEFI variable of PCR 7 event %d could not be parsed: %s", i, err.Error()),
+				}
+			}
+			vendorGuid, ok := uefiVariableVendors[variable.name]
+			if !ok || vendorGuid != variable.vendorGuid {
+				continue
+			}
+			// the variables are measured once, the first measurement is the configuration the host booted with
+			if _, ok := variables[variable.name]; !ok {
+				variables[variable.name] = variable
+			}
+		}
+		return variables, nil
+	}
+
+	fault := newPcrEventLogMissingFault(types.PCR7, types.SHA256)
+	return nil, &fault
+}
+
+// getSecureBootVariable returns a Secure Boot variable, or a SecureBootVariableMissing fault when the variable was
+// not measured or was reported without its data
+func getSecureBootVariable(variables map[string]*uefiVariable, name string) (*uefiVariable, *hvs.Fault) {
+	variable, ok := variables[name]
+	if !ok {
+		return nil, &hvs.Fault{
+			Name:        constants.FaultSecureBootVariableMissing,
+			Description: fmt.Sprintf("The UEFI variable %s is not reported with its data in the PCR 7 event log, the host must report the event data of the EV_EFI_VARIABLE_DRIVER_CONFIG events", name),
+		}
+	}
+	return variable, nil
+}
+
+// getSignatureDatabase returns the signatures of a signature database variable
+func getSignatureDatabase(variables map[string]*uefiVariable, name string) ([]efiSignature, *hvs.Fault) {
+	variable, fault := getSecureBootVariable(variables, name)
+	if fault != nil {
+		return nil, fault
+	}
+	signatures, err := parseEfiSignatureLists(variable.data)
+	if err != nil {
+		return nil, &hvs.Fault{
+			Name:        constants.FaultSecureBootVariableInvalid,
+			Description: fmt.Sprintf("The signature lists of the UEFI variable %s could not be parsed: %s", name, err.Error()),
+		}
+	}
+	return signatures, nil
+}
+
+func newSecureBootEventLogInvalidFault(description string) *hvs.Fault {
+	return &hvs.Fault{
+		Name:        constants.FaultSecureBootEventLogInvalid,
+		Description: description,
+	}
+}
+
+// getSha256ListParameter returns the value of a list parameter of hex encoded SHA256 digests, in lower case
+func getSha256ListParameter(parameters map[string]interface{}, name string) ([]string, error) {
+	values, err := getStringListParameter(parameters, name)
+	if err != nil {
+		return nil, err
+	}
+	digests := make([]string, 0, len(values))
+	for _, value := range values {
+		digest, err := hex.DecodeString(value)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, errors.Errorf("The parameter '%s' must hold hex encoded SHA256 digests, '%s' is not", name, value)
+		}
+		digests = append(digests, hex.EncodeToString(digest))
+	}
+	return digests, nil
+}
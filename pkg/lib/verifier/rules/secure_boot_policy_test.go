@@ -0,0 +1,253 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"unicode/utf16"
+
+	constants "github.com/intel-secl/intel-secl/v4/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// the DER content does not matter to the rule, which only matches the certificate fingerprints
+	testDbCertificate  = []byte("db certificate")
+	testKekCertificate = []byte("kek certificate")
+	testRevokedHash    = sha256.Sum256([]byte("grub with BootHole vulnerability"))
+)
+
+// newUefiVariableData returns the UEFI_VARIABLE_DATA of a variable
+func newUefiVariableData(vendorGuid efiGuid, name string, data []byte) []byte {
+	unicodeName := utf16.Encode([]rune(name))
+	buffer := bytes.Buffer{}
+	buffer.Write(vendorGuid[:])
+	_ = binary.Write(&buffer, binary.LittleEndian, uint64(len(unicodeName)))
+	_ = binary.Write(&buffer, binary.LittleEndian, uint64(len(data)))
+	_ = binary.Write(&buffer, binary.LittleEndian, unicodeName)
+	buffer.Write(data)
+	return buffer.Bytes()
+}
+
+// newEfiSignatureList returns an EFI_SIGNATURE_LIST holding signatures of the same size
+func newEfiSignatureList(signatureType efiGuid, signatures ...[]byte) []byte {
+	signatureSize := 16 + len(signatures[0])
+	buffer := bytes.Buffer{}
+	buffer.Write(signatureType[:])
+	_ = binary.Write(&buffer, binary.LittleEndian, uint32(28+len(signatures)*signatureSize))
+	_ = binary.Write(&buffer, binary.LittleEndian, uint32(0))
+	_ = binary.Write(&buffer, binary.LittleEndian, uint32(signatureSize))
+	for _, signature := range signatures {
+		// signature owner
+		buffer.Write(efiGlobalVariableGuid[:])
+		buffer.Write(signature)
+	}
+	return buffer.Bytes()
+}
+
+func newVariableEvent(vendorGuid efiGuid, name string, data []byte) types.EventLog {
+	eventData := newUefiVariableData(vendorGuid, name, data)
+	digest := sha256.Sum256(eventData)
+	return types.EventLog{
+		TypeID:      "0x80000001",
+		TypeName:    efiVariableDriverConfigEvent,
+		Tags:        []string{name},
+		Measurement: hex.EncodeToString(digest[:]),
+		EventData:   eventData,
+	}
+}
+
+// newSecureBootHostManifest returns a host manifest whose PCR 7 event log measures the variables and whose PCR 7
+// value is the replay of the event log
+func newSecureBootHostManifest(t *testing.T, events ...types.EventLog) *types.HostManifest {
+	eventLog := types.TpmEventLog{
+		Pcr:      types.Pcr{Index: 7, Bank: "SHA256"},
+		TpmEvent: events,
+	}
+	pcrValue, err := eventLog.Replay()
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.PcrManifest.Sha256Pcrs = []types.HostManifestPcrs{{Index: types.PCR7, Value: pcrValue, PcrBank: types.SHA256}}
+	hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs = []types.TpmEventLog{eventLog}
+	return &hostManifest
+}
+
+func newSecureBootVariableEvents(secureBoot byte, dbx []byte) []types.EventLog {
+	return []types.EventLog{
+		newVariableEvent(efiGlobalVariableGuid, uefiVariableSecureBoot, []byte{secureBoot}),
+		newVariableEvent(efiGlobalVariableGuid, uefiVariablePK, newEfiSignatureList(efiCertX509Guid, []byte("pk certificate"))),
+		newVariableEvent(efiGlobalVariableGuid, uefiVariableKEK, newEfiSignatureList(efiCertX509Guid, testKekCertificate)),
+		newVariableEvent(efiImageSecurityDatabaseGuid, uefiVariableDb, newEfiSignatureList(efiCertX509Guid, testDbCertificate)),
+		newVariableEvent(efiImageSecurityDatabaseGuid, uefiVariableDbx, dbx),
+	}
+}
+
+func fingerprint(certificate []byte) string {
+	digest := sha256.Sum256(certificate)
+	return hex.EncodeToString(digest[:])
+}
+
+func TestSecureBootPolicyNoFault(t *testing.T) {
+	otherHash := sha256.Sum256([]byte("other revoked image"))
+	dbx := newEfiSignatureList(efiCertSha256Guid, testRevokedHash[:], otherHash[:])
+	hostManifest := newSecureBootHostManifest(t, newSecureBootVariableEvents(1, dbx)...)
+
+	rule, err := NewSecureBootPolicy(map[string]interface{}{
+		secureBootEnabledParameter: true,
+		kekCertificatesParameter:   []interface{}{fingerprint(testKekCertificate)},
+		dbCertificatesParameter:    []interface{}{fingerprint(testDbCertificate)},
+		dbxMinEntriesParameter:     float64(2),
+		dbxHashesParameter:         []interface{}{hex.EncodeToString(testRevokedHash[:])},
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RuleSecureBootPolicy, result.Rule.Name)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.True(t, result.Trusted)
+}
+
+func TestSecureBootPolicyFaults(t *testing.T) {
+	// a host with Secure Boot disabled and the dbx predating the revocation of the vulnerable image
+	otherHash := sha256.Sum256([]byte("other revoked image"))
+	dbx := newEfiSignatureList(efiCertSha256Guid, otherHash[:])
+	hostManifest := newSecureBootHostManifest(t, newSecureBootVariableEvents(0, dbx)...)
+
+	rule, err := NewSecureBootPolicy(map[string]interface{}{
+		secureBootEnabledParameter: true,
+		dbCertificatesParameter:    []string{fingerprint([]byte("unknown certificate"))},
+		dbxMinEntriesParameter:     2,
+		dbxHashesParameter:         []string{hex.EncodeToString(testRevokedHash[:])},
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(result.Faults))
+	assert.Equal(t, constants.FaultSecureBootNotEnabled, result.Faults[0].Name)
+	assert.Equal(t, constants.FaultSecureBootCertificateMissing, result.Faults[1].Name)
+	assert.Equal(t, constants.FaultSecureBootDbxOutdated, result.Faults[2].Name)
+	assert.Equal(t, constants.FaultSecureBootDbxHashMissing, result.Faults[3].Name)
+}
+
+func TestSecureBootPolicyTamperedEventData(t *testing.T) {
+	events := newSecureBootVariableEvents(0, newEfiSignatureList(efiCertSha256Guid, testRevokedHash[:]))
+	hostManifest := newSecureBootHostManifest(t, events...)
+	// the event data claims Secure Boot is enabled while the measurement is the one of the disabled state
+	events[0].EventData = newUefiVariableData(efiGlobalVariableGuid, uefiVariableSecureBoot, []byte{1})
+
+	rule, err := NewSecureBootPolicy(map[string]interface{}{secureBootEnabledParameter: true}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultSecureBootEventLogInvalid, result.Faults[0].Name)
+}
+
+func TestSecureBootPolicyEventLogNotMatchingPcr(t *testing.T) {
+	hostManifest := newSecureBootHostManifest(t, newSecureBootVariableEvents(1, newEfiSignatureList(efiCertSha256Guid, testRevokedHash[:]))...)
+	hostManifest.PcrManifest.Sha256Pcrs[0].Value = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	rule, err := NewSecureBootPolicy(map[string]interface{}{secureBootEnabledParameter: true}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultSecureBootEventLogInvalid, result.Faults[0].Name)
+}
+
+func TestSecureBootPolicyVariableMissing(t *testing.T) {
+	// a host whose event log does not report the event data of the variables
+	events := newSecureBootVariableEvents(1, newEfiSignatureList(efiCertSha256Guid, testRevokedHash[:]))
+	for i := range events {
+		events[i].EventData = nil
+	}
+	hostManifest := newSecureBootHostManifest(t, events...)
+
+	rule, err := NewSecureBootPolicy(map[string]interface{}{
+		secureBootEnabledParameter: true,
+		dbxMinEntriesParameter:     1,
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Faults))
+	assert.Equal(t, constants.FaultSecureBootVariableMissing, result.Faults[0].Name)
+	assert.Equal(t, constants.FaultSecureBootVariableMissing, result.Faults[1].Name)
+
+	// no PCR 7 event log
+	hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs = nil
+	result, err = rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrEventLogMissing, result.Faults[0].Name)
+}
+
+func TestSecureBootPolicyEmptySignatureList(t *testing.T) {
+	// an EFI_SIGNATURE_LIST without signatures at the end of dbx
+	emptyList := bytes.Buffer{}
+	emptyList.Write(efiCertSha256Guid[:])
+	_ = binary.Write(&emptyList, binary.LittleEndian, []uint32{28, 0, 16 + sha256.Size})
+	dbx := append(newEfiSignatureList(efiCertSha256Guid, testRevokedHash[:]), emptyList.Bytes()...)
+	hostManifest := newSecureBootHostManifest(t, newSecureBootVariableEvents(1, dbx)...)
+
+	rule, err := NewSecureBootPolicy(map[string]interface{}{
+		dbxMinEntriesParameter: float64(1),
+		dbxHashesParameter:     []interface{}{hex.EncodeToString(testRevokedHash[:])},
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestSecureBootPolicyInvalidSignatureList(t *testing.T) {
+	dbx := newEfiSignatureList(efiCertSha256Guid, testRevokedHash[:])
+	// truncate the signature list
+	hostManifest := newSecureBootHostManifest(t, newSecureBootVariableEvents(1, dbx[:len(dbx)-1])...)
+
+	rule, err := NewSecureBootPolicy(map[string]interface{}{dbxMinEntriesParameter: 1}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultSecureBootVariableInvalid, result.Faults[0].Name)
+}
+
+func TestSecureBootPolicyInvalidParameters(t *testing.T) {
+	invalidParameters := []map[string]interface{}{
+		{},
+		{secureBootEnabledParameter: false},
+		{secureBootEnabledParameter: "true"},
+		{dbCertificatesParameter: []string{"not a fingerprint"}},
+		{dbxHashesParameter: []string{"abcd"}},
+		{dbxMinEntriesParameter: 1.5},
+		{dbxMinEntriesParameter: -1},
+	}
+	for _, parameters := range invalidParameters {
+		_, err := NewSecureBootPolicy(parameters, common.FlavorPartPlatform)
+		assert.Error(t, err, parameters)
+	}
+
+	// the rule is registered as a built-in custom rule
+	assert.NoError(t, ValidateCustomRule(model.CustomRule{
+		Name:       constants.CustomRuleSecureBootPolicy,
+		Parameters: map[string]interface{}{secureBootEnabledParameter: true},
+	}))
+}
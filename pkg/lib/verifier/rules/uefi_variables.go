@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Parsing of the UEFI variables measured in the EV_EFI_VARIABLE_DRIVER_CONFIG events of the PCR 7 event log
+// (UEFI_VARIABLE_DATA of the TCG PC Client Platform Firmware Profile) and of the EFI_SIGNATURE_LISTs of the
+// Secure Boot signature databases (UEFI specification, 32.4.1).
+//
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+// efiGuid is an EFI_GUID in its binary (mixed-endian) representation
+type efiGuid [16]byte
+
+// newEfiGuid returns the binary representation of a GUID from its fields, as printed in the canonical
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form
+func newEfiGuid(data1 uint32, data2, data3 uint16, data4 [8]byte) efiGuid {
+	var guid efiGuid
+	binary.LittleEndian.PutUint32(guid[0:4], data1)
+	binary.LittleEndian.PutUint16(guid[4:6], data2)
+	binary.LittleEndian.PutUint16(guid[6:8], data3)
+	copy(guid[8:], data4[:])
+	return guid
+}
+
+var (
+	// EFI_GLOBAL_VARIABLE, vendor of the SecureBoot, PK and KEK variables
+	efiGlobalVariableGuid = newEfiGuid(0x8be4df61, 0x93ca, 0x11d2, [8]byte{0xaa, 0x0d, 0x00, 0xe0, 0x98, 0x03, 0x2b, 0x8c})
+	// EFI_IMAGE_SECURITY_DATABASE_GUID, vendor of the db and dbx variables
+	efiImageSecurityDatabaseGuid = newEfiGuid(0xd719b2cb, 0x3d3a, 0x4596, [8]byte{0xa3, 0xbc, 0xda, 0xd0, 0x0e, 0x67, 0x65, 0x6f})
+	// EFI_CERT_SHA256_GUID, signature type of the SHA256 hashes
+	efiCertSha256Guid = newEfiGuid(0xc1c41626, 0x504c, 0x4092, [8]byte{0xac, 0xa9, 0x41, 0xf9, 0x36, 0x93, 0x43, 0x28})
+	// EFI_CERT_X509_GUID, signature type of the DER encoded X.509 certificates
+	efiCertX509Guid = newEfiGuid(0xa5c059a1, 0x94e4, 0x4aa7, [8]byte{0x87, 0xb5, 0xab, 0x15, 0x5c, 0x2b, 0xf0, 0x72})
+)
+
+const (
+	uefiVariableSecureBoot = "SecureBoot"
+	uefiVariablePK         = "PK"
+	uefiVariableKEK        = "KEK"
+	uefiVariableDb         = "db"
+	uefiVariableDbx        = "dbx"
+)
+
+// uefiVariableVendors maps the Secure Boot variables to their vendor GUID
+var uefiVariableVendors = map[string]efiGuid{
+	uefiVariableSecureBoot: efiGlobalVariableGuid,
+	uefiVariablePK:         efiGlobalVariableGuid,
+	uefiVariableKEK:        efiGlobalVariableGuid,
+	uefiVariableDb:         efiImageSecurityDatabaseGuid,
+	uefiVariableDbx:        efiImageSecurityDatabaseGuid,
+}
+
+// uefiVariable is the UEFI_VARIABLE_DATA measured in an EV_EFI_VARIABLE_DRIVER_CONFIG event
+type uefiVariable struct {
+	vendorGuid efiGuid
+	name       string
+	data       []byte
+}
+
+// efiSignature is an EFI_SIGNATURE_DATA of a signature database, without its owner
+type efiSignature struct {
+	signatureType efiGuid
+	data          []byte
+}
+
+// parseUefiVariable parses the UEFI_VARIABLE_DATA structure of an EV_EFI_VARIABLE_DRIVER_CONFIG event
+func parseUefiVariable(eventData []byte) (*uefiVariable, error) {
+	var header struct {
+		VariableName       efiGuid
+		UnicodeNameLength  uint64
+		VariableDataLength uint64
+	}
+	reader := bytes.NewReader(eventData)
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, errors.Wrap(err, "Failed to read the UEFI variable header")
+	}
+	if header.UnicodeNameLength > uint64(reader.Len())/2 {
+		return nil, errors.New("The UEFI variable name length exceeds the event data")
+	}
+	unicodeName := make([]uint16, header.UnicodeNameLength)
+	if err := binary.Read(reader, binary.LittleEndian, unicodeName); err != nil {
+		return nil, errors.Wrap(err, "Failed to read the UEFI variable name")
+	}
+	if header.VariableDataLength != uint64(reader.Len()) {
+		return nil, errors.Errorf("The UEFI variable data length %d does not match the %d remaining bytes of the event data",
+			header.VariableDataLength, reader.Len())
+	}
+	data := make([]byte, header.VariableDataLength)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, errors.Wrap(err, "Failed to read the UEFI variable data")
+	}
+
+	return &uefiVariable{
+		vendorGuid: header.VariableName,
+		name:       string(utf16.Decode(unicodeName)),
+		data:       data,
+	}, nil
+}
+
+// parseEfiSignatureLists parses the EFI_SIGNATURE_LISTs of a signature database variable (PK, KEK, db or dbx)
+func parseEfiSignatureLists(data []byte) ([]efiSignature, error) {
+	var signatures []efiSignature
+	reader := bytes.NewReader(data)
+	for reader.Len() > 0 {
+		var header struct {
+			SignatureType       efiGuid
+			SignatureListSize   uint32
+			SignatureHeaderSize uint32
+			SignatureSize       uint32
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+			return nil, errors.Wrap(err, "Failed to read the signature list header")
+		}
+		// each signature holds the 16 bytes GUID of its owner followed by the signature data
+		listSize := uint64(header.SignatureListSize) - uint64(binary.Size(header))
+		signatureHeaderSize := uint64(header.SignatureHeaderSize)
+		signatureSize := uint64(header.SignatureSize)
+		if uint64(header.SignatureListSize) < uint64(binary.Size(header))+signatureHeaderSize ||
+			listSize > uint64(reader.Len()) || signatureSize <= 16 ||
+			(listSize-signatureHeaderSize)%signatureSize != 0 {
+			return nil, errors.Errorf("Invalid signature list size %d with signature size %d",
+				header.SignatureListSize, header.SignatureSize)
+		}
+		list := make([]byte, listSize)
+		// io.ReadFull does not fail on the empty signature lists at the end of the data, unlike Read
+		if _, err := io.ReadFull(reader, list); err != nil {
+			return nil, errors.Wrap(err, "Failed to read the signature list")
+		}
+
+		list = list[signatureHeaderSize:]
+		for offset := uint64(0); offset < uint64(len(list)); offset += signatureSize {
+			signatures = append(signatures, efiSignature{
+				signatureType: header.SignatureType,
+				data:          list[offset+16 : offset+signatureSize],
+			})
+		}
+	}
+	return signatures, nil
+}
@@ -18,12 +18,17 @@ import (
 )
 
 // VerifierCertificates A collection of certificates/certificate pools that
-// must be provide to the Verifier in NewVerifier().
+// must be provide to the Verifier in NewVerifier().  FlavorSigningCertificates
+// holds the current flavor signing certificate and the previous ones that are still
+// trusted, a flavor signature is trusted when it verifies with any of them.
+// FlavorIntermediateCACertificates holds the intermediate CAs of the previous flavor
+// signing certificates, they are not trusted as roots and it may be nil.
 type VerifierCertificates struct {
-	PrivacyCACertificates    *x509.CertPool
-	AssetTagCACertificates   *x509.CertPool
-	FlavorSigningCertificate *x509.Certificate
-	FlavorCACertificates     *x509.CertPool
+	PrivacyCACertificates            *x509.CertPool
+	AssetTagCACertificates           *x509.CertPool
+	FlavorSigningCertificates        []x509.Certificate
+	FlavorCACertificates             *x509.CertPool
+	FlavorIntermediateCACertificates *x509.CertPool
 }
 
 // Verifier The interface that exposes the verification of a host manifest
@@ -35,7 +40,7 @@ type Verifier interface {
 }
 
 // NewVerifier Creates a Verifier provided a valid set of verifierCertificates.
-// An error is raised if any of the fields in VerifierCertificate is nil.
+// An error is raised if any of the fields in VerifierCertificate is nil or empty.
 func NewVerifier(verifierCertificates VerifierCertificates) (Verifier, error) {
 
 	if verifierCertificates.PrivacyCACertificates == nil {
@@ -46,8 +51,8 @@ func NewVerifier(verifierCertificates VerifierCertificates) (Verifier, error) {
 		return nil, errors.New("The asset tag ca cannot be nil")
 	}
 
-	if verifierCertificates.FlavorSigningCertificate == nil {
-		return nil, errors.New("The flavor signing certificate cannot be nil")
+	if len(verifierCertificates.FlavorSigningCertificates) == 0 {
+		return nil, errors.New("The flavor signing certificates cannot be empty")
 	}
 
 	if verifierCertificates.FlavorCACertificates == nil {
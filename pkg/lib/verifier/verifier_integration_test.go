@@ -164,7 +164,7 @@ func TestVerifierFlavorSigningFault(t *testing.T) {
 	}
 
 	//if any of the certificates is nil, it should return error
-	verifierCertificates.FlavorSigningCertificate = nil
+	verifierCertificates.FlavorSigningCertificates = nil
 	_, err = NewVerifier(verifierCertificates)
 	assert.Error(t, err)
 }
@@ -441,10 +441,10 @@ func createVerifierCertificates(t *testing.T,
 	}
 
 	return VerifierCertificates{
-		PrivacyCACertificates:    privacyCACertificates,
-		FlavorSigningCertificate: flavorSigningCertificate,
-		AssetTagCACertificates:   assetTagCACertificates,
-		FlavorCACertificates:     flavorCACertificates,
+		PrivacyCACertificates:     privacyCACertificates,
+		FlavorSigningCertificates: []x509.Certificate{*flavorSigningCertificate},
+		AssetTagCACertificates:    assetTagCACertificates,
+		FlavorCACertificates:      flavorCACertificates,
 	}, nil
 }
 
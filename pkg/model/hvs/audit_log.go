@@ -0,0 +1,38 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogEntry is a single audit log record as returned by the audit log API
+type AuditLogEntry struct {
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// swagger:strfmt uuid
+	EntityID   uuid.UUID              `json:"entity_id"`
+	EntityType string                 `json:"entity_type"`
+	CreatedAt  time.Time              `json:"created"`
+	Action     string                 `json:"action"`
+	Columns    []AuditLogColumnChange `json:"columns"`
+}
+
+// AuditLogColumnChange holds the value of a column of the audited entity and whether it was modified by the action
+type AuditLogColumnChange struct {
+	Name      string      `json:"name"`
+	Value     interface{} `json:"value"`
+	IsUpdated bool        `json:"is_updated"`
+}
+
+// AuditLogEntryCollection holds a page of audit log entries in response to a search
+type AuditLogEntryCollection struct {
+	AuditLogEntries []AuditLogEntry `json:"audit_logs"`
+	// Offset to be used to retrieve the next page, omitted when there are no more entries
+	NextOffset int `json:"next_offset,omitempty"`
+}
@@ -0,0 +1,15 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+// DataEncryptionKeyRotation is the outcome of a rotation of the data encryption key
+type DataEncryptionKeyRotation struct {
+	// KeyId identifies the new data encryption key
+	KeyId string `json:"key_id"`
+	// HostCredentials and ESXiClusters count the host credentials and ESXi cluster connection strings re-encrypted with
+	// the new data encryption key
+	HostCredentials int `json:"host_credentials"`
+	ESXiClusters    int `json:"esxi_clusters"`
+}
@@ -26,6 +26,7 @@ type SignedFlavor = model.SignedFlavor
 // SignedFlavorCollection is a list of SignedFlavor objects
 type SignedFlavorCollection struct {
 	SignedFlavors []SignedFlavor `json:"signed_flavors"`
+	*PageInfo
 }
 
 func (s SignedFlavorCollection) GetFlavors(flavorPart string) []SignedFlavor {
@@ -0,0 +1,82 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// flavor bundle import conflict strategies
+const (
+	FlavorBundleConflictSkip      = "skip"
+	FlavorBundleConflictReplace   = "replace"
+	FlavorBundleConflictSupersede = "supersede"
+)
+
+// flavor bundle import status of the flavors and flavor templates
+const (
+	FlavorBundleImportCreated    = "CREATED"
+	FlavorBundleImportExists     = "EXISTS"
+	FlavorBundleImportUnchanged  = "UNCHANGED"
+	FlavorBundleImportSkipped    = "SKIPPED"
+	FlavorBundleImportReplaced   = "REPLACED"
+	FlavorBundleImportSuperseded = "SUPERSEDED"
+	FlavorBundleImportFailed     = "FAILED"
+)
+
+// SignedFlavorBundle is the archive of flavors exported from a HVS. Content is the JSON encoded FlavorBundle and
+// Signature is its base64 encoded RSASSA-PKCS1-v1_5 SHA384 signature by the flavor signing key of the HVS.
+type SignedFlavorBundle struct {
+	Content   []byte `json:"content"`
+	Signature string `json:"signature"`
+}
+
+// FlavorBundle holds the exported flavorgroups and the flavor templates their flavors were created from
+type FlavorBundle struct {
+	Created         time.Time           `json:"created"`
+	Flavorgroups    []FlavorBundleGroup `json:"flavorgroups"`
+	FlavorTemplates []FlavorTemplate    `json:"flavor_templates,omitempty"`
+}
+
+// FlavorBundleGroup is an exported flavorgroup with its match policies and signed flavors
+type FlavorBundleGroup struct {
+	Name          string              `json:"name"`
+	MatchPolicies FlavorMatchPolicies `json:"flavor_match_policies,omitempty"`
+	SignedFlavors []SignedFlavor      `json:"signed_flavors"`
+}
+
+// FlavorBundleImportResult is the outcome of the import of a flavor bundle
+type FlavorBundleImportResult struct {
+	ConflictStrategy string                   `json:"conflict_strategy"`
+	FlavorTemplates  []FlavorBundleItemResult `json:"flavor_templates"`
+	Flavors          []FlavorBundleItemResult `json:"flavors"`
+}
+
+// FlavorBundleItemResult is the outcome of the import of one flavor or flavor template of a bundle. ID is the ID of
+// the item in the bundle, ImportedID the ID it was imported with when it differs.
+type FlavorBundleItemResult struct {
+	// swagger:strfmt uuid
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+	// Digest is the hex encoded SHA384 digest of the flavor
+	Digest string `json:"digest,omitempty"`
+	// swagger:strfmt uuid
+	ImportedID    *uuid.UUID            `json:"imported_id,omitempty"`
+	ImportedLabel string                `json:"imported_label,omitempty"`
+	Flavorgroups  []string              `json:"flavorgroup_names,omitempty"`
+	Status        string                `json:"status"`
+	Conflict      *FlavorBundleConflict `json:"conflict,omitempty"`
+	Error         string                `json:"error,omitempty"`
+}
+
+// FlavorBundleConflict is an existing flavor with the label or the ID of an imported flavor but a different digest
+type FlavorBundleConflict struct {
+	// swagger:strfmt uuid
+	FlavorID uuid.UUID `json:"flavor_id"`
+	Label    string    `json:"label"`
+	Digest   string    `json:"digest"`
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/google/uuid"
+
+// FlavorEvaluationCollection is returned by the flavor dry-run API. It contains the evaluated candidate flavors and
+// the trust results of each selected host, none of which are stored by HVS.
+type FlavorEvaluationCollection struct {
+	Flavors         []Flavors              `json:"flavors"`
+	HostEvaluations []HostFlavorEvaluation `json:"host_evaluations"`
+}
+
+// HostFlavorEvaluation holds the results of verifying the last host manifest of a host against the candidate flavors.
+// Error is set instead of the results when the host could not be evaluated.
+type HostFlavorEvaluation struct {
+	// swagger:strfmt uuid
+	HostID   uuid.UUID    `json:"host_id"`
+	HostName string       `json:"host_name"`
+	Trusted  bool         `json:"trusted"`
+	Results  []RuleResult `json:"results,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
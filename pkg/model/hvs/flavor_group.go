@@ -13,6 +13,7 @@ import (
 
 type FlavorgroupCollection struct {
 	Flavorgroups []FlavorGroup `json:"flavorgroups" xml:"flavorgroup"`
+	*PageInfo
 }
 
 type FlavorMatchPolicies []FlavorMatchPolicy
@@ -29,6 +30,22 @@ type FlavorGroup struct {
 	FlavorIds     []uuid.UUID         `json:"flavorIds,omitempty"`
 	Flavors       []Flavor            `json:"flavors,omitempty"`
 	MatchPolicies FlavorMatchPolicies `json:"flavor_match_policies,omitempty"`
+	// LabelSelector holds the labels a host must have to be linked to the flavorgroup. The hosts of a flavorgroup with a
+	// label selector are linked and unlinked automatically as their labels change.
+	LabelSelector map[string]string `json:"label_selector,omitempty"`
+	// ReportPolicy holds the validity and refresh interval of the reports of the hosts linked to the flavorgroup, the
+	// configured SAML validity and HRRS refresh period apply when it is not set.
+	ReportPolicy *ReportPolicy `json:"report_policy,omitempty"`
+}
+
+// ReportPolicy determines how long the trust reports of the hosts of a flavorgroup are valid and how often they are
+// refreshed. A host linked to several flavorgroups uses the shortest validity and refresh interval of their policies.
+type ReportPolicy struct {
+	// ValiditySeconds is the validity of the reports, 0 uses the configured SAML validity
+	ValiditySeconds int `json:"validity_seconds,omitempty"`
+	// RefreshIntervalSeconds is the time after which the reports are refreshed even though they are still valid, 0
+	// refreshes the reports only when they expire
+	RefreshIntervalSeconds int `json:"refresh_interval_seconds,omitempty"`
 }
 
 type FlavorMatchPolicy struct {
@@ -85,12 +102,16 @@ func (r FlavorGroup) MarshalJSON() ([]byte, error) {
 		FlavorIds                   []uuid.UUID                 `json:"flavorIds,omitempty"`
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		LabelSelector               map[string]string           `json:"label_selector,omitempty"`
+		ReportPolicy                *ReportPolicy               `json:"report_policy,omitempty"`
 	}{
 		ID:                          r.ID,
 		Name:                        r.Name,
 		FlavorIds:                   r.FlavorIds,
 		Flavors:                     r.Flavors,
 		FlavorMatchPolicyCollection: FlavorMatchPolicyCollection{r.MatchPolicies},
+		LabelSelector:               r.LabelSelector,
+		ReportPolicy:                r.ReportPolicy,
 	})
 }
 
@@ -101,6 +122,8 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		FlavorIds                   []uuid.UUID                 `json:"flavorIds,omitempty"`
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		LabelSelector               map[string]string           `json:"label_selector,omitempty"`
+		ReportPolicy                *ReportPolicy               `json:"report_policy,omitempty"`
 	})
 	err := json.Unmarshal(b, decoded)
 	if err == nil {
@@ -109,6 +132,8 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		r.FlavorIds = decoded.FlavorIds
 		r.Flavors = decoded.Flavors
 		r.MatchPolicies = decoded.FlavorMatchPolicyCollection.FlavorMatchPolicies
+		r.LabelSelector = decoded.LabelSelector
+		r.ReportPolicy = decoded.ReportPolicy
 	}
 	return err
 }
@@ -61,6 +61,27 @@ var _ = Describe("FlavorGroup", func() {
 		})
 	})
 
+	Describe("Marshal flavorgroup with a report policy", func() {
+		Context("Provided a Flavorgroup with a report policy", func() {
+			It("Should keep the report policy through marshalling", func() {
+				var fg hvs.FlavorGroup
+				err := json.Unmarshal([]byte(flavorgroupJson), &fg)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fg.ReportPolicy).To(BeNil())
+
+				fg.ReportPolicy = &hvs.ReportPolicy{ValiditySeconds: 86400, RefreshIntervalSeconds: 900}
+				fgJson, err := json.Marshal(fg)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(fgJson)).To(ContainSubstring(`"report_policy":{"validity_seconds":86400,"refresh_interval_seconds":900}`))
+
+				var decoded hvs.FlavorGroup
+				err = json.Unmarshal(fgJson, &decoded)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded.ReportPolicy).To(Equal(fg.ReportPolicy))
+			})
+		})
+	})
+
 	Describe("get match policy types map for each flavorgroup", func() {
 		Context("Provided a valid Flavorgroup and get match policy details", func() {
 			It("Should generate valid maps", func() {
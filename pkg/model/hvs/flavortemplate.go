@@ -6,6 +6,7 @@ package hvs
 
 import (
 	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/model"
 )
 
 //PCR - To store PCR index with respective PCR bank.
@@ -31,10 +32,15 @@ type PcrRules struct {
 	EventlogIncludes []string `json:"eventlog_includes,omitempty"`
 }
 
+// CustomRule sourced from the lib/flavor - the custom rules of a template flavor part are copied to the flavors created from it
+type CustomRule = model.CustomRule
+
 type FlavorPart struct {
 	// Meta is key:value pair section used to define flavorparts with its own meta fields.
 	Meta     map[string]interface{} `json:"meta,omitempty"`
 	PcrRules []PcrRules             `json:"pcr_rules"`
+	// Rules registered in the verifier's custom rule registry. Sample value: "custom_rules": [{"name": "BiosVersionAtLeast", "parameters": {"min_version": "SE5C620.86B.00.01.0015"}}]
+	CustomRules []CustomRule `json:"custom_rules,omitempty"`
 }
 
 // swagger:parameters FlavorParts
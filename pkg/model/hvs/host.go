@@ -8,6 +8,7 @@ import "github.com/google/uuid"
 
 type HostCollection struct {
 	Hosts []*Host `json:"hosts" xml:"host"`
+	*PageInfo
 }
 
 type Host struct {
@@ -19,16 +20,18 @@ type Host struct {
 	// swagger:strfmt uuid
 	HardwareUuid     *uuid.UUID             `json:"hardware_uuid,omitempty"`
 	FlavorgroupNames []string               `json:"flavorgroup_names,omitempty"`
+	Labels           map[string]string      `json:"labels,omitempty"`
 	Report           *TrustReport           `json:"report,omitempty"`
 	Trusted          *bool                  `json:"trusted,omitempty"`
 	ConnectionStatus *HostStatusInformation `json:"status,omitempty"`
 }
 
 type HostCreateRequest struct {
-	HostName         string   `json:"host_name"`
-	Description      string   `json:"description,omitempty"`
-	ConnectionString string   `json:"connection_string"`
-	FlavorgroupNames []string `json:"flavorgroup_names,omitempty"`
+	HostName         string            `json:"host_name"`
+	Description      string            `json:"description,omitempty"`
+	ConnectionString string            `json:"connection_string"`
+	FlavorgroupNames []string          `json:"flavorgroup_names,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
 }
 
 type HostFlavorgroupCollection struct {
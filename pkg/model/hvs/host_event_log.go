@@ -0,0 +1,39 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+)
+
+// HostEventLog holds the decoded TCG event logs of the latest host manifest of a host
+type HostEventLog struct {
+	// swagger:strfmt uuid
+	HostId uuid.UUID `json:"host_id"`
+	// Created is the time the host manifest was retrieved from the host
+	Created      time.Time          `json:"created"`
+	PcrEventLogs []PcrEventLogEntry `json:"pcr_event_logs"`
+}
+
+// PcrEventLogEntry holds the events measured in a PCR, along with the PCR value of the host manifest and the value
+// the event log replays to
+type PcrEventLogEntry struct {
+	Pcr types.Pcr `json:"pcr"`
+	// PcrValue is the value of the PCR in the host manifest, it is empty when the host manifest does not include the PCR
+	PcrValue    string `json:"pcr_value,omitempty"`
+	ReplayValue string `json:"replay_value"`
+	// PcrMatches is true when the event log replays to the value of the PCR
+	PcrMatches bool          `json:"pcr_matches"`
+	Events     []EventReplay `json:"events"`
+}
+
+// EventReplay is an event of a PCR event log with the value of the PCR after the event is extended
+type EventReplay struct {
+	types.EventLog
+	ReplayValue string `json:"replay_value"`
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// job result status
+const (
+	JobResultSucceeded = "SUCCEEDED"
+	JobResultFailed    = "FAILED"
+)
+
+type HostBulkCreateRequest struct {
+	Hosts []HostCreateRequest `json:"hosts"`
+}
+
+// Job tracks the progress of an asynchronous operation run on a set of hosts
+type Job struct {
+	// swagger:strfmt uuid
+	ID      uuid.UUID `json:"id"`
+	Action  string    `json:"action"`
+	State   string    `json:"state"`
+	Message string    `json:"message,omitempty"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+	// Total is the number of hosts of the job, Succeeded and Failed count the hosts already processed
+	Total     int         `json:"total"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	Results   []JobResult `json:"results"`
+}
+
+// JobResult is the outcome of a job for one host
+type JobResult struct {
+	HostName string `json:"host_name"`
+	// swagger:strfmt uuid
+	HostID *uuid.UUID `json:"host_id,omitempty"`
+	Status string     `json:"status"`
+	Error  string     `json:"error,omitempty"`
+	// HardwareUUID and TagCertificateID are set by the bulk tag certificate provisioning jobs
+	// swagger:strfmt uuid
+	HardwareUUID *uuid.UUID `json:"hardware_uuid,omitempty"`
+	// swagger:strfmt uuid
+	TagCertificateID *uuid.UUID `json:"tag_certificate_id,omitempty"`
+}
@@ -0,0 +1,24 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+// PageInfo is added to a collection when a page of the matching records is requested with the limit and offset
+// query parameters
+type PageInfo struct {
+	// Total number of records matching the search criteria
+	Total int `json:"total" xml:"total"`
+	// Offset to be used to retrieve the next page, omitted when there are no more records
+	NextOffset int `json:"next_offset,omitempty" xml:"next_offset,omitempty"`
+}
+
+// NewPageInfo returns the PageInfo of a page of pageSize records starting at offset
+func NewPageInfo(total, offset, pageSize int) *PageInfo {
+	pageInfo := PageInfo{Total: total}
+	if offset+pageSize < total {
+		pageInfo.NextOffset = offset + pageSize
+	}
+	return &pageInfo
+}
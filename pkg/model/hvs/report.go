@@ -14,6 +14,7 @@ import (
 
 type ReportCollection struct {
 	Reports []*Report `json:"reports" xml:"reports"`
+	*PageInfo
 }
 
 type Report struct {
@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/host-connector/types"
+)
+
+// ReportDiff is returned by the report diff API and describes what changed between two reports of the same host
+type ReportDiff struct {
+	// swagger:strfmt uuid
+	HostID uuid.UUID `json:"host_id"`
+	// swagger:strfmt uuid
+	FromReportID uuid.UUID `json:"from_report_id"`
+	// swagger:strfmt uuid
+	ToReportID  uuid.UUID `json:"to_report_id"`
+	FromCreated time.Time `json:"from_created"`
+	ToCreated   time.Time `json:"to_created"`
+	TrustReportDiff
+}
+
+// TrustReportDiff holds the differences between two trust reports. Each collection only lists the entries that changed.
+type TrustReportDiff struct {
+	FromTrusted     bool             `json:"from_trusted"`
+	ToTrusted       bool             `json:"to_trusted"`
+	RuleChanges     []RuleChange     `json:"rule_changes"`
+	PcrChanges      []PcrChange      `json:"pcr_changes"`
+	EventLogChanges []EventLogChange `json:"event_log_changes"`
+	HostInfoChanges []HostInfoChange `json:"host_info_changes"`
+}
+
+// RuleChange describes a rule whose trusted state differs between the two reports. FromTrusted or ToTrusted
+// is nil when the rule was not evaluated in that report. Faults are the faults raised by the rule in the newer report.
+type RuleChange struct {
+	RuleName string              `json:"rule_name"`
+	Markers  []common.FlavorPart `json:"markers,omitempty"`
+	// swagger:strfmt uuid
+	FlavorId    *uuid.UUID          `json:"flavor_id,omitempty"`
+	PcrBank     *types.SHAAlgorithm `json:"pcr_bank,omitempty"`
+	PcrIndex    *types.PcrIndex     `json:"pcr_index,omitempty"`
+	FromTrusted *bool               `json:"from_trusted,omitempty"`
+	ToTrusted   *bool               `json:"to_trusted,omitempty"`
+	Faults      []Fault             `json:"faults,omitempty"`
+}
+
+// PcrChange describes a PCR whose value differs between the host manifests of the two reports.
+// An empty value means that the PCR was not reported by the host.
+type PcrChange struct {
+	PcrBank   types.SHAAlgorithm `json:"pcr_bank"`
+	PcrIndex  types.PcrIndex     `json:"pcr_index"`
+	FromValue string             `json:"from_value,omitempty"`
+	ToValue   string             `json:"to_value,omitempty"`
+}
+
+// EventLogChange lists the event log entries of a PCR that were added in or removed from the newer report
+type EventLogChange struct {
+	PcrBank  string           `json:"pcr_bank"`
+	PcrIndex int              `json:"pcr_index"`
+	Added    []types.EventLog `json:"added,omitempty"`
+	Removed  []types.EventLog `json:"removed,omitempty"`
+}
+
+// HostInfoChange describes a host info field that differs between the two reports, for example the BIOS or
+// OS version. Nested fields are named using their json path, e.g. "hardware_features.TPM.meta.tpm_version".
+type HostInfoChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
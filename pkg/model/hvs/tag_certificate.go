@@ -30,6 +30,7 @@ type TagCertificate struct {
 // TagCertificateCollection is the response sent by the tag-certificate API
 type TagCertificateCollection struct {
 	TagCertificates []*TagCertificate `json:"certificates" xml:"certificates"`
+	*PageInfo
 }
 
 // SetAssetTagDigest computes the hash of the Asset Tag certificate
@@ -0,0 +1,33 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v4/pkg/lib/flavor/common"
+)
+
+// TrustStateChangeEvent is published by HVS when a newly stored trust report of a host has a different overall
+// trust status than the previous report of the host
+type TrustStateChangeEvent struct {
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// swagger:strfmt uuid
+	HostID       uuid.UUID `json:"host_id"`
+	HostName     string    `json:"host_name"`
+	HardwareUUID string    `json:"hardware_uuid"`
+	// swagger:strfmt uuid
+	ReportID        uuid.UUID `json:"report_id"`
+	PreviousTrusted bool      `json:"previous_trusted"`
+	Trusted         bool      `json:"trusted"`
+	// UntrustedFlavorParts lists the flavor parts that are not trusted in the new report
+	UntrustedFlavorParts []common.FlavorPart `json:"untrusted_flavor_parts,omitempty"`
+	// FaultNames lists the distinct names of the faults reported by the new report
+	FaultNames []string  `json:"fault_names,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
@@ -55,6 +55,7 @@ type RuleInfo struct {
 	Exclude_Tags             []string               `json:"excluding_tag,omitempty"`
 	ExpectedTag              []byte                 `json:"expected_tag,omitempty"`
 	Tags                     map[string]string      `json:"tags,omitempty"`
+	Parameters               map[string]interface{} `json:"parameters,omitempty"`
 }
 
 type Fault struct {
@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrustSummary aggregates the trust status of all the hosts registered with HVS, computed from the latest report
+// and host status of each host
+type TrustSummary struct {
+	Hosts TrustCounts `json:"hosts"`
+	// Flavorgroups holds the trust status of the hosts linked to each flavorgroup
+	Flavorgroups []FlavorgroupTrustSummary `json:"flavorgroups"`
+	// FlavorParts holds the trust status of the hosts per flavor part verified in their reports
+	FlavorParts map[string]TrustCounts `json:"flavor_parts"`
+	// Faults holds the trust status of the hosts per fault name reported in their reports
+	Faults map[string]TrustCounts `json:"faults"`
+	// ExpiringReports lists the hosts whose reports expire within the requested time window
+	ExpiringReports []ExpiringReport `json:"expiring_reports"`
+	// ConnectionFailures lists the hosts per connection state for the hosts that are not connected to HVS
+	ConnectionFailures map[string][]TrustSummaryHost `json:"connection_failures"`
+}
+
+// TrustCounts holds the number of trusted, untrusted and unknown hosts. The trust status of a host is unknown when it
+// has no report or when its report has expired
+type TrustCounts struct {
+	Trusted   int `json:"trusted"`
+	Untrusted int `json:"untrusted"`
+	Unknown   int `json:"unknown"`
+}
+
+// FlavorgroupTrustSummary holds the trust status of the hosts linked to a flavorgroup
+type FlavorgroupTrustSummary struct {
+	// swagger:strfmt uuid
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	TrustCounts
+}
+
+// TrustSummaryHost identifies a host listed in the TrustSummary
+type TrustSummaryHost struct {
+	// swagger:strfmt uuid
+	HostID   uuid.UUID `json:"host_id"`
+	HostName string    `json:"host_name"`
+}
+
+// ExpiringReport identifies a report that is about to expire
+type ExpiringReport struct {
+	TrustSummaryHost
+	// swagger:strfmt uuid
+	ReportID   uuid.UUID `json:"report_id"`
+	Trusted    bool      `json:"trusted"`
+	Expiration time.Time `json:"expiration"`
+}
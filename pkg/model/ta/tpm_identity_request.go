@@ -31,6 +31,7 @@ type IdentityProofRequest struct {
 
 type IdentityRequest struct {
 	TpmVersion string `json:"tpm_version"`
+	// AikModulus holds the modulus of an RSA AIK or the uncompressed public point of an ECC AIK
 	AikModulus []byte `json:"aik_modulus"`
 	AikName    []byte `json:"aik_name"`
 }
@@ -22,6 +22,9 @@ import "encoding/xml"
 //         <selectedPcrBanks>SHA256</selectedPcrBanks>
 //     </selectedPcrBanks>
 //     <isTagProvisioned>false</isTagProvisioned>
+//     <imaLog>10 91f34b5c671d73504b274a919661cf80dab1e127 ima-ng sha256:1a2b... boot_aggregate
+// 			<...>
+//     </imaLog>
 // </tpm_quote_response>
 type TpmQuoteResponse struct {
 	XMLName         xml.Name `xml:"tpm_quote_response"`
@@ -30,6 +33,9 @@ type TpmQuoteResponse struct {
 	ErrorMessage    string   `xml:"errorMessage"`
 	Aik             string   `xml:"aik"`
 	Quote           string   `xml:"quote"`
+	// EventLog holds the base64 encoded JSON array of the measure logs of the PCRs. An event may report its raw data
+	// in the base64 encoded "event_data" field, which is only needed for the EV_EFI_VARIABLE_DRIVER_CONFIG events
+	// verified by the SecureBootPolicy custom rule.
 	EventLog        string   `xml:"eventLog"`
 	TcbMeasurements struct {
 		XMLName         xml.Name `xml:"tcbMeasurements"`
@@ -41,4 +47,7 @@ type TpmQuoteResponse struct {
 	}
 	IsTagProvisioned bool   `xml:"isTagProvisioned"`
 	AssetTag         string `xml:"assetTag,omitempty"`
+	// ImaLog holds the IMA runtime measurement list of the host in the format of the ascii_runtime_measurements
+	// file, it is empty when IMA is not enabled on the host
+	ImaLog string `xml:"imaLog,omitempty"`
 }